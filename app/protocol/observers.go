@@ -0,0 +1,18 @@
+package protocol
+
+import (
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+var _ auth.ObserverI = (*multiAccountObservers)(nil)
+
+// multiAccountObservers combines the observers of the accounts updated, since the account keeper holds only one. It's
+// set to the account keeper before the keepers copying it are made, and the observers are added later
+type multiAccountObservers []auth.ObserverI
+
+// OnAccountUpdated notifies each of the observers in order
+func (observers *multiAccountObservers) OnAccountUpdated(acc auth.Account) {
+	for _, observer := range *observers {
+		observer.OnAccountUpdated(acc)
+	}
+}
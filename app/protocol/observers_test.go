@@ -0,0 +1,29 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/stretchr/testify/require"
+)
+
+type accountRecorder struct {
+	accounts *[]auth.Account
+}
+
+func (r accountRecorder) OnAccountUpdated(acc auth.Account) {
+	*r.accounts = append(*r.accounts, acc)
+}
+
+func TestMultiAccountObservers(t *testing.T) {
+	var first, second []auth.Account
+	observers := &multiAccountObservers{}
+	var observer auth.ObserverI = observers
+
+	// the observers added after being set are notified as well
+	*observers = append(*observers, accountRecorder{&first}, accountRecorder{&second})
+	acc := auth.NewBaseAccountWithAddress(nil)
+	observer.OnAccountUpdated(&acc)
+	require.Equal(t, 1, len(first))
+	require.Equal(t, 1, len(second))
+}
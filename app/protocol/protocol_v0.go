@@ -28,6 +28,7 @@ import (
 	"github.com/okex/okexchain/x/dex"
 	dexClient "github.com/okex/okexchain/x/dex/client"
 	distr "github.com/okex/okexchain/x/distribution"
+	"github.com/okex/okexchain/x/farm"
 	"github.com/okex/okexchain/x/genutil"
 	"github.com/okex/okexchain/x/gov"
 	"github.com/okex/okexchain/x/gov/keeper"
@@ -80,6 +81,7 @@ var (
 		stream.AppModuleBasic{},
 		debug.AppModuleBasic{},
 		ammswap.AppModuleBasic{},
+		farm.AppModuleBasic{},
 	)
 
 	// module account permissions for bankKeeper and supplyKeeper
@@ -95,6 +97,7 @@ var (
 		backend.ModuleName:        nil,
 		dex.ModuleName:            nil,
 		ammswap.ModuleName:        {supply.Minter, supply.Burner},
+		farm.ModuleName:           nil,
 	}
 )
 
@@ -125,6 +128,7 @@ type ProtocolV0 struct {
 	dexKeeper      dex.Keeper
 	orderKeeper    order.Keeper
 	swapKeeper     ammswap.Keeper
+	farmKeeper     farm.Keeper
 	protocolKeeper proto.ProtocolKeeper
 	backendKeeper  backend.Keeper
 	streamKeeper   stream.Keeper
@@ -275,6 +279,9 @@ func (p *ProtocolV0) produceKeepers() {
 
 	// 2.add keepers
 	p.accountKeeper = auth.NewAccountKeeper(p.cdc, p.keys[auth.StoreKey], authSubspace, auth.ProtoBaseAccount)
+	// the keepers below hold copies of the account keeper, so the observers are set before & added later
+	accountObservers := &multiAccountObservers{}
+	p.accountKeeper.SetObserverKeeper(accountObservers)
 	p.bankKeeper = bank.NewBaseKeeper(p.accountKeeper, bankSubspace, bank.DefaultCodespace, p.moduleAccountAddrs())
 	p.paramsKeeper.SetBankKeeper(p.bankKeeper)
 	p.supplyKeeper = supply.NewKeeper(p.cdc, p.keys[supply.StoreKey], p.accountKeeper, p.bankKeeper, maccPerms)
@@ -310,13 +317,20 @@ func (p *ProtocolV0) produceKeepers() {
 		p.keys[order.OrderStoreKey], p.cdc, appConfig.BackendConfig.EnableBackend, orderMetrics,
 	)
 
-	p.swapKeeper = ammswap.NewKeeper(p.supplyKeeper, p.tokenKeeper, p.cdc, p.keys[ammswap.StoreKey], swapSubSpace)
+	p.swapKeeper = ammswap.NewKeeper(p.supplyKeeper, p.tokenKeeper, p.distrKeeper, p.cdc, p.keys[ammswap.StoreKey],
+		p.tkeys[ammswap.TStoreKey], swapSubSpace)
+	p.orderKeeper.SetSwapKeeper(p.swapKeeper)
+
+	p.farmKeeper = farm.NewKeeper(p.supplyKeeper, p.tokenKeeper, p.cdc, p.keys[farm.StoreKey])
 
 	p.streamKeeper = stream.NewKeeper(p.orderKeeper, p.tokenKeeper, &p.dexKeeper, &p.accountKeeper,
-		p.cdc, p.logger, appConfig, streamMetrics)
+		p.swapKeeper, &stakingKeeper, p.cdc, p.logger, appConfig, streamMetrics)
 
-	p.backendKeeper = backend.NewKeeper(p.orderKeeper, p.tokenKeeper, &p.dexKeeper, p.streamKeeper.GetMarketKeeper(),
-		p.cdc, p.logger, appConfig.BackendConfig)
+	p.backendKeeper = backend.NewKeeper(p.orderKeeper, p.tokenKeeper, &p.dexKeeper, p.swapKeeper,
+		p.streamKeeper.GetMarketKeeper(), p.cdc, p.logger, appConfig.BackendConfig)
+	// the accounts updated are observed by the stream & the backend, which replace the stream set by itself
+	*accountObservers = append(*accountObservers, p.streamKeeper, p.backendKeeper)
+	p.accountKeeper.SetObserverKeeper(accountObservers)
 
 	// 3.register the proposal types
 	govRouter := gov.NewRouter()
@@ -336,6 +350,7 @@ func (p *ProtocolV0) produceKeepers() {
 	)
 	p.paramsKeeper.SetGovKeeper(p.govKeeper)
 	p.dexKeeper.SetGovKeeper(p.govKeeper)
+	p.streamKeeper.SetGovKeeper(p.govKeeper)
 	// 4.register the staking hooks
 	p.stakingKeeper = *stakingKeeper.SetHooks(
 		staking.NewMultiStakingHooks(p.distrKeeper.Hooks(), p.slashingKeeper.Hooks()),
@@ -375,6 +390,7 @@ func (p *ProtocolV0) setManager() {
 		order.NewAppModule(version.ProtocolVersionV0, p.orderKeeper, p.supplyKeeper),
 		token.NewAppModule(version.ProtocolVersionV0, p.tokenKeeper, p.supplyKeeper),
 		ammswap.NewAppModule(p.swapKeeper),
+		farm.NewAppModule(p.farmKeeper),
 
 		// TODO
 		dex.NewAppModule(version.ProtocolVersionV0, p.dexKeeper, p.supplyKeeper),
@@ -422,6 +438,7 @@ func (p *ProtocolV0) setManager() {
 		dex.ModuleName,
 		order.ModuleName,
 		ammswap.ModuleName,
+		farm.ModuleName,
 		upgrade.ModuleName,
 		crisis.ModuleName,
 		genutil.ModuleName,
@@ -487,7 +504,7 @@ func validateMsgHook(orderKeeper order.Keeper) auth.ValidateMsgHandler {
 
 		wrongMsgRes := sdk.Result{
 			Code: sdk.CodeUnknownRequest,
-			Log:  "It is not allowed that a transaction with more than one message contains placeOrder or cancelOrder message",
+			Log:  "It is not allowed that a transaction with more than one message contains placeOrder, cancelOrder, amendOrder or hybridSwap message",
 		}
 
 		for _, msg := range msgs {
@@ -504,6 +521,16 @@ func validateMsgHook(orderKeeper order.Keeper) auth.ValidateMsgHandler {
 					break
 				}
 				res = order.ValidateMsgCancelOrders(newCtx, orderKeeper, assertedMsg)
+			case order.MsgAmendOrders:
+				if len(msgs) > 1 {
+					res = wrongMsgRes
+					break
+				}
+				res = order.ValidateMsgAmendOrders(newCtx, orderKeeper, assertedMsg)
+			case order.MsgHybridSwap:
+				if len(msgs) > 1 {
+					res = wrongMsgRes
+				}
 			}
 
 			if !res.IsOK() {
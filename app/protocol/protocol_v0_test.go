@@ -7,6 +7,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/okex/okexchain/x/common"
 	"github.com/okex/okexchain/x/order"
+	ordertypes "github.com/okex/okexchain/x/order/types"
 	"github.com/okex/okexchain/x/token"
 	"github.com/okex/okexchain/x/upgrade"
 
@@ -184,3 +185,14 @@ func TestProtocolV0_Hooks(t *testing.T) {
 	// condition 3
 	require.False(t, isSystemFreeHook(mockContext, mockMsgs3))
 }
+
+func TestValidateMsgHook_HybridSwap(t *testing.T) {
+	hook := validateMsgHook(order.Keeper{})
+	addr := sdk.AccAddress([]byte("hybrid_swap_sender__"))
+	msg := ordertypes.NewMsgHybridSwap(addr, common.TestToken+"_"+common.NativeToken, ordertypes.BuyOrder,
+		sdk.NewDec(1), sdk.ZeroDec())
+
+	require.True(t, hook(sdk.Context{}, []sdk.Msg{msg}).IsOK())
+	// a hybrid swap is the only message of its transaction, as the order messages are
+	require.Equal(t, sdk.CodeUnknownRequest, hook(sdk.Context{}, []sdk.Msg{msg, msg}).Code)
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/server/config"
+	"github.com/okex/okexchain/app/protocol"
+	backendcfg "github.com/okex/okexchain/x/backend/config"
+	"github.com/okex/okexchain/x/backend/orm"
+	backendtypes "github.com/okex/okexchain/x/backend/types"
+	"github.com/okex/okexchain/x/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/cli"
+	"github.com/tendermint/tendermint/mock"
+	"github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	fromHeightFlag = "from"
+	toHeightFlag   = "to"
+	reindexDataDir = "reindex"
+)
+
+var (
+	// the keys in the state db of the reindex, which record the blocks stored by the blocks executed
+	reindexStoredFromKey   = []byte("backendReindexStoredFrom")
+	reindexStoredHeightKey = []byte("backendReindexStoredHeight")
+)
+
+func backendCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backend",
+		Short: "Maintain the backend database",
+	}
+	cmd.AddCommand(reindexCmd(ctx))
+	return cmd
+}
+
+func reindexCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reindex",
+		Short: "Rebuild the backend database from the blocks in local db",
+		Long: `Rebuild the orders, deals, match results, fee details, transactions and klines of the backend database
+from the blocks in [from, to] of local db.
+
+The blocks are executed again from the genesis by a standalone app in the reindex directory of the node data,
+and the node's own data is left alone. The rows of the blocks in the range are replaced, so it could be run again
+on the same range. An interrupted reindex resumes from the last executed block if all of the blocks executed in the
+range have been stored, otherwise the blocks are executed from the genesis again.
+
+The node runs it before it starts for the blocks missed in the backend database, unless --backend.fill_gap=false.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, to := viper.GetInt64(fromHeightFlag), viper.GetInt64(toHeightFlag)
+			if from <= 0 || to < from {
+				return fmt.Errorf("invalid block range [%d, %d]", from, to)
+			}
+			log.Println("--------- backend reindex start ---------")
+			reindexBackend(ctx, viper.GetString(dataDirFlag), from, to)
+			log.Println("--------- backend reindex success ---------")
+			return nil
+		},
+	}
+	cmd.Flags().StringP(dataDirFlag, "d", ".okexchaind/data", "Directory of block data for reindexing")
+	cmd.Flags().Int64(fromHeightFlag, 1, "The first block height to reindex")
+	cmd.Flags().Int64(toHeightFlag, 0, "The last block height to reindex")
+	cmd.Flags().String(server.FlagBacekendOrmEngineType, config.DefaultBackendConfig().OrmEngine.EngineType,
+		"Backend plugin`s db (mysql or sqlite3)")
+	cmd.Flags().String(server.FlagBackendOrmEngineConnectStr, config.DefaultBackendConfig().OrmEngine.ConnectStr,
+		"Backend plugin`s db connect address")
+	addBackendFlags(cmd)
+	return cmd
+}
+
+// addBackendFlags adds the flags of the backend to the command running the app
+func addBackendFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64(backendcfg.FlagSnapshotInterval, backendcfg.DefaultSnapshotInterval,
+		"The interval in blocks between the balance snapshots of the accounts changed")
+}
+
+// addStartFlags adds the flags of the backend & the stream to the start command, and fills the gap of the backend
+// database before the node starts
+func addStartFlags(ctx *server.Context, rootCmd *cobra.Command) {
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "start" {
+			addBackendFlags(cmd)
+			addStreamFlags(cmd)
+			cmd.Flags().Bool(backendcfg.FlagFillGap, true,
+				"Reindex the blocks missed in the backend database before the node starts")
+			cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+				return fillBackendGap(ctx)
+			}
+		}
+	}
+}
+
+// fillBackendGap reindexes the blocks missed in the backend database, which are the blocks in the block store after
+// the last indexed height and the ones from the first missed height. The reindex runs in another process, so that it
+// doesn't share the protocol engine with the app of the node
+func fillBackendGap(ctx *server.Context) error {
+	appConfig, err := config.ParseConfig()
+	if err != nil {
+		return err
+	}
+	backendConfig := appConfig.BackendConfig
+	if !backendConfig.EnableBackend || !backendConfig.EnableMktCompute || !viper.GetBool(backendcfg.FlagFillGap) {
+		return nil
+	}
+
+	blockStoreDB, err := openDB(blockStoreDB, ctx.Config.DBDir())
+	if err != nil {
+		return err
+	}
+	to := store.NewBlockStore(blockStoreDB).Height()
+	blockStoreDB.Close()
+
+	backendOrm, err := orm.New(false, &backendConfig.OrmEngine, &ctx.Logger)
+	if err != nil {
+		return err
+	}
+	lastIndexed, err := backendOrm.GetCheckpoint(backendtypes.CheckpointLastIndexedHeight)
+	if err != nil {
+		backendOrm.Close()
+		return err
+	}
+	firstMissed, err := backendOrm.GetCheckpoint(backendtypes.CheckpointFirstMissedHeight)
+	backendOrm.Close()
+	if err != nil {
+		return err
+	}
+	from := lastIndexed.Height + 1
+	if firstMissed.Height > 0 && firstMissed.Height < from {
+		from = firstMissed.Height
+	}
+	if from > to {
+		return nil
+	}
+
+	ctx.Logger.Info(fmt.Sprintf("filling the blocks in [%d, %d] missed in the backend database", from, to))
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	reindex := exec.Command(executable, "backend", "reindex",
+		"--"+cli.HomeFlag, viper.GetString(cli.HomeFlag),
+		"--"+dataDirFlag, ctx.Config.DBDir(),
+		"--"+fromHeightFlag, strconv.FormatInt(from, 10),
+		"--"+toHeightFlag, strconv.FormatInt(to, 10),
+		"--"+server.FlagBacekendOrmEngineType, backendConfig.OrmEngine.EngineType,
+		"--"+server.FlagBackendOrmEngineConnectStr, backendConfig.OrmEngine.ConnectStr,
+		"--"+backendcfg.FlagSnapshotInterval, strconv.FormatInt(backendcfg.GetSnapshotInterval(), 10))
+	reindex.Stdout, reindex.Stderr = os.Stdout, os.Stderr
+	if err = reindex.Run(); err != nil {
+		return fmt.Errorf("failed to fill the blocks in [%d, %d] of the backend database: %v", from, to, err)
+	}
+	return nil
+}
+
+// reindexBackend stores the backend data of the blocks in [from, to] again, if something goes wrong, it will panic
+// with error message.
+func reindexBackend(ctx *server.Context, originDataDir string, from, to int64) {
+	originBlockStoreDB, err := openDB(blockStoreDB, originDataDir)
+	panicError(err)
+	originBlockStore := store.NewBlockStore(originBlockStoreDB)
+	if to > originBlockStore.Height() {
+		panicError(fmt.Errorf("the last block height %d is greater than the origin one %d", to, originBlockStore.Height()))
+	}
+	blockTime := func(height int64) int64 {
+		return originBlockStore.LoadBlockMeta(height).Header.Time.Unix()
+	}
+
+	// the market data is computed only for the blocks in the range, and the stream engines are not started
+	viper.Set(server.FlagBackendEnableBackend, true)
+	viper.Set(server.FlagBackendEnableMktCompute, false)
+	viper.Set(server.FlagStreamEngine, "")
+
+	// the blocks executed before can't be executed again, they must have been stored since a height not above from
+	reindexDir := filepath.Join(ctx.Config.RootDir, "data", reindexDataDir)
+	stateStoreDB, err := openDB(stateDB, reindexDir)
+	panicError(err)
+	if lastHeight := sm.LoadState(stateStoreDB).LastBlockHeight; lastHeight >= from &&
+		(getReindexHeight(stateStoreDB, reindexStoredFromKey) > from ||
+			getReindexHeight(stateStoreDB, reindexStoredHeightKey) < lastHeight) {
+		log.Println("the blocks executed are not all stored, execute the blocks from the genesis again")
+		stateStoreDB.Close()
+		panicError(os.RemoveAll(reindexDir))
+		stateStoreDB, err = openDB(stateDB, reindexDir)
+		panicError(err)
+	}
+
+	db, err := openDB(applicationDB, reindexDir)
+	panicError(err)
+	proxyApp, err := createAndStartProxyAppConns(proxy.NewLocalClientCreator(newApp(ctx.Logger, db, nil)))
+	panicError(err)
+	backendKeeper := protocol.GetEngine().GetCurrentProtocol().GetBackendKeeper()
+	if backendKeeper.Orm == nil {
+		panicError(fmt.Errorf("failed to open the backend database"))
+	}
+
+	res, err := proxyApp.Query().InfoSync(proxy.RequestInfo)
+	panicError(err)
+	currentBlockHeight := res.LastBlockHeight
+	log.Println("current block height", "height", currentBlockHeight)
+
+	genesisDocProvider := node.DefaultGenesisDocProviderFunc(ctx.Config)
+	state, genDoc, err := node.LoadStateFromDBOrGenesisDocProvider(stateStoreDB, genesisDocProvider)
+	panicError(err)
+	if currentBlockHeight == types.GetStartBlockHeight() {
+		panicError(initChain(state, stateStoreDB, genDoc, proxyApp))
+		state = sm.LoadState(stateStoreDB)
+	}
+
+	// the rows of the blocks to be stored might have been stored by the reindex interrupted, they're deleted by the
+	// heights and stored again
+	startBlockHeight := currentBlockHeight + 1
+	storeBlockHeight := startBlockHeight
+	if storeBlockHeight <= from {
+		storeBlockHeight = from
+		stateStoreDB.SetSync(reindexStoredFromKey, common.Int64ToBytes(from))
+	}
+	if storeBlockHeight <= to {
+		panicError(backendKeeper.Orm.DeleteBlocksBetween(storeBlockHeight, to))
+	}
+
+	blockExec := sm.NewBlockExecutor(stateStoreDB, ctx.Logger, proxyApp.Consensus(), mock.Mempool{}, sm.MockEvidencePool{})
+	for height := startBlockHeight; height <= to; height++ {
+		log.Println("reindexing ", height)
+		backendKeeper.Config.EnableMktCompute = height >= from
+		block := originBlockStore.LoadBlock(height)
+		meta := originBlockStore.LoadBlockMeta(height)
+		state, err = blockExec.ApplyBlock(state, meta.BlockID, block)
+		panicError(err)
+		if height >= from {
+			if len(backendKeeper.Cache.PendingBlocks) > 0 {
+				panicError(fmt.Errorf("failed to store block %d", height))
+			}
+			stateStoreDB.SetSync(reindexStoredHeightKey, common.Int64ToBytes(height))
+		}
+	}
+	backendKeeper.Config.EnableMktCompute = false
+
+	log.Println("rebuilding klines")
+	panicError(backendKeeper.Orm.RebuildKlines(blockTime(from), blockTime(to)))
+	panicError(backendKeeper.Orm.ClearMissedHeight(from, to))
+}
+
+// getReindexHeight returns the height recorded with the key in the state db of the reindex, 0 if there's none
+func getReindexHeight(db dbm.DB, key []byte) int64 {
+	if bz := db.Get(key); len(bz) > 0 {
+		return common.BytesToInt64(bz)
+	}
+	return 0
+}
@@ -53,7 +53,10 @@ func main() {
 	rootCmd.AddCommand(client.NewCompletionCmd(rootCmd, true))
 	rootCmd.AddCommand(testnetCmd(ctx, cdc, app.ModuleBasics, genaccounts.AppModuleBasic{}))
 	rootCmd.AddCommand(replayCmd(ctx))
+	rootCmd.AddCommand(backendCmd(ctx))
+	rootCmd.AddCommand(streamCmd(ctx))
 	server.AddCommands(ctx, cdc, rootCmd, newApp, exportAppStateAndTMValidators, registerRoutes)
+	addStartFlags(ctx, rootCmd)
 	rootCmd.PersistentFlags().String(client.FlagKeyPass, client.DefaultKeyPass, "Pass word of sender")
 
 	// prepare and add flags
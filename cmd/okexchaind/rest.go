@@ -11,6 +11,7 @@ import (
 	dexrest "github.com/okex/okexchain/x/dex/client/rest"
 	dist "github.com/okex/okexchain/x/distribution"
 	distrest "github.com/okex/okexchain/x/distribution/client/rest"
+	farmrest "github.com/okex/okexchain/x/farm/client/rest"
 	orderrest "github.com/okex/okexchain/x/order/client/rest"
 	stakingrest "github.com/okex/okexchain/x/staking/client/rest"
 	"github.com/okex/okexchain/x/token"
@@ -38,6 +39,7 @@ func registerRoutesV1(rs *lcd.RestServer) {
 	backendrest.RegisterRoutes(rs.CliCtx, v1Router)
 	dexrest.RegisterRoutes(rs.CliCtx, v1Router)
 	ammswaprest.RegisterRoutes(rs.CliCtx, v1Router)
+	farmrest.RegisterRoutes(rs.CliCtx, v1Router)
 	supplyrest.RegisterRoutes(rs.CliCtx, v1Router)
 }
 
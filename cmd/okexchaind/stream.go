@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/server/config"
+	"github.com/okex/okexchain/x/stream"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const engineFlag = "engine"
+
+func streamCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stream",
+		Short: "Maintain the data of the stream engines",
+	}
+	cmd.AddCommand(
+		streamPendingCmd(),
+		streamReplayCmd(ctx),
+	)
+	return cmd
+}
+
+func streamPendingCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pending",
+		Short: "Show the blocks in the outbox not acknowledged by each stream engine",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outbox, err := stream.NewOutbox(stream.GetOutboxDir())
+			if err != nil {
+				return err
+			}
+			defer outbox.Close()
+
+			for _, name := range []string{"mysql", "redis", "pulsar"} {
+				heights := outbox.PendingHeights(stream.StringToStreamKind(name))
+				if len(heights) == 0 {
+					fmt.Printf("%s: no pending block\n", name)
+					continue
+				}
+				fmt.Printf("%s: %d pending blocks in [%d, %d]\n", name, len(heights), heights[0], heights[len(heights)-1])
+			}
+			return nil
+		},
+	}
+	addStreamFlags(cmd)
+	return cmd
+}
+
+func streamReplayCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Replay the blocks in the outbox into a stream engine",
+		Long: `Write the data of the blocks in [from, to] which is kept in the outbox into the stream engine, in the same
+form as the stream.engine config, e.g. "notify|redis|redis://127.0.0.1:6379".
+
+The data of each block is kept in the outbox until the engine acknowledges it, so the blocks lost by the engine
+while the node stops could be replayed after the outage. The blocks are replayed in order and acknowledged one by
+one, the replay stops at the first block failed to be written and could be run again. The node should be stopped
+while replaying, since the outbox is opened by one process only.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, to := viper.GetInt64(fromHeightFlag), viper.GetInt64(toHeightFlag)
+			if from <= 0 || to < from {
+				return fmt.Errorf("invalid block range [%d, %d]", from, to)
+			}
+
+			appConfig, err := config.ParseConfig()
+			if err != nil {
+				return err
+			}
+			streamConfig := *appConfig.StreamConfig
+			streamConfig.Engine = viper.GetString(engineFlag)
+			engines, err := stream.ParseStreamEngineConfig(ctx.Logger, &streamConfig)
+			if err != nil {
+				return err
+			}
+			if len(engines) != 1 {
+				return fmt.Errorf("only one engine could be replayed into, got %d", len(engines))
+			}
+
+			outbox, err := stream.NewOutbox(stream.GetOutboxDir())
+			if err != nil {
+				return err
+			}
+			defer outbox.Close()
+
+			log.Println("--------- stream replay start ---------")
+			for engineKind, engine := range engines {
+				replayed, err := stream.ReplayOutbox(outbox, stream.EngineKind2StreamKindMap[engineKind], engine,
+					from, to, ctx.Logger)
+				log.Printf("%d blocks replayed\n", len(replayed))
+				if err != nil {
+					return err
+				}
+			}
+			log.Println("--------- stream replay success ---------")
+			return nil
+		},
+	}
+	cmd.Flags().Int64(fromHeightFlag, 1, "The first block height to replay")
+	cmd.Flags().Int64(toHeightFlag, 0, "The last block height to replay")
+	cmd.Flags().String(engineFlag, "", "The stream engine to replay into, e.g. notify|redis|redis://127.0.0.1:6379")
+	addStreamFlags(cmd)
+	return cmd
+}
+
+// addStreamFlags adds the flags of the stream to the command running the app
+func addStreamFlags(cmd *cobra.Command) {
+	cmd.Flags().String(stream.FlagOutboxDir, "",
+		"The directory of the stream outbox db, which is the data directory of the node home by default")
+}
@@ -10,6 +10,7 @@ const (
 	ModuleName        = types.ModuleName
 	RouterKey         = types.RouterKey
 	StoreKey          = types.StoreKey
+	TStoreKey         = types.TStoreKey
 	DefaultParamspace = types.DefaultParamspace
 	QuerierRoute      = types.QuerierRoute
 )
@@ -34,4 +35,5 @@ type (
 
 	// nolint
 	SwapTokenPair = types.SwapTokenPair
+	SwapExecution = types.SwapExecution
 )
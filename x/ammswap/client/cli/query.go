@@ -2,6 +2,8 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -11,7 +13,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/okex/okexchain/x/ammswap/types"
 	"github.com/spf13/cobra"
-	"strings"
+	"github.com/spf13/viper"
 )
 
 // GetQueryCmd returns the cli query commands for this module
@@ -32,6 +34,10 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			GetCmdAllSwapTokenPairs(queryRoute, cdc),
 			GetCmdRedeemableAssets(queryRoute, cdc),
 			GetCmdQueryBuyAmount(queryRoute, cdc),
+			GetCmdQuerySellAmount(queryRoute, cdc),
+			GetCmdQuerySwapRoute(queryRoute, cdc),
+			GetCmdQueryPoolAPRs(queryRoute, cdc),
+			GetCmdQueryTWAP(queryRoute, cdc),
 		)...,
 	)
 
@@ -109,6 +115,186 @@ $ %s query swap amount 100eth-245 xxb`, version.ClientName,
 	}
 }
 
+// GetCmdQuerySellAmount queries amount of token to sell to buy exactly the given amount of token
+func GetCmdQuerySellAmount(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sell-amount [token-to-buy] [token-name-to-sell]",
+		Short: "Query how many token to sell to buy exactly the given amount of token",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Query how many token to sell to buy exactly the given amount of token, through the pool of the two tokens
+or the pools with the native token, through the swap token pairs of the path, or through the best path.
+
+Example:
+$ %s query swap sell-amount 100xxb eth-245
+$ %s query swap sell-amount 100xxb eth-245 --path eth-245_okt,okt_xxb
+$ %s query swap sell-amount 100xxb eth-245 --best-path`, version.ClientName, version.ClientName, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			buyToken, err := sdk.ParseDecCoin(args[0])
+			if err != nil {
+				return err
+			}
+			params := types.QueryBuyAmountParams{
+				BoughtToken: buyToken,
+				TokenToSell: args[1],
+				BestPath:    viper.GetBool(flagBestPath),
+			}
+			if path := viper.GetString(flagPath); path != "" {
+				params.Path = strings.Split(path, ",")
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryBuyAmount), bz)
+			if err != nil {
+				return err
+			}
+
+			var sellAmt sdk.Dec
+			cdc.MustUnmarshalJSON(res, &sellAmt)
+			return cliCtx.PrintOutput(sellAmt)
+		},
+	}
+	cmd.Flags().String(flagPath, "", "Names of the swap token pairs to route through, separated by comma")
+	cmd.Flags().Bool(flagBestPath, false, "Route through the path selling the least")
+	return cmd
+}
+
+// GetCmdQuerySwapRoute quotes the token bought through a path of swap token pairs and the price impact
+func GetCmdQuerySwapRoute(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "route [token-to-sell] [token-name-to-buy]",
+		Short: "Quote the token bought through a path of swap token pairs and the price impact",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Quote the token bought through the swap token pairs of the path, or through the best path if no path given.
+
+Example:
+$ %s query swap route 100eth-245 xxb --path eth-245_okt,okt_xxb
+$ %s query swap route 100eth-245 xxb`, version.ClientName, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sellToken, err := sdk.ParseDecCoin(args[0])
+			if err != nil {
+				return err
+			}
+			params := types.QuerySwapRouteParams{
+				SoldToken:  sellToken,
+				TokenToBuy: args[1],
+			}
+			if path := viper.GetString(flagPath); path != "" {
+				params.Path = strings.Split(path, ",")
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QuerySwapRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			var route types.SwapRoute
+			cdc.MustUnmarshalJSON(res, &route)
+			return cliCtx.PrintOutput(route)
+		},
+	}
+	cmd.Flags().String(flagPath, "", "Names of the swap token pairs to route through, separated by comma")
+	return cmd
+}
+
+// GetCmdQueryPoolAPRs queries the APRs earned by the liquidity providers from the swap fees
+func GetCmdQueryPoolAPRs(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "aprs [base-token] [quote-token]",
+		Short: "Query the APRs of all the pools, or of the pool of the tokens",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Query the annual percentage rates earned by the liquidity providers from the swap fees,
+annualized from the fees since the stats of the pools start.
+
+Example:
+$ %s query swap aprs
+$ %s query swap aprs eth-355 okt`, version.ClientName, version.ClientName,
+			),
+		),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 && len(args) != 2 {
+				return fmt.Errorf("accepts 0 or 2 arg(s), received %d", len(args))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryPoolAPRs)
+			if len(args) == 2 {
+				route = fmt.Sprintf("%s/%s/%s", route, args[0], args[1])
+			}
+			res, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var aprs types.PoolAPRs
+			cdc.MustUnmarshalJSON(res, &aprs)
+			return cliCtx.PrintOutput(aprs)
+		},
+	}
+}
+
+// GetCmdQueryTWAP queries the time-weighted average prices of a pool between two block heights
+func GetCmdQueryTWAP(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "twap [base-token] [quote-token] [from-height]",
+		Short: "Query the time-weighted average prices of the pool between two block heights",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Query the time-weighted average prices of the pool from the height, to the latest height if no to-height given.
+
+Example:
+$ %s query swap twap eth-355 okt 1000
+$ %s query swap twap eth-355 okt 1000 --to-height 2000`, version.ClientName, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			fromHeight, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+			params := types.QueryTWAPParams{
+				BaseToken:  args[0],
+				QuoteToken: args[1],
+				FromHeight: fromHeight,
+				ToHeight:   viper.GetInt64(flagToHeight),
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryTWAP), bz)
+			if err != nil {
+				return err
+			}
+
+			var twap types.TWAP
+			cdc.MustUnmarshalJSON(res, &twap)
+			return cliCtx.PrintOutput(twap)
+		},
+	}
+	cmd.Flags().Int64(flagToHeight, 0, "The height the average ends at, the latest height if zero")
+	return cmd
+}
+
 // GetCmdQueryParams queries the parameters of the AMM swap system
 func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
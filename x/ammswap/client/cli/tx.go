@@ -29,6 +29,14 @@ const (
 	flagRecipient        = "recipient"
 	flagToken0           = "token0"
 	flagToken1           = "token1"
+	flagPath             = "path"
+	flagBestPath         = "best-path"
+	flagMaxSellAmount    = "max-sell-amount"
+	flagBuyAmount        = "buy-amount"
+	flagToHeight         = "to-height"
+	flagPoolType         = "pool-type"
+	flagAmplification    = "amplification"
+	flagWeights          = "weights"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -43,6 +51,7 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		getCmdRemoveLiquidity(cdc),
 		getCmdCreateExchange(cdc),
 		getCmdTokenSwap(cdc),
+		getCmdTokenSwapForExactToken(cdc),
 	)...)
 
 	return txCmd
@@ -160,22 +169,42 @@ func getCmdCreateExchange(cdc *codec.Codec) *cobra.Command {
 	// flags
 	var token0 string
 	var token1 string
+	var poolType string
+	var amplification int64
+	var weights string
 	cmd := &cobra.Command{
 		Use:   "create-pair",
 		Short: "create token pair",
 		Long: strings.TrimSpace(
-			fmt.Sprintf(`create token pair.
+			fmt.Sprintf(`create token pair, priced with the constant product curve by default.
 
 Example:
 $ okexchaincli tx swap create-pair --token0 eth-355 --token1 btc-366 --fees 0.01okt 
+$ okexchaincli tx swap create-pair --token0 usdt-355 --token1 usdk-366 --pool-type %s --amplification 100 --fees 0.01okt 
+$ okexchaincli tx swap create-pair --token0 eth-355 --token1 btc-366 --pool-type %s --weights 0.8,0.2 --fees 0.01okt 
 
-`),
+`, types.PoolTypeStable, types.PoolTypeWeighted),
 		),
 
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
-			msg := types.NewMsgCreateExchange(token0, token1, cliCtx.FromAddress)
+			var token0Weight, token1Weight sdk.Dec
+			if weights != "" {
+				weightStrs := strings.Split(weights, ",")
+				if len(weightStrs) != 2 {
+					return fmt.Errorf("invalid weights: %s", weights)
+				}
+				var err error
+				if token0Weight, err = sdk.NewDecFromStr(strings.TrimSpace(weightStrs[0])); err != nil {
+					return fmt.Errorf("invalid weights: %s", weights)
+				}
+				if token1Weight, err = sdk.NewDecFromStr(strings.TrimSpace(weightStrs[1])); err != nil {
+					return fmt.Errorf("invalid weights: %s", weights)
+				}
+			}
+			msg := types.NewMsgCreateExchangeWithCurve(token0, token1, poolType, amplification,
+				token0Weight, token1Weight, cliCtx.FromAddress)
 
 			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
 		},
@@ -183,6 +212,10 @@ $ okexchaincli tx swap create-pair --token0 eth-355 --token1 btc-366 --fees 0.01
 
 	cmd.Flags().StringVar(&token0, flagToken0, "", "the base token name is required to create an AMM swap pair")
 	cmd.Flags().StringVar(&token1, flagToken1, "", "the quote token name is required to create an AMM swap pair")
+	cmd.Flags().StringVar(&poolType, flagPoolType, "", fmt.Sprintf("the curve of the pool: %s, %s or %s",
+		types.PoolTypeConstantProduct, types.PoolTypeStable, types.PoolTypeWeighted))
+	cmd.Flags().Int64Var(&amplification, flagAmplification, 0, "the amplification coefficient of a stable pool")
+	cmd.Flags().StringVar(&weights, flagWeights, "", "the weights of token0 & token1 in a weighted pool, separated by comma")
 	cmd.MarkFlagRequired(flagToken0)
 	cmd.MarkFlagRequired(flagToken1)
 	return cmd
@@ -194,6 +227,8 @@ func getCmdTokenSwap(cdc *codec.Codec) *cobra.Command {
 	var minBoughtTokenAmount string
 	var deadline string
 	var recipient string
+	var path string
+	var bestPath bool
 	cmd := &cobra.Command{
 		Use:   "token",
 		Short: "swap token",
@@ -203,6 +238,10 @@ func getCmdTokenSwap(cdc *codec.Codec) *cobra.Command {
 Example:
 $ okexchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366
 
+Route through the swap token pairs of the path, or through the best path found on chain:
+$ okexchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366 --path eth-355_okt,btc-366_okt
+$ okexchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366 --best-path
+
 `),
 		),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -232,7 +271,11 @@ $ okexchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366
 				}
 			}
 
-			msg := types.NewMsgTokenToToken(soldTokenAmount, minBoughtTokenAmount,
+			var swapPath []string
+			if path != "" {
+				swapPath = strings.Split(path, ",")
+			}
+			msg := types.NewMsgTokenToTokenByRoute(soldTokenAmount, minBoughtTokenAmount, swapPath, bestPath,
 				deadline, recip, cliCtx.FromAddress)
 
 			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
@@ -245,6 +288,10 @@ $ okexchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366
 		"Minimum amount expected to buy")
 	cmd.Flags().StringVarP(&recipient, flagRecipient, "", "",
 		"The address to receive the amount bought")
+	cmd.Flags().StringVarP(&path, flagPath, "", "",
+		"Names of the swap token pairs to route through, separated by comma")
+	cmd.Flags().BoolVarP(&bestPath, flagBestPath, "", false,
+		"Route through the path buying the most, found on chain")
 	cmd.Flags().StringVarP(&deadline, flagDeadlineDuration, "", "100s",
 		"Duration after which this transaction can no longer be executed. such as \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
 	cmd.MarkFlagRequired(flagSellAmount)
@@ -252,3 +299,82 @@ $ okexchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366
 
 	return cmd
 }
+
+func getCmdTokenSwapForExactToken(cdc *codec.Codec) *cobra.Command {
+	// flags
+	var maxSoldTokenAmount string
+	var boughtTokenAmount string
+	var deadline string
+	var recipient string
+	var path string
+	var bestPath bool
+	cmd := &cobra.Command{
+		Use:   "token-for-exact",
+		Short: "swap token for an exact amount of token",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`swap token for an exact amount of token, selling at most the max sell amount.
+
+Example:
+$ okexchaincli tx swap token-for-exact --max-sell-amount 2eth-355 --buy-amount 60btc-366
+
+Route through the swap token pairs of the path, or through the best path found on chain:
+$ okexchaincli tx swap token-for-exact --max-sell-amount 2eth-355 --buy-amount 60btc-366 --path eth-355_okt,btc-366_okt
+$ okexchaincli tx swap token-for-exact --max-sell-amount 2eth-355 --buy-amount 60btc-366 --best-path
+
+`),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			maxSoldTokenAmount, err := sdk.ParseDecCoin(maxSoldTokenAmount)
+			if err != nil {
+				return err
+			}
+			boughtTokenAmount, err := sdk.ParseDecCoin(boughtTokenAmount)
+			if err != nil {
+				return err
+			}
+			dur, err := time.ParseDuration(deadline)
+			if err != nil {
+				return err
+			}
+			deadline := time.Now().Add(dur).Unix()
+			var recip sdk.AccAddress
+			if recipient == "" {
+				recip = cliCtx.FromAddress
+			} else {
+				recip, err = sdk.AccAddressFromBech32(recipient)
+				if err != nil {
+					return err
+				}
+			}
+
+			var swapPath []string
+			if path != "" {
+				swapPath = strings.Split(path, ",")
+			}
+			msg := types.NewMsgTokenToExactToken(maxSoldTokenAmount, boughtTokenAmount, swapPath, bestPath,
+				deadline, recip, cliCtx.FromAddress)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&maxSoldTokenAmount, flagMaxSellAmount, "", "",
+		"Maximum amount expected to sell")
+	cmd.Flags().StringVarP(&boughtTokenAmount, flagBuyAmount, "", "",
+		"Exact amount expected to buy")
+	cmd.Flags().StringVarP(&recipient, flagRecipient, "", "",
+		"The address to receive the amount bought")
+	cmd.Flags().StringVarP(&path, flagPath, "", "",
+		"Names of the swap token pairs to route through, separated by comma")
+	cmd.Flags().BoolVarP(&bestPath, flagBestPath, "", false,
+		"Route through the path selling the least, found on chain")
+	cmd.Flags().StringVarP(&deadline, flagDeadlineDuration, "", "100s",
+		"Duration after which this transaction can no longer be executed. such as \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
+	cmd.MarkFlagRequired(flagMaxSellAmount)
+	cmd.MarkFlagRequired(flagBuyAmount)
+
+	return cmd
+}
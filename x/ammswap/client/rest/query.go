@@ -11,6 +11,7 @@ import (
 	"github.com/okex/okexchain/x/ammswap/types"
 	"github.com/okex/okexchain/x/common"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -20,6 +21,10 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/swap_token_pairs", querySwapTokenPairsHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/params", queryParamsHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/buy_amount", queryBuyAmountHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/sell_amount", querySellAmountHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/swap_route", querySwapRouteHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/pool_aprs", queryPoolAPRsHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/twap", queryTWAPHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/redeemable_assets", queryRedeemableAssetsHandler(cliCtx)).Methods("GET")
 }
 
@@ -97,6 +102,134 @@ func queryBuyAmountHandler(cliContext context.CLIContext) func(http.ResponseWrit
 
 }
 
+func querySellAmountHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		boughtTokenStr := r.URL.Query().Get("bought_token")
+		tokenToSellStr := r.URL.Query().Get("token_to_sell")
+		pathStr := r.URL.Query().Get("path")
+		bestPathStr := r.URL.Query().Get("best_path")
+
+		buyToken, err := sdk.ParseDecCoin(boughtTokenStr)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+		params := types.QueryBuyAmountParams{
+			BoughtToken: buyToken,
+			TokenToSell: tokenToSellStr,
+		}
+		if pathStr != "" {
+			params.Path = strings.Split(pathStr, ",")
+		}
+		if bestPathStr != "" {
+			if params.BestPath, err = strconv.ParseBool(bestPathStr); err != nil {
+				common.HandleErrorMsg(w, cliContext, err.Error())
+				return
+			}
+		}
+		bz, err := codec.Cdc.MarshalJSON(params)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+		res, _, err := cliContext.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryBuyAmount), bz)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		formatAndReturnResult(w, cliContext, res)
+	}
+
+}
+
+func querySwapRouteHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		soldTokenStr := r.URL.Query().Get("sold_token")
+		tokenToBuyStr := r.URL.Query().Get("token_to_buy")
+		pathStr := r.URL.Query().Get("path")
+
+		sellToken, err := sdk.ParseDecCoin(soldTokenStr)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+		params := types.QuerySwapRouteParams{
+			SoldToken:  sellToken,
+			TokenToBuy: tokenToBuyStr,
+		}
+		if pathStr != "" {
+			params.Path = strings.Split(pathStr, ",")
+		}
+		bz, err := codec.Cdc.MarshalJSON(params)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+		res, _, err := cliContext.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QuerySwapRoute), bz)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		formatAndReturnResult(w, cliContext, res)
+	}
+
+}
+
+func queryPoolAPRsHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseToken := r.URL.Query().Get("base_token")
+		quoteToken := r.URL.Query().Get("quote_token")
+
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryPoolAPRs)
+		if baseToken != "" || quoteToken != "" {
+			route = fmt.Sprintf("%s/%s/%s", route, baseToken, quoteToken)
+		}
+		res, _, err := cliContext.QueryWithData(route, nil)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		formatAndReturnResult(w, cliContext, res)
+	}
+
+}
+
+func queryTWAPHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := types.QueryTWAPParams{
+			BaseToken:  r.URL.Query().Get("base_token"),
+			QuoteToken: r.URL.Query().Get("quote_token"),
+		}
+		var err error
+		if params.FromHeight, err = strconv.ParseInt(r.URL.Query().Get("from_height"), 10, 64); err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+		if toHeightStr := r.URL.Query().Get("to_height"); toHeightStr != "" {
+			if params.ToHeight, err = strconv.ParseInt(toHeightStr, 10, 64); err != nil {
+				common.HandleErrorMsg(w, cliContext, err.Error())
+				return
+			}
+		}
+		bz, err := codec.Cdc.MarshalJSON(params)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+		res, _, err := cliContext.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryTWAP), bz)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		formatAndReturnResult(w, cliContext, res)
+	}
+
+}
+
 func queryRedeemableAssetsHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		baseTokenName := r.URL.Query().Get("base_token_name")
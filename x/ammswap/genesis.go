@@ -21,6 +21,9 @@ func NewGenesisState(swapTokenPairRecords []SwapTokenPair) GenesisState {
 
 // ValidateGenesis validates the format of the specified genesisState
 func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
 	for _, record := range data.SwapTokenPairRecords {
 		if !record.QuotePooledCoin.IsValid() {
 			return fmt.Errorf("invalid SwapTokenPairRecord: QuotePooledCoin: %s", record.QuotePooledCoin.String())
@@ -31,6 +34,9 @@ func ValidateGenesis(data GenesisState) error {
 		if !tokentypes.NotAllowedOriginSymbol(record.PoolTokenName) {
 			return fmt.Errorf("invalid SwapTokenPairRecord: PoolToken: %s. Error: invalid PoolToken", record.PoolTokenName)
 		}
+		if err := types.ValidatePoolCurve(record.PoolType, record.Amplification, record.BaseWeight, record.QuoteWeight); err != nil {
+			return fmt.Errorf("invalid SwapTokenPairRecord: %s", err.Error())
+		}
 	}
 	return nil
 }
@@ -42,6 +42,11 @@ func TestValidateGenesis(t *testing.T) {
 	}
 	err = ValidateGenesis(defaultGenesisState)
 	require.NotNil(t, err)
+
+	defaultGenesisState.SwapTokenPairRecords = []SwapTokenPair{testSwapTokenPair}
+	defaultGenesisState.Params.ProtocolFeeRate = sdk.NewDecWithPrec(11, 1)
+	err = ValidateGenesis(defaultGenesisState)
+	require.NotNil(t, err)
 }
 
 func TestInitAndExportGenesis(t *testing.T) {
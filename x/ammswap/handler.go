@@ -2,6 +2,8 @@ package ammswap
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/okex/okexchain/x/ammswap/keeper"
 	"github.com/okex/okexchain/x/ammswap/types"
 	"github.com/okex/okexchain/x/common"
@@ -37,6 +39,11 @@ func NewHandler(k Keeper) sdk.Handler {
 			handlerFun = func() sdk.Result {
 				return handleMsgTokenToToken(ctx, k, msg)
 			}
+		case types.MsgTokenToExactToken:
+			name = "handleMsgTokenToExactToken"
+			handlerFun = func() sdk.Result {
+				return handleMsgTokenToExactToken(ctx, k, msg)
+			}
 		default:
 			errMsg := fmt.Sprintf("Invalid msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -48,6 +55,9 @@ func NewHandler(k Keeper) sdk.Handler {
 }
 
 func handleMsgTokenToToken(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) sdk.Result {
+	if msg.BestPath || len(msg.Path) > 0 {
+		return swapTokenByPath(ctx, k, msg)
+	}
 	_, err := k.GetSwapTokenPair(ctx, msg.GetSwapTokenPairName())
 	if err != nil {
 		return swapTokenByRouter(ctx, k, msg)
@@ -96,11 +106,14 @@ func handleMsgCreateExchange(ctx sdk.Context, k Keeper, msg types.MsgCreateExcha
 	k.NewPoolToken(ctx, poolTokenName)
 
 	// 4. create the token pair
-	swapTokenPair := types.NewSwapPair(msg.Token0Name, msg.Token1Name)
+	swapTokenPair := types.NewSwapPairWithCurve(msg.Token0Name, msg.Token1Name, msg.PoolType, msg.Amplification,
+		msg.Token0Weight, msg.Token1Weight)
+	swapTokenPair.StatsStartTime = ctx.BlockTime().Unix()
 	k.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
 
 	event = event.AppendAttributes(sdk.NewAttribute("pool-token-name", poolTokenName))
 	event = event.AppendAttributes(sdk.NewAttribute("token-pair", tokenPairName))
+	event = event.AppendAttributes(sdk.NewAttribute("pool-type", swapTokenPair.GetPoolType()))
 	ctx.EventManager().EmitEvent(event)
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
@@ -129,6 +142,8 @@ func handleMsgAddLiquidity(ctx sdk.Context, k Keeper, msg types.MsgAddLiquidity)
 			Log:  fmt.Sprintf("failed to get pool token %s : %s", swapTokenPair.PoolTokenName, err.Error()),
 		}
 	}
+	// the first liquidity sets the price of the pool, the later ones are added in proportion to the reserves,
+	// which keeps the prices of all the pool types
 	if swapTokenPair.QuotePooledCoin.Amount.IsZero() && swapTokenPair.BasePooledCoin.Amount.IsZero() {
 		baseTokens.Amount = msg.MaxBaseAmount.Amount
 		liquidity = sdk.NewDec(1)
@@ -186,6 +201,7 @@ func handleMsgAddLiquidity(ctx sdk.Context, k Keeper, msg types.MsgAddLiquidity)
 		}
 	}
 	// update swapTokenPair
+	k.UpdatePriceCumulative(ctx, &swapTokenPair)
 	swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(msg.QuoteAmount)
 	swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(baseTokens)
 	k.SetSwapTokenPair(ctx, msg.GetSwapTokenPairName(), swapTokenPair)
@@ -265,6 +281,7 @@ func handleMsgRemoveLiquidity(ctx sdk.Context, k Keeper, msg types.MsgRemoveLiqu
 		}
 	}
 	// update swapTokenPair
+	k.UpdatePriceCumulative(ctx, &swapTokenPair)
 	swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(quoteAmount)
 	swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(baseAmount)
 	k.SetSwapTokenPair(ctx, msg.GetSwapTokenPairName(), swapTokenPair)
@@ -428,6 +445,100 @@ func swapTokenByRouter(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) sdk
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
+func swapTokenByPath(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) sdk.Result {
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+
+	if msg.Deadline < ctx.BlockTime().Unix() {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  "Failed: block time exceeded deadline",
+		}
+	}
+	if err := common.HasSufficientCoins(msg.Sender, k.GetTokenKeeper().GetCoins(ctx, msg.Sender),
+		sdk.DecCoins{msg.SoldTokenAmount}); err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInsufficientCoins,
+			Log:  err.Error(),
+		}
+	}
+
+	route, tokenPairs, err := k.QuoteSwapRoute(ctx, msg.SoldTokenAmount, msg.MinBoughtTokenAmount.Denom, msg.Path)
+	if err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  fmt.Sprintf("Failed to swap token by path: %s", err.Error()),
+		}
+	}
+	// slippage is only checked against the token bought by the last hop
+	tokenBuy := route.BoughtToken()
+	if tokenBuy.Amount.LT(msg.MinBoughtTokenAmount.Amount) {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  fmt.Sprintf("Failed: expected minimum token to buy is %s but got %s", msg.MinBoughtTokenAmount, tokenBuy),
+		}
+	}
+
+	if err := k.SwapByRoute(ctx, msg.Sender, msg.Recipient, tokenPairs, route); err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInsufficientCoins,
+			Log:  fmt.Sprintf("insufficient Coins: %s", err.Error()),
+		}
+	}
+
+	event = event.AppendAttributes(sdk.NewAttribute("bought_token_amount", tokenBuy.String()))
+	event = event.AppendAttributes(sdk.NewAttribute("recipient", msg.Recipient.String()))
+	event = event.AppendAttributes(sdk.NewAttribute("path", strings.Join(route.Path, ",")))
+	ctx.EventManager().EmitEvent(event)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgTokenToExactToken(ctx sdk.Context, k Keeper, msg types.MsgTokenToExactToken) sdk.Result {
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+
+	if msg.Deadline < ctx.BlockTime().Unix() {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  "Failed: block time exceeded deadline",
+		}
+	}
+
+	route, tokenPairs, err := k.QuoteSwapRouteForExactOutput(ctx, msg.MaxSoldTokenAmount.Denom, msg.BoughtTokenAmount,
+		msg.Path, msg.BestPath)
+	if err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  fmt.Sprintf("Failed to swap token for exact token: %s", err.Error()),
+		}
+	}
+	if route.SoldToken.Amount.GT(msg.MaxSoldTokenAmount.Amount) {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  fmt.Sprintf("Failed: expected maximum token to sell is %s but got %s", msg.MaxSoldTokenAmount, route.SoldToken),
+		}
+	}
+	if err := common.HasSufficientCoins(msg.Sender, k.GetTokenKeeper().GetCoins(ctx, msg.Sender),
+		sdk.DecCoins{route.SoldToken}); err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInsufficientCoins,
+			Log:  err.Error(),
+		}
+	}
+
+	if err := k.SwapByRoute(ctx, msg.Sender, msg.Recipient, tokenPairs, route); err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInsufficientCoins,
+			Log:  fmt.Sprintf("insufficient Coins: %s", err.Error()),
+		}
+	}
+
+	event = event.AppendAttributes(sdk.NewAttribute("sold_token_amount", route.SoldToken.String()))
+	event = event.AppendAttributes(sdk.NewAttribute("bought_token_amount", route.BoughtToken().String()))
+	event = event.AppendAttributes(sdk.NewAttribute("recipient", msg.Recipient.String()))
+	event = event.AppendAttributes(sdk.NewAttribute("path", strings.Join(route.Path, ",")))
+	ctx.EventManager().EmitEvent(event)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
 func swapTokenNativeToken(
 	ctx sdk.Context, k Keeper, swapTokenPair SwapTokenPair, tokenBuy sdk.DecCoin,
 	msg types.MsgTokenToToken,
@@ -449,15 +560,25 @@ func swapTokenNativeToken(
 		}
 	}
 
+	soldToken, err := k.SettleSwapFee(ctx, &swapTokenPair, msg.SoldTokenAmount)
+	if err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  fmt.Sprintf("failed to settle swap fee: %s", err.Error()),
+		}
+	}
+
 	// update swapTokenPair
+	k.UpdatePriceCumulative(ctx, &swapTokenPair)
 	if msg.MinBoughtTokenAmount.Denom < msg.SoldTokenAmount.Denom {
-		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(msg.SoldTokenAmount)
+		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(soldToken)
 		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(tokenBuy)
 	} else {
 		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(tokenBuy)
-		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(msg.SoldTokenAmount)
+		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(soldToken)
 	}
 	k.SetSwapTokenPair(ctx, msg.GetSwapTokenPairName(), swapTokenPair)
+	k.RecordSwapExecution(ctx, msg.Sender, msg.GetSwapTokenPairName(), msg.SoldTokenAmount, tokenBuy)
 	return sdk.Result{}
 }
 
@@ -545,3 +545,221 @@ func buildRandomMsgTokenToToken(addr sdk.AccAddress) types.MsgTokenToToken {
 
 	return msg
 }
+
+func TestHandleMsgTokenToTokenByPath(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 2, 100000)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(keeper)
+	addr := addrKeysSlice[0].Address
+	recipient := addrKeysSlice[1].Address
+	deadLine := time.Now().Unix()
+
+	aab, ccb, okt := types.TestBasePooledToken, types.TestBasePooledToken2, types.TestQuotePooledToken
+	for _, symbol := range []string{aab, ccb, okt} {
+		mapp.tokenKeeper.NewToken(ctx, initToken(symbol))
+	}
+	pools := []struct {
+		base, quote string
+		amount      int64
+	}{
+		{aab, okt, 10000},
+		{ccb, okt, 10000},
+		{aab, ccb, 100},
+	}
+	for _, pool := range pools {
+		result := handler(ctx, types.NewMsgCreateExchange(pool.base, pool.quote, addr))
+		require.Equal(t, "", result.Log)
+		result = handler(ctx, types.NewMsgAddLiquidity(sdk.NewDec(1),
+			sdk.NewDecCoinFromDec(pool.base, sdk.NewDec(pool.amount)),
+			sdk.NewDecCoinFromDec(pool.quote, sdk.NewDec(pool.amount)), deadLine, addr))
+		require.Equal(t, "", result.Log)
+	}
+
+	soldTokenAmount := sdk.NewDecCoinFromDec(aab, sdk.NewDec(10))
+	okPath := []string{types.GetSwapTokenPairName(aab, okt), types.GetSwapTokenPairName(ccb, okt)}
+	route, _, err := keeper.QuoteSwapRoute(ctx, soldTokenAmount, ccb, okPath)
+	require.Nil(t, err)
+
+	tests := []struct {
+		testCase             string
+		minBoughtTokenAmount sdk.DecCoin
+		path                 []string
+		bestPath             bool
+		exceptResultCode     sdk.CodeType
+	}{
+		{"slippage is checked against the final output",
+			sdk.NewDecCoinFromDec(ccb, route.BoughtToken().Amount.Add(sdk.NewDecWithPrec(1, 8))),
+			okPath, false, sdk.CodeInternal},
+		{"path not ending with the bought token",
+			sdk.NewDecCoinFromDec(ccb, sdk.ZeroDec()), okPath[:1], false, sdk.CodeInternal},
+		{"success through the explicit path", route.BoughtToken(), okPath, false, sdk.CodeOK},
+		{"success through the best path", sdk.NewDecCoinFromDec(ccb, sdk.NewDec(9)), nil, true, sdk.CodeOK},
+	}
+	for _, testCase := range tests {
+		fmt.Println(testCase.testCase)
+		msg := types.NewMsgTokenToTokenByRoute(soldTokenAmount, testCase.minBoughtTokenAmount, testCase.path,
+			testCase.bestPath, deadLine, recipient, addr)
+		result := handler(ctx, msg)
+		require.Equal(t, testCase.exceptResultCode, result.Code, result.Log)
+	}
+
+	// the intermediate okt stays in the pools
+	acc := mapp.AccountKeeper.GetAccount(ctx, recipient)
+	require.True(t, acc.GetCoins().AmountOf(ccb).GT(sdk.NewDec(100000).Add(route.BoughtToken().Amount)))
+	require.Equal(t, sdk.NewDec(100000), acc.GetCoins().AmountOf(okt))
+	acc = mapp.AccountKeeper.GetAccount(ctx, addr)
+	require.Equal(t, sdk.NewDec(100000-10000-100-20), acc.GetCoins().AmountOf(aab))
+	aabPool, err := keeper.GetSwapTokenPair(ctx, okPath[0])
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(10020), aabPool.BasePooledCoin.Amount)
+	shallowPool, err := keeper.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(aab, ccb))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(100), shallowPool.BasePooledCoin.Amount)
+
+	// every hop of the swaps is recorded in order
+	executions := keeper.GetSwapExecutions(ctx)
+	require.Equal(t, 4, len(executions))
+	require.Equal(t, okPath[0], executions[0].TokenPairName)
+	require.Equal(t, soldTokenAmount, executions[0].SoldToken)
+	require.Equal(t, soldTokenAmount.Amount.MulTruncate(types.DefaultParams().FeeRate), executions[0].Fee.Amount)
+	require.Equal(t, okPath[1], executions[1].TokenPairName)
+	require.Equal(t, executions[0].BoughtToken, executions[1].SoldToken)
+	require.Equal(t, route.BoughtToken(), executions[1].BoughtToken)
+	require.Equal(t, addr, executions[1].Sender)
+	require.EqualValues(t, 1, executions[1].Index)
+}
+
+func TestHandleMsgTokenToExactToken(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 2, 100000)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(keeper)
+	addr := addrKeysSlice[0].Address
+	recipient := addrKeysSlice[1].Address
+	deadLine := time.Now().Unix()
+
+	aab, ccb, okt := types.TestBasePooledToken, types.TestBasePooledToken2, types.TestQuotePooledToken
+	for _, symbol := range []string{aab, ccb, okt} {
+		mapp.tokenKeeper.NewToken(ctx, initToken(symbol))
+	}
+	for _, base := range []string{aab, ccb} {
+		result := handler(ctx, types.NewMsgCreateExchange(base, okt, addr))
+		require.Equal(t, "", result.Log)
+		result = handler(ctx, types.NewMsgAddLiquidity(sdk.NewDec(1),
+			sdk.NewDecCoinFromDec(base, sdk.NewDec(10000)),
+			sdk.NewDecCoinFromDec(okt, sdk.NewDec(10000)), deadLine, addr))
+		require.Equal(t, "", result.Log)
+	}
+
+	boughtToken := sdk.NewDecCoinFromDec(okt, sdk.NewDec(10))
+	direct, _, err := keeper.QuoteSwapRouteForExactOutput(ctx, aab, boughtToken, nil, false)
+	require.Nil(t, err)
+	routedToken := sdk.NewDecCoinFromDec(ccb, sdk.NewDec(10))
+	maxRoutedSoldToken := sdk.NewDecCoinFromDec(aab, sdk.NewDec(11))
+
+	tests := []struct {
+		testCase           string
+		maxSoldTokenAmount sdk.DecCoin
+		boughtTokenAmount  sdk.DecCoin
+		deadline           int64
+		exceptResultCode   sdk.CodeType
+	}{
+		{"max sold token amount exceeded",
+			sdk.NewDecCoinFromDec(aab, direct.SoldToken.Amount.Sub(sdk.NewDecWithPrec(1, 8))),
+			boughtToken, deadLine, sdk.CodeInternal},
+		{"block time exceeded deadline", direct.SoldToken, boughtToken, deadLine - 1, sdk.CodeInternal},
+		{"insufficient liquidity", sdk.NewDecCoinFromDec(aab, sdk.NewDec(100000)),
+			sdk.NewDecCoinFromDec(okt, sdk.NewDec(10000)), deadLine, sdk.CodeInternal},
+		{"success through the pool", direct.SoldToken, boughtToken, deadLine, sdk.CodeOK},
+		{"success through the pools with the native token", maxRoutedSoldToken, routedToken, deadLine, sdk.CodeOK},
+	}
+	for _, testCase := range tests {
+		fmt.Println(testCase.testCase)
+		msg := types.NewMsgTokenToExactToken(testCase.maxSoldTokenAmount, testCase.boughtTokenAmount, nil, false,
+			testCase.deadline, recipient, addr)
+		result := handler(ctx, msg)
+		require.Equal(t, testCase.exceptResultCode, result.Code, result.Log)
+	}
+
+	acc := mapp.AccountKeeper.GetAccount(ctx, recipient)
+	require.Equal(t, sdk.NewDec(100010), acc.GetCoins().AmountOf(okt))
+	require.Equal(t, sdk.NewDec(100010), acc.GetCoins().AmountOf(ccb))
+	acc = mapp.AccountKeeper.GetAccount(ctx, addr)
+	routedSoldAmount := sdk.NewDec(90000).Sub(direct.SoldToken.Amount).Sub(acc.GetCoins().AmountOf(aab))
+	require.True(t, routedSoldAmount.GT(sdk.NewDec(10)) && routedSoldAmount.LTE(maxRoutedSoldToken.Amount))
+	// the intermediate okt stays in the pools
+	require.Equal(t, sdk.NewDec(80000), acc.GetCoins().AmountOf(okt))
+}
+
+func TestHandleMsgCreateExchangeWithCurve(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(keeper)
+	addr := addrKeysSlice[0].Address
+	deadLine := time.Now().Unix()
+
+	aab, ccb, okt := types.TestBasePooledToken, types.TestBasePooledToken2, types.TestQuotePooledToken
+	for _, symbol := range []string{aab, ccb, okt} {
+		mapp.tokenKeeper.NewToken(ctx, initToken(symbol))
+	}
+	// the weights are the ones of token0 & token1
+	msgs := []types.MsgCreateExchange{
+		types.NewMsgCreateExchangeWithCurve(aab, ccb, types.PoolTypeStable, 100, sdk.Dec{}, sdk.Dec{}, addr),
+		types.NewMsgCreateExchangeWithCurve(okt, ccb, types.PoolTypeWeighted, 0,
+			sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(8, 1), addr),
+	}
+	for _, msg := range msgs {
+		result := handler(ctx, msg)
+		require.Equal(t, "", result.Log)
+		base, quote := types.GetBaseQuoteTokenName(msg.Token0Name, msg.Token1Name)
+		result = handler(ctx, types.NewMsgAddLiquidity(sdk.NewDec(1),
+			sdk.NewDecCoinFromDec(base, sdk.NewDec(10000)),
+			sdk.NewDecCoinFromDec(quote, sdk.NewDec(10000)), deadLine, addr))
+		require.Equal(t, "", result.Log)
+	}
+	stablePair, err := keeper.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(aab, ccb))
+	require.Nil(t, err)
+	require.Equal(t, types.PoolTypeStable, stablePair.PoolType)
+	require.Equal(t, int64(100), stablePair.Amplification)
+	weightedPair, err := keeper.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(ccb, okt))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDecWithPrec(8, 1), weightedPair.BaseWeight)
+	require.Equal(t, sdk.NewDecWithPrec(2, 1), weightedPair.QuoteWeight)
+
+	// the stable pool swaps at almost 1:1
+	soldToken := sdk.NewDecCoinFromDec(aab, sdk.NewDec(100))
+	result := handler(ctx, types.NewMsgTokenToToken(soldToken, sdk.NewDecCoinFromDec(ccb, sdk.NewDec(99)), deadLine, addr, addr))
+	require.Equal(t, "", result.Log)
+	acc := mapp.AccountKeeper.GetAccount(ctx, addr)
+	require.True(t, acc.GetCoins().AmountOf(ccb).GT(sdk.NewDec(100000-20000+99)))
+
+	// buy exactly through the weighted pool, which prices ccb at 4 okt
+	ccbAmount := acc.GetCoins().AmountOf(ccb)
+	boughtToken := sdk.NewDecCoinFromDec(okt, sdk.NewDec(10))
+	result = handler(ctx, types.NewMsgTokenToExactToken(sdk.NewDecCoinFromDec(ccb, sdk.NewDec(3)), boughtToken,
+		nil, false, deadLine, addr, addr))
+	require.Equal(t, "", result.Log)
+	acc = mapp.AccountKeeper.GetAccount(ctx, addr)
+	require.Equal(t, sdk.NewDec(100000-10000+10), acc.GetCoins().AmountOf(okt))
+	soldAmount := ccbAmount.Sub(acc.GetCoins().AmountOf(ccb))
+	require.True(t, soldAmount.GT(sdk.MustNewDecFromStr("2.5")) && soldAmount.LT(sdk.MustNewDecFromStr("2.52")))
+
+	// the liquidity is redeemed in proportion to the reserves
+	weightedPair, err = keeper.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(ccb, okt))
+	require.Nil(t, err)
+	result = handler(ctx, types.NewMsgRemoveLiquidity(sdk.NewDecWithPrec(5, 1),
+		sdk.NewDecCoinFromDec(ccb, weightedPair.BasePooledCoin.Amount.QuoInt64(2)),
+		sdk.NewDecCoinFromDec(okt, weightedPair.QuotePooledCoin.Amount.QuoInt64(2)), deadLine, addr))
+	require.Equal(t, "", result.Log)
+}
@@ -24,6 +24,7 @@ type TestInput struct {
 	*mock.App
 
 	keySwap   *sdk.KVStoreKey
+	tkeySwap  *sdk.TransientStoreKey
 	keyToken  *sdk.KVStoreKey
 	keyLock   *sdk.KVStoreKey
 	keySupply *sdk.KVStoreKey
@@ -53,6 +54,7 @@ func getTestInputWithBalance(t *testing.T, numGenAccs int, balance int64) (mockA
 	mockApp = &TestInput{
 		App:       mapp,
 		keySwap:   sdk.NewKVStoreKey(types.StoreKey),
+		tkeySwap:  sdk.NewTransientStoreKey(types.TStoreKey),
 		keyToken:  sdk.NewKVStoreKey(token.StoreKey),
 		keyLock:   sdk.NewKVStoreKey(token.KeyLock),
 		keySupply: sdk.NewKVStoreKey(supply.StoreKey),
@@ -87,8 +89,10 @@ func getTestInputWithBalance(t *testing.T, numGenAccs int, balance int64) (mockA
 	mockApp.swapKeeper = NewKeeper(
 		mockApp.supplyKeeper,
 		mockApp.tokenKeeper,
+		nil,
 		mockApp.Cdc,
 		mockApp.keySwap,
+		mockApp.tkeySwap,
 		mockApp.ParamsKeeper.Subspace(types.DefaultParamspace),
 	)
 
@@ -111,6 +115,7 @@ func getTestInputWithBalance(t *testing.T, numGenAccs int, balance int64) (mockA
 	app := mockApp
 	require.NoError(t, app.CompleteSetup(
 		app.keySwap,
+		app.tkeySwap,
 		app.keyToken,
 		app.keyLock,
 		app.keySupply,
@@ -0,0 +1,343 @@
+package keeper
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/ammswap/types"
+)
+
+// curvePrecision is the number of decimals of the fixed-point numbers the stable & weighted curves are computed with,
+// it's higher than sdk.Precision so that the iterations & the series don't lose the precision of sdk.Dec
+const curvePrecision = 27
+
+// the max number of the Newton iterations of the StableSwap invariant
+const maxStableIterations = 255
+
+// the max number of the input prices searchInputAmount computes, which bisects up to 2^128 times the smallest unit
+const maxInputSearchSteps = 3 * 128
+
+var (
+	curveOne      = new(big.Int).Exp(big.NewInt(10), big.NewInt(curvePrecision), nil)
+	curveTwo      = new(big.Int).Mul(curveOne, big.NewInt(2))
+	decToCurveInt = new(big.Int).Exp(big.NewInt(10), big.NewInt(curvePrecision-sdk.Precision), nil)
+	curveLn2      = lnSeries(curveTwo)
+	// the input amounts beyond 2^128 times the smallest unit of sdk.Dec are out of the range of searchInputAmount
+	maxCurveInputAmount = new(big.Int).Lsh(decToCurveInt, 128)
+)
+
+// getPoolReserves returns the reserves & the weights of the sold token & its counterpart in the pool
+func getPoolReserves(tokenPair types.SwapTokenPair, soldTokenName string) (inputReserve, outputReserve,
+	inputWeight, outputWeight sdk.Dec) {
+	if soldTokenName == tokenPair.QuotePooledCoin.Denom {
+		return tokenPair.QuotePooledCoin.Amount, tokenPair.BasePooledCoin.Amount, tokenPair.QuoteWeight, tokenPair.BaseWeight
+	}
+	return tokenPair.BasePooledCoin.Amount, tokenPair.QuotePooledCoin.Amount, tokenPair.BaseWeight, tokenPair.QuoteWeight
+}
+
+// GetSpotPrice returns the marginal price of the token in its counterpart in the pool, without the fee
+func GetSpotPrice(tokenPair types.SwapTokenPair, tokenName string) sdk.Dec {
+	inputReserve, outputReserve, inputWeight, outputWeight := getPoolReserves(tokenPair, tokenName)
+	if !inputReserve.IsPositive() || !outputReserve.IsPositive() {
+		return sdk.ZeroDec()
+	}
+	switch tokenPair.GetPoolType() {
+	case types.PoolTypeStable:
+		// -dy/dx of the invariant, (4·Ann·x²y² + D³y) / (4·Ann·x²y² + D³x)
+		x, y := toCurveInt(inputReserve), toCurveInt(outputReserve)
+		ann := getStableAnn(tokenPair.Amplification)
+		d := getStableInvariant(x, y, ann)
+		d3 := new(big.Int).Exp(d, big.NewInt(3), nil)
+		xy := new(big.Int).Mul(x, y)
+		a := new(big.Int).Mul(xy, xy)
+		a.Mul(a, ann).Mul(a, big.NewInt(4))
+		numerator := new(big.Int).Add(a, new(big.Int).Mul(d3, y))
+		denominator := new(big.Int).Add(a, new(big.Int).Mul(d3, x))
+		return fromCurveInt(numerator.Mul(numerator, curveOne).Quo(numerator, denominator))
+	case types.PoolTypeWeighted:
+		return outputReserve.Mul(inputWeight).Quo(inputReserve.Mul(outputWeight))
+	default:
+		return outputReserve.Quo(inputReserve)
+	}
+}
+
+// GetStableInputPrice returns the amount bought from the stable pool, which keeps the StableSwap invariant
+// A·n^n·Σx + D = A·D·n^n + D^(n+1) / (n^n·Πx) of Curve with n = 2
+func GetStableInputPrice(inputAmount, inputReserve, outputReserve sdk.Dec, amplification int64, feeRate sdk.Dec) sdk.Dec {
+	inputAmountWithFee := inputAmount.MulTruncate(sdk.OneDec().Sub(feeRate))
+	if !inputAmountWithFee.IsPositive() || !inputReserve.IsPositive() || !outputReserve.IsPositive() {
+		return sdk.ZeroDec()
+	}
+	ann := getStableAnn(amplification)
+	x, y := toCurveInt(inputReserve), toCurveInt(outputReserve)
+	d := getStableInvariant(x, y, ann)
+	newY := getStableReserve(new(big.Int).Add(x, toCurveInt(inputAmountWithFee)), d, ann)
+	// round the output down against the iterations
+	outputAmount := new(big.Int).Sub(y, newY)
+	outputAmount.Sub(outputAmount, big.NewInt(1))
+	if outputAmount.Sign() <= 0 {
+		return sdk.ZeroDec()
+	}
+	return fromCurveInt(outputAmount)
+}
+
+// GetStableOutputPrice is the inverse of GetStableInputPrice, it returns the least input amount
+// for which GetStableInputPrice returns at least the output amount
+func GetStableOutputPrice(outputAmount, inputReserve, outputReserve sdk.Dec, amplification int64,
+	feeRate sdk.Dec) (sdk.Dec, error) {
+	feeFactor, err := checkOutputAmount(outputAmount, inputReserve, outputReserve, feeRate)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	ann := getStableAnn(amplification)
+	x, y := toCurveInt(inputReserve), toCurveInt(outputReserve)
+	d := getStableInvariant(x, y, ann)
+	newX := getStableReserve(new(big.Int).Sub(y, toCurveInt(outputAmount)), d, ann)
+	inputAmount := new(big.Int).Sub(newX, x)
+	inputAmount.Add(inputAmount, big.NewInt(1))
+	if inputAmount.Cmp(maxCurveInputAmount) > 0 {
+		return sdk.Dec{}, errors.New("insufficient liquidity")
+	}
+	estimate := fromCurveIntRoundUp(inputAmount).QuoRoundUp(feeFactor)
+	return searchInputAmount(estimate, outputAmount, func(inputAmount sdk.Dec) sdk.Dec {
+		return GetStableInputPrice(inputAmount, inputReserve, outputReserve, amplification, feeRate)
+	})
+}
+
+// GetWeightedInputPrice returns the amount bought from the weighted pool, which keeps the invariant Π(x^w) of Balancer:
+// outputReserve · (1 - (inputReserve / (inputReserve + inputAmount)) ^ (inputWeight / outputWeight))
+func GetWeightedInputPrice(inputAmount, inputReserve, outputReserve, inputWeight, outputWeight,
+	feeRate sdk.Dec) sdk.Dec {
+	inputAmountWithFee := inputAmount.MulTruncate(sdk.OneDec().Sub(feeRate))
+	if !inputAmountWithFee.IsPositive() || !inputReserve.IsPositive() || !outputReserve.IsPositive() {
+		return sdk.ZeroDec()
+	}
+	x := toCurveInt(inputReserve)
+	base := new(big.Int).Mul(x, curveOne)
+	base.Quo(base, x.Add(x, toCurveInt(inputAmountWithFee)))
+	ratio := curvePow(base, curveQuo(toCurveInt(inputWeight), toCurveInt(outputWeight)))
+	if ratio.Cmp(curveOne) >= 0 {
+		return sdk.ZeroDec()
+	}
+	return fromCurveInt(curveMul(toCurveInt(outputReserve), ratio.Sub(curveOne, ratio)))
+}
+
+// GetWeightedOutputPrice is the inverse of GetWeightedInputPrice, it returns the least input amount
+// for which GetWeightedInputPrice returns at least the output amount
+func GetWeightedOutputPrice(outputAmount, inputReserve, outputReserve, inputWeight, outputWeight,
+	feeRate sdk.Dec) (sdk.Dec, error) {
+	feeFactor, err := checkOutputAmount(outputAmount, inputReserve, outputReserve, feeRate)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	// inputReserve · ((outputReserve / (outputReserve - outputAmount)) ^ (outputWeight / inputWeight) - 1)
+	y := toCurveInt(outputReserve)
+	base := curveQuo(y, new(big.Int).Sub(y, toCurveInt(outputAmount)))
+	ratio := curvePow(base, curveQuo(toCurveInt(outputWeight), toCurveInt(inputWeight)))
+	inputAmount := curveMul(toCurveInt(inputReserve), ratio.Sub(ratio, curveOne))
+	if inputAmount.Cmp(maxCurveInputAmount) > 0 {
+		return sdk.Dec{}, errors.New("insufficient liquidity")
+	}
+	estimate := fromCurveIntRoundUp(inputAmount).QuoRoundUp(feeFactor)
+	return searchInputAmount(estimate, outputAmount, func(inputAmount sdk.Dec) sdk.Dec {
+		return GetWeightedInputPrice(inputAmount, inputReserve, outputReserve, inputWeight, outputWeight, feeRate)
+	})
+}
+
+// checkOutputAmount checks the output amount can be bought from the pool, and returns the share of the input left by the fee
+func checkOutputAmount(outputAmount, inputReserve, outputReserve, feeRate sdk.Dec) (sdk.Dec, error) {
+	if !outputAmount.LT(outputReserve) || !inputReserve.IsPositive() {
+		return sdk.Dec{}, errors.New("insufficient liquidity")
+	}
+	feeFactor := sdk.OneDec().Sub(feeRate)
+	if !feeFactor.IsPositive() {
+		return sdk.Dec{}, fmt.Errorf("invalid fee rate: %s", feeRate.String())
+	}
+	return feeFactor, nil
+}
+
+// searchInputAmount bisects the least input amount for which the input price returns at least the output amount.
+// The bounds are found by doubling the distance from the estimated input amount, so that a close estimate takes
+// a few steps, and the search fails after maxInputSearchSteps prices rather than running unbounded.
+func searchInputAmount(estimate, outputAmount sdk.Dec, getInputPrice func(sdk.Dec) sdk.Dec) (sdk.Dec, error) {
+	steps := 0
+	buys := func(inputAmount sdk.Dec) bool {
+		steps++
+		return !getInputPrice(inputAmount).LT(outputAmount)
+	}
+	minUnit := sdk.NewDecWithPrec(1, sdk.Precision)
+	low, high := sdk.ZeroDec(), sdk.MaxDec(estimate, minUnit)
+	// raise the high bound until it buys the output amount
+	for step := minUnit; !buys(high); step = step.MulInt64(2) {
+		if steps >= maxInputSearchSteps {
+			return sdk.Dec{}, errors.New("insufficient liquidity")
+		}
+		low, high = high, high.Add(step)
+	}
+	// lower the low bound until it doesn't, unless it's known already
+	if low.IsZero() {
+		for step := minUnit; step.LT(high); step = step.MulInt64(2) {
+			if !buys(high.Sub(step)) {
+				low = high.Sub(step)
+				break
+			}
+			if steps >= maxInputSearchSteps {
+				return sdk.Dec{}, errors.New("failed to search the input amount")
+			}
+		}
+	}
+	for high.Sub(low).GT(minUnit) {
+		if steps >= maxInputSearchSteps {
+			return sdk.Dec{}, errors.New("failed to search the input amount")
+		}
+		mid := sdk.NewDecFromBigIntWithPrec(new(big.Int).Rsh(new(big.Int).Add(low.Int, high.Int), 1), sdk.Precision)
+		if buys(mid) {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+	return high, nil
+}
+
+// getStableAnn returns A·n^n of the StableSwap invariant
+func getStableAnn(amplification int64) *big.Int {
+	return big.NewInt(amplification * 4)
+}
+
+// getStableInvariant solves D of the StableSwap invariant with the Newton's method
+func getStableInvariant(x, y, ann *big.Int) *big.Int {
+	s := new(big.Int).Add(x, y)
+	if s.Sign() == 0 {
+		return s
+	}
+	d := new(big.Int).Set(s)
+	annS := new(big.Int).Mul(ann, s)
+	annMinusOne := new(big.Int).Sub(ann, big.NewInt(1))
+	for i := 0; i < maxStableIterations; i++ {
+		// dP = D^3 / (4xy)
+		dP := new(big.Int).Mul(d, d)
+		dP.Quo(dP, new(big.Int).Lsh(x, 1))
+		dP.Mul(dP, d)
+		dP.Quo(dP, new(big.Int).Lsh(y, 1))
+		prev := d
+		// D = (Ann·S + 2·dP)·D / ((Ann - 1)·D + 3·dP)
+		numerator := new(big.Int).Add(annS, new(big.Int).Lsh(dP, 1))
+		numerator.Mul(numerator, d)
+		denominator := new(big.Int).Mul(annMinusOne, d)
+		denominator.Add(denominator, dP.Mul(dP, big.NewInt(3)))
+		d = numerator.Quo(numerator, denominator)
+		if new(big.Int).Sub(d, prev).CmpAbs(big.NewInt(1)) <= 0 {
+			break
+		}
+	}
+	return d
+}
+
+// getStableReserve solves the reserve of a token of the StableSwap invariant D with the reserve x of its counterpart,
+// which is the root of y² + (x + D/Ann - D)·y = D³ / (4x·Ann)
+func getStableReserve(x, d, ann *big.Int) *big.Int {
+	c := new(big.Int).Mul(d, d)
+	c.Quo(c, new(big.Int).Lsh(x, 1))
+	c.Mul(c, d)
+	c.Quo(c, new(big.Int).Lsh(ann, 1))
+	b := new(big.Int).Quo(d, ann)
+	b.Add(b, x)
+	y := new(big.Int).Set(d)
+	for i := 0; i < maxStableIterations; i++ {
+		prev := y
+		// y = (y² + c) / (2y + b - D)
+		numerator := new(big.Int).Mul(y, y)
+		numerator.Add(numerator, c)
+		denominator := new(big.Int).Lsh(y, 1)
+		denominator.Add(denominator, b).Sub(denominator, d)
+		y = numerator.Quo(numerator, denominator)
+		if new(big.Int).Sub(y, prev).CmpAbs(big.NewInt(1)) <= 0 {
+			break
+		}
+	}
+	return y
+}
+
+// curvePow returns base^exponent of the fixed-point numbers, exp(exponent · ln(base))
+func curvePow(base, exponent *big.Int) *big.Int {
+	if base.Sign() <= 0 {
+		return new(big.Int)
+	}
+	return curveExp(curveMul(exponent, curveLn(base)))
+}
+
+// curveLn returns ln(x) of the fixed-point number, x = m · 2^k with m in [1, 2)
+func curveLn(x *big.Int) *big.Int {
+	m := new(big.Int).Set(x)
+	k := int64(0)
+	for m.Cmp(curveTwo) >= 0 {
+		m.Rsh(m, 1)
+		k++
+	}
+	for m.Cmp(curveOne) < 0 {
+		m.Lsh(m, 1)
+		k--
+	}
+	result := lnSeries(m)
+	return result.Add(result, new(big.Int).Mul(curveLn2, big.NewInt(k)))
+}
+
+// lnSeries returns ln(m) of the fixed-point number m in [1, 2] by the series 2·atanh((m - 1) / (m + 1))
+func lnSeries(m *big.Int) *big.Int {
+	z := curveQuo(new(big.Int).Sub(m, curveOne), new(big.Int).Add(m, curveOne))
+	z2 := curveMul(z, z)
+	sum := new(big.Int)
+	term := z
+	for n := int64(1); term.Sign() > 0; n += 2 {
+		sum.Add(sum, new(big.Int).Quo(term, big.NewInt(n)))
+		term = curveMul(term, z2)
+	}
+	return sum.Lsh(sum, 1)
+}
+
+// curveExp returns e^y of the fixed-point number, y = k · ln2 + r with r in [0, ln2)
+func curveExp(y *big.Int) *big.Int {
+	k := new(big.Int).Div(y, curveLn2)
+	r := new(big.Int).Sub(y, new(big.Int).Mul(k, curveLn2))
+	sum := new(big.Int).Set(curveOne)
+	term := new(big.Int).Set(curveOne)
+	for n := int64(1); term.Sign() > 0; n++ {
+		term = curveMul(term, r)
+		term.Quo(term, big.NewInt(n))
+		sum.Add(sum, term)
+	}
+	if k.Sign() >= 0 {
+		return sum.Lsh(sum, uint(k.Uint64()))
+	}
+	return sum.Rsh(sum, uint(new(big.Int).Neg(k).Uint64()))
+}
+
+func curveMul(a, b *big.Int) *big.Int {
+	result := new(big.Int).Mul(a, b)
+	return result.Quo(result, curveOne)
+}
+
+func curveQuo(a, b *big.Int) *big.Int {
+	result := new(big.Int).Mul(a, curveOne)
+	return result.Quo(result, b)
+}
+
+func toCurveInt(d sdk.Dec) *big.Int {
+	return new(big.Int).Mul(d.Int, decToCurveInt)
+}
+
+func fromCurveInt(i *big.Int) sdk.Dec {
+	return sdk.NewDecFromBigIntWithPrec(new(big.Int).Quo(i, decToCurveInt), sdk.Precision)
+}
+
+func fromCurveIntRoundUp(i *big.Int) sdk.Dec {
+	quo, rem := new(big.Int).QuoRem(i, decToCurveInt, new(big.Int))
+	if rem.Sign() > 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	return sdk.NewDecFromBigIntWithPrec(quo, sdk.Precision)
+}
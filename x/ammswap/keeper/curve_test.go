@@ -0,0 +1,146 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/okexchain/x/ammswap/types"
+)
+
+func TestCurvePow(t *testing.T) {
+	tests := []struct {
+		base, exponent, expected string
+	}{
+		{"2", "0.5", "1.41421356"},
+		{"0.5", "4", "0.0625"},
+		{"0.9", "0.25", "0.97400375"},
+		{"1.5", "49", "425081000.14269972"},
+		{"1", "0.33333333", "1"},
+	}
+	for _, test := range tests {
+		result := fromCurveInt(curvePow(toCurveInt(sdk.MustNewDecFromStr(test.base)),
+			toCurveInt(sdk.MustNewDecFromStr(test.exponent))))
+		require.True(t, result.Sub(sdk.MustNewDecFromStr(test.expected)).Abs().LTE(sdk.NewDecWithPrec(1, sdk.Precision)),
+			"%s^%s = %s", test.base, test.exponent, result)
+	}
+}
+
+func TestGetStableInputPrice(t *testing.T) {
+	feeRate := types.DefaultParams().FeeRate
+	reserve := sdk.NewDec(10000)
+	soldAmount := sdk.NewDec(100)
+	constantProduct := GetInputPrice(soldAmount, reserve, reserve, feeRate)
+	amount := sdk.ZeroDec()
+	// the larger the amplification, the closer the price to 1
+	for _, amplification := range []int64{1, 10, 100, 1000} {
+		boughtAmount := GetStableInputPrice(soldAmount, reserve, reserve, amplification, feeRate)
+		require.True(t, boughtAmount.GT(constantProduct))
+		require.True(t, boughtAmount.GT(amount))
+		require.True(t, boughtAmount.LT(soldAmount.Mul(sdk.OneDec().Sub(feeRate))))
+		amount = boughtAmount
+	}
+
+	tokenPair := types.NewSwapPairWithCurve("aab", "ccb", types.PoolTypeStable, 100, sdk.Dec{}, sdk.Dec{})
+	tokenPair.BasePooledCoin.Amount = reserve
+	tokenPair.QuotePooledCoin.Amount = reserve
+	require.Equal(t, sdk.OneDec(), GetSpotPrice(tokenPair, "aab"))
+	tokenPair.BasePooledCoin.Amount = sdk.NewDec(15000)
+	basePrice := GetSpotPrice(tokenPair, "aab")
+	require.True(t, basePrice.LT(sdk.OneDec()))
+	require.True(t, basePrice.GT(sdk.MustNewDecFromStr("0.99")))
+	// the prices of the tokens in each other are reciprocal
+	require.True(t, basePrice.Mul(GetSpotPrice(tokenPair, "ccb")).Sub(sdk.OneDec()).Abs().LTE(sdk.NewDecWithPrec(1, 7)))
+}
+
+func TestGetWeightedInputPrice(t *testing.T) {
+	inputReserve, outputReserve := sdk.NewDec(1000), sdk.NewDec(4000)
+	half := sdk.NewDecWithPrec(5, 1)
+	// the equal weights make a constant product pool
+	boughtAmount := GetWeightedInputPrice(sdk.NewDec(10), inputReserve, outputReserve, half, half, sdk.ZeroDec())
+	require.True(t, boughtAmount.Sub(sdk.MustNewDecFromStr("39.60396039")).Abs().LTE(sdk.NewDecWithPrec(1, sdk.Precision)))
+
+	// 80/20 pool
+	inputWeight, outputWeight := sdk.NewDecWithPrec(8, 1), sdk.NewDecWithPrec(2, 1)
+	boughtAmount = GetWeightedInputPrice(sdk.NewDec(10), inputReserve, outputReserve, inputWeight, outputWeight, sdk.ZeroDec())
+	require.True(t, boughtAmount.Sub(sdk.MustNewDecFromStr("156.07862206")).Abs().LTE(sdk.NewDecWithPrec(1, sdk.Precision)))
+
+	tokenPair := types.NewSwapPairWithCurve("ccb", "aab", types.PoolTypeWeighted, 0, inputWeight, outputWeight)
+	require.Equal(t, outputWeight, tokenPair.BaseWeight)
+	tokenPair.BasePooledCoin.Amount = outputReserve
+	tokenPair.QuotePooledCoin.Amount = inputReserve
+	require.Equal(t, sdk.NewDec(16), GetSpotPrice(tokenPair, "ccb"))
+	require.Equal(t, sdk.MustNewDecFromStr("0.0625"), GetSpotPrice(tokenPair, "aab"))
+}
+
+func TestGetCurveOutputPrice(t *testing.T) {
+	feeRate := types.DefaultParams().FeeRate
+	minUnit := sdk.NewDecWithPrec(1, sdk.Precision)
+	inputWeight, outputWeight := sdk.NewDecWithPrec(3, 1), sdk.NewDecWithPrec(7, 1)
+	tests := []struct {
+		outputAmount, inputReserve, outputReserve sdk.Dec
+	}{
+		{sdk.NewDec(10), sdk.NewDec(10000), sdk.NewDec(10000)},
+		{sdk.NewDecWithPrec(1, 8), sdk.NewDec(10000), sdk.NewDec(10000)},
+		{sdk.MustNewDecFromStr("123.45678901"), sdk.MustNewDecFromStr("3456.789"), sdk.NewDec(9999)},
+		{sdk.MustNewDecFromStr("99.9"), sdk.NewDec(100), sdk.NewDec(100)},
+	}
+	for _, test := range tests {
+		inputAmount, err := GetStableOutputPrice(test.outputAmount, test.inputReserve, test.outputReserve, 100, feeRate)
+		require.Nil(t, err)
+		require.True(t, GetStableInputPrice(inputAmount, test.inputReserve, test.outputReserve, 100, feeRate).GTE(test.outputAmount))
+		require.True(t, GetStableInputPrice(inputAmount.Sub(minUnit), test.inputReserve, test.outputReserve, 100, feeRate).LT(test.outputAmount))
+
+		inputAmount, err = GetWeightedOutputPrice(test.outputAmount, test.inputReserve, test.outputReserve,
+			inputWeight, outputWeight, feeRate)
+		require.Nil(t, err)
+		require.True(t, GetWeightedInputPrice(inputAmount, test.inputReserve, test.outputReserve,
+			inputWeight, outputWeight, feeRate).GTE(test.outputAmount))
+		require.True(t, GetWeightedInputPrice(inputAmount.Sub(minUnit), test.inputReserve, test.outputReserve,
+			inputWeight, outputWeight, feeRate).LT(test.outputAmount))
+	}
+
+	_, err := GetStableOutputPrice(sdk.NewDec(100), sdk.NewDec(100), sdk.NewDec(100), 100, feeRate)
+	require.NotNil(t, err)
+	_, err = GetWeightedOutputPrice(sdk.NewDec(100), sdk.NewDec(100), sdk.NewDec(100), inputWeight, outputWeight, feeRate)
+	require.NotNil(t, err)
+}
+
+func TestGetCurveOutputPriceWithExtremePool(t *testing.T) {
+	feeRate := types.DefaultParams().FeeRate
+	tests := []struct {
+		outputAmount, inputReserve, outputReserve, inputWeight, outputWeight sdk.Dec
+	}{
+		{sdk.MustNewDecFromStr("29.28113846"), sdk.NewDec(46561827000), sdk.MustNewDecFromStr("39.63027"),
+			sdk.MustNewDecFromStr("0.06"), sdk.MustNewDecFromStr("0.94")},
+		{sdk.MustNewDecFromStr("39.63026999"), sdk.NewDec(46561827000), sdk.MustNewDecFromStr("39.63027"),
+			sdk.MustNewDecFromStr("0.02"), sdk.MustNewDecFromStr("0.98")},
+		{sdk.NewDecWithPrec(1, sdk.Precision), sdk.NewDecWithPrec(1, sdk.Precision), sdk.NewDec(46561827000),
+			sdk.MustNewDecFromStr("0.98"), sdk.MustNewDecFromStr("0.02")},
+	}
+	for _, test := range tests {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			// the search either fails or finds an input amount buying the output amount
+			inputAmount, err := GetWeightedOutputPrice(test.outputAmount, test.inputReserve, test.outputReserve,
+				test.inputWeight, test.outputWeight, feeRate)
+			if err == nil {
+				require.True(t, GetWeightedInputPrice(inputAmount, test.inputReserve, test.outputReserve,
+					test.inputWeight, test.outputWeight, feeRate).GTE(test.outputAmount))
+			}
+			inputAmount, err = GetStableOutputPrice(test.outputAmount, test.inputReserve, test.outputReserve, 1, feeRate)
+			if err == nil {
+				require.True(t, GetStableInputPrice(inputAmount, test.inputReserve, test.outputReserve, 1,
+					feeRate).GTE(test.outputAmount))
+			}
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("the output price of %s isn't found in 5s", test.outputAmount)
+		}
+	}
+}
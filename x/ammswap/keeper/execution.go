@@ -0,0 +1,46 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	"github.com/okex/okexchain/x/ammswap/types"
+)
+
+// RecordSwapExecution keeps the hop of the swap executed in the transient store, which is dropped at the end of the
+// block. The hops recorded by a failed tx are dropped with its writes
+func (k Keeper) RecordSwapExecution(ctx sdk.Context, sender sdk.AccAddress, tokenPairName string,
+	soldToken, boughtToken sdk.DecCoin) {
+	store := ctx.TransientStore(k.tstoreKey)
+	var index uint64
+	if bz := store.Get(types.SwapExecutionCountKey); bz != nil {
+		index = binary.BigEndian.Uint64(bz)
+	}
+
+	execution := types.SwapExecution{
+		TxHash:        fmt.Sprintf("%X", tmhash.Sum(ctx.TxBytes())),
+		Index:         index,
+		TokenPairName: tokenPairName,
+		Sender:        sender,
+		SoldToken:     soldToken,
+		BoughtToken:   boughtToken,
+		Fee:           sdk.NewDecCoinFromDec(soldToken.Denom, soldToken.Amount.MulTruncate(k.GetParams(ctx).FeeRate)),
+	}
+	store.Set(types.GetSwapExecutionKey(index), k.cdc.MustMarshalBinaryLengthPrefixed(execution))
+	store.Set(types.SwapExecutionCountKey, sdk.Uint64ToBigEndian(index+1))
+}
+
+// GetSwapExecutions returns the hops of the swaps executed in the block in order
+func (k Keeper) GetSwapExecutions(ctx sdk.Context) (executions []types.SwapExecution) {
+	iterator := sdk.KVStorePrefixIterator(ctx.TransientStore(k.tstoreKey), types.SwapExecutionPrefixKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var execution types.SwapExecution
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &execution)
+		executions = append(executions, execution)
+	}
+	return executions
+}
@@ -0,0 +1,68 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	"github.com/okex/okexchain/x/ammswap/types"
+)
+
+// SettleSwapFee counts the volume & fees of selling the sold token to the swap token pair,
+// and sends the protocol's share of the swap fee out of the pool.
+// It returns the part of the sold token going into the reserve of the pool.
+func (k Keeper) SettleSwapFee(ctx sdk.Context, tokenPair *types.SwapTokenPair, soldToken sdk.DecCoin) (
+	sdk.DecCoin, error) {
+	params := k.GetParams(ctx)
+	fee := soldToken.Amount.MulTruncate(params.FeeRate)
+	protocolFee := sdk.NewDecCoinFromDec(soldToken.Denom, fee.MulTruncate(params.ProtocolFeeRate))
+	if protocolFee.IsPositive() {
+		if err := k.sendProtocolFee(ctx, sdk.DecCoins{protocolFee}, params.ProtocolFeeRecipient); err != nil {
+			return sdk.DecCoin{}, err
+		}
+		tokenPair.ProtocolFees = tokenPair.ProtocolFees.Add(sdk.DecCoins{protocolFee})
+	}
+	if lpFee := fee.Sub(protocolFee.Amount); lpFee.IsPositive() {
+		tokenPair.Fees = tokenPair.Fees.Add(sdk.DecCoins{sdk.NewDecCoinFromDec(soldToken.Denom, lpFee)})
+	}
+	tokenPair.Volume = tokenPair.Volume.Add(sdk.DecCoins{soldToken})
+	if tokenPair.StatsStartTime == 0 {
+		tokenPair.StatsStartTime = ctx.BlockTime().Unix()
+	}
+	return soldToken.Sub(protocolFee), nil
+}
+
+// sendProtocolFee sends the protocol fee from the pools to the recipient, to the community pool if it's empty
+func (k Keeper) sendProtocolFee(ctx sdk.Context, protocolFee sdk.DecCoins, recipient sdk.AccAddress) error {
+	if recipient.Empty() {
+		return k.distrKeeper.FundCommunityPool(ctx, protocolFee, supply.NewModuleAddress(types.ModuleName))
+	}
+	return k.SendCoinsFromPoolToAccount(ctx, protocolFee, recipient)
+}
+
+// CalculatePoolAPR annualizes the swap fees kept by the pool since the stats start,
+// the fees & the liquidity are valued in quote token at the current price.
+// The quote reserve of a weighted pool is its weight of the liquidity, rather than half of it.
+func CalculatePoolAPR(tokenPair types.SwapTokenPair, blockTime int64) types.PoolAPR {
+	apr := types.PoolAPR{
+		TokenPairName: tokenPair.TokenPairName(),
+		Fees:          sdk.ZeroDec(),
+		Liquidity:     sdk.ZeroDec(),
+		APR:           sdk.ZeroDec(),
+	}
+	if tokenPair.BasePooledCoin.IsZero() || tokenPair.QuotePooledCoin.IsZero() {
+		return apr
+	}
+	basePrice := GetSpotPrice(tokenPair, tokenPair.BasePooledCoin.Denom)
+	apr.Fees = tokenPair.Fees.AmountOf(tokenPair.QuotePooledCoin.Denom).Add(
+		tokenPair.Fees.AmountOf(tokenPair.BasePooledCoin.Denom).Mul(basePrice))
+	if tokenPair.GetPoolType() == types.PoolTypeWeighted {
+		apr.Liquidity = tokenPair.QuotePooledCoin.Amount.Quo(tokenPair.QuoteWeight)
+	} else {
+		apr.Liquidity = tokenPair.QuotePooledCoin.Amount.Add(tokenPair.BasePooledCoin.Amount.Mul(basePrice))
+	}
+	if tokenPair.StatsStartTime > 0 && blockTime > tokenPair.StatsStartTime {
+		apr.Duration = blockTime - tokenPair.StatsStartTime
+		apr.APR = apr.Fees.MulInt64(types.SecondsPerYear).Quo(apr.Liquidity).QuoInt64(apr.Duration)
+	}
+	return apr
+}
@@ -0,0 +1,116 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/okex/okexchain/x/ammswap/types"
+)
+
+// mockDistrKeeper funds the fee collector instead of the community pool
+type mockDistrKeeper struct {
+	supplyKeeper  types.SupplyKeeper
+	communityPool sdk.DecCoins
+}
+
+func (k *mockDistrKeeper) FundCommunityPool(ctx sdk.Context, amount sdk.Coins, sender sdk.AccAddress) sdk.Error {
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, sender, auth.FeeCollectorName, amount); err != nil {
+		return err
+	}
+	k.communityPool = k.communityPool.Add(amount)
+	return nil
+}
+
+func TestSettleSwapFee(t *testing.T) {
+	mapp, addrSlice, ctx, keeper, querier := initQurierTest(t)
+	distrKeeper := &mockDistrKeeper{supplyKeeper: mapp.supplyKeeper}
+	keeper.distrKeeper = distrKeeper
+	addr := addrSlice[0].Address
+	aab, okt := types.TestBasePooledToken, types.TestQuotePooledToken
+	ctx = ctx.WithBlockTime(time.Unix(1600000000, 0))
+
+	setTestPool(ctx, keeper, aab, okt, 50, 50)
+	pooledCoins := sdk.NewDecCoinsFromDec(aab, sdk.NewDec(50)).Add(sdk.NewDecCoinsFromDec(okt, sdk.NewDec(50)))
+	require.Nil(t, keeper.SendCoinsToPool(ctx, pooledCoins, addr))
+
+	params := types.DefaultParams()
+	params.ProtocolFeeRate = sdk.NewDecWithPrec(5, 1)
+	keeper.SetParams(ctx, params)
+	soldToken := sdk.NewDecCoinFromDec(aab, sdk.NewDec(10))
+
+	// the protocol fee goes to the community pool without the recipient
+	route, tokenPairs, err := keeper.QuoteSwapRoute(ctx, soldToken, okt, []string{"aab_" + okt})
+	require.Nil(t, err)
+	require.Nil(t, keeper.SwapByRoute(ctx, addr, addr, tokenPairs, route))
+	protocolFee := sdk.NewDecCoinsFromDec(aab, sdk.MustNewDecFromStr("0.015"))
+	require.Equal(t, protocolFee, distrKeeper.communityPool)
+
+	tokenPair, err := keeper.GetSwapTokenPair(ctx, "aab_"+okt)
+	require.Nil(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("59.985"), tokenPair.BasePooledCoin.Amount)
+	require.Equal(t, sdk.NewDecCoinsFromDec(aab, sdk.NewDec(10)), tokenPair.Volume)
+	require.Equal(t, sdk.NewDecCoinsFromDec(aab, sdk.MustNewDecFromStr("0.015")), tokenPair.Fees)
+	require.Equal(t, protocolFee, tokenPair.ProtocolFees)
+	require.Equal(t, ctx.BlockTime().Unix(), tokenPair.StatsStartTime)
+
+	// the protocol fee goes to the recipient
+	recipient := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	params.ProtocolFeeRecipient = recipient
+	keeper.SetParams(ctx, params)
+	soldToken = sdk.NewDecCoinFromDec(okt, sdk.NewDec(10))
+	route, tokenPairs, err = keeper.QuoteSwapRoute(ctx, soldToken, aab, []string{"aab_" + okt})
+	require.Nil(t, err)
+	require.Nil(t, keeper.SwapByRoute(ctx, addr, addr, tokenPairs, route))
+	require.Equal(t, sdk.NewDecCoinsFromDec(okt, sdk.MustNewDecFromStr("0.015")),
+		mapp.tokenKeeper.GetCoins(ctx, recipient))
+	require.Equal(t, protocolFee, distrKeeper.communityPool)
+
+	// the pool keeps the whole fee with the switch off
+	params.ProtocolFeeRate = sdk.ZeroDec()
+	keeper.SetParams(ctx, params)
+	tokenPair, err = keeper.GetSwapTokenPair(ctx, "aab_"+okt)
+	require.Nil(t, err)
+	pooledToken, err := keeper.SettleSwapFee(ctx, &tokenPair, soldToken)
+	require.Nil(t, err)
+	require.Equal(t, soldToken, pooledToken)
+	require.Equal(t, sdk.MustNewDecFromStr("0.045"), tokenPair.Fees.AmountOf(okt))
+	require.Equal(t, sdk.NewDec(20), tokenPair.Volume.AmountOf(okt))
+
+	// APR
+	tokenPair, err = keeper.GetSwapTokenPair(ctx, "aab_"+okt)
+	require.Nil(t, err)
+	apr := CalculatePoolAPR(tokenPair, tokenPair.StatsStartTime+types.SecondsPerYear)
+	require.True(t, tokenPair.QuotePooledCoin.Amount.MulInt64(2).Sub(apr.Liquidity).Abs().LTE(sdk.NewDecWithPrec(1, 6)))
+	require.True(t, apr.Fees.GT(sdk.MustNewDecFromStr("0.03")))
+	require.True(t, apr.Fees.Quo(apr.Liquidity).Sub(apr.APR).Abs().LTE(sdk.NewDecWithPrec(1, sdk.Precision)))
+	require.True(t, CalculatePoolAPR(tokenPair, tokenPair.StatsStartTime).APR.IsZero())
+
+	// the base reserve of a weighted pool is valued at its weighted spot price
+	weighted := tokenPair
+	weighted.PoolType = types.PoolTypeWeighted
+	weighted.BaseWeight, weighted.QuoteWeight = sdk.MustNewDecFromStr("0.8"), sdk.MustNewDecFromStr("0.2")
+	weighted.BasePooledCoin = sdk.NewDecCoinFromDec(aab, sdk.NewDec(100))
+	weighted.QuotePooledCoin = sdk.NewDecCoinFromDec(okt, sdk.NewDec(50))
+	weightedAPR := CalculatePoolAPR(weighted, weighted.StatsStartTime+types.SecondsPerYear)
+	require.Equal(t, sdk.NewDec(250), weightedAPR.Liquidity)
+	require.True(t, weightedAPR.Fees.Quo(weightedAPR.Liquidity).Sub(weightedAPR.APR).Abs().LTE(
+		sdk.NewDecWithPrec(1, sdk.Precision)))
+
+	res, sdkErr := querier(ctx.WithBlockTime(ctx.BlockTime().Add(time.Hour)), []string{types.QueryPoolAPRs, aab, okt},
+		abci.RequestQuery{})
+	require.Nil(t, sdkErr)
+	var aprs types.PoolAPRs
+	keeper.cdc.MustUnmarshalJSON(res, &aprs)
+	require.Equal(t, 1, len(aprs))
+	require.Equal(t, int64(3600), aprs[0].Duration)
+	require.True(t, aprs[0].APR.IsPositive())
+
+	_, sdkErr = querier(ctx, []string{types.QueryPoolAPRs, okt, aab}, abci.RequestQuery{})
+	require.NotNil(t, sdkErr)
+}
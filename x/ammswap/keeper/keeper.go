@@ -17,18 +17,22 @@ import (
 type Keeper struct {
 	supplyKeeper types.SupplyKeeper
 	tokenKeeper  types.TokenKeeper
+	distrKeeper  types.DistributionKeeper
 
 	storeKey   sdk.StoreKey
+	tstoreKey  sdk.StoreKey
 	cdc        *codec.Codec
 	paramSpace types.ParamSubspace
 }
 
 // NewKeeper creates a swap keeper
-func NewKeeper(supplyKeeper types.SupplyKeeper, tokenKeeper types.TokenKeeper, cdc *codec.Codec, key sdk.StoreKey, paramspace types.ParamSubspace) Keeper {
+func NewKeeper(supplyKeeper types.SupplyKeeper, tokenKeeper types.TokenKeeper, distrKeeper types.DistributionKeeper, cdc *codec.Codec, key, tkey sdk.StoreKey, paramspace types.ParamSubspace) Keeper {
 	keeper := Keeper{
 		supplyKeeper: supplyKeeper,
 		tokenKeeper:  tokenKeeper,
+		distrKeeper:  distrKeeper,
 		storeKey:     key,
+		tstoreKey:    tkey,
 		cdc:          cdc,
 		paramSpace:   paramspace.WithKeyTable(types.ParamKeyTable()),
 	}
@@ -62,6 +66,20 @@ func (k Keeper) SetSwapTokenPair(ctx sdk.Context, tokenPairName string, swapToke
 	store := ctx.KVStore(k.storeKey)
 	bz := k.cdc.MustMarshalBinaryLengthPrefixed(swapTokenPair)
 	store.Set(types.GetTokenPairKey(tokenPairName), bz)
+	ctx.TransientStore(k.tstoreKey).Set(types.GetChangedTokenPairKey(tokenPairName), []byte{})
+}
+
+// GetChangedSwapTokenPairs returns the SwapTokenPairs set in the block
+func (k Keeper) GetChangedSwapTokenPairs(ctx sdk.Context) (tokenPairs []types.SwapTokenPair) {
+	iterator := sdk.KVStorePrefixIterator(ctx.TransientStore(k.tstoreKey), types.ChangedTokenPairPrefixKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		tokenPair, err := k.GetSwapTokenPair(ctx, string(iterator.Key()[len(types.ChangedTokenPairPrefixKey):]))
+		if err == nil {
+			tokenPairs = append(tokenPairs, tokenPair)
+		}
+	}
+	return tokenPairs
 }
 
 // DeleteSwapTokenPair deletes the entire SwapTokenPair data struct for a quote token name
@@ -152,6 +170,8 @@ func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 }
 
 
+// GetRedeemableAssets returns the tokens the liquidity redeems, which are in proportion to the reserves for all the pool types,
+// as the constant product, the StableSwap & the weighted invariants all scale with the reserves taken out in proportion
 func (k Keeper) GetRedeemableAssets(ctx sdk.Context,baseAmountName, quoteAmountName string, liquidity sdk.Dec) (baseAmount, quoteAmount sdk.DecCoin, err error) {
 	err = types.ValidateBaseAndQuoteAmount(baseAmountName, quoteAmountName)
 	if err != nil {
@@ -174,17 +194,18 @@ func (k Keeper) GetRedeemableAssets(ctx sdk.Context,baseAmountName, quoteAmountN
 	return baseAmount, quoteAmount, nil
 }
 
-//CalculateTokenToBuy calculates the amount to buy
+//CalculateTokenToBuy calculates the amount to buy with the curve of the pool
 func CalculateTokenToBuy(swapTokenPair types.SwapTokenPair, sellToken sdk.DecCoin, buyTokenDenom string, params types.Params) sdk.DecCoin {
-	var inputReserve, outputReserve sdk.Dec
-	if buyTokenDenom < sellToken.Denom {
-		inputReserve = swapTokenPair.QuotePooledCoin.Amount
-		outputReserve = swapTokenPair.BasePooledCoin.Amount
-	} else {
-		inputReserve = swapTokenPair.BasePooledCoin.Amount
-		outputReserve = swapTokenPair.QuotePooledCoin.Amount
-	}
-	tokenBuyAmt := GetInputPrice(sellToken.Amount, inputReserve, outputReserve, params.FeeRate)
+	inputReserve, outputReserve, inputWeight, outputWeight := getPoolReserves(swapTokenPair, sellToken.Denom)
+	var tokenBuyAmt sdk.Dec
+	switch swapTokenPair.GetPoolType() {
+	case types.PoolTypeStable:
+		tokenBuyAmt = GetStableInputPrice(sellToken.Amount, inputReserve, outputReserve, swapTokenPair.Amplification, params.FeeRate)
+	case types.PoolTypeWeighted:
+		tokenBuyAmt = GetWeightedInputPrice(sellToken.Amount, inputReserve, outputReserve, inputWeight, outputWeight, params.FeeRate)
+	default:
+		tokenBuyAmt = GetInputPrice(sellToken.Amount, inputReserve, outputReserve, params.FeeRate)
+	}
 	tokenBuy := sdk.NewDecCoinFromDec(buyTokenDenom, tokenBuyAmt)
 
 	return tokenBuy
@@ -195,3 +216,42 @@ func GetInputPrice(inputAmount, inputReserve, outputReserve, feeRate sdk.Dec) sd
 	denominator := inputReserve.MulTruncate(sdk.NewDec(1000)).Add(inputAmountWithFee)
 	return common.MulAndQuo(inputAmountWithFee, outputReserve, denominator)
 }
+
+//CalculateTokenToSell calculates the amount to sell to buy exactly the buy token with the curve of the pool
+func CalculateTokenToSell(swapTokenPair types.SwapTokenPair, buyToken sdk.DecCoin, sellTokenDenom string, params types.Params) (sdk.DecCoin, error) {
+	inputReserve, outputReserve, inputWeight, outputWeight := getPoolReserves(swapTokenPair, sellTokenDenom)
+	var tokenSellAmt sdk.Dec
+	var err error
+	switch swapTokenPair.GetPoolType() {
+	case types.PoolTypeStable:
+		tokenSellAmt, err = GetStableOutputPrice(buyToken.Amount, inputReserve, outputReserve, swapTokenPair.Amplification, params.FeeRate)
+	case types.PoolTypeWeighted:
+		tokenSellAmt, err = GetWeightedOutputPrice(buyToken.Amount, inputReserve, outputReserve, inputWeight, outputWeight, params.FeeRate)
+	default:
+		tokenSellAmt, err = GetOutputPrice(buyToken.Amount, inputReserve, outputReserve, params.FeeRate)
+	}
+	if err != nil {
+		return sdk.DecCoin{}, fmt.Errorf("failed to buy %s from %s: %s", buyToken.String(), swapTokenPair.TokenPairName(), err.Error())
+	}
+	return sdk.NewDecCoinFromDec(sellTokenDenom, tokenSellAmt), nil
+}
+
+// GetOutputPrice is the inverse of GetInputPrice, it returns the least input amount
+// for which GetInputPrice returns at least the output amount
+func GetOutputPrice(outputAmount, inputReserve, outputReserve, feeRate sdk.Dec) (sdk.Dec, error) {
+	if !outputAmount.LT(outputReserve) {
+		return sdk.Dec{}, errors.New("insufficient liquidity")
+	}
+	feeFactor := sdk.OneDec().Sub(feeRate).MulTruncate(sdk.NewDec(1000))
+	if !feeFactor.IsPositive() {
+		return sdk.Dec{}, fmt.Errorf("invalid fee rate: %s", feeRate.String())
+	}
+	numerator := inputReserve.MulTruncate(sdk.NewDec(1000)).Mul(outputAmount)
+	denominator := outputReserve.Sub(outputAmount).Mul(feeFactor)
+	inputAmount := numerator.QuoRoundUp(denominator)
+
+	// GetInputPrice truncates at every step, round the input up until it buys the output amount
+	return searchInputAmount(inputAmount, outputAmount, func(inputAmount sdk.Dec) sdk.Dec {
+		return GetInputPrice(inputAmount, inputReserve, outputReserve, feeRate)
+	})
+}
@@ -54,6 +54,14 @@ func TestKeeper_GetSwapTokenPairs(t *testing.T) {
 	expectedSwapTokenPairList := []types.SwapTokenPair{swapTokenPair}
 	swapTokenPairList := keeper.GetSwapTokenPairs(ctx)
 	require.Equal(t, expectedSwapTokenPairList, swapTokenPairList)
+	require.Equal(t, expectedSwapTokenPairList, keeper.GetChangedSwapTokenPairs(ctx))
+
+	// the changed swap token pairs are dropped at the end of the block
+	mapp.EndBlock(abci.RequestEndBlock{Height: 2})
+	mapp.Commit()
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	ctx = mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(11)
+	require.Empty(t, keeper.GetChangedSwapTokenPairs(ctx))
 }
 
 func TestKeeper_GetRedeemableAssets(t *testing.T) {
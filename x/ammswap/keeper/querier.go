@@ -22,6 +22,12 @@ func NewQuerier(k Keeper) sdk.Querier {
 			return queryRedeemableAssets(ctx, path[1:], req, k)
 		case types.QueryBuyAmount:
 			return queryBuyAmount(ctx, path[1:], req, k)
+		case types.QuerySwapRoute:
+			return querySwapRoute(ctx, req, k)
+		case types.QueryPoolAPRs:
+			return queryPoolAPRs(ctx, path[1:], k)
+		case types.QueryTWAP:
+			return queryTWAP(ctx, req, k)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown swap query endpoint")
 		}
@@ -56,6 +62,9 @@ func queryBuyAmount(
 	if err != nil {
 		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
 	}
+	if queryParams.BoughtToken.Denom != "" {
+		return querySellAmount(ctx, queryParams, keeper)
+	}
 	errToken := types.ValidateSwapAmountName(queryParams.TokenToBuy)
 	if errToken != nil {
 		return nil, sdk.ErrUnknownRequest(errToken.Error())
@@ -99,6 +108,53 @@ func queryBuyAmount(
 	return bz, nil
 }
 
+// querySellAmount quotes the amount of token to sell to buy exactly the bought token
+func querySellAmount(ctx sdk.Context, queryParams types.QueryBuyAmountParams, keeper Keeper) ([]byte, sdk.Error) {
+	if !queryParams.BoughtToken.IsValid() || !queryParams.BoughtToken.IsPositive() {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid bought token: %s", queryParams.BoughtToken))
+	}
+	if err := types.ValidateSwapAmountName(queryParams.TokenToSell); err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+	if queryParams.BestPath && len(queryParams.Path) > 0 {
+		return nil, sdk.ErrUnknownRequest("path should be empty when routing through the best path")
+	}
+	if err := types.ValidateSwapPath(queryParams.Path); err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+
+	route, _, err := keeper.QuoteSwapRouteForExactOutput(ctx, queryParams.TokenToSell, queryParams.BoughtToken,
+		queryParams.Path, queryParams.BestPath)
+	if err != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to query sell amount: %s", err.Error()))
+	}
+	return keeper.cdc.MustMarshalJSON(route.SoldToken.Amount), nil
+}
+
+// querySwapRoute quotes the token bought through the path and the price impact
+func querySwapRoute(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var queryParams types.QuerySwapRouteParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &queryParams)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if !queryParams.SoldToken.IsValid() || !queryParams.SoldToken.IsPositive() {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid sold token: %s", queryParams.SoldToken))
+	}
+	if err := types.ValidateSwapAmountName(queryParams.TokenToBuy); err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+	if err := types.ValidateSwapPath(queryParams.Path); err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+
+	route, _, err := keeper.QuoteSwapRoute(ctx, queryParams.SoldToken, queryParams.TokenToBuy, queryParams.Path)
+	if err != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to quote swap route: %s", err.Error()))
+	}
+	return keeper.cdc.MustMarshalJSON(route), nil
+}
+
 func queryParams(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
 	return keeper.cdc.MustMarshalJSON(keeper.GetParams(ctx)), nil
 }
@@ -131,4 +187,50 @@ func queryRedeemableAssets(ctx sdk.Context, path []string, req abci.RequestQuery
 	tokenList = append(tokenList, baseToken, quoteToken)
 	bz := keeper.cdc.MustMarshalJSON(tokenList)
 	return bz, nil
-}
\ No newline at end of file
+}
+
+// queryPoolAPRs queries the APRs of all the pools, or of the pool of the base & quote tokens in the path
+func queryPoolAPRs(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	var tokenPairs []types.SwapTokenPair
+	if len(path) >= 2 {
+		if err := types.ValidateBaseAndQuoteAmount(path[0], path[1]); err != nil {
+			return nil, sdk.ErrUnknownRequest(err.Error())
+		}
+		tokenPair, err := keeper.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(path[0], path[1]))
+		if err != nil {
+			return nil, sdk.ErrUnknownRequest(err.Error())
+		}
+		tokenPairs = append(tokenPairs, tokenPair)
+	} else {
+		tokenPairs = keeper.GetSwapTokenPairs(ctx)
+	}
+
+	aprs := make(types.PoolAPRs, 0, len(tokenPairs))
+	for _, tokenPair := range tokenPairs {
+		aprs = append(aprs, CalculatePoolAPR(tokenPair, ctx.BlockTime().Unix()))
+	}
+	return keeper.cdc.MustMarshalJSON(aprs), nil
+}
+
+// queryTWAP queries the time-weighted average prices of the pool between two block heights
+func queryTWAP(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var queryParams types.QueryTWAPParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &queryParams)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if err := types.ValidateBaseAndQuoteAmount(queryParams.BaseToken, queryParams.QuoteToken); err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+	toHeight := queryParams.ToHeight
+	if toHeight == 0 {
+		toHeight = ctx.BlockHeight()
+	}
+
+	twap, err := keeper.GetTWAP(ctx, types.GetSwapTokenPairName(queryParams.BaseToken, queryParams.QuoteToken),
+		queryParams.FromHeight, toHeight)
+	if err != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to query twap: %s", err.Error()))
+	}
+	return keeper.cdc.MustMarshalJSON(twap), nil
+}
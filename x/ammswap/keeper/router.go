@@ -0,0 +1,317 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/ammswap/types"
+)
+
+// GetSwapPath gets the swap token pairs of the path, checking that they chain the sold token to the bought token
+func (k Keeper) GetSwapPath(ctx sdk.Context, soldTokenName, boughtTokenName string,
+	path []string) ([]types.SwapTokenPair, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("empty swap path")
+	}
+	tokenPairs := make([]types.SwapTokenPair, 0, len(path))
+	tokenName := soldTokenName
+	for _, tokenPairName := range path {
+		tokenPair, err := k.GetSwapTokenPair(ctx, tokenPairName)
+		if err != nil {
+			return nil, err
+		}
+		if tokenPair.BasePooledCoin.IsZero() || tokenPair.QuotePooledCoin.IsZero() {
+			return nil, fmt.Errorf("empty pool: %s", tokenPair.TokenPairName())
+		}
+		tokenName = getCounterpartTokenName(tokenPair, tokenName)
+		if tokenName == "" {
+			return nil, fmt.Errorf("swap token pair %s is not chained in path %v", tokenPairName, path)
+		}
+		tokenPairs = append(tokenPairs, tokenPair)
+	}
+	if tokenName != boughtTokenName {
+		return nil, fmt.Errorf("path %v ends with %s instead of %s", path, tokenName, boughtTokenName)
+	}
+	return tokenPairs, nil
+}
+
+// FindBestSwapPath finds the path of at most MaxSwapPathLength non-empty swap token pairs
+// buying the most of the bought token, the shorter one of the paths buying the same amount
+func (k Keeper) FindBestSwapPath(ctx sdk.Context, soldToken sdk.DecCoin, boughtTokenName string) (
+	path []string, tokenPairs []types.SwapTokenPair, err error) {
+	graph := k.getSwapGraph(ctx)
+	params := k.GetParams(ctx)
+	bestAmount := sdk.ZeroDec()
+	visited := map[string]bool{soldToken.Denom: true}
+	var current []types.SwapTokenPair
+	var search func(token sdk.DecCoin)
+	search = func(token sdk.DecCoin) {
+		if token.Denom == boughtTokenName {
+			if token.Amount.GT(bestAmount) || (token.Amount.Equal(bestAmount) && len(current) < len(tokenPairs)) {
+				bestAmount = token.Amount
+				tokenPairs = append([]types.SwapTokenPair{}, current...)
+			}
+			return
+		}
+		if len(current) == types.MaxSwapPathLength {
+			return
+		}
+		for _, tokenPair := range graph[token.Denom] {
+			nextTokenName := getCounterpartTokenName(tokenPair, token.Denom)
+			if visited[nextTokenName] {
+				continue
+			}
+			nextToken := CalculateTokenToBuy(tokenPair, token, nextTokenName, params)
+			if !nextToken.IsPositive() {
+				continue
+			}
+			visited[nextTokenName] = true
+			current = append(current, tokenPair)
+			search(nextToken)
+			current = current[:len(current)-1]
+			visited[nextTokenName] = false
+		}
+	}
+	search(soldToken)
+
+	if len(tokenPairs) == 0 {
+		return nil, nil, fmt.Errorf("no swap path from %s to %s", soldToken.Denom, boughtTokenName)
+	}
+	for _, tokenPair := range tokenPairs {
+		path = append(path, tokenPair.TokenPairName())
+	}
+	return path, tokenPairs, nil
+}
+
+// CalculateSwapRoute calculates the tokens bought by every hop of the swap token pairs,
+// and the price impact of the swap
+func CalculateSwapRoute(tokenPairs []types.SwapTokenPair, soldToken sdk.DecCoin,
+	params types.Params) (types.SwapRoute, error) {
+	route := types.SwapRoute{SoldToken: soldToken}
+	token := soldToken
+	for _, tokenPair := range tokenPairs {
+		boughtTokenName := getCounterpartTokenName(tokenPair, token.Denom)
+		token = CalculateTokenToBuy(tokenPair, token, boughtTokenName, params)
+		if !token.IsPositive() {
+			return types.SwapRoute{}, fmt.Errorf("amount(%s) is too small to swap", token.String())
+		}
+		route.Path = append(route.Path, tokenPair.TokenPairName())
+		route.Amounts = append(route.Amounts, token)
+	}
+	route.PriceImpact = calculatePriceImpact(tokenPairs, soldToken, token.Amount, params)
+	return route, nil
+}
+
+// CalculateSwapRoute calculates the route swapping the sold token through the swap token pairs,
+// for the modules swapping through the keeper
+func (k Keeper) CalculateSwapRoute(tokenPairs []types.SwapTokenPair, soldToken sdk.DecCoin,
+	params types.Params) (types.SwapRoute, error) {
+	return CalculateSwapRoute(tokenPairs, soldToken, params)
+}
+
+// CalculateSwapRouteForExactOutput calculates backwards the tokens sold to every hop of the swap token pairs
+// to buy exactly the bought token, and the price impact of the swap
+func CalculateSwapRouteForExactOutput(tokenPairs []types.SwapTokenPair, soldTokenName string, boughtToken sdk.DecCoin,
+	params types.Params) (types.SwapRoute, error) {
+	route := types.SwapRoute{
+		Path:    make([]string, len(tokenPairs)),
+		Amounts: make([]sdk.DecCoin, len(tokenPairs)),
+	}
+	token := boughtToken
+	for i := len(tokenPairs) - 1; i >= 0; i-- {
+		route.Path[i] = tokenPairs[i].TokenPairName()
+		route.Amounts[i] = token
+		var err error
+		token, err = CalculateTokenToSell(tokenPairs[i], token, getCounterpartTokenName(tokenPairs[i], token.Denom), params)
+		if err != nil {
+			return types.SwapRoute{}, err
+		}
+	}
+	if token.Denom != soldTokenName {
+		return types.SwapRoute{}, fmt.Errorf("path %v starts with %s instead of %s", route.Path, token.Denom, soldTokenName)
+	}
+	route.SoldToken = token
+	route.PriceImpact = calculatePriceImpact(tokenPairs, token, boughtToken.Amount, params)
+	return route, nil
+}
+
+// calculatePriceImpact returns how much the bought amount is less than the amount bought at the current prices
+func calculatePriceImpact(tokenPairs []types.SwapTokenPair, soldToken sdk.DecCoin, boughtAmount sdk.Dec,
+	params types.Params) sdk.Dec {
+	tokenName := soldToken.Denom
+	spotAmount := soldToken.Amount
+	for _, tokenPair := range tokenPairs {
+		// the amount bought at the current price, with the fee charged
+		spotAmount = spotAmount.Mul(sdk.OneDec().Sub(params.FeeRate)).Mul(GetSpotPrice(tokenPair, tokenName))
+		tokenName = getCounterpartTokenName(tokenPair, tokenName)
+	}
+
+	if spotAmount.IsPositive() && boughtAmount.LT(spotAmount) {
+		return spotAmount.Sub(boughtAmount).Quo(spotAmount)
+	}
+	return sdk.ZeroDec()
+}
+
+// QuoteSwapRoute quotes swapping through the path, through the best path if the path is empty
+func (k Keeper) QuoteSwapRoute(ctx sdk.Context, soldToken sdk.DecCoin, boughtTokenName string,
+	path []string) (types.SwapRoute, []types.SwapTokenPair, error) {
+	var tokenPairs []types.SwapTokenPair
+	var err error
+	if len(path) == 0 {
+		_, tokenPairs, err = k.FindBestSwapPath(ctx, soldToken, boughtTokenName)
+	} else {
+		tokenPairs, err = k.GetSwapPath(ctx, soldToken.Denom, boughtTokenName, path)
+	}
+	if err != nil {
+		return types.SwapRoute{}, nil, err
+	}
+	route, err := CalculateSwapRoute(tokenPairs, soldToken, k.GetParams(ctx))
+	return route, tokenPairs, err
+}
+
+// FindBestSwapPathForExactOutput finds the path of at most MaxSwapPathLength non-empty swap token pairs
+// selling the least of the sold token to buy exactly the bought token, the shorter one of the paths selling the same amount
+func (k Keeper) FindBestSwapPathForExactOutput(ctx sdk.Context, soldTokenName string, boughtToken sdk.DecCoin) (
+	path []string, tokenPairs []types.SwapTokenPair, err error) {
+	graph := k.getSwapGraph(ctx)
+	params := k.GetParams(ctx)
+	var bestAmount sdk.Dec
+	visited := map[string]bool{boughtToken.Denom: true}
+	// the swap token pairs are searched backwards from the bought token
+	var current []types.SwapTokenPair
+	var search func(token sdk.DecCoin)
+	search = func(token sdk.DecCoin) {
+		if token.Denom == soldTokenName {
+			if bestAmount.IsNil() || token.Amount.LT(bestAmount) ||
+				(token.Amount.Equal(bestAmount) && len(current) < len(tokenPairs)) {
+				bestAmount = token.Amount
+				tokenPairs = make([]types.SwapTokenPair, len(current))
+				for i, tokenPair := range current {
+					tokenPairs[len(current)-1-i] = tokenPair
+				}
+			}
+			return
+		}
+		if len(current) == types.MaxSwapPathLength {
+			return
+		}
+		for _, tokenPair := range graph[token.Denom] {
+			prevTokenName := getCounterpartTokenName(tokenPair, token.Denom)
+			if visited[prevTokenName] {
+				continue
+			}
+			prevToken, err := CalculateTokenToSell(tokenPair, token, prevTokenName, params)
+			if err != nil {
+				continue
+			}
+			visited[prevTokenName] = true
+			current = append(current, tokenPair)
+			search(prevToken)
+			current = current[:len(current)-1]
+			visited[prevTokenName] = false
+		}
+	}
+	search(boughtToken)
+
+	if len(tokenPairs) == 0 {
+		return nil, nil, fmt.Errorf("no swap path from %s to %s", soldTokenName, boughtToken.Denom)
+	}
+	for _, tokenPair := range tokenPairs {
+		path = append(path, tokenPair.TokenPairName())
+	}
+	return path, tokenPairs, nil
+}
+
+// GetDefaultSwapPath returns the swap token pair of the two tokens if it exists,
+// or the swap token pairs of the two tokens with the native token otherwise
+func (k Keeper) GetDefaultSwapPath(ctx sdk.Context, soldTokenName, boughtTokenName string) []string {
+	tokenPairName := types.GetSwapTokenPairName(soldTokenName, boughtTokenName)
+	if _, err := k.GetSwapTokenPair(ctx, tokenPairName); err == nil {
+		return []string{tokenPairName}
+	}
+	return []string{
+		types.GetSwapTokenPairName(soldTokenName, sdk.DefaultBondDenom),
+		types.GetSwapTokenPairName(boughtTokenName, sdk.DefaultBondDenom),
+	}
+}
+
+// QuoteSwapRouteForExactOutput quotes buying exactly the bought token through the path,
+// through the best path if bestPath, or through the default path if the path is empty
+func (k Keeper) QuoteSwapRouteForExactOutput(ctx sdk.Context, soldTokenName string, boughtToken sdk.DecCoin,
+	path []string, bestPath bool) (types.SwapRoute, []types.SwapTokenPair, error) {
+	var tokenPairs []types.SwapTokenPair
+	var err error
+	if bestPath {
+		_, tokenPairs, err = k.FindBestSwapPathForExactOutput(ctx, soldTokenName, boughtToken)
+	} else {
+		if len(path) == 0 {
+			path = k.GetDefaultSwapPath(ctx, soldTokenName, boughtToken.Denom)
+		}
+		tokenPairs, err = k.GetSwapPath(ctx, soldTokenName, boughtToken.Denom, path)
+	}
+	if err != nil {
+		return types.SwapRoute{}, nil, err
+	}
+	route, err := CalculateSwapRouteForExactOutput(tokenPairs, soldTokenName, boughtToken, k.GetParams(ctx))
+	return route, tokenPairs, err
+}
+
+// SwapByRoute swaps the sold token of the route from the sender through the swap token pairs of the route,
+// the tokens bought by the intermediate hops stay in the pools, only the last one is sent to the recipient.
+// The protocol fee is charged by every hop
+func (k Keeper) SwapByRoute(ctx sdk.Context, sender, recipient sdk.AccAddress,
+	tokenPairs []types.SwapTokenPair, route types.SwapRoute) error {
+	if err := k.SendCoinsToPool(ctx, sdk.DecCoins{route.SoldToken}, sender); err != nil {
+		return err
+	}
+	if err := k.SendCoinsFromPoolToAccount(ctx, sdk.DecCoins{route.BoughtToken()}, recipient); err != nil {
+		return err
+	}
+
+	token := route.SoldToken
+	for i, tokenPair := range tokenPairs {
+		boughtToken := route.Amounts[i]
+		pooledToken, err := k.SettleSwapFee(ctx, &tokenPair, token)
+		if err != nil {
+			return err
+		}
+		k.UpdatePriceCumulative(ctx, &tokenPair)
+		if token.Denom == tokenPair.BasePooledCoin.Denom {
+			tokenPair.BasePooledCoin = tokenPair.BasePooledCoin.Add(pooledToken)
+			tokenPair.QuotePooledCoin = tokenPair.QuotePooledCoin.Sub(boughtToken)
+		} else {
+			tokenPair.QuotePooledCoin = tokenPair.QuotePooledCoin.Add(pooledToken)
+			tokenPair.BasePooledCoin = tokenPair.BasePooledCoin.Sub(boughtToken)
+		}
+		k.SetSwapTokenPair(ctx, tokenPair.TokenPairName(), tokenPair)
+		k.RecordSwapExecution(ctx, sender, tokenPair.TokenPairName(), token, boughtToken)
+		token = boughtToken
+	}
+	return nil
+}
+
+// getSwapGraph returns the non-empty swap token pairs by the names of their tokens
+func (k Keeper) getSwapGraph(ctx sdk.Context) map[string][]types.SwapTokenPair {
+	graph := make(map[string][]types.SwapTokenPair)
+	for _, tokenPair := range k.GetSwapTokenPairs(ctx) {
+		if tokenPair.BasePooledCoin.IsZero() || tokenPair.QuotePooledCoin.IsZero() {
+			continue
+		}
+		graph[tokenPair.BasePooledCoin.Denom] = append(graph[tokenPair.BasePooledCoin.Denom], tokenPair)
+		graph[tokenPair.QuotePooledCoin.Denom] = append(graph[tokenPair.QuotePooledCoin.Denom], tokenPair)
+	}
+	return graph
+}
+
+// getCounterpartTokenName returns the other token of the swap token pair, empty if the token isn't in the pair
+func getCounterpartTokenName(tokenPair types.SwapTokenPair, tokenName string) string {
+	switch tokenName {
+	case tokenPair.BasePooledCoin.Denom:
+		return tokenPair.QuotePooledCoin.Denom
+	case tokenPair.QuotePooledCoin.Denom:
+		return tokenPair.BasePooledCoin.Denom
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,150 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/okex/okexchain/x/ammswap/types"
+)
+
+func setTestPool(ctx sdk.Context, keeper Keeper, token0, token1 string, amount0, amount1 int64) {
+	swapTokenPair := types.NewSwapPair(token0, token1)
+	base, _ := types.GetBaseQuoteTokenName(token0, token1)
+	if base != token0 {
+		amount0, amount1 = amount1, amount0
+	}
+	swapTokenPair.BasePooledCoin.Amount = sdk.NewDec(amount0)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(amount1)
+	keeper.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+}
+
+func TestFindBestSwapPath(t *testing.T) {
+	_, _, ctx, keeper, querier := initQurierTest(t)
+	aab, ccb, ddb, okt := types.TestBasePooledToken, types.TestBasePooledToken2, types.TestBasePooledToken3,
+		types.TestQuotePooledToken
+	setTestPool(ctx, keeper, aab, okt, 10000, 10000)
+	setTestPool(ctx, keeper, ccb, okt, 10000, 10000)
+	// the direct pool is too shallow to be the best path
+	setTestPool(ctx, keeper, aab, ccb, 100, 100)
+	// empty pools are never routed through
+	setTestPool(ctx, keeper, ddb, okt, 0, 0)
+
+	soldToken := sdk.NewDecCoinFromDec(aab, sdk.NewDec(10))
+	path, tokenPairs, err := keeper.FindBestSwapPath(ctx, soldToken, ccb)
+	require.Nil(t, err)
+	require.EqualValues(t, []string{"aab_" + okt, "ccb_" + okt}, path)
+	require.EqualValues(t, 2, len(tokenPairs))
+
+	_, _, err = keeper.FindBestSwapPath(ctx, soldToken, ddb)
+	require.NotNil(t, err)
+
+	// the explicit path is checked against the sold & bought tokens
+	_, err = keeper.GetSwapPath(ctx, aab, ccb, []string{"aab_ccb"})
+	require.Nil(t, err)
+	_, err = keeper.GetSwapPath(ctx, aab, ccb, []string{"ccb_" + okt})
+	require.NotNil(t, err)
+	_, err = keeper.GetSwapPath(ctx, aab, ccb, []string{"aab_" + okt})
+	require.NotNil(t, err)
+	_, err = keeper.GetSwapPath(ctx, aab, ddb, []string{"aab_" + okt, "ddb_" + okt})
+	require.NotNil(t, err)
+
+	// the price impact of the direct pool is much higher
+	best, _, err := keeper.QuoteSwapRoute(ctx, soldToken, ccb, nil)
+	require.Nil(t, err)
+	direct, _, err := keeper.QuoteSwapRoute(ctx, soldToken, ccb, []string{"aab_ccb"})
+	require.Nil(t, err)
+	require.True(t, best.BoughtToken().Amount.GT(direct.BoughtToken().Amount))
+	require.True(t, best.PriceImpact.LT(direct.PriceImpact))
+	require.True(t, direct.PriceImpact.GT(sdk.NewDecWithPrec(9, 2)))
+
+	// querier
+	bz := keeper.cdc.MustMarshalJSON(types.QuerySwapRouteParams{SoldToken: soldToken, TokenToBuy: ccb})
+	res, sdkErr := querier(ctx, []string{types.QuerySwapRoute}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	var route types.SwapRoute
+	keeper.cdc.MustUnmarshalJSON(res, &route)
+	require.EqualValues(t, best, route)
+
+	bz = keeper.cdc.MustMarshalJSON(types.QuerySwapRouteParams{SoldToken: soldToken, TokenToBuy: ccb,
+		Path: []string{"aab_ddb"}})
+	_, sdkErr = querier(ctx, []string{types.QuerySwapRoute}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, sdkErr)
+}
+
+func TestGetOutputPrice(t *testing.T) {
+	feeRate := types.DefaultParams().FeeRate
+	tests := []struct {
+		outputAmount, inputReserve, outputReserve sdk.Dec
+	}{
+		{sdk.NewDec(10), sdk.NewDec(10000), sdk.NewDec(10000)},
+		{sdk.NewDecWithPrec(1, 8), sdk.NewDec(10000), sdk.NewDec(10000)},
+		{sdk.MustNewDecFromStr("123.45678901"), sdk.MustNewDecFromStr("3456.789"), sdk.NewDec(9999)},
+		{sdk.MustNewDecFromStr("99.99999999"), sdk.NewDec(100), sdk.NewDec(100)},
+	}
+	minUnit := sdk.NewDecWithPrec(1, sdk.Precision)
+	for _, test := range tests {
+		inputAmount, err := GetOutputPrice(test.outputAmount, test.inputReserve, test.outputReserve, feeRate)
+		require.Nil(t, err)
+		// the input buys the output amount, and one unit less doesn't
+		require.True(t, GetInputPrice(inputAmount, test.inputReserve, test.outputReserve, feeRate).GTE(test.outputAmount))
+		require.True(t, GetInputPrice(inputAmount.Sub(minUnit), test.inputReserve, test.outputReserve, feeRate).LT(test.outputAmount))
+	}
+
+	_, err := GetOutputPrice(sdk.NewDec(100), sdk.NewDec(100), sdk.NewDec(100), feeRate)
+	require.NotNil(t, err)
+}
+
+func TestQuoteSwapRouteForExactOutput(t *testing.T) {
+	_, _, ctx, keeper, querier := initQurierTest(t)
+	aab, ccb, ddb, okt := types.TestBasePooledToken, types.TestBasePooledToken2, types.TestBasePooledToken3,
+		types.TestQuotePooledToken
+	setTestPool(ctx, keeper, aab, okt, 10000, 10000)
+	setTestPool(ctx, keeper, ccb, okt, 10000, 10000)
+	setTestPool(ctx, keeper, aab, ccb, 100, 100)
+	setTestPool(ctx, keeper, ddb, okt, 0, 0)
+
+	boughtToken := sdk.NewDecCoinFromDec(ccb, sdk.NewDec(10))
+
+	// the direct pool is the default path if it exists
+	require.EqualValues(t, []string{"aab_ccb"}, keeper.GetDefaultSwapPath(ctx, aab, ccb))
+	require.EqualValues(t, []string{"aab_" + okt, "ddb_" + okt}, keeper.GetDefaultSwapPath(ctx, aab, ddb))
+	direct, _, err := keeper.QuoteSwapRouteForExactOutput(ctx, aab, boughtToken, nil, false)
+	require.Nil(t, err)
+	require.EqualValues(t, []string{"aab_ccb"}, direct.Path)
+
+	best, tokenPairs, err := keeper.QuoteSwapRouteForExactOutput(ctx, aab, boughtToken, nil, true)
+	require.Nil(t, err)
+	require.EqualValues(t, []string{"aab_" + okt, "ccb_" + okt}, best.Path)
+	require.True(t, best.SoldToken.Amount.LT(direct.SoldToken.Amount))
+	require.Equal(t, boughtToken, best.BoughtToken())
+
+	// selling the quoted amount forwards buys at least the bought token
+	forward, err := CalculateSwapRoute(tokenPairs, best.SoldToken, keeper.GetParams(ctx))
+	require.Nil(t, err)
+	require.True(t, forward.BoughtToken().Amount.GTE(boughtToken.Amount))
+	require.True(t, forward.Amounts[0].Amount.GTE(best.Amounts[0].Amount))
+
+	// buying the whole pool is impossible
+	_, _, err = keeper.QuoteSwapRouteForExactOutput(ctx, aab, sdk.NewDecCoinFromDec(ccb, sdk.NewDec(100)),
+		[]string{"aab_ccb"}, false)
+	require.NotNil(t, err)
+	_, _, err = keeper.QuoteSwapRouteForExactOutput(ctx, aab, sdk.NewDecCoinFromDec(ddb, sdk.NewDec(1)), nil, false)
+	require.NotNil(t, err)
+
+	// querier
+	bz := keeper.cdc.MustMarshalJSON(types.QueryBuyAmountParams{BoughtToken: boughtToken, TokenToSell: aab,
+		BestPath: true})
+	res, sdkErr := querier(ctx, []string{types.QueryBuyAmount}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	var sellAmount sdk.Dec
+	keeper.cdc.MustUnmarshalJSON(res, &sellAmount)
+	require.Equal(t, best.SoldToken.Amount, sellAmount)
+
+	bz = keeper.cdc.MustMarshalJSON(types.QueryBuyAmountParams{BoughtToken: boughtToken, TokenToSell: aab,
+		Path: []string{"aab_ccb"}, BestPath: true})
+	_, sdkErr = querier(ctx, []string{types.QueryBuyAmount}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, sdkErr)
+}
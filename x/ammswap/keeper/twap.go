@@ -0,0 +1,152 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/ammswap/types"
+)
+
+// UpdatePriceCumulative accumulates the prices of the swap token pair weighted by the seconds since the last update,
+// it must be called before the reserves change so that the prices of the previous blocks are accumulated.
+// The cumulative prices are observed at the first reserve change of every block.
+func (k Keeper) UpdatePriceCumulative(ctx sdk.Context, tokenPair *types.SwapTokenPair) {
+	blockTime := ctx.BlockTime().Unix()
+	tokenPair.BasePriceCumulative, tokenPair.QuotePriceCumulative = getPriceCumulative(*tokenPair, blockTime)
+	tokenPair.PriceLastUpdateTime = blockTime
+
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetPriceObservationKey(tokenPair.TokenPairName(), ctx.BlockHeight())
+	if store.Has(key) {
+		return
+	}
+	observation := types.PriceObservation{
+		Height:               ctx.BlockHeight(),
+		Time:                 blockTime,
+		BasePriceCumulative:  tokenPair.BasePriceCumulative,
+		QuotePriceCumulative: tokenPair.QuotePriceCumulative,
+	}
+	store.Set(key, k.cdc.MustMarshalBinaryBare(observation))
+	k.prunePriceObservations(ctx, tokenPair.TokenPairName())
+}
+
+// GetPriceObservation gets the last price observation of the swap token pair at or before the height
+func (k Keeper) GetPriceObservation(ctx sdk.Context, tokenPairName string, height int64) (
+	types.PriceObservation, bool) {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.ReverseIterator(types.GetPriceObservationPrefix(tokenPairName),
+		types.GetPriceObservationKey(tokenPairName, height+1))
+	defer iter.Close()
+	if !iter.Valid() {
+		return types.PriceObservation{}, false
+	}
+	var observation types.PriceObservation
+	k.cdc.MustUnmarshalBinaryBare(iter.Value(), &observation)
+	return observation, true
+}
+
+// GetTWAP gets the time-weighted average prices of the swap token pair between the block times
+// of the last reserve changes at or before the heights, to the current block time if toHeight is the current height.
+// If the reserves didn't change between the heights, the constant prices are averaged over the span they last.
+func (k Keeper) GetTWAP(ctx sdk.Context, tokenPairName string, fromHeight, toHeight int64) (types.TWAP, error) {
+	if fromHeight >= toHeight {
+		return types.TWAP{}, fmt.Errorf("from height %d should be less than to height %d", fromHeight, toHeight)
+	}
+	if toHeight > ctx.BlockHeight() {
+		return types.TWAP{}, fmt.Errorf("to height %d is greater than the current height %d", toHeight, ctx.BlockHeight())
+	}
+	tokenPair, err := k.GetSwapTokenPair(ctx, tokenPairName)
+	if err != nil {
+		return types.TWAP{}, err
+	}
+
+	from, ok := k.GetPriceObservation(ctx, tokenPairName, fromHeight)
+	if !ok {
+		return types.TWAP{}, fmt.Errorf("no price observation of %s at or before height %d", tokenPairName, fromHeight)
+	}
+	currentObservation := func() types.PriceObservation {
+		observation := types.PriceObservation{Height: ctx.BlockHeight(), Time: ctx.BlockTime().Unix()}
+		observation.BasePriceCumulative, observation.QuotePriceCumulative = getPriceCumulative(tokenPair, observation.Time)
+		return observation
+	}
+	var to types.PriceObservation
+	if toHeight == ctx.BlockHeight() {
+		to = currentObservation()
+	} else if to, _ = k.GetPriceObservation(ctx, tokenPairName, toHeight); to.Height == from.Height {
+		// the prices are constant between the heights,
+		// the average is extended to the next observation or to the current block time
+		if to, ok = k.getNextPriceObservation(ctx, tokenPairName, from.Height); !ok {
+			to = currentObservation()
+		}
+	}
+	elapsed := to.Time - from.Time
+	if elapsed <= 0 {
+		return types.TWAP{}, fmt.Errorf("no time elapsed between height %d and %d", fromHeight, toHeight)
+	}
+
+	return types.TWAP{
+		TokenPairName: tokenPairName,
+		FromHeight:    fromHeight,
+		ToHeight:      toHeight,
+		FromTime:      from.Time,
+		ToTime:        to.Time,
+		BasePrice:     to.BasePriceCumulative.Sub(from.BasePriceCumulative).QuoInt64(elapsed),
+		QuotePrice:    to.QuotePriceCumulative.Sub(from.QuotePriceCumulative).QuoInt64(elapsed),
+	}, nil
+}
+
+// getNextPriceObservation gets the first price observation of the swap token pair after the height
+func (k Keeper) getNextPriceObservation(ctx sdk.Context, tokenPairName string, height int64) (
+	types.PriceObservation, bool) {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(types.GetPriceObservationKey(tokenPairName, height+1),
+		sdk.PrefixEndBytes(types.GetPriceObservationPrefix(tokenPairName)))
+	defer iter.Close()
+	if !iter.Valid() {
+		return types.PriceObservation{}, false
+	}
+	var observation types.PriceObservation
+	k.cdc.MustUnmarshalBinaryBare(iter.Value(), &observation)
+	return observation, true
+}
+
+// prunePriceObservations deletes the price observations older than PriceObservationRetentionBlocks,
+// except the last one of them
+func (k Keeper) prunePriceObservations(ctx sdk.Context, tokenPairName string) {
+	height := ctx.BlockHeight() - types.PriceObservationRetentionBlocks
+	if height <= 0 {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(types.GetPriceObservationPrefix(tokenPairName),
+		types.GetPriceObservationKey(tokenPairName, height))
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	for i := 0; i < len(keys)-1; i++ {
+		store.Delete(keys[i])
+	}
+}
+
+// getPriceCumulative returns the cumulative prices of the swap token pair at the block time,
+// the prices are only accumulated since the first update when the pool is not empty
+func getPriceCumulative(tokenPair types.SwapTokenPair, blockTime int64) (base, quote sdk.Dec) {
+	base, quote = tokenPair.BasePriceCumulative, tokenPair.QuotePriceCumulative
+	if base.IsNil() {
+		base = sdk.ZeroDec()
+	}
+	if quote.IsNil() {
+		quote = sdk.ZeroDec()
+	}
+	elapsed := blockTime - tokenPair.PriceLastUpdateTime
+	if tokenPair.PriceLastUpdateTime == 0 || elapsed <= 0 ||
+		tokenPair.BasePooledCoin.IsZero() || tokenPair.QuotePooledCoin.IsZero() {
+		return base, quote
+	}
+	base = base.Add(GetSpotPrice(tokenPair, tokenPair.BasePooledCoin.Denom).MulInt64(elapsed))
+	quote = quote.Add(GetSpotPrice(tokenPair, tokenPair.QuotePooledCoin.Denom).MulInt64(elapsed))
+	return base, quote
+}
@@ -0,0 +1,106 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/okex/okexchain/x/ammswap/types"
+)
+
+func TestGetTWAP(t *testing.T) {
+	_, _, ctx, keeper, querier := initQurierTest(t)
+	aab, okt := types.TestBasePooledToken, types.TestQuotePooledToken
+	tokenPairName := types.GetSwapTokenPairName(aab, okt)
+	setTestPool(ctx, keeper, aab, okt, 100, 200)
+
+	// changes the reserves of the pool at the height & time
+	changeReserves := func(height, blockTime, base, quote int64) {
+		ctx = ctx.WithBlockHeight(height).WithBlockTime(time.Unix(blockTime, 0))
+		tokenPair, err := keeper.GetSwapTokenPair(ctx, tokenPairName)
+		require.Nil(t, err)
+		keeper.UpdatePriceCumulative(ctx, &tokenPair)
+		tokenPair.BasePooledCoin.Amount = sdk.NewDec(base)
+		tokenPair.QuotePooledCoin.Amount = sdk.NewDec(quote)
+		keeper.SetSwapTokenPair(ctx, tokenPairName, tokenPair)
+	}
+	// the prices start to accumulate from the first update
+	changeReserves(10, 1000, 100, 200)
+	changeReserves(20, 1100, 200, 200)
+	changeReserves(30, 1300, 100, 400)
+	// the observation of a block is taken at its first reserve change
+	changeReserves(30, 1300, 100, 500)
+	changeReserves(30, 1300, 100, 400)
+	ctx = ctx.WithBlockHeight(40).WithBlockTime(time.Unix(1400, 0))
+
+	observation, ok := keeper.GetPriceObservation(ctx, tokenPairName, 35)
+	require.True(t, ok)
+	require.Equal(t, int64(30), observation.Height)
+	require.Equal(t, sdk.NewDec(400), observation.BasePriceCumulative)
+	_, ok = keeper.GetPriceObservation(ctx, tokenPairName, 9)
+	require.False(t, ok)
+
+	tests := []struct {
+		fromHeight, toHeight  int64
+		basePrice, quotePrice string
+		fromTime, toTime      int64
+	}{
+		{10, 30, "1.33333333", "0.83333333", 1000, 1300},
+		// to the current block time
+		{20, 40, "2", "0.75", 1100, 1400},
+		// the constant prices between the observations
+		{22, 25, "1", "1", 1100, 1300},
+		{35, 39, "4", "0.25", 1300, 1400},
+	}
+	for _, test := range tests {
+		twap, err := keeper.GetTWAP(ctx, tokenPairName, test.fromHeight, test.toHeight)
+		require.Nil(t, err)
+		require.Equal(t, sdk.MustNewDecFromStr(test.basePrice), twap.BasePrice, test)
+		require.Equal(t, sdk.MustNewDecFromStr(test.quotePrice), twap.QuotePrice, test)
+		require.Equal(t, test.fromTime, twap.FromTime)
+		require.Equal(t, test.toTime, twap.ToTime)
+	}
+
+	_, err := keeper.GetTWAP(ctx, tokenPairName, 5, 20)
+	require.NotNil(t, err)
+	_, err = keeper.GetTWAP(ctx, tokenPairName, 30, 30)
+	require.NotNil(t, err)
+	_, err = keeper.GetTWAP(ctx, tokenPairName, 30, 41)
+	require.NotNil(t, err)
+
+	// querier
+	bz := keeper.cdc.MustMarshalJSON(types.QueryTWAPParams{BaseToken: aab, QuoteToken: okt, FromHeight: 20})
+	res, sdkErr := querier(ctx, []string{types.QueryTWAP}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	var twap types.TWAP
+	keeper.cdc.MustUnmarshalJSON(res, &twap)
+	require.Equal(t, int64(40), twap.ToHeight)
+	require.Equal(t, sdk.NewDec(2), twap.BasePrice)
+
+	bz = keeper.cdc.MustMarshalJSON(types.QueryTWAPParams{BaseToken: okt, QuoteToken: aab, FromHeight: 20})
+	_, sdkErr = querier(ctx, []string{types.QueryTWAP}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, sdkErr)
+}
+
+func TestPrunePriceObservations(t *testing.T) {
+	_, _, ctx, keeper, _ := initQurierTest(t)
+	aab, okt := types.TestBasePooledToken, types.TestQuotePooledToken
+	tokenPairName := types.GetSwapTokenPairName(aab, okt)
+	setTestPool(ctx, keeper, aab, okt, 100, 100)
+	tokenPair, err := keeper.GetSwapTokenPair(ctx, tokenPairName)
+	require.Nil(t, err)
+
+	for _, height := range []int64{10, 20, 30, types.PriceObservationRetentionBlocks + 25} {
+		ctx = ctx.WithBlockHeight(height).WithBlockTime(time.Unix(height, 0))
+		keeper.UpdatePriceCumulative(ctx, &tokenPair)
+	}
+	// the last observation before the retention is kept
+	_, ok := keeper.GetPriceObservation(ctx, tokenPairName, 19)
+	require.False(t, ok)
+	observation, ok := keeper.GetPriceObservation(ctx, tokenPairName, 29)
+	require.True(t, ok)
+	require.Equal(t, int64(20), observation.Height)
+}
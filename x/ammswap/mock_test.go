@@ -24,6 +24,7 @@ type MockApp struct {
 	*mock.App
 
 	keySwap   *sdk.KVStoreKey
+	tkeySwap  *sdk.TransientStoreKey
 	keyToken  *sdk.KVStoreKey
 	keyLock   *sdk.KVStoreKey
 	keySupply *sdk.KVStoreKey
@@ -53,6 +54,7 @@ func getMockAppWithBalance(t *testing.T, numGenAccs int, balance int64) (mockApp
 	mockApp = &MockApp{
 		App:       mapp,
 		keySwap:   sdk.NewKVStoreKey(StoreKey),
+		tkeySwap:  sdk.NewTransientStoreKey(TStoreKey),
 		keyToken:  sdk.NewKVStoreKey(token.StoreKey),
 		keyLock:   sdk.NewKVStoreKey(token.KeyLock),
 		keySupply: sdk.NewKVStoreKey(supply.StoreKey),
@@ -87,8 +89,10 @@ func getMockAppWithBalance(t *testing.T, numGenAccs int, balance int64) (mockApp
 	mockApp.swapKeeper = NewKeeper(
 		mockApp.supplyKeeper,
 		mockApp.tokenKeeper,
+		nil,
 		mockApp.Cdc,
 		mockApp.keySwap,
+		mockApp.tkeySwap,
 		mockApp.ParamsKeeper.Subspace(DefaultParamspace),
 	)
 
@@ -114,6 +118,7 @@ func getMockAppWithBalance(t *testing.T, numGenAccs int, balance int64) (mockApp
 	app := mockApp
 	require.NoError(t, app.CompleteSetup(
 		app.keySwap,
+		app.tkeySwap,
 		app.keyToken,
 		app.keyLock,
 		app.keySupply,
@@ -0,0 +1,17 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SwapExecution is a hop of a swap executed in the block, one swap through a path executes a hop for every swap
+// token pair of the path
+type SwapExecution struct {
+	TxHash        string         `json:"tx_hash"`
+	Index         uint64         `json:"index"` // the order of the hop in the block
+	TokenPairName string         `json:"token_pair_name"`
+	Sender        sdk.AccAddress `json:"sender"`
+	SoldToken     sdk.DecCoin    `json:"sold_token"`
+	BoughtToken   sdk.DecCoin    `json:"bought_token"`
+	Fee           sdk.DecCoin    `json:"fee"` // the swap fee charged from the sold token
+}
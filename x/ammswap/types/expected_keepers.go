@@ -40,3 +40,8 @@ type TokenKeeper interface {
 	GetCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.DecCoins
 	TokenExist(ctx sdk.Context, symbol string) bool
 }
+
+// DistributionKeeper defines the expected distribution interface
+type DistributionKeeper interface {
+	FundCommunityPool(ctx sdk.Context, amount sdk.Coins, sender sdk.AccAddress) sdk.Error
+}
@@ -0,0 +1,40 @@
+package types
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SecondsPerYear is used to annualize the fees earned by the pools
+const SecondsPerYear = 365 * 24 * 60 * 60
+
+// PoolAPR is the annual percentage rate earned by the liquidity providers of a pool from the swap fees
+type PoolAPR struct {
+	TokenPairName string  `json:"token_pair_name"`
+	Fees          sdk.Dec `json:"fees"`      // the swap fees kept by the pool since the stats start, valued in quote token
+	Liquidity     sdk.Dec `json:"liquidity"` // the tokens pooled, valued in quote token
+	Duration      int64   `json:"duration"`  // seconds since the stats start
+	APR           sdk.Dec `json:"apr"`
+}
+
+// String implement fmt.Stringer
+func (a PoolAPR) String() string {
+	aprJSON, err := json.Marshal(a)
+	if err != nil {
+		panic(err)
+	}
+	return string(aprJSON)
+}
+
+// PoolAPRs is a list of PoolAPR
+type PoolAPRs []PoolAPR
+
+// String implement fmt.Stringer
+func (a PoolAPRs) String() string {
+	aprsJSON, err := json.Marshal(a)
+	if err != nil {
+		panic(err)
+	}
+	return string(aprsJSON)
+}
@@ -1,5 +1,9 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName is the name of the module
 	ModuleName = "ammswap"
@@ -7,6 +11,9 @@ const (
 	// StoreKey to be used when creating the KVStore
 	StoreKey = ModuleName
 
+	// TStoreKey is the key of the transient store keeping the swaps executed and the swap token pairs changed in the block
+	TStoreKey = "transient_" + ModuleName
+
 	// RouterKey to be used for routing msgs
 	RouterKey = ModuleName
 
@@ -23,14 +30,48 @@ const (
 	QueryParams = "params"
 
 	QueryBuyAmount = "buy"
+
+	QuerySwapRoute = "route"
+
+	QueryPoolAPRs = "aprs"
+
+	QueryTWAP = "twap"
 )
 
 var (
 	// TokenPairPrefixKey to be used for KVStore
 	TokenPairPrefixKey = []byte{0x01}
+	// PriceObservationPrefixKey is the prefix of the price observations of the swap token pairs
+	PriceObservationPrefixKey = []byte{0x02}
+	// SwapExecutionPrefixKey is the prefix of the swaps executed in the block, kept in the transient store
+	SwapExecutionPrefixKey = []byte{0x03}
+	// SwapExecutionCountKey is the number of the swaps executed in the block, kept in the transient store
+	SwapExecutionCountKey = []byte{0x04}
+	// ChangedTokenPairPrefixKey is the prefix of the swap token pairs changed in the block, kept in the transient store
+	ChangedTokenPairPrefixKey = []byte{0x05}
 )
 
 // nolint
 func GetTokenPairKey(key string) []byte {
 	return append(TokenPairPrefixKey, []byte(key)...)
 }
+
+// GetPriceObservationPrefix returns the prefix of the price observations of the swap token pair
+func GetPriceObservationPrefix(tokenPairName string) []byte {
+	return append(append(PriceObservationPrefixKey, []byte(tokenPairName)...), 0x00)
+}
+
+// GetPriceObservationKey returns the key of the price observation of the swap token pair at the height
+func GetPriceObservationKey(tokenPairName string, height int64) []byte {
+	return append(GetPriceObservationPrefix(tokenPairName), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetSwapExecutionKey returns the key of the swap executed in the block by its index
+func GetSwapExecutionKey(index uint64) []byte {
+	return append(SwapExecutionPrefixKey, sdk.Uint64ToBigEndian(index)...)
+}
+
+// GetChangedTokenPairKey returns the transient key marking the swap token pair as changed in the block
+func GetChangedTokenPairKey(tokenPairName string) []byte {
+	return append(ChangedTokenPairPrefixKey, []byte(tokenPairName)...)
+}
@@ -34,6 +34,38 @@ func TestMsgCreateExchange(t *testing.T) {
 	require.Equal(t, expectTokenPair, msg.GetSwapTokenPairName())
 }
 
+func TestMsgCreateExchangeWithCurve(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	// the curve fields are left out of the sign bytes of the constant product pools
+	msg := NewMsgCreateExchange("aaa", "bbb", addr)
+	require.NotContains(t, string(msg.GetSignBytes()), "pool_type")
+
+	weight := sdk.MustNewDecFromStr
+	tests := []struct {
+		poolType       string
+		amplification  int64
+		weight0        sdk.Dec
+		weight1        sdk.Dec
+		expectedResult bool
+	}{
+		{PoolTypeConstantProduct, 0, sdk.Dec{}, sdk.Dec{}, true},
+		{PoolTypeStable, 100, sdk.Dec{}, sdk.Dec{}, true},
+		{PoolTypeStable, 0, sdk.Dec{}, sdk.Dec{}, false},
+		{PoolTypeStable, MaxAmplification + 1, sdk.Dec{}, sdk.Dec{}, false},
+		{PoolTypeWeighted, 0, weight("0.8"), weight("0.2"), true},
+		{PoolTypeWeighted, 0, weight("0.02"), weight("0.98"), true},
+		{PoolTypeWeighted, 0, weight("0.01"), weight("0.99"), false},
+		{PoolTypeWeighted, 0, weight("0.8"), weight("0.3"), false},
+		{PoolTypeWeighted, 0, sdk.Dec{}, sdk.Dec{}, false},
+		{"unknown", 0, sdk.Dec{}, sdk.Dec{}, false},
+	}
+	for _, test := range tests {
+		msg := NewMsgCreateExchangeWithCurve("aaa", "bbb", test.poolType, test.amplification, test.weight0, test.weight1, addr)
+		require.Equal(t, test.expectedResult, msg.ValidateBasic() == nil, test)
+	}
+}
+
 func TestMsgCreateExchangeInvalid(t *testing.T) {
 	addr, err := hex.DecodeString(addrStr)
 	require.Nil(t, err)
@@ -272,3 +304,83 @@ func TestMsgTokenToTokenInvalid(t *testing.T) {
 		require.Equal(t, testCase.exceptResultCode, err.Code())
 	}
 }
+
+func TestMsgTokenToTokenByRoute(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	minBoughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(1))
+	deadLine := time.Now().Unix()
+	soldTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken2, sdk.NewDec(2))
+	path := []string{GetSwapTokenPairName(TestBasePooledToken2, TestQuotePooledToken),
+		GetSwapTokenPairName(TestBasePooledToken, TestQuotePooledToken)}
+
+	tests := []struct {
+		testCase         string
+		path             []string
+		bestPath         bool
+		exceptResultCode sdk.CodeType
+	}{
+		{"success(path)", path, false, sdk.CodeOK},
+		{"success(best path)", nil, true, sdk.CodeOK},
+		{"both path and best path", path, true, sdk.CodeUnknownRequest},
+		{"path too long", append(path, "ccb_ddb", "aab_ddb"), false, sdk.CodeUnknownRequest},
+		{"invalid swap token pair name", []string{"aab-okt"}, false, sdk.CodeUnknownRequest},
+		{"duplicated swap token pair", []string{path[0], path[0]}, false, sdk.CodeUnknownRequest},
+	}
+	for _, testCase := range tests {
+		msg := NewMsgTokenToTokenByRoute(soldTokenAmount, minBoughtTokenAmount, testCase.path, testCase.bestPath,
+			deadLine, addr, addr)
+		err := msg.ValidateBasic()
+		if testCase.exceptResultCode == sdk.CodeOK {
+			require.Nil(t, err, testCase.testCase)
+		} else {
+			require.NotNil(t, err, testCase.testCase)
+			require.Equal(t, testCase.exceptResultCode, err.Code(), testCase.testCase)
+		}
+	}
+}
+
+func TestMsgTokenToExactToken(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	maxSoldTokenAmount := sdk.NewDecCoinFromDec(TestQuotePooledToken, sdk.NewDec(2))
+	boughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(1))
+	deadLine := time.Now().Unix()
+	msg := NewMsgTokenToExactToken(maxSoldTokenAmount, boughtTokenAmount, nil, false, deadLine, addr, addr)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, TypeMsgTokenSwapForExactToken, msg.Type())
+	require.EqualValues(t, addr, msg.GetSigners()[0])
+	resMsg := &MsgTokenToExactToken{}
+	require.Nil(t, json.Unmarshal(msg.GetSignBytes(), resMsg))
+
+	zeroBoughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.ZeroDec())
+	path := []string{GetSwapTokenPairName(TestBasePooledToken, TestQuotePooledToken)}
+	tests := []struct {
+		testCase           string
+		maxSoldTokenAmount sdk.DecCoin
+		boughtTokenAmount  sdk.DecCoin
+		path               []string
+		bestPath           bool
+		addr               sdk.AccAddress
+		exceptResultCode   sdk.CodeType
+	}{
+		{"success(path)", maxSoldTokenAmount, boughtTokenAmount, path, false, addr, sdk.CodeOK},
+		{"success(best path)", maxSoldTokenAmount, boughtTokenAmount, nil, true, addr, sdk.CodeOK},
+		{"empty sender", maxSoldTokenAmount, boughtTokenAmount, nil, false, nil, sdk.CodeInvalidAddress},
+		{"zero bought token amount", maxSoldTokenAmount, zeroBoughtTokenAmount, nil, false, addr, sdk.CodeUnknownRequest},
+		{"same tokens", boughtTokenAmount, boughtTokenAmount, nil, false, addr, sdk.CodeUnknownRequest},
+		{"both path and best path", maxSoldTokenAmount, boughtTokenAmount, path, true, addr, sdk.CodeUnknownRequest},
+	}
+	for _, testCase := range tests {
+		msg := NewMsgTokenToExactToken(testCase.maxSoldTokenAmount, testCase.boughtTokenAmount, testCase.path,
+			testCase.bestPath, deadLine, testCase.addr, testCase.addr)
+		err := msg.ValidateBasic()
+		if testCase.exceptResultCode == sdk.CodeOK {
+			require.Nil(t, err, testCase.testCase)
+		} else {
+			require.NotNil(t, err, testCase.testCase)
+			require.Equal(t, testCase.exceptResultCode, err.Code(), testCase.testCase)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 const (
 	TypeMsgAddLiquidity = "add_liquidity"
 	TypeMsgTokenSwap    = "token_swap"
+
+	TypeMsgTokenSwapForExactToken = "token_swap_for_exact_token"
 )
 
 // MsgAddLiquidity Deposit quote_amount and base_amount at current ratio to mint pool tokens.
@@ -143,6 +145,11 @@ type MsgCreateExchange struct {
 	Token0Name string          `json:"token0_name"`
 	Token1Name string          `json:"token1_name"`
 	Sender          sdk.AccAddress `json:"sender"` // Sender
+
+	PoolType      string  `json:"pool_type,omitempty"`     // The curve of the pool, constant product if it's empty
+	Amplification int64   `json:"amplification,omitempty"` // The amplification coefficient of a stable pool
+	Token0Weight  sdk.Dec `json:"token0_weight,omitempty"` // The weight of token0 in a weighted pool
+	Token1Weight  sdk.Dec `json:"token1_weight,omitempty"` // The weight of token1 in a weighted pool
 }
 
 // NewMsgCreateExchange create a new exchange with token
@@ -154,6 +161,17 @@ func NewMsgCreateExchange(token0Name string, token1Name string, sender sdk.AccAd
 	}
 }
 
+// NewMsgCreateExchangeWithCurve creates a new exchange priced with the curve of the pool type
+func NewMsgCreateExchangeWithCurve(token0Name, token1Name, poolType string, amplification int64,
+	token0Weight, token1Weight sdk.Dec, sender sdk.AccAddress) MsgCreateExchange {
+	msg := NewMsgCreateExchange(token0Name, token1Name, sender)
+	msg.PoolType = poolType
+	msg.Amplification = amplification
+	msg.Token0Weight = token0Weight
+	msg.Token1Weight = token1Weight
+	return msg
+}
+
 // Route should return the name of the module
 func (msg MsgCreateExchange) Route() string { return RouterKey }
 
@@ -176,6 +194,9 @@ func (msg MsgCreateExchange) ValidateBasic() sdk.Error {
 	if msg.Token0Name == msg.Token1Name {
 		return sdk.ErrInvalidCoins("Token0Name should not equal to Token1Name")
 	}
+	if err := ValidatePoolCurve(msg.PoolType, msg.Amplification, msg.Token0Weight, msg.Token1Weight); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
 	return nil
 }
 
@@ -201,6 +222,8 @@ type MsgTokenToToken struct {
 	Deadline             int64          `json:"deadline"`                // Time after which this transaction can no longer be executed.
 	Recipient            sdk.AccAddress `json:"recipient"`               // Recipient address,transfer Tokens to recipient.default recipient is sender.
 	Sender               sdk.AccAddress `json:"sender"`                  // Sender
+	Path                 []string       `json:"path,omitempty"`          // Names of the swap token pairs to route through, in order.
+	BestPath             bool           `json:"best_path,omitempty"`     // Route through the path with the most output found by the keeper.
 }
 
 // NewMsgTokenToToken is a constructor function for MsgTokenOKTSwap
@@ -216,6 +239,18 @@ func NewMsgTokenToToken(
 	}
 }
 
+// NewMsgTokenToTokenByRoute is a constructor function for MsgTokenToToken routed through the path,
+// or through the best path found by the keeper if bestPath
+func NewMsgTokenToTokenByRoute(
+	soldTokenAmount, minBoughtTokenAmount sdk.DecCoin, path []string, bestPath bool, deadline int64,
+	recipient, sender sdk.AccAddress,
+) MsgTokenToToken {
+	msg := NewMsgTokenToToken(soldTokenAmount, minBoughtTokenAmount, deadline, recipient, sender)
+	msg.Path = path
+	msg.BestPath = bestPath
+	return msg
+}
+
 // Route should return the name of the module
 func (msg MsgTokenToToken) Route() string { return RouterKey }
 
@@ -248,6 +283,13 @@ func (msg MsgTokenToToken) ValidateBasic() sdk.Error {
 	if err != nil {
 		return sdk.ErrUnknownRequest(err.Error())
 	}
+
+	if msg.BestPath && len(msg.Path) > 0 {
+		return sdk.ErrUnknownRequest("path should be empty when routing through the best path")
+	}
+	if err := ValidateSwapPath(msg.Path); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
 	return nil
 }
 
@@ -264,4 +306,79 @@ func (msg MsgTokenToToken) GetSigners() []sdk.AccAddress {
 // GetSwapTokenPair defines token pair
 func (msg MsgTokenToToken) GetSwapTokenPairName() string {
 	return GetSwapTokenPairName(msg.MinBoughtTokenAmount.Denom, msg.SoldTokenAmount.Denom)
-}
\ No newline at end of file
+}
+
+// MsgTokenToExactToken buys exactly BoughtTokenAmount, selling at most MaxSoldTokenAmount
+type MsgTokenToExactToken struct {
+	MaxSoldTokenAmount sdk.DecCoin    `json:"max_sold_token_amount"` // Maximum token sold.
+	BoughtTokenAmount  sdk.DecCoin    `json:"bought_token_amount"`   // Exact token purchased.
+	Deadline           int64          `json:"deadline"`              // Time after which this transaction can no longer be executed.
+	Recipient          sdk.AccAddress `json:"recipient"`             // Recipient address,transfer Tokens to recipient.default recipient is sender.
+	Sender             sdk.AccAddress `json:"sender"`                // Sender
+	Path               []string       `json:"path,omitempty"`        // Names of the swap token pairs to route through, in order.
+	BestPath           bool           `json:"best_path,omitempty"`   // Route through the path with the least input found by the keeper.
+}
+
+// NewMsgTokenToExactToken is a constructor function for MsgTokenToExactToken
+func NewMsgTokenToExactToken(
+	maxSoldTokenAmount, boughtTokenAmount sdk.DecCoin, path []string, bestPath bool, deadline int64,
+	recipient, sender sdk.AccAddress,
+) MsgTokenToExactToken {
+	return MsgTokenToExactToken{
+		MaxSoldTokenAmount: maxSoldTokenAmount,
+		BoughtTokenAmount:  boughtTokenAmount,
+		Deadline:           deadline,
+		Recipient:          recipient,
+		Sender:             sender,
+		Path:               path,
+		BestPath:           bestPath,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgTokenToExactToken) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgTokenToExactToken) Type() string { return TypeMsgTokenSwapForExactToken }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgTokenToExactToken) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+
+	if msg.Recipient.Empty() {
+		return sdk.ErrInvalidAddress(msg.Recipient.String())
+	}
+
+	if !msg.MaxSoldTokenAmount.IsPositive() || !msg.MaxSoldTokenAmount.IsValid() {
+		return sdk.ErrUnknownRequest("invalid maximum of sold token amount")
+	}
+
+	if !msg.BoughtTokenAmount.IsPositive() || !msg.BoughtTokenAmount.IsValid() {
+		return sdk.ErrUnknownRequest("invalid bought token amount")
+	}
+
+	baseAmountName, quoteAmountName := GetBaseQuoteTokenName(msg.MaxSoldTokenAmount.Denom, msg.BoughtTokenAmount.Denom)
+	if err := ValidateBaseAndQuoteAmount(baseAmountName, quoteAmountName); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+
+	if msg.BestPath && len(msg.Path) > 0 {
+		return sdk.ErrUnknownRequest("path should be empty when routing through the best path")
+	}
+	if err := ValidateSwapPath(msg.Path); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgTokenToExactToken) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgTokenToExactToken) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
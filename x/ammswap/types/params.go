@@ -11,6 +11,8 @@ import (
 // FeeRate defines swap fee rate
 var (
 	defaultFeeRate = sdk.NewDecWithPrec(3, 3)
+	// the protocol fee switch is off by default
+	defaultProtocolFeeRate = sdk.ZeroDec()
 )
 
 // Default parameter namespace
@@ -22,7 +24,9 @@ const (
 
 // Parameter store keys
 var (
-	KeyFeeRate = []byte("FeeRate")
+	KeyFeeRate              = []byte("FeeRate")
+	KeyProtocolFeeRate      = []byte("ProtocolFeeRate")
+	KeyProtocolFeeRecipient = []byte("ProtocolFeeRecipient")
 )
 
 // ParamKeyTable for swap module
@@ -33,29 +37,50 @@ func ParamKeyTable() params.KeyTable {
 // Params - used for initializing default parameter for swap at genesis
 type Params struct {
 	FeeRate sdk.Dec `json:"fee_rate"`
+	// ProtocolFeeRate is the share of the swap fee taken out of the pools, zero switches the protocol fee off
+	ProtocolFeeRate sdk.Dec `json:"protocol_fee_rate"`
+	// ProtocolFeeRecipient receives the protocol fee, it goes to the community pool if empty
+	ProtocolFeeRecipient sdk.AccAddress `json:"protocol_fee_recipient"`
 }
 
 // NewParams creates a new Params object
-func NewParams(feeRate sdk.Dec) Params {
+func NewParams(feeRate, protocolFeeRate sdk.Dec, protocolFeeRecipient sdk.AccAddress) Params {
 	return Params{
-		FeeRate: feeRate,
+		FeeRate:              feeRate,
+		ProtocolFeeRate:      protocolFeeRate,
+		ProtocolFeeRecipient: protocolFeeRecipient,
 	}
 }
 
 // String implements the stringer interface for Params
 func (p Params) String() string {
 	return fmt.Sprintf(`Poolswap Params:
-  TradeFeeRate: %s`, p.FeeRate)
+  TradeFeeRate: %s
+  ProtocolFeeRate: %s
+  ProtocolFeeRecipient: %s`, p.FeeRate, p.ProtocolFeeRate, p.ProtocolFeeRecipient)
+}
+
+// Validate checks that the rates are in [0, 1]
+func (p Params) Validate() error {
+	if p.FeeRate.IsNil() || p.FeeRate.IsNegative() || p.FeeRate.GTE(sdk.OneDec()) {
+		return fmt.Errorf("invalid fee rate: %s", p.FeeRate)
+	}
+	if p.ProtocolFeeRate.IsNil() || p.ProtocolFeeRate.IsNegative() || p.ProtocolFeeRate.GT(sdk.OneDec()) {
+		return fmt.Errorf("invalid protocol fee rate: %s", p.ProtocolFeeRate)
+	}
+	return nil
 }
 
 // ParamSetPairs implements params.ParamSet
 func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
 		{Key: KeyFeeRate, Value: &p.FeeRate},
+		{Key: KeyProtocolFeeRate, Value: &p.ProtocolFeeRate},
+		{Key: KeyProtocolFeeRecipient, Value: &p.ProtocolFeeRecipient},
 	}
 }
 
 // DefaultParams defines the parameters for this module
 func DefaultParams() Params {
-	return NewParams(defaultFeeRate)
+	return NewParams(defaultFeeRate, defaultProtocolFeeRate, sdk.AccAddress{})
 }
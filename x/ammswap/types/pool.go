@@ -0,0 +1,48 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// the curves a swap token pair prices its swaps with
+const (
+	PoolTypeConstantProduct = "constant_product" // x * y = k, the pools created before the pool types are constant product ones
+	PoolTypeStable          = "stable"           // the StableSwap invariant of Curve, for the pegged tokens
+	PoolTypeWeighted        = "weighted"         // the weighted product invariant of Balancer
+)
+
+// the bounds of the parameters of the pool curves
+const (
+	MaxAmplification = 1000000
+)
+
+var (
+	// MinPoolWeight is the least weight of a token in a weighted pool, it bounds the weight ratio to 49
+	MinPoolWeight = sdk.NewDecWithPrec(2, 2)
+	// MaxPoolWeight is the largest weight of a token in a weighted pool
+	MaxPoolWeight = sdk.OneDec().Sub(MinPoolWeight)
+)
+
+// ValidatePoolCurve checks the pool type & the parameters of its curve,
+// the weights are the ones of token0 & token1 and must sum up to one
+func ValidatePoolCurve(poolType string, amplification int64, weight0, weight1 sdk.Dec) error {
+	switch poolType {
+	case "", PoolTypeConstantProduct:
+	case PoolTypeStable:
+		if amplification <= 0 || amplification > MaxAmplification {
+			return fmt.Errorf("the amplification of a stable pool should be in [1, %d]", MaxAmplification)
+		}
+	case PoolTypeWeighted:
+		if weight0.IsNil() || weight1.IsNil() || !weight0.Add(weight1).Equal(sdk.OneDec()) {
+			return fmt.Errorf("the weights of a weighted pool should sum up to 1")
+		}
+		if weight0.LT(MinPoolWeight) || weight0.GT(MaxPoolWeight) {
+			return fmt.Errorf("the weights of a weighted pool should be in [%s, %s]", MinPoolWeight, MaxPoolWeight)
+		}
+	default:
+		return fmt.Errorf("invalid pool type: %s", poolType)
+	}
+	return nil
+}
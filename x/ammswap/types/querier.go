@@ -4,7 +4,32 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
+// QueryBuyAmountParams is the params of quoting the token bought by selling SoldToken,
+// or of quoting the token sold to buy exactly BoughtToken if it's set
 type QueryBuyAmountParams struct {
-	SoldToken    sdk.DecCoin
+	SoldToken  sdk.DecCoin
 	TokenToBuy string
+	// the exact output quote goes through the path, the best path found by the keeper if BestPath,
+	// or the pool of the two tokens or the pools with the native token if both are empty
+	BoughtToken sdk.DecCoin
+	TokenToSell string
+	Path        []string
+	BestPath    bool
+}
+
+// QuerySwapRouteParams is the params of quoting a swap through the path,
+// through the best path found by the keeper if Path is empty
+type QuerySwapRouteParams struct {
+	SoldToken  sdk.DecCoin
+	TokenToBuy string
+	Path       []string
+}
+
+// QueryTWAPParams is the params of querying the TWAP of the pool of the base & quote tokens,
+// to the current height if ToHeight is zero
+type QueryTWAPParams struct {
+	BaseToken  string
+	QuoteToken string
+	FromHeight int64
+	ToHeight   int64
 }
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MaxSwapPathLength is the max number of swap token pairs a swap can route through
+const MaxSwapPathLength = 3
+
+// SwapRoute is the quote of swapping through a path of swap token pairs
+type SwapRoute struct {
+	SoldToken   sdk.DecCoin   `json:"sold_token"`   // the token sold by the swap
+	Path        []string      `json:"path"`         // names of the swap token pairs routed through
+	Amounts     []sdk.DecCoin `json:"amounts"`      // the token bought by every hop, the last one is bought by the swap
+	PriceImpact sdk.Dec       `json:"price_impact"` // how much the output is worse than swapping at the current prices
+}
+
+// String implement fmt.Stringer
+func (r SwapRoute) String() string {
+	routeJSON, err := json.Marshal(r)
+	if err != nil {
+		panic(err)
+	}
+	return string(routeJSON)
+}
+
+// BoughtToken returns the token bought by the swap
+func (r SwapRoute) BoughtToken() sdk.DecCoin {
+	return r.Amounts[len(r.Amounts)-1]
+}
+
+// ParseSwapTokenPairName splits the name of a swap token pair into its base & quote token names
+func ParseSwapTokenPairName(tokenPairName string) (baseTokenName, quoteTokenName string, err error) {
+	tokens := strings.Split(tokenPairName, "_")
+	if len(tokens) != 2 {
+		return "", "", fmt.Errorf("invalid swap token pair name: %s", tokenPairName)
+	}
+	if err := ValidateBaseAndQuoteAmount(tokens[0], tokens[1]); err != nil {
+		return "", "", err
+	}
+	return tokens[0], tokens[1], nil
+}
+
+// ValidateSwapPath checks the names of the swap token pairs in the path,
+// whether they're chained is checked against the sold & bought tokens by the keeper
+func ValidateSwapPath(path []string) error {
+	if len(path) > MaxSwapPathLength {
+		return fmt.Errorf("the length of path should not be greater than %d", MaxSwapPathLength)
+	}
+	seen := make(map[string]bool, len(path))
+	for _, tokenPairName := range path {
+		if _, _, err := ParseSwapTokenPairName(tokenPairName); err != nil {
+			return err
+		}
+		if seen[tokenPairName] {
+			return fmt.Errorf("duplicated swap token pair in path: %s", tokenPairName)
+		}
+		seen[tokenPairName] = true
+	}
+	return nil
+}
@@ -18,15 +18,54 @@ type SwapTokenPair struct {
 	QuotePooledCoin sdk.DecCoin `json:"quote_pooled_coin"` // The volume of quote token in the token pair exchange pool
 	BasePooledCoin  sdk.DecCoin `json:"base_pooled_coin"`  // The volume of base token in the token pair exchange pool
 	PoolTokenName   string      `json:"pool_token_name"`   // The name of pool token
+
+	Volume         sdk.DecCoins `json:"volume"`           // The cumulative amounts of tokens sold to the pool
+	Fees           sdk.DecCoins `json:"fees"`             // The cumulative swap fees kept by the pool for the liquidity providers
+	ProtocolFees   sdk.DecCoins `json:"protocol_fees"`    // The cumulative swap fees taken out of the pool by the protocol
+	StatsStartTime int64        `json:"stats_start_time"` // The unix time since which the volume & fees are counted
+
+	BasePriceCumulative  sdk.Dec `json:"base_price_cumulative"`  // The sum of the base token prices in quote token weighted by seconds
+	QuotePriceCumulative sdk.Dec `json:"quote_price_cumulative"` // The sum of the quote token prices in base token weighted by seconds
+	PriceLastUpdateTime  int64   `json:"price_last_update_time"` // The unix time of the last update of the cumulative prices
+
+	PoolType      string  `json:"pool_type"`     // The curve the pool prices its swaps with, constant product if it's empty
+	Amplification int64   `json:"amplification"` // The amplification coefficient of a stable pool
+	BaseWeight    sdk.Dec `json:"base_weight"`   // The weight of base token in a weighted pool
+	QuoteWeight   sdk.Dec `json:"quote_weight"`  // The weight of quote token in a weighted pool
 }
 
 func NewSwapPair(token0, token1 string) SwapTokenPair {
 	base, quote := GetBaseQuoteTokenName(token0, token1)
 
 	swapTokenPair := SwapTokenPair{
-		sdk.NewDecCoinFromDec(quote, sdk.ZeroDec()),
-		sdk.NewDecCoinFromDec(base, sdk.ZeroDec()),
-		GetPoolTokenName(token0, token1),
+		QuotePooledCoin: sdk.NewDecCoinFromDec(quote, sdk.ZeroDec()),
+		BasePooledCoin:  sdk.NewDecCoinFromDec(base, sdk.ZeroDec()),
+		PoolTokenName:   GetPoolTokenName(token0, token1),
+
+		BasePriceCumulative:  sdk.ZeroDec(),
+		QuotePriceCumulative: sdk.ZeroDec(),
+
+		PoolType:    PoolTypeConstantProduct,
+		BaseWeight:  sdk.ZeroDec(),
+		QuoteWeight: sdk.ZeroDec(),
+	}
+	return swapTokenPair
+}
+
+// NewSwapPairWithCurve creates a swap token pair priced with the curve,
+// the weights are the ones of token0 & token1 in a weighted pool
+func NewSwapPairWithCurve(token0, token1, poolType string, amplification int64, weight0, weight1 sdk.Dec) SwapTokenPair {
+	swapTokenPair := NewSwapPair(token0, token1)
+	switch poolType {
+	case PoolTypeStable:
+		swapTokenPair.PoolType = poolType
+		swapTokenPair.Amplification = amplification
+	case PoolTypeWeighted:
+		swapTokenPair.PoolType = poolType
+		swapTokenPair.BaseWeight, swapTokenPair.QuoteWeight = weight0, weight1
+		if token0 > token1 {
+			swapTokenPair.BaseWeight, swapTokenPair.QuoteWeight = weight1, weight0
+		}
 	}
 	return swapTokenPair
 }
@@ -37,6 +76,13 @@ func NewSwapTokenPair(quotePooledCoin sdk.DecCoin, basePooledCoin sdk.DecCoin, p
 		QuotePooledCoin: quotePooledCoin,
 		BasePooledCoin:  basePooledCoin,
 		PoolTokenName:   poolTokenName,
+
+		BasePriceCumulative:  sdk.ZeroDec(),
+		QuotePriceCumulative: sdk.ZeroDec(),
+
+		PoolType:    PoolTypeConstantProduct,
+		BaseWeight:  sdk.ZeroDec(),
+		QuoteWeight: sdk.ZeroDec(),
 	}
 	return swapTokenPair
 }
@@ -45,7 +91,29 @@ func NewSwapTokenPair(quotePooledCoin sdk.DecCoin, basePooledCoin sdk.DecCoin, p
 func (s SwapTokenPair) String() string {
 	return strings.TrimSpace(fmt.Sprintf(`QuotePooledCoin: %s
 BasePooledCoin: %s
-PoolTokenName: %s`, s.QuotePooledCoin.String(), s.BasePooledCoin.String(), s.PoolTokenName))
+PoolTokenName: %s
+Volume: %s
+Fees: %s
+ProtocolFees: %s
+StatsStartTime: %d
+BasePriceCumulative: %s
+QuotePriceCumulative: %s
+PriceLastUpdateTime: %d
+PoolType: %s
+Amplification: %d
+BaseWeight: %s
+QuoteWeight: %s`, s.QuotePooledCoin.String(), s.BasePooledCoin.String(), s.PoolTokenName,
+		s.Volume.String(), s.Fees.String(), s.ProtocolFees.String(), s.StatsStartTime,
+		s.BasePriceCumulative, s.QuotePriceCumulative, s.PriceLastUpdateTime,
+		s.GetPoolType(), s.Amplification, s.BaseWeight, s.QuoteWeight))
+}
+
+// GetPoolType returns the curve the pool prices its swaps with
+func (s SwapTokenPair) GetPoolType() string {
+	if s.PoolType == "" {
+		return PoolTypeConstantProduct
+	}
+	return s.PoolType
 }
 
 // TokenPairName defines token pair
@@ -18,5 +18,12 @@ func GetTestSwapTokenPair() SwapTokenPair {
 		QuotePooledCoin: sdk.NewDecCoinFromDec(TestQuotePooledToken, sdk.NewDec(0)),
 		BasePooledCoin:  sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(0)),
 		PoolTokenName:   GetPoolTokenName(TestBasePooledToken, TestQuotePooledToken),
+
+		BasePriceCumulative:  sdk.ZeroDec(),
+		QuotePriceCumulative: sdk.ZeroDec(),
+
+		PoolType:    PoolTypeConstantProduct,
+		BaseWeight:  sdk.ZeroDec(),
+		QuoteWeight: sdk.ZeroDec(),
 	}
 }
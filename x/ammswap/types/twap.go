@@ -0,0 +1,39 @@
+package types
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PriceObservationRetentionBlocks is how many blocks the price observations are kept for,
+// the last observation before them is kept as well so that the TWAP can start from it
+const PriceObservationRetentionBlocks = 100000
+
+// PriceObservation is the cumulative prices of a swap token pair at the first reserve change of a block
+type PriceObservation struct {
+	Height               int64   `json:"height"`
+	Time                 int64   `json:"time"`
+	BasePriceCumulative  sdk.Dec `json:"base_price_cumulative"`
+	QuotePriceCumulative sdk.Dec `json:"quote_price_cumulative"`
+}
+
+// TWAP is the time-weighted average prices of a swap token pair between two block heights
+type TWAP struct {
+	TokenPairName string  `json:"token_pair_name"`
+	FromHeight    int64   `json:"from_height"`
+	ToHeight      int64   `json:"to_height"`
+	FromTime      int64   `json:"from_time"`   // the unix time the average starts from
+	ToTime        int64   `json:"to_time"`     // the unix time the average ends at
+	BasePrice     sdk.Dec `json:"base_price"`  // the average price of the base token in quote token
+	QuotePrice    sdk.Dec `json:"quote_price"` // the average price of the quote token in base token
+}
+
+// String implement fmt.Stringer
+func (t TWAP) String() string {
+	twapJSON, err := json.Marshal(t)
+	if err != nil {
+		panic(err)
+	}
+	return string(twapJSON)
+}
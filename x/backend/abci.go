@@ -2,7 +2,6 @@ package backend
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/okex/okexchain/x/backend/types"
 
@@ -15,11 +14,9 @@ import (
 func EndBlocker(ctx sdk.Context, keeper Keeper) {
 	if keeper.Config.EnableBackend && keeper.Config.EnableMktCompute {
 		keeper.Logger.Debug(fmt.Sprintf("begin backend endblocker: block---%d", ctx.BlockHeight()))
-		storeNewOrders(ctx, keeper)
-		updateOrders(ctx, keeper)
-		storeDealAndMatchResult(ctx, keeper)
-		storeFeeDetails(keeper)
-		storeTransactions(keeper)
+		keeper.CheckGap(ctx.BlockHeight())
+		keeper.Orm.SetMaxBlockTimestamp(ctx.BlockHeader().Time.Unix())
+		updateTickers(keeper, keeper.StoreBlock(getBlockData(ctx, keeper)))
 		keeper.Flush()
 		keeper.Logger.Debug(fmt.Sprintf("end backend endblocker: block---%d", ctx.BlockHeight()))
 		keeper.EmitAllWsItems(ctx)
@@ -27,50 +24,48 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) {
 
 }
 
-func storeTransactions(keeper Keeper) {
+// getBlockData collects all of the backend data of the block, which is stored atomically
+func getBlockData(ctx sdk.Context, keeper Keeper) *types.BlockData {
 	defer types.PrintStackIfPanic()
 
-	txs := keeper.Cache.GetTransactions()
-	txsLen := len(txs)
-
-	cnt, err := keeper.Orm.AddTransactions(txs)
+	newOrders, err := GetNewOrdersAtEndBlock(ctx, keeper.OrderKeeper)
 	if err != nil {
-		keeper.Logger.Error(fmt.Sprintf("[backend] Expect to insert %d txs, inserted Count %d, err: %+v", txsLen, cnt, err))
-	} else {
-		keeper.Logger.Debug(fmt.Sprintf("[backend] Expect to insert %d txs, inserted Count %d", txsLen, cnt))
+		keeper.Logger.Error(fmt.Sprintf("[backend] failed to GetNewOrdersAtEndBlock, error: %s", err.Error()))
 	}
-}
-
-func storeDealAndMatchResult(ctx sdk.Context, keeper Keeper) {
-	timestamp := ctx.BlockHeader().Time.Unix()
-	keeper.Orm.SetMaxBlockTimestamp(timestamp)
 	deals, results, err := GetNewDealsAndMatchResultsAtEndBlock(ctx, keeper.OrderKeeper)
-
 	if err != nil {
 		keeper.Logger.Error(fmt.Sprintf("[backend] failed to GetNewDealsAndMatchResultsAtEndBlock, error: %s", err.Error()))
 	}
 
-	if len(results) > 0 {
-		cnt, err := keeper.Orm.AddMatchResults(results)
-		if err != nil {
-			keeper.Logger.Error(fmt.Sprintf("[backend] Expect to insert %d matchResults, inserted Count %d, err: %+v", len(results), cnt, err))
-		} else {
-			keeper.Logger.Debug(fmt.Sprintf("[backend] Expect to insert %d matchResults, inserted Count %d", len(results), cnt))
-		}
+	block := &types.BlockData{
+		Height:        ctx.BlockHeight(),
+		Timestamp:     ctx.BlockHeader().Time.Unix(),
+		NewOrders:     newOrders,
+		UpdatedOrders: GetUpdatedOrdersAtEndBlock(ctx, keeper.OrderKeeper),
+		Deals:         deals,
+		MatchResults:  results,
+		FeeDetails:    keeper.TokenKeeper.GetFeeDetailList(),
+		Transactions:  keeper.Cache.GetTransactions(),
+		SwapTrades:    keeper.GetNewSwapTrades(ctx),
 	}
-	if len(deals) > 0 {
-		cnt, err := keeper.Orm.AddDeals(deals)
-		if err != nil {
-			keeper.Logger.Error(fmt.Sprintf("[backend] Expect to insert %d deals, inserted Count %d, err: %+v", len(deals), cnt, err))
-		} else {
-			keeper.Logger.Debug(fmt.Sprintf("[backend] Expect to insert %d deals, inserted Count %d", len(deals), cnt))
-		}
+	// the fee details & the transactions are stamped with the height, so that they could be deleted by the height
+	for _, feeDetail := range block.FeeDetails {
+		feeDetail.BlockHeight = block.Height
+	}
+	for _, transaction := range block.Transactions {
+		transaction.BlockHeight = block.Height
 	}
+	block.BalanceSnapshots = keeper.GetNewBalanceSnapshots(ctx, block)
+	return block
+}
 
-	// update ticker
+// updateTickers updates the tickers of the products matched in the blocks stored
+func updateTickers(keeper Keeper, blocks []*types.BlockData) {
 	var productList []string
-	for _, result := range results {
-		productList = append(productList, result.Product)
+	for _, block := range blocks {
+		for _, result := range block.MatchResults {
+			productList = append(productList, result.Product)
+		}
 	}
 	if len(productList) > 0 {
 		ts := keeper.Orm.GetMaxBlockTimestamp()
@@ -78,46 +73,6 @@ func storeDealAndMatchResult(ctx sdk.Context, keeper Keeper) {
 	}
 }
 
-func storeFeeDetails(keeper Keeper) {
-	feeDetails := keeper.TokenKeeper.GetFeeDetailList()
-	if len(feeDetails) > 0 {
-		cnt, err := keeper.Orm.AddFeeDetails(feeDetails)
-		if err != nil {
-			keeper.Logger.Error(fmt.Sprintf("[backend] Expect to insert %d feeDetails, inserted Count %d, err: %+v", len(feeDetails), cnt, err))
-		} else {
-			keeper.Logger.Debug(fmt.Sprintf("[backend] Expect to insert %d feeDetails, inserted Count %d", len(feeDetails), cnt))
-		}
-	}
-}
-
-func storeNewOrders(ctx sdk.Context, keeper Keeper) {
-	orders, err := GetNewOrdersAtEndBlock(ctx, keeper.OrderKeeper)
-	if err != nil {
-		keeper.Logger.Error(fmt.Sprintf("[backend] failed to GetNewOrdersAtEndBlock, error: %s", err.Error()))
-	}
-
-	if len(orders) > 0 {
-		cnt, err := keeper.Orm.AddOrders(orders)
-		if err != nil {
-			keeper.Logger.Error(fmt.Sprintf("[backend] Expect to insert %d orders, inserted Count %d, err: %+v", len(orders), cnt, err))
-		} else {
-			keeper.Logger.Debug(fmt.Sprintf("[backend] Expect to insert %d orders, inserted Count %d", len(orders), cnt))
-		}
-	}
-}
-
-func updateOrders(ctx sdk.Context, keeper Keeper) {
-	orders := GetUpdatedOrdersAtEndBlock(ctx, keeper.OrderKeeper)
-	if len(orders) > 0 {
-		cnt, err := keeper.Orm.UpdateOrders(orders)
-		if err != nil {
-			keeper.Logger.Error(fmt.Sprintf("[backend] Expect to update %d orders, updated Count %d, err: %+v", len(orders), cnt, err))
-		} else {
-			keeper.Logger.Debug(fmt.Sprintf("[backend] Expect to update %d orders, updated Count %d", len(orders), cnt))
-		}
-	}
-}
-
 // nolint
 func GetNewDealsAndMatchResultsAtEndBlock(ctx sdk.Context, orderKeeper types.OrderKeeper) ([]*types.Deal, []*types.MatchResult, error) {
 	result := orderKeeper.GetBlockMatchResult()
@@ -133,40 +88,39 @@ func GetNewDealsAndMatchResultsAtEndBlock(ctx sdk.Context, orderKeeper types.Ord
 	deals := make([]*types.Deal, 0, totalDeals)
 	results := make([]*types.MatchResult, 0, len(result.ResultMap))
 	for product, matchResult := range result.ResultMap {
-		price, err := strconv.ParseFloat(matchResult.Price.String(), 64)
-		if err == nil && matchResult.BlockHeight == blockHeight {
-			if total, err := strconv.ParseFloat(matchResult.Quantity.String(), 64); err == nil {
-				results = append(results, &types.MatchResult{
-					BlockHeight: blockHeight,
-					Product:     product,
-					Price:       price,
-					Quantity:    total,
-					Timestamp:   ctx.BlockHeader().Time.Unix(),
-				})
-			}
-		} else {
-			return deals, results, err
+		if matchResult.BlockHeight != blockHeight {
+			return deals, results, nil
 		}
-
-		for _, record := range matchResult.Deals {
+		price := types.NewDecimalFromDec(matchResult.Price)
+		results = append(results, &types.MatchResult{
+			BlockHeight: blockHeight,
+			Product:     product,
+			Price:       price,
+			Quantity:    types.NewDecimalFromDec(matchResult.Quantity),
+			Timestamp:   ctx.BlockHeader().Time.Unix(),
+		})
+
+		for sequence, record := range matchResult.Deals {
 			order := orderKeeper.GetOrder(ctx, record.OrderID)
-			if quantity, err := strconv.ParseFloat(record.Quantity.String(), 64); err == nil {
-
-				deal := &types.Deal{
-					BlockHeight: blockHeight,
-					OrderID:     record.OrderID,
-					Side:        record.Side,
-					Sender:      order.Sender.String(),
-					Product:     product,
-					Price:       price,
-					Quantity:    quantity,
-					Fee:         record.Fee,
-					Timestamp:   ctx.BlockHeader().Time.Unix(),
-					FeeReceiver: record.FeeReceiver,
-				}
-				deals = append(deals, deal)
-
+			// the continuous auction fills each maker at its own price
+			dealPrice := price
+			if !record.Price.IsNil() {
+				dealPrice = types.NewDecimalFromDec(record.Price)
+			}
+			deal := &types.Deal{
+				BlockHeight: blockHeight,
+				Sequence:    int64(sequence),
+				OrderID:     record.OrderID,
+				Side:        record.Side,
+				Sender:      order.Sender.String(),
+				Product:     product,
+				Price:       dealPrice,
+				Quantity:    types.NewDecimalFromDec(record.Quantity),
+				Fee:         record.Fee,
+				Timestamp:   ctx.BlockHeader().Time.Unix(),
+				FeeReceiver: record.FeeReceiver,
 			}
+			deals = append(deals, deal)
 		}
 	}
 	return deals, results, nil
@@ -194,6 +148,8 @@ func GetNewOrdersAtEndBlock(ctx sdk.Context, orderKeeper types.OrderKeeper) ([]*
 				FilledAvgPrice: order.FilledAvgPrice.String(),
 				RemainQuantity: order.RemainQuantity.String(),
 				Timestamp:      order.Timestamp,
+				OrderType:      order.GetOrderType(),
+				CloseReason:    order.CloseReason,
 			}
 			orders = append(orders, orderDb)
 		} else {
@@ -222,6 +178,8 @@ func GetUpdatedOrdersAtEndBlock(ctx sdk.Context, orderKeeper types.OrderKeeper)
 				FilledAvgPrice: order.FilledAvgPrice.String(),
 				RemainQuantity: order.RemainQuantity.String(),
 				Timestamp:      order.Timestamp,
+				OrderType:      order.GetOrderType(),
+				CloseReason:    order.CloseReason,
 			}
 			orders = append(orders, orderDb)
 		}
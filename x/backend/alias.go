@@ -29,6 +29,7 @@ type (
 	Order       = types.Order
 	Transaction = types.Transaction
 	MatchResult = types.MatchResult
+	Decimal     = types.Decimal
 
 	ORM           = orm.ORM
 	OrmEngineInfo = orm.OrmEngineInfo
@@ -43,6 +44,8 @@ var (
 
 	GenerateTx = types.GenerateTx
 
+	NewDecimalFromFloat = types.NewDecimalFromFloat
+
 	NewORM = orm.New
 
 	DefaultConfig = config.DefaultConfig
@@ -1,6 +1,10 @@
 package cache
 
-import "github.com/okex/okexchain/x/backend/types"
+import (
+	"sort"
+
+	"github.com/okex/okexchain/x/backend/types"
+)
 
 // Cache defines struct to store data in memory
 type Cache struct {
@@ -9,13 +13,25 @@ type Cache struct {
 
 	// persist in memory
 	LatestTicker map[string]*types.Ticker
+
+	// the blocks failed to be stored, which are stored again at the next EndBlock
+	PendingBlocks []*types.BlockData
+	// the blocks missed in the database, which could be filled by the reindex of the backend
+	Gaps []types.HeightRange
+	// the first height of the blocks missed, which hasn't been saved in the database yet
+	UnsavedMissedHeight int64
+	// whether the gap between the database and the chain has been checked since the start
+	GapChecked bool
+	// the accounts whose balances changed since the last balance snapshot
+	ChangedAccounts map[string]struct{}
 }
 
 // NewCache return  cache pointer address, called at NewKeeper
 func NewCache() *Cache {
 	return &Cache{
-		Transactions: make([]*types.Transaction, 0, 2000),
-		LatestTicker: make(map[string]*types.Ticker),
+		Transactions:    make([]*types.Transaction, 0, 2000),
+		LatestTicker:    make(map[string]*types.Ticker),
+		ChangedAccounts: make(map[string]struct{}),
 	}
 }
 
@@ -33,3 +49,32 @@ func (c *Cache) AddTransaction(transaction []*types.Transaction) {
 func (c *Cache) GetTransactions() []*types.Transaction {
 	return c.Transactions
 }
+
+// AddGap records the missed blocks in [from, to], the adjacent ranges are merged
+func (c *Cache) AddGap(from, to int64) {
+	if n := len(c.Gaps); n > 0 && c.Gaps[n-1].To+1 == from {
+		c.Gaps[n-1].To = to
+		return
+	}
+	c.Gaps = append(c.Gaps, types.HeightRange{From: from, To: to})
+}
+
+// AddChangedAccounts records the accounts whose balances changed, the empty addresses are ignored
+func (c *Cache) AddChangedAccounts(addresses ...string) {
+	for _, address := range addresses {
+		if address != "" {
+			c.ChangedAccounts[address] = struct{}{}
+		}
+	}
+}
+
+// PopChangedAccounts returns the accounts changed since the last call in order, and clears them
+func (c *Cache) PopChangedAccounts() []string {
+	addresses := make([]string, 0, len(c.ChangedAccounts))
+	for address := range c.ChangedAccounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	c.ChangedAccounts = make(map[string]struct{})
+	return addresses
+}
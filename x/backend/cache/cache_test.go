@@ -27,4 +27,13 @@ func TestCache(t *testing.T) {
 	cache.Flush()
 	require.Equal(t, 0, len(cache.GetTransactions()))
 
+	cache.AddGap(3, 5)
+	cache.AddGap(6, 6)
+	cache.AddGap(9, 9)
+	require.Equal(t, []types.HeightRange{{From: 3, To: 6}, {From: 9, To: 9}}, cache.Gaps)
+
+	cache.AddChangedAccounts("addr2", "", "addr1", "addr2")
+	require.Equal(t, []string{"addr1", "addr2"}, cache.PopChangedAccounts())
+	require.Equal(t, 0, len(cache.PopChangedAccounts()))
+
 }
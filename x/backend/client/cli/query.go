@@ -32,6 +32,7 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdTickers(queryRoute, cdc),
 		GetCmdTxList(queryRoute, cdc),
 		GetBlockTxHashesCommand(queryRoute, cdc),
+		GetCmdIndexerStatus(queryRoute, cdc),
 	)...)
 
 	return queryCmd
@@ -96,13 +97,14 @@ func GetCmdDeals(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			page, errPage := flags.GetInt("page")
 			perPage, errPerPage := flags.GetInt("per-page")
 			side, errSide := flags.GetString("side")
+			source, errSource := flags.GetString("source")
 
-			mError := types.NewErrorsMerged(errAddr, errProduct, errST, errET, errPage, errPerPage, errSide)
+			mError := types.NewErrorsMerged(errAddr, errProduct, errST, errET, errPage, errPerPage, errSide, errSource)
 			if mError != nil {
 				return mError
 			}
 
-			params := types.NewQueryDealsParams(addr, product, startTime, endTime, page, perPage, side)
+			params := types.NewQueryDealsParams(addr, product, startTime, endTime, page, perPage, side, source)
 			bz, err := cdc.MarshalJSON(params)
 			if err != nil {
 				return err
@@ -124,6 +126,7 @@ func GetCmdDeals(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().IntP("page", "", 1, "page num")
 	cmd.Flags().IntP("per-page", "", 50, "items per page")
 	cmd.Flags().StringP("side", "", "", "filter deals by side, support SELL|BUY|ALL, default for empty string means all")
+	cmd.Flags().StringP("source", "", "", "list the trades of orderbook|amm|all, default for empty string means orderbook")
 	return cmd
 }
 
@@ -139,13 +142,14 @@ func GetCmdCandles(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			granularity, errGranularity := flags.GetInt("granularity")
 			product, errProduct := flags.GetString("product")
 			size, errSide := flags.GetInt("limit")
+			source, errSource := flags.GetString("source")
 
-			mError := types.NewErrorsMerged(errGranularity, errProduct, errSide)
+			mError := types.NewErrorsMerged(errGranularity, errProduct, errSide, errSource)
 			if mError != nil {
 				return mError
 			}
 
-			params := types.NewQueryKlinesParams(product, granularity, size)
+			params := types.NewQueryKlinesParams(product, granularity, size, source)
 			bz, err := cdc.MarshalJSON(params)
 			var out bytes.Buffer
 			if err != nil {
@@ -169,6 +173,7 @@ func GetCmdCandles(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().IntP("granularity", "g", 60, "[60/180/300/900/1800/3600/7200/14400/21600/43200/86400/604800], second in unit")
 	cmd.Flags().StringP("product", "p", "", "name of token pair")
 	cmd.Flags().IntP("limit", "", 10, "at most 1000")
+	cmd.Flags().StringP("source", "", "", "aggregate the trades of orderbook|amm|all, default for empty string means orderbook")
 	return cmd
 }
 
@@ -184,8 +189,9 @@ func GetCmdTickers(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			count, errCnt := flags.GetInt("limit")
 			sort, errSort := flags.GetBool("sort")
 			product, errProduct := flags.GetString("product")
+			source, errSource := flags.GetString("source")
 
-			mError := types.NewErrorsMerged(errCnt, errSort, errProduct)
+			mError := types.NewErrorsMerged(errCnt, errSort, errProduct, errSource)
 			if mError != nil {
 				return mError
 			}
@@ -194,6 +200,7 @@ func GetCmdTickers(queryRoute string, cdc *codec.Codec) *cobra.Command {
 				Product: product,
 				Count:   count,
 				Sort:    sort,
+				Source:  source,
 			}
 
 			bz, err := cdc.MarshalJSON(params)
@@ -219,6 +226,7 @@ func GetCmdTickers(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().IntP("limit", "", 10, "ticker count")
 	cmd.Flags().StringP("product", "p", "", "name of token pair")
 	cmd.Flags().BoolP("sort", "s", true, "true or false")
+	cmd.Flags().StringP("source", "", "", "compute the tickers from the trades of orderbook|amm|all, default for empty string means orderbook")
 	return cmd
 }
 
@@ -351,7 +359,7 @@ func GetCmdTxList(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			return nil
 		},
 	}
-	cmd.Flags().Int64P("type", "", 0, "filter txs by txType")
+	cmd.Flags().Int64P("type", "", 0, fmt.Sprintf("filter txs by txType in [1, %d], 0 for all", types.TxTypeOther))
 	cmd.Flags().Int64P("start", "", 0, "filter txs by start timestamp")
 	cmd.Flags().Int64P("end", "", 0, "filter txs by end timestamp")
 	cmd.Flags().IntP("page", "", 1, "page num")
@@ -389,6 +397,25 @@ func GetBlockTxHashesCommand(queryRoute string, cdc *codec.Codec) *cobra.Command
 	return cmd
 }
 
+// GetCmdIndexerStatus queries how far the backend database is behind the chain
+func GetCmdIndexerStatus(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "indexer-status",
+		Short: "get the last indexed height and the lag of the backend database",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryIndexerStatus), nil)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
+
 // GetBlockTxHashes return tx hashes in the block of the given height
 func GetBlockTxHashes(cliCtx context.CLIContext, height int64) ([]string, error) {
 	// get the node
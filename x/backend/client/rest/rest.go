@@ -28,6 +28,7 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/transactions", txListHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/latestheight", latestHeightHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/dex/fees", dexFeesHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/indexer/status", indexerStatusHandler(cliCtx)).Methods("GET")
 }
 
 func candleHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -57,7 +58,7 @@ func candleHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		params := types.NewQueryKlinesParams(product, granularity, size)
+		params := types.NewQueryKlinesParams(product, granularity, size, r.URL.Query().Get("source"))
 
 		bz, err := cliCtx.Codec.MarshalJSON(params)
 		if err != nil {
@@ -102,6 +103,7 @@ func tickerHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			Product: product,
 			Sort:    sort,
 			Count:   count,
+			Source:  r.URL.Query().Get("source"),
 		}
 
 		bz, err := cliCtx.Codec.MarshalJSON(params)
@@ -208,7 +210,8 @@ func dealHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		params := types.NewQueryDealsParams(addr, product, start, end, page, perPage, sideStr)
+		params := types.NewQueryDealsParams(addr, product, start, end, page, perPage, sideStr,
+			r.URL.Query().Get("source"))
 		bz, err := cliCtx.Codec.MarshalJSON(params)
 		if err != nil {
 			common.HandleErrorMsg(w, cliCtx, err.Error())
@@ -449,3 +452,15 @@ func dexFeesHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
+
+func indexerStatusHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/backend/%s", types.QueryIndexerStatus), nil)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
@@ -33,6 +33,8 @@ func RegisterRoutesV2(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/fees", feesHandlerV2(cliCtx)).Methods("GET")
 	r.HandleFunc("/deals", dealsHandlerV2(cliCtx)).Methods("GET")
 	r.HandleFunc("/transactions", txListHandlerV2(cliCtx)).Methods("GET")
+	r.HandleFunc("/portfolio/balances", balanceSnapshotsHandlerV2(cliCtx)).Methods("GET")
+	r.HandleFunc("/portfolio/pnl", realizedPnLHandlerV2(cliCtx)).Methods("GET")
 }
 
 func txListHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
@@ -257,7 +259,7 @@ func candleHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		params := types.NewQueryKlinesParams(product, granularity, size)
+		params := types.NewQueryKlinesParams(product, granularity, size, "")
 
 		req := cliCtx.Codec.MustMarshalJSON(params)
 		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/backend/%s", types.QueryCandleListV2), req)
@@ -434,3 +436,105 @@ func orderHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
 		common.HandleSuccessResponseV2(w, res)
 	}
 }
+
+func balanceSnapshotsHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address := r.URL.Query().Get("address")
+		currency := r.URL.Query().Get("currency")
+		after := r.URL.Query().Get("after")
+		before := r.URL.Query().Get("before")
+		limit := r.URL.Query().Get("limit")
+
+		// validate request
+		if address == "" {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorMissingRequiredParam)
+			return
+		}
+		if _, err := sdk.AccAddressFromBech32(address); err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidAddress)
+			return
+		}
+		if _, err := strconv.Atoi(after); after != "" && err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+			return
+		}
+		if _, err := strconv.Atoi(before); before != "" && err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+			return
+		}
+		// default limit 100
+		if limit == "" {
+			limit = defaultLimit
+		}
+		limitInt, err := strconv.Atoi(limit)
+		if err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+			return
+		}
+
+		params := types.QueryBalanceSnapshotsParamsV2{
+			Address:  address,
+			Currency: currency,
+			After:    after,
+			Before:   before,
+			Limit:    limitInt,
+		}
+		req := cliCtx.Codec.MustMarshalJSON(params)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/backend/%s", types.QueryBalanceSnapshotsV2), req)
+		common.HandleResponseV2(w, res, err)
+	}
+}
+
+func realizedPnLHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address := r.URL.Query().Get("address")
+		product := r.URL.Query().Get("instrument_id")
+		method := strings.ToLower(r.URL.Query().Get("method"))
+		after := r.URL.Query().Get("after")
+		before := r.URL.Query().Get("before")
+		limit := r.URL.Query().Get("limit")
+
+		// validate request
+		if address == "" {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorMissingRequiredParam)
+			return
+		}
+		if _, err := sdk.AccAddressFromBech32(address); err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidAddress)
+			return
+		}
+		if err := types.ValidatePnLMethod(method); err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+			return
+		}
+		if _, err := strconv.Atoi(after); after != "" && err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+			return
+		}
+		if _, err := strconv.Atoi(before); before != "" && err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+			return
+		}
+		// default limit 100
+		if limit == "" {
+			limit = defaultLimit
+		}
+		limitInt, err := strconv.Atoi(limit)
+		if err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+			return
+		}
+
+		params := types.QueryRealizedPnLParamsV2{
+			Address: address,
+			Product: product,
+			Method:  method,
+			After:   after,
+			Before:  before,
+			Limit:   limitInt,
+		}
+		req := cliCtx.Codec.MustMarshalJSON(params)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/backend/%s", types.QueryRealizedPnLV2), req)
+		common.HandleResponseV2(w, res, err)
+	}
+}
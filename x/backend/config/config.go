@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	okexchaincfg "github.com/cosmos/cosmos-sdk/server/config"
+	"github.com/spf13/viper"
 
 	"github.com/tendermint/tendermint/libs/common"
 )
@@ -23,6 +24,26 @@ var (
 // nolint
 type Config = okexchaincfg.BackendConfig
 
+const (
+	// FlagSnapshotInterval is the flag of the interval in blocks between the balance snapshots of the accounts, which
+	// could be set as snapshot_interval of [backend] in config.toml as well
+	FlagSnapshotInterval = "backend.snapshot_interval"
+	// DefaultSnapshotInterval is the interval of the balance snapshots by default
+	DefaultSnapshotInterval int64 = 100
+	// FlagFillGap is the flag of whether the blocks missed in the backend database are reindexed before the node
+	// starts
+	FlagFillGap = "backend.fill_gap"
+)
+
+// GetSnapshotInterval returns the interval in blocks between the balance snapshots, the default one is returned if
+// it isn't set to be positive
+func GetSnapshotInterval() int64 {
+	if interval := viper.GetInt64(FlagSnapshotInterval); interval > 0 {
+		return interval
+	}
+	return DefaultSnapshotInterval
+}
+
 func loadMaintainConf(confDir string, fileName string) (*Config, error) {
 	fPath := confDir + string(os.PathSeparator) + fileName
 	if _, err := os.Stat(fPath); err != nil {
@@ -5,6 +5,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
 	"github.com/stretchr/testify/assert"
@@ -40,3 +41,13 @@ func TestConf(t *testing.T) {
 	config, err := SafeLoadMaintainConfig(DefaultTestConfig)
 	assert.True(t, config != nil && err == nil)
 }
+
+func TestGetSnapshotInterval(t *testing.T) {
+	defer viper.Set(FlagSnapshotInterval, nil)
+
+	require.Equal(t, DefaultSnapshotInterval, GetSnapshotInterval())
+	viper.Set(FlagSnapshotInterval, 10)
+	require.EqualValues(t, 10, GetSnapshotInterval())
+	viper.Set(FlagSnapshotInterval, -1)
+	require.Equal(t, DefaultSnapshotInterval, GetSnapshotInterval())
+}
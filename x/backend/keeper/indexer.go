@@ -0,0 +1,92 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/okex/okexchain/x/backend/types"
+)
+
+// CheckGap records the blocks missed between the last indexed height and the first block after the start, which are
+// filled by the reindex of the backend before the next start
+func (k Keeper) CheckGap(height int64) {
+	if k.Cache.GapChecked {
+		return
+	}
+	checkpoint, err := k.Orm.GetCheckpoint(types.CheckpointLastIndexedHeight)
+	if err != nil {
+		k.Logger.Error(fmt.Sprintf("[backend] failed to get the last indexed height, err: %+v", err))
+		return
+	}
+	k.Cache.GapChecked = true
+
+	if checkpoint.Height+1 < height {
+		k.addGap(checkpoint.Height+1, height-1)
+		k.Logger.Error(fmt.Sprintf("[backend] the blocks in [%d, %d] are missed in the database, "+
+			"they're filled before the next start or by `okexchaind backend reindex --from %d --to %d`",
+			checkpoint.Height+1, height-1, checkpoint.Height+1, height-1))
+	}
+}
+
+// addGap records the missed blocks in [from, to], the first missed height is saved in the database with the blocks
+// stored next, so that the blocks from it are filled before the next start
+func (k Keeper) addGap(from, to int64) {
+	k.Cache.AddGap(from, to)
+	if k.Cache.UnsavedMissedHeight == 0 || from < k.Cache.UnsavedMissedHeight {
+		k.Cache.UnsavedMissedHeight = from
+	}
+}
+
+// StoreBlock stores the block after the pending ones in order, the blocks failed to be stored are kept pending and
+// stored again with the next block. It returns the blocks stored
+func (k Keeper) StoreBlock(block *types.BlockData) (stored []*types.BlockData) {
+	k.Cache.PendingBlocks = append(k.Cache.PendingBlocks, block)
+	if len(k.Cache.PendingBlocks) > types.MaxPendingBlocks {
+		dropped := k.Cache.PendingBlocks[0]
+		k.Cache.PendingBlocks = k.Cache.PendingBlocks[1:]
+		k.addGap(dropped.Height, dropped.Height)
+		k.Logger.Error(fmt.Sprintf("[backend] too many blocks pending, block %d is dropped", dropped.Height))
+	}
+
+	for len(k.Cache.PendingBlocks) > 0 {
+		pending := k.Cache.PendingBlocks[0]
+		resultMap, err := k.Orm.StoreBlock(pending)
+		if err != nil {
+			k.Logger.Error(fmt.Sprintf("[backend] failed to store block %d, %d blocks pending, err: %+v",
+				pending.Height, len(k.Cache.PendingBlocks), err))
+			break
+		}
+		k.Logger.Debug(fmt.Sprintf("[backend] block %d stored, result: %+v", pending.Height, resultMap))
+		stored = append(stored, pending)
+		k.Cache.PendingBlocks = k.Cache.PendingBlocks[1:]
+	}
+
+	if len(stored) > 0 && k.Cache.UnsavedMissedHeight > 0 {
+		if err := k.Orm.SaveMissedHeight(k.Cache.UnsavedMissedHeight); err != nil {
+			k.Logger.Error(fmt.Sprintf("[backend] failed to save the missed height %d, err: %+v",
+				k.Cache.UnsavedMissedHeight, err))
+		} else {
+			k.Cache.UnsavedMissedHeight = 0
+		}
+	}
+	return stored
+}
+
+// GetIndexerStatus returns how far the backend database is behind the chain at the latest height
+func (k Keeper) GetIndexerStatus(latestHeight int64) (types.IndexerStatus, error) {
+	checkpoint, err := k.Orm.GetCheckpoint(types.CheckpointLastIndexedHeight)
+	if err != nil {
+		return types.IndexerStatus{}, err
+	}
+
+	status := types.IndexerStatus{
+		LastIndexedHeight:    checkpoint.Height,
+		LastIndexedTimestamp: checkpoint.Timestamp,
+		LatestHeight:         latestHeight,
+		PendingBlocks:        len(k.Cache.PendingBlocks),
+		Gaps:                 append([]types.HeightRange{}, k.Cache.Gaps...),
+	}
+	if latestHeight > checkpoint.Height {
+		status.Lag = latestHeight - checkpoint.Height
+	}
+	return status, nil
+}
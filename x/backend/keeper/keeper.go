@@ -26,6 +26,7 @@ type Keeper struct {
 	TokenKeeper  types.TokenKeeper  // The reference to the TokenKeeper to get fee details
 	marketKeeper types.MarketKeeper // The reference to the MarketKeeper to get fee details
 	dexKeeper    types.DexKeeper    // The reference to the DexKeeper to get tokenpair
+	SwapKeeper   types.SwapKeeper   // The reference to the SwapKeeper to get swap executions
 	cdc          *codec.Codec       // The wire codec for binary encoding/decoding.
 	Orm          *orm.ORM
 	stopChan     chan struct{}
@@ -34,19 +35,24 @@ type Keeper struct {
 	wsChan       chan types.IWebsocket // Websocket channel, it's only available when websocket config enabled
 	ticker3sChan chan types.IWebsocket // Websocket channel, it's used by tickers merge triggered 3s once
 	Cache        *cache.Cache          // Memory cache
+	// the interval in blocks between the balance snapshots
+	snapshotInterval int64
 }
 
 // NewKeeper creates new instances of the nameservice Keeper
-func NewKeeper(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dexKeeper types.DexKeeper, marketKeeper types.MarketKeeper, cdc *codec.Codec, logger log.Logger, cfg *config.Config) Keeper {
+func NewKeeper(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dexKeeper types.DexKeeper, swapKeeper types.SwapKeeper, marketKeeper types.MarketKeeper, cdc *codec.Codec, logger log.Logger, cfg *config.Config) Keeper {
 	k := Keeper{
 		OrderKeeper:  orderKeeper,
 		TokenKeeper:  tokenKeeper,
 		marketKeeper: marketKeeper,
 		dexKeeper:    dexKeeper,
+		SwapKeeper:   swapKeeper,
 		cdc:          cdc,
 		Logger:       logger.With("module", "backend"),
 		Config:       cfg,
 		wsChan:       nil,
+
+		snapshotInterval: config.GetSnapshotInterval(),
 	}
 
 	if k.Config.EnableBackend {
@@ -370,8 +376,8 @@ func (k Keeper) UpdateTickersBuffer(startTS, endTS int64, productList []string)
 				previousTicker.Open = previousTicker.Close
 				previousTicker.High = previousTicker.Close
 				previousTicker.Low = previousTicker.Close
-				previousTicker.Volume = 0
-				previousTicker.Change = 0
+				previousTicker.Volume = types.ZeroDecimal()
+				previousTicker.Change = types.ZeroDecimal()
 				previousTicker.ChangePercentage = "0.00%"
 			}
 
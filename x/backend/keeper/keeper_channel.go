@@ -40,8 +40,8 @@ func generateKline1M(keeper Keeper) {
 		endTS = keeper.Orm.GetMaxBlockTimestamp()
 	}
 
-	//ds := DealDataSource{orm: orm}
-	ds := orm.MergeResultDataSource{Orm: keeper.Orm}
+	// the deals keep the price of each fill, while a match result keeps the last price of the block
+	ds := orm.DealDataSource{Orm: keeper.Orm}
 	anchorNewStartTS, _, newKline1s, err := keeper.Orm.CreateKline1M(startTS, endTS, &ds)
 	if err != nil {
 		keeper.Logger.Debug(fmt.Sprintf("[backend] generateKline1M go routine error: %+v \n", err))
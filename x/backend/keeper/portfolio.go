@@ -0,0 +1,48 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/okex/okexchain/x/backend/types"
+)
+
+// OnAccountUpdated records the account updated, whose balances are snapshotted at the next height of the snapshot
+// interval. It's called by auth for all of the balance changes, including the ones not made by txs
+func (k Keeper) OnAccountUpdated(acc auth.Account) {
+	if k.Cache != nil {
+		k.Cache.AddChangedAccounts(acc.GetAddress().String())
+	}
+}
+
+// GetNewBalanceSnapshots takes the balance snapshots of all of the accounts changed since the last snapshot at the
+// heights of the snapshot interval
+func (k Keeper) GetNewBalanceSnapshots(ctx sdk.Context, block *types.BlockData) []*types.BalanceSnapshot {
+	if block.Height%k.snapshotInterval != 0 {
+		return nil
+	}
+
+	var snapshots []*types.BalanceSnapshot
+	for _, address := range k.Cache.PopChangedAccounts() {
+		addr, err := sdk.AccAddressFromBech32(address)
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, types.NewBalanceSnapshots(address, k.TokenKeeper.GetCoinsInfo(ctx, addr),
+			block.Height, block.Timestamp)...)
+	}
+	return snapshots
+}
+
+// GetBalanceSnapshotsV2 returns the balance snapshots of the account in the order of the time desc
+func (k Keeper) GetBalanceSnapshotsV2(address, currency, after, before string, limit int) []types.BalanceSnapshot {
+	return k.Orm.GetBalanceSnapshotsV2(address, currency, after, before, limit)
+}
+
+// GetRealizedPnLV2 returns the pnl realized by the sell deals of the account in the order of the time desc, the
+// empty method is fifo
+func (k Keeper) GetRealizedPnLV2(address, product, method, after, before string, limit int) []types.RealizedPnL {
+	if method == "" {
+		method = types.PnLMethodFIFO
+	}
+	return k.Orm.GetRealizedPnLV2(address, product, method, after, before, limit)
+}
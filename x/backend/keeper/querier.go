@@ -67,6 +67,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			}
 		case types.QueryDexFeesList:
 			res, err = queryDexFees(ctx, path[1:], req, keeper)
+		case types.QueryIndexerStatus:
+			res, err = queryIndexerStatus(ctx, keeper)
 
 		case types.QueryTickerListV2:
 			if keeper.Config.EnableMktCompute {
@@ -100,6 +102,10 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			res, err = queryDealsV2(ctx, path[1:], req, keeper)
 		case types.QueryTxListV2:
 			res, err = queryTxListV2(ctx, path[1:], req, keeper)
+		case types.QueryBalanceSnapshotsV2:
+			res, err = queryBalanceSnapshotsV2(ctx, path[1:], req, keeper)
+		case types.QueryRealizedPnLV2:
+			res, err = queryRealizedPnLV2(ctx, path[1:], req, keeper)
 		default:
 			res, err = nil, sdk.ErrUnknownRequest("unknown backend endpoint")
 		}
@@ -133,14 +139,32 @@ func queryDeals(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Ke
 	if params.Side != "" && params.Side != orderTypes.BuyOrder && params.Side != orderTypes.SellOrder {
 		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("Side should not be %s", params.Side))
 	}
+	if err := types.ValidateTradeSource(params.Source); err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
 	if params.Page < 0 || params.PerPage < 0 {
 		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid page %d or per_page %d", params.Page, params.PerPage))
 	}
 
 	offset, limit := common.GetPage(params.Page, params.PerPage)
-	deals, total := keeper.GetDeals(ctx, params.Address, params.Product, params.Side, params.Start, params.End, offset, limit)
+	var deals interface{}
+	var count, total int
+	switch params.Source {
+	case types.TradeSourceAMM:
+		swapTrades, n := keeper.GetSwapTrades(ctx, params.Address, params.Product, params.Side, params.Start, params.End,
+			offset, limit)
+		deals, count, total = swapTrades, len(swapTrades), n
+	case types.TradeSourceAll:
+		trades, n := keeper.GetTrades(ctx, params.Address, params.Product, params.Side, params.Start, params.End,
+			offset, limit)
+		deals, count, total = trades, len(trades), n
+	default:
+		orderBookDeals, n := keeper.GetDeals(ctx, params.Address, params.Product, params.Side, params.Start, params.End,
+			offset, limit)
+		deals, count, total = orderBookDeals, len(orderBookDeals), n
+	}
 	var response *common.ListResponse
-	if len(deals) > 0 {
+	if count > 0 {
 		response = common.GetListResponse(total, params.Page, params.PerPage, deals)
 	} else {
 		response = common.GetEmptyListResponse(total, params.Page, params.PerPage)
@@ -217,12 +241,16 @@ func queryCandleList(ctx sdk.Context, path []string, req abci.RequestQuery, keep
 	if params.Product == "" {
 		return nil, sdk.ErrUnknownRequest("invalid params: product is required")
 	}
-	if keeper.dexKeeper.GetTokenPair(ctx, params.Product) == nil {
+	if err := types.ValidateTradeSource(params.Source); err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+	if !keeper.productExists(ctx, params.Product, params.Source) {
 		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("product %s does not exist", params.Product))
 	}
 
 	ctx.Logger().Debug(fmt.Sprintf("queryCandleList : %+v", params))
-	restData, err := keeper.GetCandles(params.Product, params.Granularity, params.Size)
+	restData, err := keeper.GetCandlesFromTrades(params.Product, params.Source, params.Granularity, params.Size,
+		time.Now().Unix())
 
 	var response *common.BaseResponse
 	if err != nil {
@@ -276,14 +304,18 @@ func queryTickerList(ctx sdk.Context, path []string, req abci.RequestQuery, keep
 		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data, ", err.Error()))
 	}
 
+	if err := types.ValidateTradeSource(params.Source); err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+
 	products := []string{}
 	if params.Product != "" {
-		if tokenPair := keeper.dexKeeper.GetTokenPair(ctx, params.Product); tokenPair == nil {
+		if !keeper.productExists(ctx, params.Product, params.Source) {
 			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("product %s does not exist", params.Product))
 		}
 		products = append(products, params.Product)
 	} else {
-		products = keeper.getAllProducts(ctx)
+		products = keeper.getProductsBySource(ctx, params.Source)
 	}
 
 	// set default count to 10
@@ -292,7 +324,15 @@ func queryTickerList(ctx sdk.Context, path []string, req abci.RequestQuery, keep
 	}
 
 	addedTickers := []types.Ticker{}
-	tickers := keeper.GetTickers(products, params.Count)
+	var tickers []types.Ticker
+	if params.Source == types.TradeSourceAMM || params.Source == types.TradeSourceAll {
+		var err error
+		if tickers, err = keeper.GetTickersFromTrades(products, params.Source, params.Count, time.Now().Unix()); err != nil {
+			return nil, sdk.ErrInternal(err.Error())
+		}
+	} else {
+		tickers = keeper.GetTickers(products, params.Count)
+	}
 	for _, p := range products {
 
 		exists := false
@@ -306,15 +346,15 @@ func queryTickerList(ctx sdk.Context, path []string, req abci.RequestQuery, keep
 		if !exists {
 			//tmpPrice := keeper.orderKeeper.GetLastPrice(ctx, p)
 			tmpTicker := types.Ticker{
-				Price:            -1,
+				Price:            types.NewDecimal(-1),
 				Product:          p,
 				Symbol:           p,
-				Open:             0,
-				Close:            0,
-				High:             0,
-				Low:              0,
-				Volume:           0,
-				Change:           0,
+				Open:             types.ZeroDecimal(),
+				Close:            types.ZeroDecimal(),
+				High:             types.ZeroDecimal(),
+				Low:              types.ZeroDecimal(),
+				Volume:           types.ZeroDecimal(),
+				Change:           types.ZeroDecimal(),
 				ChangePercentage: "0.00%",
 				Timestamp:        time.Now().Unix(),
 			}
@@ -454,6 +494,9 @@ func queryTxList(ctx sdk.Context, path []string, req abci.RequestQuery, keeper K
 	if params.Page < 0 || params.PerPage < 0 {
 		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid page %d or per_page %d", params.Page, params.PerPage))
 	}
+	if !types.IsValidTxType(params.TxType) {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid tx type %d", params.TxType))
+	}
 	offset, limit := common.GetPage(params.Page, params.PerPage)
 	txs, total := keeper.GetTransactionList(ctx, params.Address, params.TxType, params.StartTime, params.EndTime, offset, limit)
 
@@ -512,3 +555,15 @@ func queryDexFees(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 	}
 	return bz, nil
 }
+
+func queryIndexerStatus(ctx sdk.Context, keeper Keeper) ([]byte, sdk.Error) {
+	status, err := keeper.GetIndexerStatus(ctx.BlockHeight())
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+	bz, err := json.Marshal(common.GetBaseResponse(status))
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+	return bz, nil
+}
@@ -104,12 +104,12 @@ func queryTickerV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 	for _, t := range tickers {
 		if params.Product == t.Product {
 			notExist = false
-			result.Last = fmt.Sprintf("%f", t.Price)
-			result.Open24H = fmt.Sprintf("%f", t.Open)
-			result.High24H = fmt.Sprintf("%f", t.High)
-			result.Low24H = fmt.Sprintf("%f", t.Low)
-			result.BaseVolume24H = fmt.Sprintf("%f", t.Volume)
-			result.QuoteVolume24H = fmt.Sprintf("%f", t.Volume)
+			result.Last = t.Price.StringFixed(6)
+			result.Open24H = t.Open.StringFixed(6)
+			result.High24H = t.High.StringFixed(6)
+			result.Low24H = t.Low.StringFixed(6)
+			result.BaseVolume24H = t.Volume.StringFixed(6)
+			result.QuoteVolume24H = t.Volume.StringFixed(6)
 			result.Timestamp = time.Unix(t.Timestamp, 0).UTC().Format("2006-01-02T15:04:05.000Z")
 			break
 		}
@@ -136,12 +136,12 @@ func queryTickerListV2(ctx sdk.Context, path []string, req abci.RequestQuery, ke
 	var tickerList []types.TickerV2
 	for _, t := range tickers {
 		var ticker types.TickerV2
-		ticker.Last = fmt.Sprintf("%f", t.Price)
-		ticker.Open24H = fmt.Sprintf("%f", t.Open)
-		ticker.High24H = fmt.Sprintf("%f", t.High)
-		ticker.Low24H = fmt.Sprintf("%f", t.Low)
-		ticker.BaseVolume24H = fmt.Sprintf("%f", t.Volume)
-		ticker.QuoteVolume24H = fmt.Sprintf("%f", t.Volume)
+		ticker.Last = t.Price.StringFixed(6)
+		ticker.Open24H = t.Open.StringFixed(6)
+		ticker.High24H = t.High.StringFixed(6)
+		ticker.Low24H = t.Low.StringFixed(6)
+		ticker.BaseVolume24H = t.Volume.StringFixed(6)
+		ticker.QuoteVolume24H = t.Volume.StringFixed(6)
 		ticker.Timestamp = time.Unix(t.Timestamp, 0).UTC().Format("2006-01-02T15:04:05.000Z")
 		bestBid, bestAsk := keeper.OrderKeeper.GetBestBidAndAsk(ctx, t.Product)
 		ticker.BestBid = bestBid.String()
@@ -344,6 +344,9 @@ func queryTxListV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 	if _, err := sdk.AccAddressFromBech32(params.Address); err != nil {
 		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("invalid address", err.Error()))
 	}
+	if !types.IsValidTxType(int64(params.TxType)) {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid tx type %d", params.TxType))
+	}
 
 	txs := keeper.getTransactionListV2(ctx, params.Address, params.TxType, params.After, params.Before, params.Limit)
 	if len(txs) == 0 {
@@ -357,3 +360,52 @@ func queryTxListV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 
 	return res, nil
 }
+
+func queryBalanceSnapshotsV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params types.QueryBalanceSnapshotsParamsV2
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if _, err := sdk.AccAddressFromBech32(params.Address); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("invalid address", err.Error()))
+	}
+
+	snapshots := keeper.GetBalanceSnapshotsV2(params.Address, params.Currency, params.After, params.Before,
+		params.Limit)
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+
+	res, err := common.JSONMarshalV2(snapshots)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+
+	return res, nil
+}
+
+func queryRealizedPnLV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params types.QueryRealizedPnLParamsV2
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if _, err := sdk.AccAddressFromBech32(params.Address); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("invalid address", err.Error()))
+	}
+	if err := types.ValidatePnLMethod(params.Method); err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+
+	pnls := keeper.GetRealizedPnLV2(params.Address, params.Product, params.Method, params.After, params.Before,
+		params.Limit)
+	if len(pnls) == 0 {
+		return nil, nil
+	}
+
+	res, err := common.JSONMarshalV2(pnls)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+
+	return res, nil
+}
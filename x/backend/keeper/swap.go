@@ -0,0 +1,155 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ammswaptypes "github.com/okex/okexchain/x/ammswap/types"
+	"github.com/okex/okexchain/x/backend/types"
+	orderTypes "github.com/okex/okexchain/x/order/types"
+)
+
+// getSwapProduct returns the product traded by swapping the two tokens and its base token. It's the token pair of the
+// dex if there's one of the two tokens, so that the swaps are merged with the deals of the order book, or the swap
+// token pair otherwise
+func (k Keeper) getSwapProduct(ctx sdk.Context, token0, token1 string) (product, baseToken string) {
+	for _, pair := range [][2]string{{token0, token1}, {token1, token0}} {
+		product = fmt.Sprintf("%s_%s", pair[0], pair[1])
+		if k.dexKeeper.GetTokenPair(ctx, product) != nil {
+			return product, pair[0]
+		}
+	}
+	baseToken, _ = ammswaptypes.GetBaseQuoteTokenName(token0, token1)
+	return ammswaptypes.GetSwapTokenPairName(token0, token1), baseToken
+}
+
+// GetNewSwapTrades returns the trades of the hops of the swaps executed in the block
+func (k Keeper) GetNewSwapTrades(ctx sdk.Context) []*types.SwapTrade {
+	if k.SwapKeeper == nil {
+		return nil
+	}
+	executions := k.SwapKeeper.GetSwapExecutions(ctx)
+	swapTrades := make([]*types.SwapTrade, 0, len(executions))
+	for _, execution := range executions {
+		product, baseToken := k.getSwapProduct(ctx, execution.SoldToken.Denom, execution.BoughtToken.Denom)
+		side, base, quote := orderTypes.BuyOrder, execution.BoughtToken.Amount, execution.SoldToken.Amount
+		if execution.SoldToken.Denom == baseToken {
+			side, base, quote = orderTypes.SellOrder, execution.SoldToken.Amount, execution.BoughtToken.Amount
+		}
+		if !base.IsPositive() {
+			continue
+		}
+		swapTrades = append(swapTrades, &types.SwapTrade{
+			Timestamp:     ctx.BlockHeader().Time.Unix(),
+			BlockHeight:   ctx.BlockHeight(),
+			Sequence:      int64(execution.Index),
+			TxHash:        execution.TxHash,
+			Pool:          execution.TokenPairName,
+			Product:       product,
+			Sender:        execution.Sender.String(),
+			Side:          side,
+			Price:         types.NewDecimalFromDec(quote.Quo(base)),
+			Quantity:      types.NewDecimalFromDec(base),
+			QuoteQuantity: types.NewDecimalFromDec(quote),
+			Fee:           execution.Fee.String(),
+		})
+	}
+	return swapTrades
+}
+
+// getAllSwapProducts returns the products traded by the swap token pairs
+func (k Keeper) getAllSwapProducts(ctx sdk.Context) []string {
+	if k.SwapKeeper == nil {
+		return nil
+	}
+	var products []string
+	for _, tokenPair := range k.SwapKeeper.GetSwapTokenPairs(ctx) {
+		product, _ := k.getSwapProduct(ctx, tokenPair.BasePooledCoin.Denom, tokenPair.QuotePooledCoin.Denom)
+		products = append(products, product)
+	}
+	return products
+}
+
+// GetSwapTrades returns the swap trades in the order of the time desc
+func (k Keeper) GetSwapTrades(ctx sdk.Context, sender, product, side string, start, end int64, offset, limit int) (
+	[]types.SwapTrade, int) {
+	return k.Orm.GetSwapTrades(sender, product, side, start, end, offset, limit)
+}
+
+// GetTrades returns the deals & the swap trades together in the order of the time desc
+func (k Keeper) GetTrades(ctx sdk.Context, sender, product, side string, start, end int64, offset, limit int) (
+	[]types.Trade, int) {
+	return k.Orm.GetTrades(sender, product, side, start, end, offset, limit)
+}
+
+// GetCandlesFromTrades returns the candles of the product aggregated from the trades of the source, which is the
+// order book, the ammswap pools or both of them
+func (k Keeper) GetCandlesFromTrades(product, source string, granularity, size int, ts int64) (r [][]string, err error) {
+	if !k.Config.EnableBackend {
+		return nil, fmt.Errorf("backend is not enabled, no candle found, maintian.conf: %+v", k.Config)
+	}
+	if source == "" || source == types.TradeSourceOrderBook {
+		return k.GetCandlesWithTime(product, granularity, size, ts)
+	}
+
+	candleType := types.GetAllKlineMap()[granularity]
+	if candleType == "" || (size < 0 || size > 1000) {
+		return nil, fmt.Errorf("parameter's not correct, size: %d, granularity: %d", size, granularity)
+	}
+	iklines, err := k.Orm.GetKlinesFromTrades(product, source,
+		types.MustNewKlineFactory(candleType, nil).(types.IKline), size, ts)
+	if err != nil {
+		return nil, err
+	}
+	return types.ToRestfulData(&iklines, size), nil
+}
+
+// GetTickersFromTrades returns the tickers of the products computed from the trades of the source in the 24 hours
+func (k Keeper) GetTickersFromTrades(products []string, source string, count int, ts int64) ([]types.Ticker, error) {
+	tickerMap, err := k.Orm.GetTickersFromTrades(products, source, ts)
+	if err != nil {
+		return nil, err
+	}
+	tickers := []types.Ticker{}
+	for _, product := range products {
+		if ticker := tickerMap[product]; ticker != nil && len(tickers) < count {
+			tickers = append(tickers, *ticker)
+		}
+	}
+	return tickers, nil
+}
+
+// getProductsBySource returns all of the products traded from the source
+func (k Keeper) getProductsBySource(ctx sdk.Context, source string) []string {
+	products := k.getAllProducts(ctx)
+	if source != types.TradeSourceAMM && source != types.TradeSourceAll {
+		return products
+	}
+	exists := make(map[string]bool, len(products))
+	for _, product := range products {
+		exists[product] = true
+	}
+	for _, product := range k.getAllSwapProducts(ctx) {
+		if !exists[product] {
+			exists[product] = true
+			products = append(products, product)
+		}
+	}
+	return products
+}
+
+// productExists checks whether the product is traded from the source
+func (k Keeper) productExists(ctx sdk.Context, product, source string) bool {
+	if k.dexKeeper.GetTokenPair(ctx, product) != nil {
+		return true
+	}
+	if source != types.TradeSourceAMM && source != types.TradeSourceAll {
+		return false
+	}
+	for _, swapProduct := range k.getAllSwapProducts(ctx) {
+		if swapProduct == product {
+			return true
+		}
+	}
+	return false
+}
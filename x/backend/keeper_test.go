@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	ammswaptypes "github.com/okex/okexchain/x/ammswap/types"
 	"github.com/okex/okexchain/x/backend/cases"
 	"github.com/okex/okexchain/x/backend/config"
 	"github.com/okex/okexchain/x/backend/orm"
@@ -16,6 +17,7 @@ import (
 	"github.com/okex/okexchain/x/backend/types"
 	"github.com/okex/okexchain/x/common"
 	tokenTypes "github.com/okex/okexchain/x/token/types"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -145,12 +147,12 @@ func sumKlinesVolume(product string, o *orm.ORM, ikline types.IKline) (float64,
 		return 0, err
 	}
 	iklines := types.ToIKlinesArray(klines, time.Now().Unix(), false)
-	volume := 0.0
+	volume := types.ZeroDecimal()
 	for _, i := range iklines {
-		volume += i.GetVolume()
+		volume = volume.Add(i.GetVolume())
 	}
 
-	return volume, nil
+	return volume.Float64(), nil
 }
 
 // TestKeeper_FixJira85 is related to OKDEX-83, OKDEX-85
@@ -402,3 +404,179 @@ func buildCancelOrderMsg(sender sdk.AccAddress, blockHeight, orderNum int) order
 
 	return msgCancelOrder
 }
+
+func TestKeeper_StoreBlock(t *testing.T) {
+	mapp, _ := getMockApp(t, 2, true, "")
+	k := mapp.backendKeeper
+	newBlock := func(height int64, orderIDs ...string) *types.BlockData {
+		block := &types.BlockData{Height: height, Timestamp: 1000 + height}
+		for _, orderID := range orderIDs {
+			block.NewOrders = append(block.NewOrders, &types.Order{OrderID: orderID, Product: types.TestTokenPair,
+				Timestamp: block.Timestamp})
+		}
+		block.MatchResults = []*types.MatchResult{{BlockHeight: height, Product: types.TestTokenPair,
+			Price: types.NewDecimal(10), Quantity: types.NewDecimal(1), Timestamp: block.Timestamp}}
+		return block
+	}
+
+	// the block stored moves the checkpoint, and could be stored again
+	require.Equal(t, 1, len(k.StoreBlock(newBlock(2, orderTypes.FormatOrderID(2, 1)))))
+	require.Equal(t, 1, len(k.StoreBlock(newBlock(2, orderTypes.FormatOrderID(2, 1)))))
+	_, total := k.Orm.GetMatchResults("", 0, 0, 0, 10)
+	require.Equal(t, 1, total)
+	status, err := k.GetIndexerStatus(2)
+	require.Nil(t, err)
+	require.EqualValues(t, 2, status.LastIndexedHeight)
+	require.EqualValues(t, 1002, status.LastIndexedTimestamp)
+	require.EqualValues(t, 0, status.Lag)
+
+	// nothing of the block failed is stored, it's kept pending and stored with the next block
+	failed := newBlock(3, orderTypes.FormatOrderID(3, 1), orderTypes.FormatOrderID(3, 1))
+	require.Equal(t, 0, len(k.StoreBlock(failed)))
+	_, total = k.Orm.GetMatchResults("", 0, 0, 0, 10)
+	require.Equal(t, 1, total)
+	status, err = k.GetIndexerStatus(3)
+	require.Nil(t, err)
+	require.EqualValues(t, 2, status.LastIndexedHeight)
+	require.EqualValues(t, 1, status.Lag)
+	require.Equal(t, 1, status.PendingBlocks)
+
+	failed.NewOrders = failed.NewOrders[:1]
+	stored := k.StoreBlock(newBlock(4, orderTypes.FormatOrderID(4, 1)))
+	require.Equal(t, 2, len(stored))
+	require.EqualValues(t, 3, stored[0].Height)
+	require.EqualValues(t, 4, stored[1].Height)
+	status, err = k.GetIndexerStatus(4)
+	require.Nil(t, err)
+	require.EqualValues(t, 4, status.LastIndexedHeight)
+	require.Equal(t, 0, status.PendingBlocks)
+
+	// the blocks missed before the start are recorded once
+	k.CheckGap(7)
+	k.CheckGap(9)
+	status, err = k.GetIndexerStatus(9)
+	require.Nil(t, err)
+	require.EqualValues(t, 5, status.Lag)
+	require.Equal(t, []types.HeightRange{{From: 5, To: 6}}, status.Gaps)
+
+	// the first missed height is saved with the next block stored, so that the gap is filled before the next start
+	require.Equal(t, 1, len(k.StoreBlock(newBlock(9))))
+	checkpoint, err := k.Orm.GetCheckpoint(types.CheckpointFirstMissedHeight)
+	require.Nil(t, err)
+	require.EqualValues(t, 5, checkpoint.Height)
+	require.EqualValues(t, 0, k.Cache.UnsavedMissedHeight)
+}
+
+func TestGetNewDealsAndMatchResultsAtEndBlock(t *testing.T) {
+	mapp, orders := FireEndBlockerPeriodicMatch(t, true)
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: time.Now()}).WithBlockHeight(10)
+
+	// the buy order walks two price levels of the continuous auction
+	mapp.orderKeeper.SetBlockMatchResult(&orderTypes.BlockMatchResult{BlockHeight: 10,
+		ResultMap: map[string]orderTypes.MatchResult{types.TestTokenPair: {
+			BlockHeight: 10, Price: sdk.MustNewDecFromStr("9.5"), Quantity: sdk.NewDec(2),
+			Deals: []orderTypes.Deal{
+				{OrderID: orders[1].OrderID, Side: types.SellOrder, Price: sdk.NewDec(9), Quantity: sdk.OneDec()},
+				{OrderID: orders[0].OrderID, Side: types.BuyOrder, Price: sdk.NewDec(9), Quantity: sdk.OneDec()},
+				{OrderID: orders[1].OrderID, Side: types.SellOrder, Price: sdk.MustNewDecFromStr("9.5"),
+					Quantity: sdk.OneDec()},
+				{OrderID: orders[0].OrderID, Side: types.BuyOrder, Price: sdk.MustNewDecFromStr("9.5"),
+					Quantity: sdk.OneDec()},
+			}}}})
+
+	deals, matchResults, err := GetNewDealsAndMatchResultsAtEndBlock(ctx, mapp.orderKeeper)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(matchResults))
+	require.Equal(t, "9.5", matchResults[0].Price.String())
+	require.Equal(t, 4, len(deals))
+	expectedPrices := []string{"9", "9", "9.5", "9.5"}
+	for i, deal := range deals {
+		require.Equal(t, expectedPrices[i], deal.Price.String())
+		require.EqualValues(t, i, deal.Sequence)
+	}
+}
+
+func TestKeeper_GetNewSwapTrades(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2, true, "")
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: time.Now()}).WithBlockHeight(2)
+	require.Nil(t, mapp.dexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair()))
+	sender := addrKeysSlice[0].Address
+	mapp.swapKeeper.executions = []ammswaptypes.SwapExecution{
+		// buys 2xxb by 10okt through the swap token pair okt_xxb
+		{TxHash: "TX1", Index: 0, TokenPairName: "okt_xxb", Sender: sender,
+			SoldToken:   sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10)),
+			BoughtToken: sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(2)),
+			Fee:         sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDecWithPrec(3, 2))},
+		// sells 1aab for 4okt through the swap token pair aab_okt, which isn't a token pair of the dex
+		{TxHash: "TX2", Index: 1, TokenPairName: "aab_okt", Sender: sender,
+			SoldToken:   sdk.NewDecCoinFromDec("aab", sdk.NewDec(1)),
+			BoughtToken: sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(4)),
+			Fee:         sdk.NewDecCoinFromDec("aab", sdk.NewDecWithPrec(3, 3))},
+	}
+
+	swapTrades := mapp.backendKeeper.GetNewSwapTrades(ctx)
+	require.Equal(t, 2, len(swapTrades))
+	require.Equal(t, types.TestTokenPair, swapTrades[0].Product)
+	require.Equal(t, "okt_xxb", swapTrades[0].Pool)
+	require.Equal(t, types.BuyOrder, swapTrades[0].Side)
+	require.Equal(t, "5", swapTrades[0].Price.String())
+	require.Equal(t, "2", swapTrades[0].Quantity.String())
+	require.Equal(t, "10", swapTrades[0].QuoteQuantity.String())
+	require.Equal(t, "aab_okt", swapTrades[1].Product)
+	require.Equal(t, types.SellOrder, swapTrades[1].Side)
+	require.Equal(t, "4", swapTrades[1].Price.String())
+	require.EqualValues(t, 1, swapTrades[1].Sequence)
+
+	// the swap trades are stored with the block
+	EndBlocker(ctx, mapp.backendKeeper)
+	stored, total := mapp.backendKeeper.GetSwapTrades(ctx, sender.String(), "", "", 0, time.Now().Unix()+1, 0, 10)
+	require.Equal(t, 2, total)
+	require.Equal(t, "TX2", stored[0].TxHash)
+	trades, total := mapp.backendKeeper.GetTrades(ctx, sender.String(), types.TestTokenPair, "", 0,
+		time.Now().Unix()+1, 0, 10)
+	require.Equal(t, 1, total)
+	require.Equal(t, types.TradeSourceAMM, trades[0].Source)
+
+	candles, err := mapp.backendKeeper.GetCandlesFromTrades(types.TestTokenPair, types.TradeSourceAMM, 60, 10,
+		time.Now().Unix()+1)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(candles))
+	tickers, err := mapp.backendKeeper.GetTickersFromTrades([]string{types.TestTokenPair, "aab_okt"},
+		types.TradeSourceAMM, 10, time.Now().Unix()+1)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(tickers))
+}
+
+func TestKeeper_GetNewBalanceSnapshots(t *testing.T) {
+	viper.Set(config.FlagSnapshotInterval, 2)
+	defer viper.Set(config.FlagSnapshotInterval, nil)
+	mapp, addrKeysSlice := getMockApp(t, 2, true, "")
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: time.Now()})
+	sender := addrKeysSlice[0].Address.String()
+
+	// the accounts updated are kept until the height of the snapshot interval
+	mapp.backendKeeper.OnAccountUpdated(mapp.AccountKeeper.GetAccount(ctx, addrKeysSlice[0].Address))
+	block := &types.BlockData{Height: 1, Timestamp: 100}
+	require.Equal(t, 0, len(mapp.backendKeeper.GetNewBalanceSnapshots(ctx, block)))
+	block = &types.BlockData{Height: 2, Timestamp: 200}
+	snapshots := mapp.backendKeeper.GetNewBalanceSnapshots(ctx, block)
+	coinsInfo := mapp.tokenKeeper.GetCoinsInfo(ctx, addrKeysSlice[0].Address)
+	require.Equal(t, len(coinsInfo), len(snapshots))
+	require.True(t, len(snapshots) > 0)
+	require.Equal(t, sender, snapshots[0].Address)
+	require.Equal(t, coinsInfo[0].Symbol, snapshots[0].Currency)
+	require.EqualValues(t, 2, snapshots[0].BlockHeight)
+	block.BalanceSnapshots = snapshots
+	_, err := mapp.backendKeeper.Orm.StoreBlock(block)
+	require.Nil(t, err)
+
+	// the accounts are cleared by the snapshot
+	block = &types.BlockData{Height: 4, Timestamp: 400}
+	require.Equal(t, 0, len(mapp.backendKeeper.GetNewBalanceSnapshots(ctx, block)))
+
+	stored := mapp.backendKeeper.GetBalanceSnapshotsV2(sender, coinsInfo[0].Symbol, "", "", 10)
+	require.Equal(t, 1, len(stored))
+	require.Equal(t, coinsInfo[0].Available, stored[0].Available.StringFixed(8))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/lcd"
 	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/cosmos/cosmos-sdk/x/supply/exported"
+	ammswaptypes "github.com/okex/okexchain/x/ammswap/types"
 	"github.com/okex/okexchain/x/backend/client/cli"
 	"github.com/okex/okexchain/x/backend/config"
 	"github.com/okex/okexchain/x/backend/orm"
@@ -57,6 +58,21 @@ type MockApp struct {
 	tokenKeeper   token.Keeper
 	backendKeeper Keeper
 	supplyKeeper  supply.Keeper
+	swapKeeper    *mockSwapKeeper
+}
+
+// mockSwapKeeper returns the swap executions & the swap token pairs set by the tests
+type mockSwapKeeper struct {
+	executions []ammswaptypes.SwapExecution
+	tokenPairs []ammswaptypes.SwapTokenPair
+}
+
+func (k *mockSwapKeeper) GetSwapExecutions(ctx sdk.Context) []ammswaptypes.SwapExecution {
+	return k.executions
+}
+
+func (k *mockSwapKeeper) GetSwapTokenPairs(ctx sdk.Context) []ammswaptypes.SwapTokenPair {
+	return k.tokenPairs
 }
 
 func registerCdc(cdc *codec.Codec) {
@@ -77,6 +93,8 @@ func getMockApp(t *testing.T, numGenAccs int, enableBackend bool, dbDir string)
 		keyTokenPair: sdk.NewKVStoreKey(dex.TokenPairStoreKey),
 
 		keySupply: sdk.NewKVStoreKey(supply.StoreKey),
+
+		swapKeeper: &mockSwapKeeper{},
 	}
 
 	feeCollector := supply.NewEmptyModuleAccount(auth.FeeCollectorName)
@@ -149,6 +167,7 @@ func getMockApp(t *testing.T, numGenAccs int, enableBackend bool, dbDir string)
 		mockApp.orderKeeper,
 		mockApp.tokenKeeper,
 		&mockApp.dexKeeper,
+		mockApp.swapKeeper,
 		nil,
 		mockApp.Cdc,
 		mockApp.Logger(),
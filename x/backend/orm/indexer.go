@@ -0,0 +1,88 @@
+package orm
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/okex/okexchain/x/backend/types"
+)
+
+// StoreBlock stores all of the data of the block atomically, and moves the checkpoint of the last indexed height
+// forward to the block. The rows stored before with the height of the block are replaced, so that a block could be
+// stored again after a crash
+func (orm *ORM) StoreBlock(block *types.BlockData) (resultMap map[string]int, err error) {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	trx := orm.db.Begin()
+	defer func() {
+		orm.deferRollbackTx(trx, err)
+	}()
+
+	if err = deleteBlocksBetween(trx, block.Height, block.Height); err != nil {
+		return nil, err
+	}
+	resultMap, err = orm.batchInsertOrUpdate(trx, block.NewOrders, block.UpdatedOrders, block.Deals,
+		block.MatchResults, block.FeeDetails, block.Transactions, block.SwapTrades)
+	if err != nil {
+		return resultMap, err
+	}
+	if resultMap["balanceSnapshots"], err = orm.batchInsertBalanceSnapshots(trx, block.BalanceSnapshots); err != nil {
+		return resultMap, err
+	}
+	if resultMap["realizedPnLs"], err = storeRealizedPnLs(trx, block.Deals); err != nil {
+		return resultMap, err
+	}
+	if err = saveCheckpoint(trx, block.Height, block.Timestamp); err != nil {
+		return resultMap, err
+	}
+	return resultMap, trx.Commit().Error
+}
+
+// saveCheckpoint moves the checkpoint of the last indexed height forward, a lower height is ignored
+func saveCheckpoint(trx *gorm.DB, height, timestamp int64) error {
+	checkpoint, err := getCheckpoint(trx, types.CheckpointLastIndexedHeight)
+	if err != nil || checkpoint.Height >= height {
+		return err
+	}
+	checkpoint.Height, checkpoint.Timestamp = height, timestamp
+	return trx.Save(&checkpoint).Error
+}
+
+// SaveMissedHeight records the first height of the blocks missed, a height above the recorded one is ignored
+func (orm *ORM) SaveMissedHeight(height int64) error {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	checkpoint, err := getCheckpoint(orm.db, types.CheckpointFirstMissedHeight)
+	if err != nil || (checkpoint.Height > 0 && checkpoint.Height <= height) {
+		return err
+	}
+	checkpoint.Height = height
+	return orm.db.Save(&checkpoint).Error
+}
+
+// ClearMissedHeight removes the first missed height once the blocks in [from, to] have been stored again, it's kept
+// if the height is out of the range
+func (orm *ORM) ClearMissedHeight(from, to int64) error {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	checkpoint, err := getCheckpoint(orm.db, types.CheckpointFirstMissedHeight)
+	if err != nil || checkpoint.Height < from || checkpoint.Height > to {
+		return err
+	}
+	return orm.db.Delete(&checkpoint).Error
+}
+
+// GetCheckpoint returns the checkpoint of the name, whose height is 0 when it doesn't exist
+func (orm *ORM) GetCheckpoint(name string) (types.Checkpoint, error) {
+	return getCheckpoint(orm.db, name)
+}
+
+func getCheckpoint(db *gorm.DB, name string) (types.Checkpoint, error) {
+	checkpoint := types.Checkpoint{Name: name}
+	err := db.Where("name = ?", name).First(&checkpoint).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return checkpoint, nil
+	}
+	return checkpoint, err
+}
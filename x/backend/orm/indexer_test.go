@@ -0,0 +1,105 @@
+package orm
+
+import (
+	"testing"
+
+	"github.com/okex/okexchain/x/backend/types"
+	"github.com/okex/okexchain/x/token"
+	"github.com/stretchr/testify/require"
+)
+
+func TestORM_StoreBlock(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+
+	checkpoint, err := orm.GetCheckpoint(types.CheckpointLastIndexedHeight)
+	require.Nil(t, err)
+	require.EqualValues(t, 0, checkpoint.Height)
+
+	block := &types.BlockData{
+		Height:    2,
+		Timestamp: 200,
+		NewOrders: []*types.Order{{OrderID: "ID0000000002-1", Sender: "addr1", Product: types.TestTokenPair,
+			Side: types.BuyOrder, Timestamp: 200, OrderType: "LIMIT", CloseReason: "expired"}},
+		Deals: []*types.Deal{{Timestamp: 200, BlockHeight: 2, OrderID: "ID0000000002-1", Sender: "addr1",
+			Product: types.TestTokenPair, Side: types.BuyOrder, Price: types.NewDecimal(10), Quantity: types.NewDecimal(1)}},
+		FeeDetails: []*token.FeeDetail{{Address: "addr1", Receiver: "addr2", Fee: "0.1", Timestamp: 200,
+			BlockHeight: 2}},
+	}
+	resultMap, err := orm.StoreBlock(block)
+	require.Nil(t, err)
+	require.Equal(t, 1, resultMap["newOrders"])
+
+	// all of the columns are stored by the batch insert
+	order := orm.GetOrderByID("ID0000000002-1")
+	require.NotNil(t, order)
+	require.Equal(t, "LIMIT", order.OrderType)
+	require.Equal(t, "expired", order.CloseReason)
+	feeDetails, _ := orm.GetFeeDetails("addr1", 0, 10)
+	require.Equal(t, "addr2", feeDetails[0].Receiver)
+
+	// the block stored again replaces the rows
+	_, err = orm.StoreBlock(block)
+	require.Nil(t, err)
+	_, total := orm.GetDeals("addr1", "", "", 0, 0, 0, 10)
+	require.Equal(t, 1, total)
+	checkpoint, err = orm.GetCheckpoint(types.CheckpointLastIndexedHeight)
+	require.Nil(t, err)
+	require.EqualValues(t, 2, checkpoint.Height)
+	require.EqualValues(t, 200, checkpoint.Timestamp)
+
+	// the next block committed in the same second keeps the rows of the block
+	_, err = orm.StoreBlock(&types.BlockData{Height: 3, Timestamp: 200,
+		Deals: []*types.Deal{{Timestamp: 200, BlockHeight: 3, OrderID: "ID0000000002-1", Sender: "addr1",
+			Product: types.TestTokenPair, Side: types.BuyOrder, Price: types.NewDecimal(10), Quantity: types.NewDecimal(1)}}})
+	require.Nil(t, err)
+	require.NotNil(t, orm.GetOrderByID("ID0000000002-1"))
+	_, total = orm.GetDeals("addr1", "", "", 0, 0, 0, 10)
+	require.Equal(t, 2, total)
+	feeDetails, _ = orm.GetFeeDetails("addr1", 0, 10)
+	require.Equal(t, 1, len(feeDetails))
+
+	// nothing is stored by the block failed
+	_, err = orm.StoreBlock(&types.BlockData{Height: 4, Timestamp: 300, NewOrders: []*types.Order{
+		{OrderID: "ID0000000004-1", Timestamp: 300}, {OrderID: "ID0000000004-1", Timestamp: 300}}})
+	require.NotNil(t, err)
+	require.Nil(t, orm.GetOrderByID("ID0000000004-1"))
+	checkpoint, err = orm.GetCheckpoint(types.CheckpointLastIndexedHeight)
+	require.Nil(t, err)
+	require.EqualValues(t, 3, checkpoint.Height)
+
+	// the checkpoint only moves forward
+	_, err = orm.StoreBlock(&types.BlockData{Height: 5, Timestamp: 500})
+	require.Nil(t, err)
+	_, err = orm.StoreBlock(&types.BlockData{Height: 4, Timestamp: 400})
+	require.Nil(t, err)
+	checkpoint, err = orm.GetCheckpoint(types.CheckpointLastIndexedHeight)
+	require.Nil(t, err)
+	require.EqualValues(t, 5, checkpoint.Height)
+}
+
+func TestORM_MissedHeight(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+
+	// the first missed height is kept
+	require.Nil(t, orm.SaveMissedHeight(5))
+	require.Nil(t, orm.SaveMissedHeight(8))
+	checkpoint, err := orm.GetCheckpoint(types.CheckpointFirstMissedHeight)
+	require.Nil(t, err)
+	require.EqualValues(t, 5, checkpoint.Height)
+	require.Nil(t, orm.SaveMissedHeight(3))
+	checkpoint, err = orm.GetCheckpoint(types.CheckpointFirstMissedHeight)
+	require.Nil(t, err)
+	require.EqualValues(t, 3, checkpoint.Height)
+
+	// it's cleared only by the blocks stored again from a height not above it
+	require.Nil(t, orm.ClearMissedHeight(4, 10))
+	checkpoint, err = orm.GetCheckpoint(types.CheckpointFirstMissedHeight)
+	require.Nil(t, err)
+	require.EqualValues(t, 3, checkpoint.Height)
+	require.Nil(t, orm.ClearMissedHeight(1, 10))
+	checkpoint, err = orm.GetCheckpoint(types.CheckpointFirstMissedHeight)
+	require.Nil(t, err)
+	require.EqualValues(t, 0, checkpoint.Height)
+}
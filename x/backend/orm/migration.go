@@ -0,0 +1,225 @@
+package orm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/okex/okexchain/x/backend/types"
+)
+
+const legacyTableSuffix = "_legacy"
+
+// decimalTable is a table whose prices & volumes were DOUBLE columns before, the column is checked for the legacy type
+type decimalTable struct {
+	model  interface{}
+	column string
+}
+
+func getDecimalTables() []decimalTable {
+	tables := []decimalTable{
+		{&types.MatchResult{}, "price"},
+		{&types.Deal{}, "price"},
+	}
+	for _, v := range types.GetAllKlineMap() {
+		tables = append(tables, decimalTable{types.MustNewKlineFactory(v, nil), "open"})
+	}
+	return tables
+}
+
+// migrateDecimalColumns migrates the tables with the legacy DOUBLE prices & volumes to the exact decimal columns.
+// Each legacy table is renamed, the new one is created & the rows are copied into it, the doubles are converted into
+// their shortest decimal strings by the database.
+// The steps of a table run in a transaction if the dialect supports transactional DDL. Otherwise a migration
+// interrupted after the rename leaves the legacy table behind, which is found & copied again at the next startup
+func (orm *ORM) migrateDecimalColumns() error {
+	for _, table := range getDecimalTables() {
+		tbName := orm.db.NewScope(table.model).TableName()
+		legacyTbName := tbName + legacyTableSuffix
+		if orm.db.HasTable(legacyTbName) {
+			orm.Debug(fmt.Sprintf("[backend] resuming the migration of %s to the decimal columns", tbName))
+			if err := orm.copyLegacyTable(orm.db, table.model, tbName, legacyTbName); err != nil {
+				return err
+			}
+			continue
+		}
+		legacy, err := orm.isLegacyDoubleColumn(tbName, table.column)
+		if err != nil {
+			return err
+		}
+		if !legacy {
+			continue
+		}
+
+		orm.Debug(fmt.Sprintf("[backend] migrating %s to the decimal columns", tbName))
+		err = orm.runMigration(func(db *gorm.DB) error {
+			if err := db.Exec(fmt.Sprintf("ALTER TABLE %s RENAME TO %s", tbName, legacyTbName)).Error; err != nil {
+				return err
+			}
+			return orm.copyLegacyTable(db, table.model, tbName, legacyTbName)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyColumnTable is a table whose primary key was extended by the column
+type keyColumnTable struct {
+	model  interface{}
+	column string
+}
+
+func getKeyColumnTables() []keyColumnTable {
+	return []keyColumnTable{
+		{&types.Deal{}, "sequence"},
+		{&types.RealizedPnL{}, "sequence"},
+	}
+}
+
+// migrateKeyColumns creates the tables missing the columns added to their primary keys again, as the auto migration
+// doesn't alter the primary key of an existing table. The rows are copied the same way as the decimal migration, with
+// the new column set to its default, so an interrupted one is resumed by migrateDecimalColumns
+func (orm *ORM) migrateKeyColumns() error {
+	for _, table := range getKeyColumnTables() {
+		tbName := orm.db.NewScope(table.model).TableName()
+		if !orm.db.HasTable(tbName) || orm.db.Dialect().HasColumn(tbName, table.column) {
+			continue
+		}
+
+		orm.Debug(fmt.Sprintf("[backend] migrating %s to the primary key with %s", tbName, table.column))
+		legacyTbName := tbName + legacyTableSuffix
+		err := orm.runMigration(func(db *gorm.DB) error {
+			if err := db.Exec(fmt.Sprintf("ALTER TABLE %s RENAME TO %s", tbName, legacyTbName)).Error; err != nil {
+				return err
+			}
+			return orm.copyLegacyTable(db, table.model, tbName, legacyTbName)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedLastIndexedHeight saves the checkpoint of the last indexed height of a database stored before the checkpoint was
+// kept, with the highest block stored in it. Otherwise the first start after the upgrade reindexes it from the genesis
+func (orm *ORM) seedLastIndexedHeight() error {
+	checkpoint, err := getCheckpoint(orm.db, types.CheckpointLastIndexedHeight)
+	if err != nil || checkpoint.Height > 0 {
+		return err
+	}
+
+	for _, model := range []interface{}{&types.MatchResult{}, &types.Deal{}, &types.Transaction{}, &types.SwapTrade{}} {
+		var height, timestamp int64
+		err = orm.db.Model(model).Select("COALESCE(MAX(block_height), 0), COALESCE(MAX(timestamp), 0)").
+			Row().Scan(&height, &timestamp)
+		if err != nil {
+			return err
+		}
+		if height > checkpoint.Height {
+			checkpoint.Height, checkpoint.Timestamp = height, timestamp
+		}
+	}
+	if checkpoint.Height == 0 {
+		return nil
+	}
+	orm.Debug(fmt.Sprintf("[backend] seeding the last indexed height with %d", checkpoint.Height))
+	return orm.db.Save(&checkpoint).Error
+}
+
+// runMigration runs the migration in a transaction, except on mysql whose DDL statements commit implicitly
+func (orm *ORM) runMigration(migrate func(db *gorm.DB) error) error {
+	if orm.db.Dialect().GetName() == EngineTypeMysql {
+		return migrate(orm.db)
+	}
+	tx := orm.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if err := migrate(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// copyLegacyTable creates the table again, copies the rows of the legacy table into it & drops the legacy one.
+// The rows copied by an interrupted migration are deleted first, the table isn't written by anything else
+// before the migration completes
+func (orm *ORM) copyLegacyTable(db *gorm.DB, model interface{}, tbName, legacyTbName string) error {
+	if err := dropSqliteIndexes(db, legacyTbName); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(model).Error; err != nil {
+		return err
+	}
+	if err := db.Exec(fmt.Sprintf("DELETE FROM %s", tbName)).Error; err != nil {
+		return err
+	}
+	columns, err := getColumns(db, legacyTbName)
+	if err != nil {
+		return err
+	}
+	copySQL := fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s", tbName, strings.Join(columns, ","),
+		strings.Join(columns, ","), legacyTbName)
+	if err = db.Exec(copySQL).Error; err != nil {
+		return err
+	}
+	return db.DropTable(legacyTbName).Error
+}
+
+// isLegacyDoubleColumn returns whether the column of the table is a floating point one
+func (orm *ORM) isLegacyDoubleColumn(tbName, column string) (bool, error) {
+	if !orm.db.HasTable(tbName) {
+		return false, nil
+	}
+	rows, err := orm.db.DB().Query(fmt.Sprintf("SELECT %s FROM %s LIMIT 1", column, tbName))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil || len(columnTypes) == 0 {
+		return false, err
+	}
+	switch strings.ToUpper(columnTypes[0].DatabaseTypeName()) {
+	case "DOUBLE", "REAL", "FLOAT", "FLOAT8":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// getColumns returns the names of the columns of the table
+func getColumns(db *gorm.DB, tbName string) ([]string, error) {
+	rows, err := db.CommonDB().Query(fmt.Sprintf("SELECT * FROM %s LIMIT 1", tbName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return rows.Columns()
+}
+
+// dropSqliteIndexes drops the indexes of the sqlite table, whose names are unique in the whole database & would
+// conflict with the ones of the table created again
+func dropSqliteIndexes(db *gorm.DB, tbName string) error {
+	if db.Dialect().GetName() != EngineTypeSqlite {
+		return nil
+	}
+	var indexes []struct {
+		Name string
+	}
+	err := db.Raw("SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL",
+		tbName).Scan(&indexes).Error
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if err = db.Exec(fmt.Sprintf("DROP INDEX %s", index.Name)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package orm
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/okex/okexchain/x/backend/types"
+	"github.com/stretchr/testify/require"
+)
+
+type legacyDeal struct {
+	Timestamp   int64   `gorm:"index;"`
+	BlockHeight int64   `gorm:"PRIMARY_KEY;type:bigint"`
+	OrderID     string  `gorm:"PRIMARY_KEY;type:varchar(30)"`
+	Sender      string  `gorm:"index;type:varchar(80)"`
+	Product     string  `gorm:"index;type:varchar(20)"`
+	Side        string  `gorm:"type:varchar(10)"`
+	Price       float64 `gorm:"type:DOUBLE"`
+	Quantity    float64 `gorm:"type:DOUBLE"`
+	Fee         string  `gorm:"type:varchar(20)"`
+	FeeReceiver string  `gorm:"index;type:varchar(80)"`
+}
+
+func (legacyDeal) TableName() string {
+	return "deals"
+}
+
+// unsequencedDeal is a deal keyed by the block height & the order id only
+type unsequencedDeal struct {
+	Timestamp   int64         `gorm:"index;"`
+	BlockHeight int64         `gorm:"PRIMARY_KEY;type:bigint"`
+	OrderID     string        `gorm:"PRIMARY_KEY;type:varchar(30)"`
+	Sender      string        `gorm:"index;type:varchar(80)"`
+	Product     string        `gorm:"index;type:varchar(20)"`
+	Side        string        `gorm:"type:varchar(10)"`
+	Price       types.Decimal `gorm:"type:varchar(40)"`
+	Quantity    types.Decimal `gorm:"type:varchar(40)"`
+	Fee         string        `gorm:"type:varchar(20)"`
+	FeeReceiver string        `gorm:"index;type:varchar(80)"`
+}
+
+func (unsequencedDeal) TableName() string {
+	return "deals"
+}
+
+type legacyKlineM1 struct {
+	Product   string  `gorm:"PRIMARY_KEY;type:varchar(20)"`
+	Timestamp int64   `gorm:"PRIMARY_KEY;"`
+	Open      float64 `gorm:"type:DOUBLE"`
+	Close     float64 `gorm:"type:DOUBLE"`
+	High      float64 `gorm:"type:DOUBLE"`
+	Low       float64 `gorm:"type:DOUBLE"`
+	Volume    float64 `gorm:"type:DOUBLE"`
+}
+
+func (legacyKlineM1) TableName() string {
+	return "kline_m1"
+}
+
+func TestMigrateDecimalColumns(t *testing.T) {
+	dbDir, dbName := "/tmp", fmt.Sprintf("testdb_legacy_%010d.db", time.Now().Unix())
+	dbPath := dbDir + "/" + dbName
+	defer DeleteDB(dbPath)
+
+	// the database created by the legacy version
+	db, err := gorm.Open(EngineTypeSqlite, dbPath)
+	require.Nil(t, err)
+	db.AutoMigrate(&legacyDeal{}, &legacyKlineM1{})
+	require.Nil(t, db.Create(&legacyDeal{Timestamp: 100, BlockHeight: 1, OrderID: "ID1", Product: types.TestTokenPair,
+		Side: types.BuyOrder, Price: 0.1, Quantity: 1.23456789}).Error)
+	require.Nil(t, db.Create(&legacyKlineM1{Product: types.TestTokenPair, Timestamp: 60, Open: 0.1, Close: 0.3,
+		High: 0.3, Low: 0.1, Volume: 100.5}).Error)
+	require.Nil(t, db.Close())
+
+	orm, err := NewSqlite3ORM(false, dbDir, dbName, nil)
+	require.Nil(t, err)
+	legacy, err := orm.isLegacyDoubleColumn("deals", "price")
+	require.Nil(t, err)
+	require.False(t, legacy)
+	require.False(t, orm.db.HasTable("deals"+legacyTableSuffix))
+
+	deals, total := orm.GetDeals("", types.TestTokenPair, "", 0, 0, 0, 10)
+	require.Equal(t, 1, total)
+	require.Equal(t, "0.1", deals[0].Price.String())
+	require.Equal(t, "1.23456789", deals[0].Quantity.String())
+
+	var klines []types.KlineM1
+	require.Nil(t, orm.GetLatestKlinesByProduct(types.TestTokenPair, 10, 0, &klines))
+	require.Equal(t, 1, len(klines))
+	require.Equal(t, "0.3", klines[0].Close.String())
+	require.Equal(t, "100.5", klines[0].Volume.String())
+
+	// the new rows are exact, and the migrated tables are left alone
+	require.Nil(t, orm.Close())
+	orm, err = NewSqlite3ORM(false, dbDir, dbName, nil)
+	require.Nil(t, err)
+	_, err = orm.AddDeals([]*types.Deal{{Timestamp: 200, BlockHeight: 2, OrderID: "ID2", Product: types.TestTokenPair,
+		Side: types.BuyOrder, Price: types.MustNewDecimalFromStr("0.30000001"),
+		Quantity: types.MustNewDecimalFromStr("12345678901.12345678")}})
+	require.Nil(t, err)
+	deals, total = orm.GetDeals("", types.TestTokenPair, "", 0, 0, 0, 10)
+	require.Equal(t, 2, total)
+	require.Equal(t, "0.30000001", deals[0].Price.String())
+	require.Equal(t, "12345678901.12345678", deals[0].Quantity.String())
+	require.Nil(t, orm.Close())
+}
+
+func TestResumeInterruptedMigration(t *testing.T) {
+	dbDir, dbName := "/tmp", fmt.Sprintf("testdb_interrupted_%010d.db", time.Now().Unix())
+	dbPath := dbDir + "/" + dbName
+	defer DeleteDB(dbPath)
+
+	db, err := gorm.Open(EngineTypeSqlite, dbPath)
+	require.Nil(t, err)
+	db.AutoMigrate(&legacyDeal{})
+	for i := int64(1); i <= 2; i++ {
+		require.Nil(t, db.Create(&legacyDeal{Timestamp: 100 * i, BlockHeight: i, OrderID: fmt.Sprintf("ID%d", i),
+			Product: types.TestTokenPair, Side: types.BuyOrder, Price: 0.1, Quantity: 1.5}).Error)
+	}
+	// the migration is interrupted after copying the rows, before dropping the legacy table
+	require.Nil(t, db.Exec("ALTER TABLE deals RENAME TO deals"+legacyTableSuffix).Error)
+	require.Nil(t, dropSqliteIndexes(db, "deals"+legacyTableSuffix))
+	require.Nil(t, db.AutoMigrate(&types.Deal{}).Error)
+	require.Nil(t, db.Exec("INSERT INTO deals (timestamp, block_height, order_id, product, side, price, quantity) "+
+		"SELECT timestamp, block_height, order_id, product, side, price, quantity FROM deals"+legacyTableSuffix+
+		" WHERE block_height = 1").Error)
+	require.Nil(t, db.Close())
+
+	// the copy is resumed at the next startup, without duplicating the rows copied
+	orm, err := NewSqlite3ORM(false, dbDir, dbName, nil)
+	require.Nil(t, err)
+	require.False(t, orm.db.HasTable("deals"+legacyTableSuffix))
+	deals, total := orm.GetDeals("", types.TestTokenPair, "", 0, 0, 0, 10)
+	require.Equal(t, 2, total)
+	require.Equal(t, "0.1", deals[0].Price.String())
+	require.Equal(t, "1.5", deals[1].Quantity.String())
+	require.Nil(t, orm.Close())
+}
+
+func TestMigrateKeyColumns(t *testing.T) {
+	dbDir, dbName := "/tmp", fmt.Sprintf("testdb_unsequenced_%010d.db", time.Now().Unix())
+	dbPath := dbDir + "/" + dbName
+	defer DeleteDB(dbPath)
+
+	db, err := gorm.Open(EngineTypeSqlite, dbPath)
+	require.Nil(t, err)
+	db.AutoMigrate(&unsequencedDeal{})
+	require.Nil(t, db.Create(&unsequencedDeal{Timestamp: 100, BlockHeight: 1, OrderID: "ID1-1",
+		Product: types.TestTokenPair, Side: types.BuyOrder, Price: types.NewDecimal(1),
+		Quantity: types.NewDecimal(2)}).Error)
+	require.Nil(t, db.Close())
+
+	orm, err := NewSqlite3ORM(false, dbDir, dbName, nil)
+	require.Nil(t, err)
+	require.True(t, orm.db.Dialect().HasColumn("deals", "sequence"))
+	require.False(t, orm.db.HasTable("deals"+legacyTableSuffix))
+
+	// the deals of an order filled twice in a block are kept apart by the sequence
+	_, err = orm.AddDeals([]*types.Deal{
+		{Timestamp: 200, BlockHeight: 2, OrderID: "ID2-1", Sequence: 0, Product: types.TestTokenPair,
+			Side: types.BuyOrder, Price: types.NewDecimal(1), Quantity: types.NewDecimal(1)},
+		{Timestamp: 200, BlockHeight: 2, OrderID: "ID2-1", Sequence: 2, Product: types.TestTokenPair,
+			Side: types.BuyOrder, Price: types.NewDecimal(2), Quantity: types.NewDecimal(1)},
+	})
+	require.Nil(t, err)
+	deals, total := orm.GetDeals("", types.TestTokenPair, "", 0, 0, 0, 10)
+	require.Equal(t, 3, total)
+	require.Equal(t, int64(0), deals[2].Sequence)
+	require.Nil(t, orm.Close())
+}
+
+func TestSeedLastIndexedHeight(t *testing.T) {
+	dbDir, dbName := "/tmp", fmt.Sprintf("testdb_unindexed_%010d.db", time.Now().Unix())
+	dbPath := dbDir + "/" + dbName
+	defer DeleteDB(dbPath)
+
+	// a database stored before the checkpoint was kept
+	db, err := gorm.Open(EngineTypeSqlite, dbPath)
+	require.Nil(t, err)
+	db.AutoMigrate(&unsequencedDeal{})
+	require.Nil(t, db.Create(&unsequencedDeal{Timestamp: 500, BlockHeight: 5, OrderID: "ID5-1",
+		Product: types.TestTokenPair, Side: types.BuyOrder, Price: types.NewDecimal(1),
+		Quantity: types.NewDecimal(2)}).Error)
+	require.Nil(t, db.Close())
+
+	orm, err := NewSqlite3ORM(false, dbDir, dbName, nil)
+	require.Nil(t, err)
+	checkpoint, err := orm.GetCheckpoint(types.CheckpointLastIndexedHeight)
+	require.Nil(t, err)
+	require.EqualValues(t, 5, checkpoint.Height)
+	require.EqualValues(t, 500, checkpoint.Timestamp)
+
+	// the checkpoint saved is kept at the next start
+	_, err = orm.StoreBlock(&types.BlockData{Height: 7, Timestamp: 700})
+	require.Nil(t, err)
+	require.Nil(t, orm.Close())
+	orm, err = NewSqlite3ORM(false, dbDir, dbName, nil)
+	require.Nil(t, err)
+	checkpoint, err = orm.GetCheckpoint(types.CheckpointLastIndexedHeight)
+	require.Nil(t, err)
+	require.EqualValues(t, 7, checkpoint.Height)
+	require.Nil(t, orm.Close())
+
+	// an empty database has nothing indexed
+	emptyORM, emptyDBPath := MockSqlite3ORM()
+	defer DeleteDB(emptyDBPath)
+	checkpoint, err = emptyORM.GetCheckpoint(types.CheckpointLastIndexedHeight)
+	require.Nil(t, err)
+	require.EqualValues(t, 0, checkpoint.Height)
+}
@@ -6,17 +6,17 @@ import (
 	"path/filepath"
 	"runtime/debug"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/shopspring/decimal"
-
 	okexchaincfg "github.com/cosmos/cosmos-sdk/server/config"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/mysql"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
 	"github.com/okex/okexchain/x/backend/types"
 	"github.com/okex/okexchain/x/token"
@@ -26,8 +26,9 @@ import (
 
 // nolint
 const (
-	EngineTypeSqlite = okexchaincfg.BackendOrmEngineTypeSqlite
-	EngineTypeMysql  = okexchaincfg.BackendOrmEngineTypeMysql
+	EngineTypeSqlite   = okexchaincfg.BackendOrmEngineTypeSqlite
+	EngineTypeMysql    = okexchaincfg.BackendOrmEngineTypeMysql
+	EngineTypePostgres = "postgres"
 )
 
 // nolint
@@ -77,7 +78,7 @@ func New(enableLog bool, engineInfo *OrmEngineInfo, logger *log.Logger) (m *ORM,
 				orm.Debug(fmt.Sprintf("%s created", dbDir))
 			}
 		}
-	case EngineTypeMysql:
+	case EngineTypeMysql, EngineTypePostgres:
 	default:
 
 	}
@@ -95,11 +96,25 @@ func New(enableLog bool, engineInfo *OrmEngineInfo, logger *log.Logger) (m *ORM,
 	orm.singleEntryLock = new(sync.Mutex)
 	orm.maxBlockTimestampMutex = new(sync.RWMutex)
 	orm.db.LogMode(enableLog)
+	if err = orm.migrateDecimalColumns(); err != nil {
+		panic(fmt.Errorf("failed to migrate the decimal columns, error: %+v", err))
+	}
+	if err = orm.migrateKeyColumns(); err != nil {
+		panic(fmt.Errorf("failed to migrate the primary key columns, error: %+v", err))
+	}
 	orm.db.AutoMigrate(&types.MatchResult{})
 	orm.db.AutoMigrate(&types.Deal{})
 	orm.db.AutoMigrate(&token.FeeDetail{})
 	orm.db.AutoMigrate(&types.Order{})
 	orm.db.AutoMigrate(&types.Transaction{})
+	orm.db.AutoMigrate(&types.Checkpoint{})
+	orm.db.AutoMigrate(&types.SwapTrade{})
+	orm.db.AutoMigrate(&types.BalanceSnapshot{})
+	orm.db.AutoMigrate(&types.CostLots{})
+	orm.db.AutoMigrate(&types.RealizedPnL{})
+	if err = orm.seedLastIndexedHeight(); err != nil {
+		panic(fmt.Errorf("failed to seed the last indexed height, error: %+v", err))
+	}
 
 	allKlinesMap := types.GetAllKlineMap()
 	for _, v := range allKlinesMap {
@@ -282,29 +297,7 @@ func (orm *ORM) getLatestDeals(product string, limit int) ([]types.Deal, error)
 // nolint
 func (orm *ORM) GetDeals(address, product, side string, startTime, endTime int64, offset, limit int) ([]types.Deal, int) {
 	var deals []types.Deal
-	query := orm.db.Model(types.Deal{})
-
-	if startTime == 0 && endTime == 0 {
-		endTime = time.Now().Unix()
-	}
-
-	if address != "" {
-		query = query.Where("sender = ?", address)
-	}
-	if product != "" {
-		query = query.Where("product = ?", product)
-	}
-
-	if side != "" {
-		query = query.Where("side = ?", side)
-	}
-
-	if startTime > 0 {
-		query = query.Where("timestamp >= ?", startTime)
-	}
-	if endTime > 0 {
-		query = query.Where("timestamp < ?", endTime)
-	}
+	query := whereTrades(orm.db.Model(types.Deal{}), address, product, side, startTime, endTime)
 	var total int
 	query.Count(&total)
 	if offset >= total {
@@ -461,31 +454,57 @@ func (orm *ORM) getMergeResultMaxTimestamp() int64 {
 
 }
 
+// klineRecord is a price & a quantity traded, which klines are aggregated from
+type klineRecord struct {
+	Product   string
+	Timestamp int64
+	Price     types.Decimal
+	Quantity  types.Decimal
+}
+
 // nolint
 type IKline1MDataSource interface {
 	getDataSourceMinTimestamp() int64
-	getMaxMinSumByGroupSQL(startTS, endTS int64) string
-	getOpenClosePrice(startTS, endTS int64, product string) (float64, float64)
+	getKlineRecords(startTS, endTS int64) ([]klineRecord, error)
 }
 
-// nolint
-type DealDataSource struct {
-	orm *ORM
+// getKlineRecords returns the prices & the quantities of the table in [startTS, endTS), in the order of the time
+func (orm *ORM) getKlineRecords(tbName, condition string, startTS, endTS int64) ([]klineRecord, error) {
+	var records []klineRecord
+	query := orm.db.Table(tbName).Select("product, timestamp, price, quantity").
+		Where("Timestamp >= ? and Timestamp < ?", startTS, endTS)
+	if condition != "" {
+		query = query.Where(condition)
+	}
+	r := query.Order("Timestamp asc, block_height asc").Scan(&records)
+	return records, r.Error
 }
 
-func (dm *DealDataSource) getDataSourceMinTimestamp() int64 {
-	return dm.orm.getDealsMinTimestamp()
+// getDealKlineRecords returns the prices & the quantities of the deals of the product in [startTS, endTS), in the
+// order of the fills, the deals of all the products are returned if product is empty. A fill makes a deal of each
+// side at the same price & quantity, so only the buy ones are taken
+func (orm *ORM) getDealKlineRecords(product string, startTS, endTS int64) ([]klineRecord, error) {
+	var records []klineRecord
+	query := orm.db.Table("deals").Select("product, timestamp, price, quantity").
+		Where("side = ? and timestamp >= ? and timestamp < ?", types.BuyOrder, startTS, endTS)
+	if product != "" {
+		query = query.Where("product = ?", product)
+	}
+	r := query.Order("timestamp asc, block_height asc, sequence asc").Scan(&records)
+	return records, r.Error
 }
 
-func (dm *DealDataSource) getMaxMinSumByGroupSQL(startTS, endTS int64) string {
-	sql := fmt.Sprintf("select product, sum(Quantity) as quantity, max(Price) as high, min(Price) as low, count(price) as cnt from deals "+
-		"where Timestamp >= %d and Timestamp < %d and Side = 'BUY' group by product", startTS, endTS)
-	return sql
+// DealDataSource is the kline data source of the deals, which keeps the price of each fill
+type DealDataSource struct {
+	Orm *ORM
 }
 
-func (dm *DealDataSource) getOpenClosePrice(startTS, endTS int64, product string) (float64, float64) {
-	openDeal, closeDeal := dm.orm.getOpenCloseDeals(startTS, endTS, product)
-	return openDeal.Price, closeDeal.Price
+func (dm *DealDataSource) getDataSourceMinTimestamp() int64 {
+	return dm.Orm.getDealsMinTimestamp()
+}
+
+func (dm *DealDataSource) getKlineRecords(startTS, endTS int64) ([]klineRecord, error) {
+	return dm.Orm.getDealKlineRecords("", startTS, endTS)
 }
 
 // nolint
@@ -497,15 +516,27 @@ func (dm *MergeResultDataSource) getDataSourceMinTimestamp() int64 {
 	return dm.Orm.getMergeResultMinTimestamp()
 }
 
-func (dm *MergeResultDataSource) getMaxMinSumByGroupSQL(startTS, endTS int64) string {
-	sql := fmt.Sprintf("select product, sum(Quantity) as quantity, max(Price) as high, min(Price) as low, count(price) as cnt from match_results "+
-		"where Timestamp >= %d and Timestamp < %d group by product", startTS, endTS)
-	return sql
+func (dm *MergeResultDataSource) getKlineRecords(startTS, endTS int64) ([]klineRecord, error) {
+	return dm.Orm.getKlineRecords("match_results", "", startTS, endTS)
 }
 
-func (dm *MergeResultDataSource) getOpenClosePrice(startTS, endTS int64, product string) (float64, float64) {
-	openDeal, closeDeal := dm.Orm.getOpenCloseDeals(startTS, endTS, product)
-	return openDeal.Price, closeDeal.Price
+// aggregateKlineRecords aggregates the records in the order of the time into a BaseKline of each product
+func aggregateKlineRecords(records []klineRecord, timestamp int64) map[string]*types.BaseKline {
+	baseKlines := map[string]*types.BaseKline{}
+	for _, record := range records {
+		b, ok := baseKlines[record.Product]
+		if !ok {
+			baseKlines[record.Product] = &types.BaseKline{
+				Product: record.Product, Timestamp: timestamp, Open: record.Price, Close: record.Price,
+				High: record.Price, Low: record.Price, Volume: record.Quantity}
+			continue
+		}
+		b.Close = record.Price
+		b.High = types.MaxDecimal(b.High, record.Price)
+		b.Low = types.MinDecimal(b.Low, record.Price)
+		b.Volume = b.Volume.Add(record.Quantity)
+	}
+	return baseKlines
 }
 
 // CreateKline1M batch insert into Kline1M
@@ -543,40 +574,13 @@ func (orm *ORM) CreateKline1M(startTS, endTS int64, dataSource IKline1MDataSourc
 	nextTime := anchorStartTime.Add(time.Minute)
 	nextTimeStamp := nextTime.Unix()
 	for nextTimeStamp <= endTS {
-		sql := dataSource.getMaxMinSumByGroupSQL(anchorStartTime.Unix(), nextTime.Unix())
-		orm.Debug(fmt.Sprintf("CreateKline1M sql:%s", sql))
-		rows, err := orm.db.Raw(sql).Rows()
-
-		if rows != nil && err == nil {
-			for rows.Next() {
-				var product string
-				var quantity, high, low float64
-				var cnt int
-
-				if err = rows.Scan(&product, &quantity, &high, &low, &cnt); err != nil {
-					orm.Error(fmt.Sprintf("CreateKline1M failed to execute scan result, error:%s sql:%s", err.Error(), sql))
-				}
-				if cnt > 0 {
-					openPrice, closePrice := dataSource.getOpenClosePrice(anchorStartTime.Unix(), nextTime.Unix(), product)
-
-					b := types.BaseKline{
-						Product: product, High: high, Low: low, Volume: quantity,
-						Timestamp: anchorStartTime.Unix(), Open: openPrice, Close: closePrice}
-					k1min := types.NewKlineM1(&b)
-
-					klines := productKlines[product]
-					if klines == nil {
-						klines = []types.KlineM1{*k1min}
-					} else {
-						klines = append(klines, *k1min)
-					}
-					productKlines[product] = klines
-				}
-			}
-
-			if err = rows.Close(); err != nil {
-				orm.Error(fmt.Sprintf("CreateKline1M failed to execute close rows, error:%s", err.Error()))
-			}
+		records, err := dataSource.getKlineRecords(anchorStartTime.Unix(), nextTime.Unix())
+		if err != nil {
+			orm.Error(fmt.Sprintf("CreateKline1M failed to get the deals in [%d, %d), error:%s",
+				anchorStartTime.Unix(), nextTime.Unix(), err.Error()))
+		}
+		for product, b := range aggregateKlineRecords(records, anchorStartTime.Unix()) {
+			productKlines[product] = append(productKlines[product], *types.NewKlineM1(b))
 		}
 
 		anchorStartTime = nextTime
@@ -783,48 +787,28 @@ func (orm *ORM) MergeKlineM1(startTS, endTS int64, destKline types.IKline) (
 	nextTime := anchorStartTime.Add(interval)
 	for nextTime.Unix() <= anchorEndTime {
 
-		sql := fmt.Sprintf("select %d, product, sum(volume) as volume, max(high) as high, min(low) as low, count(*) as cnt from %s "+
-			"where Timestamp >= %d and Timestamp < %d group by product", anchorStartTime.Unix(), klineM1.(types.IKline).GetTableName(), anchorStartTime.Unix(), nextTime.Unix())
-		orm.Debug(fmt.Sprintf("[backend] MergeKlineM1 KlinesMX-#%d# sql=%s",
-			destKline.GetFreqInSecond(), sql))
-		rows, err := orm.db.Raw(sql).Rows()
-
-		if rows != nil && err == nil {
-			for rows.Next() {
-				var product string
-				var quantity, high, low float64
-				var cnt int
-				var ts int64
-
-				if err = rows.Scan(&ts, &product, &quantity, &high, &low, &cnt); err != nil {
-					orm.Error("failed to execute scan result, error:" + err.Error())
-				}
-				if cnt > 0 {
-
-					openKline := types.MustNewKlineFactory(klineM1.(types.IKline).GetTableName(), nil)
-					closeKline := types.MustNewKlineFactory(klineM1.(types.IKline).GetTableName(), nil)
-					err = orm.getOpenCloseKline(anchorStartTime.Unix(), nextTime.Unix(), product, openKline, closeKline)
-					if err != nil {
-						orm.Error(fmt.Sprintf("failed to get open and close kline, error: %s", err.Error()))
-					}
-					b := types.BaseKline{
-						Product: product, High: high, Low: low, Volume: quantity, Timestamp: anchorStartTime.Unix(),
-						Open: openKline.(types.IKline).GetOpen(), Close: closeKline.(types.IKline).GetClose()}
-
-					newDestK := types.MustNewKlineFactory(destKline.GetTableName(), &b)
-
-					klines := productKlines[product]
-					if klines == nil {
-						klines = []interface{}{newDestK}
-					} else {
-						klines = append(klines, newDestK)
-					}
-					productKlines[product] = klines
-				}
-			}
-			if err = rows.Close(); err != nil {
-				orm.Error("failed to execute close rows, error:" + err.Error())
+		var klinesM1 []types.KlineM1
+		err = orm.db.Where("Timestamp >= ? and Timestamp < ?", anchorStartTime.Unix(), nextTime.Unix()).
+			Order("Timestamp asc").Find(&klinesM1).Error
+		if err != nil {
+			orm.Error(fmt.Sprintf("[backend] MergeKlineM1 KlinesMX-#%d# failed to get klines, error: %s",
+				destKline.GetFreqInSecond(), err.Error()))
+		}
+		baseKlines := map[string]*types.BaseKline{}
+		for _, k := range klinesM1 {
+			b, ok := baseKlines[k.Product]
+			if !ok {
+				b = &types.BaseKline{Product: k.Product, Timestamp: anchorStartTime.Unix(), Open: k.Open,
+					High: k.High, Low: k.Low, Volume: types.ZeroDecimal()}
+				baseKlines[k.Product] = b
 			}
+			b.Close = k.Close
+			b.High = types.MaxDecimal(b.High, k.High)
+			b.Low = types.MinDecimal(b.Low, k.Low)
+			b.Volume = b.Volume.Add(k.Volume)
+		}
+		for product, b := range baseKlines {
+			productKlines[product] = append(productKlines[product], types.MustNewKlineFactory(destKline.GetTableName(), b))
 		}
 
 		anchorStartTime = nextTime
@@ -963,37 +947,29 @@ func (orm *ORM) RefreshTickers(startTS, endTS int64, productList []string) (m ma
 
 		// 3.2 Do iklines sort desc by timestamp.
 
-		allVolume, lowest, highest := 0.0, 0.0, 0.0
+		allVolume, lowest, highest := types.ZeroDecimal(), types.ZeroDecimal(), types.ZeroDecimal()
 		matchResults := matchResultMap[p]
 
 		if len(iklines) > 0 {
 			sort.Sort(iklines)
-			allVolume, lowest, highest = 0.0, iklines[0].GetLow(), iklines[0].GetHigh()
+			lowest, highest = iklines[0].GetLow(), iklines[0].GetHigh()
 			for _, k := range iklines {
 				orm.Debug(fmt.Sprintf("RefreshTickers, Handled Kline(%s): %s", k.GetTableName(), k.PrettyTimeString()))
 
-				allVolume += k.GetVolume()
-				if k.GetHigh() > highest {
-					highest = k.GetHigh()
-				}
-				if k.GetLow() < lowest {
-					lowest = k.GetLow()
-				}
+				allVolume = allVolume.Add(k.GetVolume())
+				highest = types.MaxDecimal(highest, k.GetHigh())
+				lowest = types.MinDecimal(lowest, k.GetLow())
 			}
 		} else {
 			if len(matchResults) > 0 {
-				allVolume, lowest, highest = 0.0, matchResults[0].Price, matchResults[0].Price
+				lowest, highest = matchResults[0].Price, matchResults[0].Price
 			}
 		}
 
 		for _, match := range matchResults {
-			allVolume += match.Quantity
-			if match.Price > highest {
-				highest = match.Price
-			}
-			if match.Price < lowest {
-				lowest = match.Price
-			}
+			allVolume = allVolume.Add(match.Quantity)
+			highest = types.MaxDecimal(highest, match.Price)
+			lowest = types.MinDecimal(lowest, match.Price)
 		}
 
 		if len(iklines) == 0 && len(matchResults) == 0 {
@@ -1028,11 +1004,11 @@ func (orm *ORM) RefreshTickers(startTS, endTS int64, productList []string) (m ma
 		t.Low = lowest
 		t.Symbol = p
 		t.Product = p
-		dClose := decimal.NewFromFloat(t.Close)
-		dOpen := decimal.NewFromFloat(t.Open)
-		dChange := dClose.Sub(dOpen)
-		t.Change, _ = dChange.Float64()
-		t.ChangePercentage = fmt.Sprintf("%.2f", t.Change*100/t.Open) + "%"
+		t.Change = t.Close.Sub(t.Open)
+		t.ChangePercentage = "0.00%"
+		if !t.Open.IsZero() {
+			t.ChangePercentage = t.Change.Mul(types.NewDecimal(100)).Div(t.Open).StringFixed(2) + "%"
+		}
 		t.Price = t.Close
 		t.Timestamp = endTS
 		tickerMap[p] = &t
@@ -1216,15 +1192,36 @@ func (orm *ORM) GetTransactionList(address string, txType, startTime, endTime in
 	return txs, total
 }
 
-// BatchInsertOrUpdate return map mean success or fail
+// batchInsertSQL returns the sql inserting the rows of values into the table, with the identifiers quoted by the dialect
+func (orm *ORM) batchInsertSQL(tbName string, columns []string, values []string) string {
+	dialect := orm.db.Dialect()
+	quotedColumns := make([]string, 0, len(columns))
+	for _, column := range columns {
+		quotedColumns = append(quotedColumns, dialect.Quote(column))
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", dialect.Quote(tbName), strings.Join(quotedColumns, ","),
+		strings.Join(values, ","))
+}
+
+// BatchInsertOrUpdate return map mean success or fail, nothing is stored if it fails
 func (orm *ORM) BatchInsertOrUpdate(newOrders []*types.Order, updatedOrders []*types.Order, deals []*types.Deal, mrs []*types.MatchResult, feeDetails []*token.FeeDetail, trxs []*types.Transaction) (resultMap map[string]int, err error) {
 
 	orm.singleEntryLock.Lock()
 	defer orm.singleEntryLock.Unlock()
 
 	trx := orm.db.Begin()
-	defer orm.deferRollbackTx(trx, err)
+	defer func() {
+		orm.deferRollbackTx(trx, err)
+	}()
+
+	if resultMap, err = orm.batchInsertOrUpdate(trx, newOrders, updatedOrders, deals, mrs, feeDetails, trxs,
+		nil); err != nil {
+		return resultMap, err
+	}
+	return resultMap, trx.Commit().Error
+}
 
+func (orm *ORM) batchInsertOrUpdate(trx *gorm.DB, newOrders []*types.Order, updatedOrders []*types.Order, deals []*types.Deal, mrs []*types.MatchResult, feeDetails []*token.FeeDetail, trxs []*types.Transaction, swapTrades []*types.SwapTrade) (resultMap map[string]int, err error) {
 	resultMap = map[string]int{}
 	resultMap["newOrders"] = 0
 	resultMap["updatedOrders"] = 0
@@ -1232,21 +1229,22 @@ func (orm *ORM) BatchInsertOrUpdate(newOrders []*types.Order, updatedOrders []*t
 	resultMap["feeDetails"] = 0
 	resultMap["transactions"] = 0
 	resultMap["matchResults"] = 0
+	resultMap["swapTrades"] = 0
 
 	// FLT. 20190909.  BatchInsert is faster than insert one by one.
 	// 1. Batch Insert Orders.
 	orderVItems := []string{}
 	for _, order := range newOrders {
-		vItem := fmt.Sprintf("('%s','%s','%s','%s','%s','%s','%s','%d','%s','%s','%d')",
+		vItem := fmt.Sprintf("('%s','%s','%s','%s','%s','%s','%s','%d','%s','%s','%d','%s','%s')",
 			order.TxHash, order.OrderID, order.Sender, order.Product, order.Side, order.Price, order.Quantity,
-			order.Status, order.FilledAvgPrice, order.RemainQuantity, order.Timestamp)
+			order.Status, order.FilledAvgPrice, order.RemainQuantity, order.Timestamp, order.OrderType, order.CloseReason)
 		orderVItems = append(orderVItems, vItem)
 
 	}
 	if len(orderVItems) > 0 {
-		orderValueSQL := strings.Join(orderVItems, ", ")
-		orderSQL := fmt.Sprintf("INSERT INTO `orders` (`tx_hash`,`order_id`,`sender`,`product`,`side`,`price`,"+
-			"`quantity`,`status`,`filled_avg_price`,`remain_quantity`,`timestamp`) VALUES %s", orderValueSQL)
+		orderSQL := orm.batchInsertSQL("orders", []string{"tx_hash", "order_id", "sender", "product", "side", "price",
+			"quantity", "status", "filled_avg_price", "remain_quantity", "timestamp", "order_type", "close_reason"},
+			orderVItems)
 		ret := trx.Exec(orderSQL)
 		if ret.Error != nil {
 			return resultMap, ret.Error
@@ -1276,13 +1274,14 @@ func (orm *ORM) BatchInsertOrUpdate(newOrders []*types.Order, updatedOrders []*t
 	// 2. Batch Insert Deals
 	dealVItems := []string{}
 	for _, d := range deals {
-		vItem := fmt.Sprintf("('%d','%d','%s','%s','%s','%s','%f','%f','%s', '%s')",
-			d.Timestamp, d.BlockHeight, d.OrderID, d.Sender, d.Product, d.Side, d.Price, d.Quantity, d.Fee, d.FeeReceiver)
+		vItem := fmt.Sprintf("('%d','%d','%s','%d','%s','%s','%s','%s','%s','%s', '%s')",
+			d.Timestamp, d.BlockHeight, d.OrderID, d.Sequence, d.Sender, d.Product, d.Side, d.Price, d.Quantity, d.Fee,
+			d.FeeReceiver)
 		dealVItems = append(dealVItems, vItem)
 	}
 	if len(dealVItems) > 0 {
-		dealsSQL := fmt.Sprintf("INSERT INTO `deals` (`timestamp`,`block_height`,`order_id`,`sender`,`product`,`side`,`price`,`quantity`,`fee`,`fee_receiver`) "+
-			"VALUES %s", strings.Join(dealVItems, ","))
+		dealsSQL := orm.batchInsertSQL("deals", []string{"timestamp", "block_height", "order_id", "sequence", "sender",
+			"product", "side", "price", "quantity", "fee", "fee_receiver"}, dealVItems)
 		ret := trx.Exec(dealsSQL)
 		if ret.Error != nil {
 			return resultMap, ret.Error
@@ -1294,13 +1293,13 @@ func (orm *ORM) BatchInsertOrUpdate(newOrders []*types.Order, updatedOrders []*t
 	// 3. Batch Insert Transactions.
 	trxVItems := []string{}
 	for _, t := range trxs {
-		vItem := fmt.Sprintf("('%s','%d','%s','%s','%d','%s','%s','%d')",
-			t.TxHash, t.Type, t.Address, t.Symbol, t.Side, t.Quantity, t.Fee, t.Timestamp)
+		vItem := fmt.Sprintf("('%s','%d','%s','%s','%d','%s','%s','%d','%d')",
+			t.TxHash, t.Type, t.Address, t.Symbol, t.Side, t.Quantity, t.Fee, t.Timestamp, t.BlockHeight)
 		trxVItems = append(trxVItems, vItem)
 	}
 	if len(trxVItems) > 0 {
-		trxSQL := fmt.Sprintf("INSERT INTO `transactions` (`tx_hash`,`type`,`address`,`symbol`,`side`,`quantity`,`fee`,`timestamp`) "+
-			"VALUES %s", strings.Join(trxVItems, ", "))
+		trxSQL := orm.batchInsertSQL("transactions", []string{"tx_hash", "type", "address", "symbol", "side",
+			"quantity", "fee", "timestamp", "block_height"}, trxVItems)
 		ret := trx.Exec(trxSQL)
 		if ret.Error != nil {
 			return resultMap, ret.Error
@@ -1313,11 +1312,13 @@ func (orm *ORM) BatchInsertOrUpdate(newOrders []*types.Order, updatedOrders []*t
 	// 4. Batch Insert Fee Details.
 	fdVItems := []string{}
 	for _, fd := range feeDetails {
-		vItem := fmt.Sprintf("('%s','%s','%s','%d')", fd.Address, fd.Fee, fd.FeeType, fd.Timestamp)
+		vItem := fmt.Sprintf("('%s','%s','%s','%s','%d','%s','%d')", fd.Address, fd.Receiver, fd.Fee, fd.FeeType,
+			fd.Timestamp, fd.FeeTier, fd.BlockHeight)
 		fdVItems = append(fdVItems, vItem)
 	}
 	if len(fdVItems) > 0 {
-		fdSQL := fmt.Sprintf("INSERT INTO `fee_details` (`address`,`fee`,`fee_type`,`timestamp`) VALUES %s", strings.Join(fdVItems, ","))
+		fdSQL := orm.batchInsertSQL("fee_details", []string{"address", "receiver", "fee", "fee_type", "timestamp",
+			"fee_tier", "block_height"}, fdVItems)
 		ret := trx.Exec(fdSQL)
 		if ret.Error != nil {
 			return resultMap, ret.Error
@@ -1326,11 +1327,41 @@ func (orm *ORM) BatchInsertOrUpdate(newOrders []*types.Order, updatedOrders []*t
 		}
 	}
 
-	trx.Commit()
+	// 5. Batch Insert Swap Trades.
+	stVItems := []string{}
+	for _, st := range swapTrades {
+		vItem := fmt.Sprintf("('%d','%d','%d','%s','%s','%s','%s','%s','%s','%s','%s','%s')", st.Timestamp,
+			st.BlockHeight, st.Sequence, st.TxHash, st.Pool, st.Product, st.Sender, st.Side, st.Price, st.Quantity,
+			st.QuoteQuantity, st.Fee)
+		stVItems = append(stVItems, vItem)
+	}
+	if len(stVItems) > 0 {
+		stSQL := orm.batchInsertSQL("swap_trades", []string{"timestamp", "block_height", "sequence", "tx_hash", "pool",
+			"product", "sender", "side", "price", "quantity", "quote_quantity", "fee"}, stVItems)
+		ret := trx.Exec(stSQL)
+		if ret.Error != nil {
+			return resultMap, ret.Error
+		} else {
+			resultMap["swapTrades"] += len(stVItems)
+		}
+	}
 
 	return resultMap, nil
 }
 
+// whereTimestampCursors filters the query by the pagination cursors of V2, which are the timestamps in seconds. The
+// cursors are bound as integers, which are compared with the timestamp columns by all the dialects. The invalid ones
+// are ignored
+func whereTimestampCursors(query *gorm.DB, after, before string) *gorm.DB {
+	if ts, err := strconv.ParseInt(after, 10, 64); err == nil {
+		query = query.Where("timestamp > ?", ts)
+	}
+	if ts, err := strconv.ParseInt(before, 10, 64); err == nil {
+		query = query.Where("timestamp < ?", ts)
+	}
+	return query
+}
+
 // nolint
 func (orm *ORM) GetOrderListV2(instrumentID string, address string, side string, open bool, after string, before string, limit int) []types.Order {
 	var orders []types.Order
@@ -1341,13 +1372,7 @@ func (orm *ORM) GetOrderListV2(instrumentID string, address string, side string,
 		query = query.Where("product = ? ", instrumentID)
 	}
 
-	if after != "" {
-		query = query.Where("timestamp > ? ", after)
-	}
-
-	if before != "" {
-		query = query.Where("timestamp < ? ", before)
-	}
+	query = whereTimestampCursors(query, after, before)
 
 	if address != "" {
 		query = query.Where("sender = ? ", address)
@@ -1390,12 +1415,7 @@ func (orm *ORM) GetMatchResultsV2(instrumentID string, after string, before stri
 		query = query.Where("product = ?", instrumentID)
 	}
 
-	if after != "" {
-		query = query.Where("timestamp > ?", after)
-	}
-	if before != "" {
-		query = query.Where("timestamp < ?", before)
-	}
+	query = whereTimestampCursors(query, after, before)
 
 	query.Order("timestamp desc").Limit(limit).Find(&matchResults)
 	return matchResults
@@ -1405,12 +1425,7 @@ func (orm *ORM) GetMatchResultsV2(instrumentID string, after string, before stri
 func (orm *ORM) GetFeeDetailsV2(address string, after string, before string, limit int) []token.FeeDetail {
 	var feeDetails []token.FeeDetail
 	query := orm.db.Model(token.FeeDetail{}).Where("address = ?", address)
-	if after != "" {
-		query = query.Where("timestamp > ?", after)
-	}
-	if before != "" {
-		query = query.Where("timestamp < ?", before)
-	}
+	query = whereTimestampCursors(query, after, before)
 
 	query.Order("timestamp desc").Limit(limit).Find(&feeDetails)
 	return feeDetails
@@ -1430,12 +1445,7 @@ func (orm *ORM) GetDealsV2(address, product, side string, after string, before s
 	if side != "" {
 		query = query.Where("side = ?", side)
 	}
-	if after != "" {
-		query = query.Where("timestamp > ?", after)
-	}
-	if before != "" {
-		query = query.Where("timestamp < ?", before)
-	}
+	query = whereTimestampCursors(query, after, before)
 
 	query.Order("timestamp desc").Limit(limit).Find(&deals)
 	return deals
@@ -1448,12 +1458,7 @@ func (orm *ORM) GetTransactionListV2(address string, txType int, after string, b
 	if txType != 0 {
 		query = query.Where("type = ?", txType)
 	}
-	if after != "" {
-		query = query.Where("timestamp > ?", after)
-	}
-	if before != "" {
-		query = query.Where("timestamp < ?", before)
-	}
+	query = whereTimestampCursors(query, after, before)
 
 	query.Order("timestamp desc").Limit(limit).Find(&txs)
 	return txs
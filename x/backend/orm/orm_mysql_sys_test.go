@@ -28,6 +28,11 @@ func (orm *DangrousORM) CleanupDataInTestEvn() (err error) {
 	if err = types.NewErrorsMerged(dealDB.Error, orderDB.Error, feeDB.Error, txDB.Error, matchDB.Error); err != nil {
 		return err
 	}
+	for _, v := range types.GetAllKlineMap() {
+		if err = tx.Delete(types.MustNewKlineFactory(v, nil)).Error; err != nil {
+			return err
+		}
+	}
 	tx.Commit()
 
 	return nil
@@ -77,3 +82,15 @@ func TestNewORM_BatchInsert(t *testing.T) {
 	orm, _ := NewMysqlORM()
 	testORMBatchInsert(t, orm)
 }
+
+func TestMysql_Matches(t *testing.T) {
+	common.SkipSysTestChecker(t)
+	orm, _ := NewMysqlORM()
+	testORMMatches(t, orm)
+}
+
+func TestMysql_AllInOne(t *testing.T) {
+	common.SkipSysTestChecker(t)
+	orm, _ := NewMysqlORM()
+	testORMAllInOne(t, orm)
+}
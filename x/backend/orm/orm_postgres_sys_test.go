@@ -0,0 +1,67 @@
+package orm
+
+import (
+	"testing"
+
+	"github.com/okex/okexchain/x/common"
+)
+
+// NewPostgresORM connects the postgres of the system tests, which listens on 15432 locally.
+// The tests are skipped unless ORM_POSTGRES_SYS_TEST=1 or SYS_TEST_ALL=1 is set, run them against a throwaway
+// database by `make test-sys-postgres`, which the test-postgres job of CI runs as well
+func NewPostgresORM() (orm *ORM, e error) {
+	engineInfo := OrmEngineInfo{
+		EngineType: EngineTypePostgres,
+		ConnectStr: "host=127.0.0.1 port=15432 user=okdexer dbname=okdex password=okdex123! sslmode=disable",
+	}
+	postgresOrm, e := New(false, &engineInfo, nil)
+
+	dorm := DangrousORM{postgresOrm}
+	if err := dorm.CleanupDataInTestEvn(); err != nil {
+		return nil, err
+	}
+
+	return postgresOrm, e
+}
+
+func TestPostgres_ORMDeals(t *testing.T) {
+	common.SkipSysTestChecker(t)
+	orm, _ := NewPostgresORM()
+	testORMDeals(t, orm)
+}
+
+func TestPostgres_FeeDetails(t *testing.T) {
+	common.SkipSysTestChecker(t)
+	orm, _ := NewPostgresORM()
+	testORMFeeDetails(t, orm)
+}
+
+func TestPostgres_Orders(t *testing.T) {
+	common.SkipSysTestChecker(t)
+	orm, _ := NewPostgresORM()
+	testORMOrders(t, orm)
+}
+
+func TestPostgres_Transactions(t *testing.T) {
+	common.SkipSysTestChecker(t)
+	orm, _ := NewPostgresORM()
+	testORMTransactions(t, orm)
+}
+
+func TestPostgres_Matches(t *testing.T) {
+	common.SkipSysTestChecker(t)
+	orm, _ := NewPostgresORM()
+	testORMMatches(t, orm)
+}
+
+func TestPostgres_BatchInsert(t *testing.T) {
+	common.SkipSysTestChecker(t)
+	orm, _ := NewPostgresORM()
+	testORMBatchInsert(t, orm)
+}
+
+func TestPostgres_AllInOne(t *testing.T) {
+	common.SkipSysTestChecker(t)
+	orm, _ := NewPostgresORM()
+	testORMAllInOne(t, orm)
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"runtime/debug"
-	"strconv"
 	"testing"
 	"time"
 
@@ -39,13 +38,13 @@ func TestGorm(t *testing.T) {
 	//db.LogMode(true)
 	p := sdk.NewDecWithPrec(1, 2)
 
-	fp, _ := strconv.ParseFloat(p.String(), 64)
+	fp := types.NewDecimalFromDec(p)
 
 	d1 := types.Deal{
-		BlockHeight: 1, OrderID: "order0", Product: "abc_bcd", Price: fp, Quantity: 100,
+		BlockHeight: 1, OrderID: "order0", Product: "abc_bcd", Price: fp, Quantity: types.NewDecimal(100),
 		Sender: "asdlfkjsd", Side: types.SellOrder, Timestamp: time.Now().Unix()}
 	d2 := types.Deal{
-		BlockHeight: 2, OrderID: "order1", Product: "abc_bcd", Price: fp, Quantity: 200,
+		BlockHeight: 2, OrderID: "order1", Product: "abc_bcd", Price: fp, Quantity: types.NewDecimal(200),
 		Sender: "asdlfkjsd", Side: types.BuyOrder, Timestamp: time.Now().Unix()}
 
 	db.AutoMigrate(&types.Deal{})
@@ -68,26 +67,21 @@ func TestGorm(t *testing.T) {
 	db.Find(&allDeals)
 	fmt.Printf("%+v", allDeals)
 
-	_, tsEnd := getTimestampRange()
-	sql := fmt.Sprintf("select side, product, sum(Quantity) as quantity, max(Price) as high, min(Price) as low from deals "+
-		"where Timestamp >= %d and Timestamp < %d group by side", 0, tsEnd)
-
-	rows, err := db.Raw(sql).Rows()
-	defer rows.Close()
-	if err != nil {
-		panic(err)
-	}
-
-	for rows.Next() {
-
-		var side, product string
-		var quantity float64
-		var high float64
-		var low float64
+	d3 := types.Deal{
+		BlockHeight: 3, OrderID: "order2", Product: "abc_bcd", Price: fp.Mul(types.NewDecimal(2)),
+		Quantity: types.NewDecimal(50), Sender: "asdlfkjsd", Side: types.SellOrder, Timestamp: time.Now().Unix()}
+	require.Nil(t, db.Create(&d3).Error)
 
-		err = rows.Scan(&side, &product, &quantity, &high, &low)
+	// the decimal columns are summed & compared exactly by the aggregation of each side
+	_, tsEnd := getTimestampRange()
+	expected := map[string][]string{types.SellOrder: {"150", "0.02", "0.01"}, types.BuyOrder: {"200", "0.01", "0.01"}}
+	for side, kline := range expected {
+		var records []klineRecord
+		err = db.Table("deals").Select("product, timestamp, price, quantity").
+			Where("side = ? and timestamp >= ? and timestamp < ?", side, 0, tsEnd).Scan(&records).Error
 		require.Nil(t, err)
-		fmt.Printf("product: %s, quantity: %f, high: %f, low: %f \n", product, quantity, high, low)
+		b := aggregateKlineRecords(records, 0)["abc_bcd"]
+		require.Equal(t, kline, []string{b.Volume.String(), b.High.String(), b.Low.String()})
 	}
 
 	db.Delete(&types.Deal{})
@@ -144,31 +138,31 @@ func testORMAllInOne(t *testing.T, orm *ORM) {
 	require.Nil(t, err)
 
 	p := sdk.NewDecWithPrec(1, 2)
-	fp, _ := strconv.ParseFloat(p.String(), 64)
-	highPrice, _ := strconv.ParseFloat("100", 64)
-	lowPrice, _ := strconv.ParseFloat("0.0001", 64)
+	fp := types.NewDecimalFromDec(p)
+	highPrice := types.MustNewDecimalFromStr("100")
+	lowPrice := types.MustNewDecimalFromStr("0.0001")
 
 	product := "abc_bcd"
 	adr1 := "asdlfkjsd"
 
 	ts := time.Now().Unix()
 	d1 := types.Deal{
-		BlockHeight: 1, OrderID: "order0", Product: product, Price: fp, Quantity: 100,
+		BlockHeight: 1, OrderID: "order0", Product: product, Price: fp, Quantity: types.NewDecimal(100),
 		Sender: adr1, Side: types.BuyOrder, Timestamp: ts - 60*30}
 	d2 := types.Deal{
-		BlockHeight: 2, OrderID: "order1", Product: product, Price: fp + 0.1, Quantity: 200,
+		BlockHeight: 2, OrderID: "order1", Product: product, Price: fp.Add(types.MustNewDecimalFromStr("0.1")), Quantity: types.NewDecimal(200),
 		Sender: "asdlfkjsd", Side: types.BuyOrder, Timestamp: ts - 60*15}
 	d3 := types.Deal{
-		BlockHeight: 3, OrderID: "order1", Product: product, Price: fp, Quantity: 300,
+		BlockHeight: 3, OrderID: "order1", Product: product, Price: fp, Quantity: types.NewDecimal(300),
 		Sender: "asdlfkjsd", Side: types.BuyOrder, Timestamp: ts - 60*5}
 	d4 := types.Deal{
-		BlockHeight: 4, OrderID: "order1", Product: product, Price: fp + 0.2, Quantity: 400,
+		BlockHeight: 4, OrderID: "order1", Product: product, Price: fp.Add(types.MustNewDecimalFromStr("0.2")), Quantity: types.NewDecimal(400),
 		Sender: "asdlfkjsd", Side: types.BuyOrder, Timestamp: ts - 60*3 - 1}
 
 	matches := []*types.MatchResult{
-		{BlockHeight: 3, Product: product, Price: fp, Quantity: 300, Timestamp: ts - 60*5},
-		{BlockHeight: 4, Product: product, Price: highPrice, Quantity: 200, Timestamp: ts - 60},
-		{BlockHeight: 5, Product: product, Price: lowPrice, Quantity: 200, Timestamp: ts - 60},
+		{BlockHeight: 3, Product: product, Price: fp, Quantity: types.NewDecimal(300), Timestamp: ts - 60*5},
+		{BlockHeight: 4, Product: product, Price: highPrice, Quantity: types.NewDecimal(200), Timestamp: ts - 60},
+		{BlockHeight: 5, Product: product, Price: lowPrice, Quantity: types.NewDecimal(200), Timestamp: ts - 60},
 	}
 	addCnt, err := orm.AddMatchResults(matches)
 	assert.Equal(t, len(matches), addCnt)
@@ -184,9 +178,9 @@ func testORMAllInOne(t *testing.T, orm *ORM) {
 	deals, err = orm.getLatestDeals(product, 100)
 	require.Nil(t, err)
 	assert.True(t, len(deals) == len(allDeals) && deals != nil)
-	var allDealVolume, allKM1Volume, allKM3Volume float64
+	allDealVolume, allKM1Volume, allKM3Volume := types.ZeroDecimal(), types.ZeroDecimal(), types.ZeroDecimal()
 	for _, d := range deals {
-		allDealVolume += d.Quantity
+		allDealVolume = allDealVolume.Add(d.Quantity)
 	}
 
 	deals, err = orm.getDealsByTimestampRange(product, 0, time.Now().Unix())
@@ -198,7 +192,7 @@ func testORMAllInOne(t *testing.T, orm *ORM) {
 	minDealTS := orm.getDealsMinTimestamp()
 	assert.True(t, minDealTS == (ts-60*30))
 
-	ds := DealDataSource{orm: orm}
+	ds := DealDataSource{Orm: orm}
 	endTS := time.Now().Unix()
 	if endTS%60 == 0 {
 		endTS += 1
@@ -219,7 +213,7 @@ func testORMAllInOne(t *testing.T, orm *ORM) {
 	r, e := orm.getLatestKlineM1ByProduct(product, 100)
 	assert.True(t, r != nil && e == nil)
 	for _, v := range *r {
-		allKM1Volume += v.Volume
+		allKM1Volume = allKM1Volume.Add(v.Volume)
 	}
 
 	klineM3, e := types.NewKlineFactory("kline_m3", nil)
@@ -250,13 +244,13 @@ func testORMAllInOne(t *testing.T, orm *ORM) {
 
 	for _, v := range klineM3List {
 		//fmt.Printf("%d, %+v\n", v.GetTimestamp(), v.PrettyTimeString())
-		allKM3Volume += v.Volume
+		allKM3Volume = allKM3Volume.Add(v.Volume)
 	}
 	err = orm.GetLatestKlinesByProduct(product, 100, -1, &klineM3List)
 	require.Nil(t, err)
 	assert.True(t, len(klineM3List) > 0)
 
-	assert.True(t, int64(allDealVolume) == int64(allKM1Volume) && int64(allKM3Volume) == int64(allKM1Volume))
+	assert.True(t, allDealVolume.Equal(allKM1Volume) && allKM3Volume.Equal(allKM1Volume))
 
 	TestORM_KlineM1ToTicker(t)
 }
@@ -380,7 +374,7 @@ func TestCandles_NewKlinesFactory(t *testing.T) {
 func constructLocalBackendDB(orm *ORM) (err error) {
 	m := types.GetAllKlineMap()
 	crrTs := time.Now().Unix()
-	ds := DealDataSource{orm: orm}
+	ds := DealDataSource{Orm: orm}
 	if _, _, _, err := orm.CreateKline1M(0, crrTs, &ds); err != nil {
 		return err
 	}
@@ -443,10 +437,10 @@ func TestCandles_FromLocalDB(t *testing.T) {
 func testORMDeals(t *testing.T, orm *ORM) {
 
 	addDeals := []*types.Deal{
-		{Timestamp: 100, BlockHeight: 1, OrderID: "ID1", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: 10.0, Quantity: 1.0, Fee: "0"},
-		{Timestamp: 300, BlockHeight: 3, OrderID: "ID2", Sender: "addr1", Product: "btc_" + common.NativeToken, Side: types.BuyOrder, Price: 10.0, Quantity: 1.0, Fee: "0"},
-		{Timestamp: 200, BlockHeight: 2, OrderID: "ID3", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: 10.0, Quantity: 1.0, Fee: "0"},
-		{Timestamp: 400, BlockHeight: 1, OrderID: "ID4", Sender: "addr2", Product: types.TestTokenPair, Side: types.BuyOrder, Price: 10.0, Quantity: 1.0, Fee: "0"},
+		{Timestamp: 100, BlockHeight: 1, OrderID: "ID1", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: types.NewDecimal(10), Quantity: types.NewDecimal(1), Fee: "0"},
+		{Timestamp: 300, BlockHeight: 3, OrderID: "ID2", Sender: "addr1", Product: "btc_" + common.NativeToken, Side: types.BuyOrder, Price: types.NewDecimal(10), Quantity: types.NewDecimal(1), Fee: "0"},
+		{Timestamp: 200, BlockHeight: 2, OrderID: "ID3", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: types.NewDecimal(10), Quantity: types.NewDecimal(1), Fee: "0"},
+		{Timestamp: 400, BlockHeight: 1, OrderID: "ID4", Sender: "addr2", Product: types.TestTokenPair, Side: types.BuyOrder, Price: types.NewDecimal(10), Quantity: types.NewDecimal(1), Fee: "0"},
 	}
 	// Test AddDeals
 	cnt, err := orm.AddDeals(addDeals)
@@ -482,22 +476,33 @@ func testORMDeals(t *testing.T, orm *ORM) {
 	require.EqualValues(t, 1, len(dealsV2))
 	require.EqualValues(t, addDeals[2], &dealsV2[0])
 
-	mrds := MergeResultDataSource{orm}
-	oPrice, cPrice := mrds.getOpenClosePrice(0, time.Now().Unix(), types.TestTokenPair)
-	require.EqualValues(t, 10, oPrice)
-	require.EqualValues(t, 10, cPrice)
+	dds := DealDataSource{orm}
+	records, err := dds.getKlineRecords(0, time.Now().Unix())
+	require.Nil(t, err)
+	require.EqualValues(t, 4, len(records))
+	require.EqualValues(t, "btc_"+common.NativeToken, records[2].Product)
+	require.EqualValues(t, "10", records[3].Price.String())
 }
 
 // Matches
 func TestORMMatches(t *testing.T) {
 	orm, dbPath := MockSqlite3ORM()
 	defer DeleteDB(dbPath)
+	testORMMatches(t, orm)
+
+	// sqlite keeps the records of the same block in the order they were added
+	mrds := MergeResultDataSource{orm}
+	records, err := mrds.getKlineRecords(0, 1574406957)
+	require.Nil(t, err)
+	require.EqualValues(t, "btc_"+common.NativeToken, records[1].Product)
+}
 
+func testORMMatches(t *testing.T, orm *ORM) {
 	addMatches := []*types.MatchResult{
-		{Timestamp: 100, BlockHeight: 1, Product: types.TestTokenPair, Price: 10.0, Quantity: 1.0},
-		{Timestamp: 100, BlockHeight: 1, Product: "btc_" + common.NativeToken, Price: 11.0, Quantity: 2.0},
-		{Timestamp: 200, BlockHeight: 2, Product: types.TestTokenPair, Price: 12.0, Quantity: 3.0},
-		{Timestamp: 300, BlockHeight: 3, Product: types.TestTokenPair, Price: 13.0, Quantity: 4.0},
+		{Timestamp: 100, BlockHeight: 1, Product: types.TestTokenPair, Price: types.NewDecimal(10), Quantity: types.NewDecimal(1)},
+		{Timestamp: 100, BlockHeight: 1, Product: "btc_" + common.NativeToken, Price: types.NewDecimal(11), Quantity: types.NewDecimal(2)},
+		{Timestamp: 200, BlockHeight: 2, Product: types.TestTokenPair, Price: types.NewDecimal(12), Quantity: types.NewDecimal(3)},
+		{Timestamp: 300, BlockHeight: 3, Product: types.TestTokenPair, Price: types.NewDecimal(13), Quantity: types.NewDecimal(4)},
 	}
 	// Test AddMatchResults
 	cnt, err := orm.AddMatchResults(addMatches)
@@ -509,8 +514,8 @@ func TestORMMatches(t *testing.T) {
 	matches, total := orm.GetMatchResults(types.TestTokenPair, 0, 0, 1, 2)
 	require.EqualValues(t, 3, total)
 	require.EqualValues(t, 2, len(matches))
-	require.EqualValues(t, 3, matches[0].Quantity)
-	require.EqualValues(t, 1, matches[1].Quantity)
+	require.EqualValues(t, "3", matches[0].Quantity.String())
+	require.EqualValues(t, "1", matches[1].Quantity.String())
 
 	// filtered by address & start end time
 	matches, total = orm.GetMatchResults("", 100, 200, 0, 3)
@@ -527,6 +532,9 @@ func TestORMMatches(t *testing.T) {
 	require.EqualValues(t, 2, len(matchesV2))
 	require.EqualValues(t, addMatches[3], &matchesV2[0])
 	require.EqualValues(t, addMatches[2], &matchesV2[1])
+	// the invalid cursors are ignored
+	matchesV2 = orm.GetMatchResultsV2(types.TestTokenPair, "", "ts", 10)
+	require.EqualValues(t, 3, len(matchesV2))
 
 	//
 	matches, err = orm.getLatestMatchResults(types.TestTokenPair, 1)
@@ -541,8 +549,11 @@ func TestORMMatches(t *testing.T) {
 	//
 	mrds := MergeResultDataSource{orm}
 	require.EqualValues(t, 100, mrds.getDataSourceMinTimestamp())
-	sql := `select product, sum(Quantity) as quantity, max(Price) as high, min(Price) as low, count(price) as cnt from match_results where Timestamp >= 0 and Timestamp < 1574406957 group by product`
-	require.EqualValues(t, sql, mrds.getMaxMinSumByGroupSQL(0, 1574406957))
+	records, err := mrds.getKlineRecords(0, 1574406957)
+	require.Nil(t, err)
+	require.EqualValues(t, 4, len(records))
+	require.EqualValues(t, "12", records[2].Price.String())
+	require.EqualValues(t, "13", records[3].Price.String())
 
 }
 
@@ -766,14 +777,14 @@ func testORMBatchInsert(t *testing.T, orm *ORM) {
 	}
 
 	addDeals := []*types.Deal{
-		{Timestamp: 100, BlockHeight: 1, OrderID: "FAKEID-0001", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: 10.0, Quantity: 1.0, Fee: "0"},
-		{Timestamp: 300, BlockHeight: 3, OrderID: "FAKEID-0002", Sender: "addr1", Product: "btc_" + common.NativeToken, Side: types.BuyOrder, Price: 10.0, Quantity: 1.0, Fee: "0"},
-		{Timestamp: 200, BlockHeight: 2, OrderID: "FAKEID-0003", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: 10.0, Quantity: 1.0, Fee: "0"},
-		{Timestamp: 400, BlockHeight: 1, OrderID: "FAKEID-0004", Sender: "addr2", Product: types.TestTokenPair, Side: types.BuyOrder, Price: 10.0, Quantity: 1.0, Fee: "0"},
+		{Timestamp: 100, BlockHeight: 1, OrderID: "FAKEID-0001", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: types.NewDecimal(10), Quantity: types.NewDecimal(1), Fee: "0"},
+		{Timestamp: 300, BlockHeight: 3, OrderID: "FAKEID-0002", Sender: "addr1", Product: "btc_" + common.NativeToken, Side: types.BuyOrder, Price: types.NewDecimal(10), Quantity: types.NewDecimal(1), Fee: "0"},
+		{Timestamp: 200, BlockHeight: 2, OrderID: "FAKEID-0003", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: types.NewDecimal(10), Quantity: types.NewDecimal(1), Fee: "0"},
+		{Timestamp: 400, BlockHeight: 1, OrderID: "FAKEID-0004", Sender: "addr2", Product: types.TestTokenPair, Side: types.BuyOrder, Price: types.NewDecimal(10), Quantity: types.NewDecimal(1), Fee: "0"},
 	}
 
 	mrs := []*types.MatchResult{
-		{Timestamp: 100, BlockHeight: 1, Product: types.TestTokenPair, Price: 10.0, Quantity: 1.0},
+		{Timestamp: 100, BlockHeight: 1, Product: types.TestTokenPair, Price: types.NewDecimal(10), Quantity: types.NewDecimal(1)},
 	}
 
 	feeDetails := []*token.FeeDetail{
@@ -843,13 +854,13 @@ func TestORM_CloseDB(t *testing.T) {
 
 	// insert after close DB
 	cnt, err := closeORM.AddMatchResults([]*types.MatchResult{
-		{Timestamp: 100, BlockHeight: 1, Product: types.TestTokenPair, Price: 10.0, Quantity: 1.0},
+		{Timestamp: 100, BlockHeight: 1, Product: types.TestTokenPair, Price: types.NewDecimal(10), Quantity: types.NewDecimal(1)},
 	})
 	require.Error(t, err)
 	require.Equal(t, 0, cnt)
 
 	cnt, err = closeORM.AddDeals([]*types.Deal{
-		{Timestamp: 100, BlockHeight: 1, OrderID: "FAKEID-0001", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: 10.0, Quantity: 1.0, Fee: "0"},
+		{Timestamp: 100, BlockHeight: 1, OrderID: "FAKEID-0001", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: types.NewDecimal(10), Quantity: types.NewDecimal(1), Fee: "0"},
 	})
 	require.Error(t, err)
 	require.Equal(t, 0, cnt)
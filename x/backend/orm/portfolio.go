@@ -0,0 +1,116 @@
+package orm
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jinzhu/gorm"
+	"github.com/okex/okexchain/x/backend/types"
+)
+
+// batchInsertBalanceSnapshots inserts the balance snapshots of a block in the transaction
+func (orm *ORM) batchInsertBalanceSnapshots(trx *gorm.DB, snapshots []*types.BalanceSnapshot) (int, error) {
+	if len(snapshots) == 0 {
+		return 0, nil
+	}
+	vItems := make([]string, 0, len(snapshots))
+	for _, s := range snapshots {
+		vItems = append(vItems, fmt.Sprintf("('%s','%s','%d','%d','%s','%s')", s.Address, s.Currency, s.Timestamp,
+			s.BlockHeight, s.Available, s.Locked))
+	}
+	sql := orm.batchInsertSQL("balance_snapshots", []string{"address", "currency", "timestamp", "block_height",
+		"available", "locked"}, vItems)
+	if err := trx.Exec(sql).Error; err != nil {
+		return 0, err
+	}
+	return len(vItems), nil
+}
+
+// GetBalanceSnapshotsV2 returns the balance snapshots of the account in the order of the time desc, the currency
+// is optional
+func (orm *ORM) GetBalanceSnapshotsV2(address, currency, after, before string, limit int) []types.BalanceSnapshot {
+	var snapshots []types.BalanceSnapshot
+	query := orm.db.Model(types.BalanceSnapshot{}).Where("address = ?", address)
+	if currency != "" {
+		query = query.Where("currency = ?", currency)
+	}
+	query = whereTimestampCursors(query, after, before)
+
+	query.Order("block_height desc, currency asc").Limit(limit).Find(&snapshots)
+	return snapshots
+}
+
+// storeRealizedPnLs applies the deals of the block to the cost lots of each account & product by all of the methods,
+// and stores the pnl realized by the sell deals with the lots after the block. The lots before the block are the last
+// ones stored below its height
+func storeRealizedPnLs(trx *gorm.DB, deals []*types.Deal) (int, error) {
+	var keys []string
+	dealsMap := map[string][]*types.Deal{}
+	for _, deal := range deals {
+		key := deal.Sender + "|" + deal.Product
+		if _, ok := dealsMap[key]; !ok {
+			keys = append(keys, key)
+		}
+		dealsMap[key] = append(dealsMap[key], deal)
+	}
+	sort.Strings(keys)
+
+	cnt := 0
+	for _, key := range keys {
+		accountDeals := dealsMap[key]
+		// the deals of a block are in the same time, they're applied in the order of the ids & the fills of each order
+		sort.SliceStable(accountDeals, func(i, j int) bool {
+			if accountDeals[i].OrderID != accountDeals[j].OrderID {
+				return accountDeals[i].OrderID < accountDeals[j].OrderID
+			}
+			return accountDeals[i].Sequence < accountDeals[j].Sequence
+		})
+		first := accountDeals[0]
+		for _, method := range []string{types.PnLMethodFIFO, types.PnLMethodAverage} {
+			costLots, err := getLastCostLots(trx, first.Sender, first.Product, method, first.BlockHeight)
+			if err != nil {
+				return cnt, err
+			}
+			pnls, err := costLots.ApplyDeals(accountDeals)
+			if err != nil {
+				return cnt, err
+			}
+			costLots.BlockHeight = first.BlockHeight
+			if err = trx.Create(&costLots).Error; err != nil {
+				return cnt, err
+			}
+			for _, pnl := range pnls {
+				if err = trx.Create(pnl).Error; err != nil {
+					return cnt, err
+				}
+			}
+			cnt += len(pnls)
+		}
+	}
+	return cnt, nil
+}
+
+// getLastCostLots returns the cost lots of the account & product by the method stored last below the height
+func getLastCostLots(trx *gorm.DB, address, product, method string, height int64) (types.CostLots, error) {
+	var costLots types.CostLots
+	err := trx.Where("address = ? and product = ? and method = ? and block_height < ?", address, product, method,
+		height).Order("block_height desc").First(&costLots).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return types.NewCostLots(address, product, method), nil
+	}
+	return costLots, err
+}
+
+// GetRealizedPnLV2 returns the pnl realized by the sell deals of the account by the method in the order of the time
+// desc, the product is optional
+func (orm *ORM) GetRealizedPnLV2(address, product, method, after, before string, limit int) []types.RealizedPnL {
+	var pnls []types.RealizedPnL
+	query := orm.db.Model(types.RealizedPnL{}).Where("address = ? and method = ?", address, method)
+	if product != "" {
+		query = query.Where("product = ?", product)
+	}
+	query = whereTimestampCursors(query, after, before)
+
+	query.Order("timestamp desc, block_height desc, order_id desc, sequence desc").Limit(limit).Find(&pnls)
+	return pnls
+}
@@ -0,0 +1,90 @@
+package orm
+
+import (
+	"testing"
+
+	"github.com/okex/okexchain/x/backend/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestORM_Portfolio(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+
+	newSnapshot := func(ts int64, currency string, available int64) *types.BalanceSnapshot {
+		return &types.BalanceSnapshot{Address: "addr1", Currency: currency, Timestamp: ts, BlockHeight: ts / 100,
+			Available: types.NewDecimal(available), Locked: types.ZeroDecimal()}
+	}
+	newDeal := func(ts int64, orderID, side string, price int64) *types.Deal {
+		return &types.Deal{Timestamp: ts, BlockHeight: ts / 100, OrderID: orderID, Sender: "addr1",
+			Product: types.TestTokenPair, Side: side, Price: types.NewDecimal(price), Quantity: types.NewDecimal(1)}
+	}
+	blocks := []*types.BlockData{
+		{Height: 1, Timestamp: 100, Deals: []*types.Deal{newDeal(100, "ID1-1", types.BuyOrder, 10)},
+			BalanceSnapshots: []*types.BalanceSnapshot{newSnapshot(100, "okt", 10), newSnapshot(100, "xxb", 1)}},
+		{Height: 2, Timestamp: 200, Deals: []*types.Deal{newDeal(200, "ID2-1", types.SellOrder, 12)},
+			BalanceSnapshots: []*types.BalanceSnapshot{newSnapshot(200, "okt", 22)}},
+	}
+	for _, block := range blocks {
+		resultMap, err := orm.StoreBlock(block)
+		require.Nil(t, err)
+		require.Equal(t, len(block.BalanceSnapshots), resultMap["balanceSnapshots"])
+	}
+
+	snapshots := orm.GetBalanceSnapshotsV2("addr1", "", "", "", 10)
+	require.Equal(t, 3, len(snapshots))
+	require.EqualValues(t, 200, snapshots[0].Timestamp)
+	require.Equal(t, "22", snapshots[0].Available.String())
+	snapshots = orm.GetBalanceSnapshotsV2("addr1", "okt", "", "200", 10)
+	require.Equal(t, 1, len(snapshots))
+	require.Equal(t, "10", snapshots[0].Available.String())
+
+	// the pool tokens fit in the currency
+	_, err := orm.StoreBlock(&types.BlockData{Height: 3, Timestamp: 300, BalanceSnapshots: []*types.BalanceSnapshot{
+		newSnapshot(300, "ammswap_xxb-a1b_usdk-c2d", 1)}})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(orm.GetBalanceSnapshotsV2("addr1", "ammswap_xxb-a1b_usdk-c2d", "", "", 10)))
+
+	// the pnl is realized with the cost lots before the block, by each of the methods
+	pnls := orm.GetRealizedPnLV2("addr1", types.TestTokenPair, types.PnLMethodFIFO, "", "", 10)
+	require.Equal(t, 1, len(pnls))
+	require.Equal(t, "ID2-1", pnls[0].OrderID)
+	require.Equal(t, "2", pnls[0].PnL.String())
+	require.Equal(t, 1, len(orm.GetRealizedPnLV2("addr1", "", types.PnLMethodAverage, "", "", 10)))
+	require.Equal(t, 0, len(orm.GetRealizedPnLV2("addr1", "", types.PnLMethodFIFO, "", "200", 10)))
+
+	// the block stored again is applied to the same lots
+	_, err = orm.StoreBlock(blocks[1])
+	require.Nil(t, err)
+	pnls = orm.GetRealizedPnLV2("addr1", "", types.PnLMethodFIFO, "", "", 10)
+	require.Equal(t, 1, len(pnls))
+	require.Equal(t, "2", pnls[0].CumulativePnL.String())
+
+	// the sell deal after the lots sold has no cost
+	_, err = orm.StoreBlock(&types.BlockData{Height: 4, Timestamp: 400,
+		Deals: []*types.Deal{newDeal(400, "ID4-1", types.SellOrder, 12)}})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(orm.GetRealizedPnLV2("addr1", "", types.PnLMethodFIFO, "", "", 10)))
+
+	// the snapshots & the pnl are deleted with the blocks
+	require.Nil(t, orm.DeleteBlocksBetween(1, 2))
+	require.Equal(t, 1, len(orm.GetBalanceSnapshotsV2("addr1", "", "", "", 10)))
+	require.Equal(t, 0, len(orm.GetRealizedPnLV2("addr1", "", types.PnLMethodFIFO, "", "", 10)))
+
+	// each of the fills of an order in a block realizes its own pnl
+	fills := []*types.Deal{newDeal(500, "ID5-1", types.BuyOrder, 10), newDeal(500, "ID5-1", types.BuyOrder, 11),
+		newDeal(500, "ID5-2", types.SellOrder, 12), newDeal(500, "ID5-2", types.SellOrder, 13)}
+	for i, fill := range fills {
+		fill.Sequence = int64(i)
+	}
+	_, err = orm.StoreBlock(&types.BlockData{Height: 5, Timestamp: 500, Deals: fills})
+	require.Nil(t, err)
+	pnls = orm.GetRealizedPnLV2("addr1", "", types.PnLMethodFIFO, "", "", 10)
+	require.Equal(t, 2, len(pnls))
+	require.EqualValues(t, 3, pnls[0].Sequence)
+	require.Equal(t, "2", pnls[0].PnL.String())
+	require.EqualValues(t, 2, pnls[1].Sequence)
+	require.Equal(t, "2", pnls[1].PnL.String())
+	// the lots of block 4 are kept with the pnl of the block 2
+	require.Equal(t, "6", pnls[0].CumulativePnL.String())
+}
@@ -0,0 +1,96 @@
+package orm
+
+import (
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+	"github.com/okex/okexchain/x/backend/types"
+	orderTypes "github.com/okex/okexchain/x/order/types"
+	"github.com/okex/okexchain/x/token"
+)
+
+// DeleteBlocksBetween deletes the orders placed, the deals, match results, fee details, transactions, swap trades,
+// balance snapshots, cost lots & realized pnl of the blocks with the height in [fromHeight, toHeight], so that the
+// blocks could be stored again
+func (orm *ORM) DeleteBlocksBetween(fromHeight, toHeight int64) (err error) {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	tx := orm.db.Begin()
+	defer orm.deferRollbackTx(tx, err)
+
+	if err = deleteBlocksBetween(tx, fromHeight, toHeight); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func deleteBlocksBetween(tx *gorm.DB, fromHeight, toHeight int64) error {
+	// the orders are placed in the blocks of the heights in their ids
+	if err := tx.Delete(&types.Order{}, "order_id >= ? and order_id < ?", orderTypes.FormatOrderIDPrefix(fromHeight),
+		orderTypes.FormatOrderIDPrefix(toHeight+1)).Error; err != nil {
+		return err
+	}
+	models := []interface{}{&types.Deal{}, &types.MatchResult{}, &token.FeeDetail{}, &types.Transaction{},
+		&types.SwapTrade{}, &types.BalanceSnapshot{}, &types.CostLots{}, &types.RealizedPnL{}}
+	for _, model := range models {
+		if err := tx.Delete(model, "block_height >= ? and block_height <= ?", fromHeight, toHeight).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RebuildKlines creates the klines of all frequencies covering [startTS, endTS] again from the deals, the
+// klines existing in the periods are replaced
+func (orm *ORM) RebuildKlines(startTS, endTS int64) error {
+	for _, name := range types.GetAllKlineMap() {
+		if err := orm.rebuildKlines(startTS, endTS, types.MustNewKlineFactory(name, nil).(types.IKline)); err != nil {
+			return fmt.Errorf("failed to rebuild %s: %s", name, err.Error())
+		}
+	}
+	return nil
+}
+
+func (orm *ORM) rebuildKlines(startTS, endTS int64, kline types.IKline) (err error) {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	freq := int64(kline.GetFreqInSecond())
+	periodStartTS := startTS / freq * freq
+	periodEndTS := endTS/freq*freq + freq
+	records, err := orm.getDealKlineRecords("", periodStartTS, periodEndTS)
+	if err != nil {
+		return err
+	}
+
+	// group the records by the period in the order of the time
+	var periods []int64
+	periodRecords := map[int64][]klineRecord{}
+	for _, record := range records {
+		ts := record.Timestamp / freq * freq
+		if _, ok := periodRecords[ts]; !ok {
+			periods = append(periods, ts)
+		}
+		periodRecords[ts] = append(periodRecords[ts], record)
+	}
+
+	tx := orm.db.Begin()
+	defer orm.deferRollbackTx(tx, err)
+
+	if err = tx.Delete(kline, "Timestamp >= ? and Timestamp < ?", periodStartTS, periodEndTS).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, ts := range periods {
+		for _, b := range aggregateKlineRecords(periodRecords[ts], ts) {
+			if err = tx.Create(types.MustNewKlineFactory(kline.GetTableName(), b)).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,105 @@
+package orm
+
+import (
+	"testing"
+
+	"github.com/okex/okexchain/x/backend/types"
+	"github.com/okex/okexchain/x/token"
+	"github.com/stretchr/testify/require"
+)
+
+func TestORM_DeleteBlocksBetween(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+
+	// the blocks 1 & 2 share the same second
+	_, err := orm.AddMatchResults([]*types.MatchResult{
+		{Timestamp: 100, BlockHeight: 1, Product: types.TestTokenPair, Price: types.NewDecimal(10), Quantity: types.NewDecimal(1)},
+		{Timestamp: 100, BlockHeight: 2, Product: types.TestTokenPair, Price: types.NewDecimal(11), Quantity: types.NewDecimal(2)},
+		{Timestamp: 300, BlockHeight: 3, Product: types.TestTokenPair, Price: types.NewDecimal(12), Quantity: types.NewDecimal(3)},
+	})
+	require.Nil(t, err)
+	_, err = orm.AddFeeDetails([]*token.FeeDetail{
+		{Address: "addr", Fee: "0.1", Timestamp: 100, BlockHeight: 1},
+		{Address: "addr", Fee: "0.2", Timestamp: 300, BlockHeight: 3},
+	})
+	require.Nil(t, err)
+	_, err = orm.AddOrders([]*types.Order{
+		{OrderID: "ID0000000001-1", Sender: "addr", Timestamp: 100},
+		{OrderID: "ID0000000002-1", Sender: "addr", Timestamp: 100},
+		{OrderID: "ID0000000002-12", Sender: "addr", Timestamp: 100},
+		{OrderID: "ID0000000003-1", Sender: "addr", Timestamp: 300},
+	})
+	require.Nil(t, err)
+
+	require.Nil(t, orm.DeleteBlocksBetween(2, 3))
+	matches, total := orm.GetMatchResults("", 0, 0, 0, 10)
+	require.Equal(t, 1, total)
+	require.EqualValues(t, 1, matches[0].BlockHeight)
+	feeDetails, total := orm.GetFeeDetails("addr", 0, 10)
+	require.Equal(t, 1, total)
+	require.EqualValues(t, 1, feeDetails[0].BlockHeight)
+	require.NotNil(t, orm.GetOrderByID("ID0000000001-1"))
+	require.Nil(t, orm.GetOrderByID("ID0000000002-1"))
+	require.Nil(t, orm.GetOrderByID("ID0000000002-12"))
+	require.Nil(t, orm.GetOrderByID("ID0000000003-1"))
+
+	// deleting again changes nothing
+	require.Nil(t, orm.DeleteBlocksBetween(2, 3))
+	_, total = orm.GetMatchResults("", 0, 0, 0, 10)
+	require.Equal(t, 1, total)
+}
+
+func TestORM_RebuildKlines(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+
+	// the start of a 5 minutes period
+	ts := int64(1800000000)
+
+	// a fill makes a deal of each side, the taker of block 2 fills two makers at their own prices
+	newDeal := func(ts, height, sequence int64, orderID, side string, price, quantity int64) *types.Deal {
+		return &types.Deal{Timestamp: ts, BlockHeight: height, Sequence: sequence, OrderID: orderID,
+			Product: types.TestTokenPair, Side: side, Price: types.NewDecimal(price), Quantity: types.NewDecimal(quantity)}
+	}
+	_, err := orm.AddDeals([]*types.Deal{
+		newDeal(ts+60, 1, 0, "ID1-1", types.SellOrder, 10, 1), newDeal(ts+60, 1, 1, "ID1-2", types.BuyOrder, 10, 1),
+		newDeal(ts+90, 2, 0, "ID1-3", types.BuyOrder, 11, 1), newDeal(ts+90, 2, 1, "ID2-1", types.SellOrder, 11, 1),
+		newDeal(ts+90, 2, 2, "ID1-4", types.BuyOrder, 12, 1), newDeal(ts+90, 2, 3, "ID2-1", types.SellOrder, 12, 1),
+		newDeal(ts+200, 3, 0, "ID3-1", types.BuyOrder, 8, 3), newDeal(ts+200, 3, 1, "ID3-2", types.SellOrder, 8, 3),
+	})
+	require.Nil(t, err)
+	// a stale kline to be replaced
+	require.Nil(t, orm.db.Create(types.NewKlineM1(&types.BaseKline{Product: types.TestTokenPair, Timestamp: ts + 60,
+		Open: types.NewDecimal(1), Close: types.NewDecimal(1), High: types.NewDecimal(1), Low: types.NewDecimal(1),
+		Volume: types.NewDecimal(1)})).Error)
+
+	// rebuilding twice gives the same klines
+	for i := 0; i < 2; i++ {
+		require.Nil(t, orm.RebuildKlines(ts+60, ts+200))
+
+		var klinesM1 []types.KlineM1
+		require.Nil(t, orm.GetLatestKlinesByProduct(types.TestTokenPair, 10, 0, &klinesM1))
+		require.Equal(t, 2, len(klinesM1))
+		require.EqualValues(t, ts+180, klinesM1[0].Timestamp)
+		require.Equal(t, "8", klinesM1[0].Close.String())
+		require.EqualValues(t, ts+60, klinesM1[1].Timestamp)
+		require.Equal(t, "10", klinesM1[1].Open.String())
+		require.Equal(t, "12", klinesM1[1].Close.String())
+		require.Equal(t, "3", klinesM1[1].Volume.String())
+
+		var klinesM3 []types.KlineM3
+		require.Nil(t, orm.GetLatestKlinesByProduct(types.TestTokenPair, 10, 0, &klinesM3))
+		require.Equal(t, 2, len(klinesM3))
+		require.Equal(t, "3", klinesM3[1].Volume.String())
+
+		var klinesM5 []types.KlineM5
+		require.Nil(t, orm.GetLatestKlinesByProduct(types.TestTokenPair, 10, 0, &klinesM5))
+		require.Equal(t, 1, len(klinesM5))
+		require.Equal(t, "10", klinesM5[0].Open.String())
+		require.Equal(t, "8", klinesM5[0].Close.String())
+		require.Equal(t, "12", klinesM5[0].High.String())
+		require.Equal(t, "8", klinesM5[0].Low.String())
+		require.Equal(t, "6", klinesM5[0].Volume.String())
+	}
+}
@@ -0,0 +1,243 @@
+package orm
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/okex/okexchain/x/backend/types"
+)
+
+// whereTrades filters the deals or the swap trades, the ones before now are filtered if the time range is empty
+func whereTrades(query *gorm.DB, address, product, side string, startTime, endTime int64) *gorm.DB {
+	if startTime == 0 && endTime == 0 {
+		endTime = time.Now().Unix()
+	}
+	if address != "" {
+		query = query.Where("sender = ?", address)
+	}
+	if product != "" {
+		query = query.Where("product = ?", product)
+	}
+	if side != "" {
+		query = query.Where("side = ?", side)
+	}
+	if startTime > 0 {
+		query = query.Where("timestamp >= ?", startTime)
+	}
+	if endTime > 0 {
+		query = query.Where("timestamp < ?", endTime)
+	}
+	return query
+}
+
+// GetSwapTrades returns the swap trades in the order of the time desc
+func (orm *ORM) GetSwapTrades(address, product, side string, startTime, endTime int64, offset, limit int) (
+	[]types.SwapTrade, int) {
+	var swapTrades []types.SwapTrade
+	query := whereTrades(orm.db.Model(types.SwapTrade{}), address, product, side, startTime, endTime)
+	var total int
+	query.Count(&total)
+	if offset >= total {
+		return swapTrades, total
+	}
+
+	query.Order("timestamp desc, sequence desc").Offset(offset).Limit(limit).Find(&swapTrades)
+	return swapTrades, total
+}
+
+// GetTrades returns the deals & the swap trades together in the order of the time desc
+func (orm *ORM) GetTrades(address, product, side string, startTime, endTime int64, offset, limit int) (
+	[]types.Trade, int) {
+	var trades []types.Trade
+	dealQuery := whereTrades(orm.db.Table("deals"), address, product, side, startTime, endTime)
+	swapQuery := whereTrades(orm.db.Table("swap_trades"), address, product, side, startTime, endTime)
+	var dealTotal, swapTotal int
+	dealQuery.Count(&dealTotal)
+	swapQuery.Count(&swapTotal)
+	total := dealTotal + swapTotal
+	if offset >= total {
+		return trades, total
+	}
+
+	dealQuery = dealQuery.Select("'" + types.TradeSourceOrderBook + "' as source, timestamp, block_height, " +
+		"order_id as id, sender, product, side, price, quantity, fee")
+	swapQuery = swapQuery.Select("'" + types.TradeSourceAMM + "' as source, timestamp, block_height, " +
+		"tx_hash as id, sender, product, side, price, quantity, fee")
+	orm.db.Raw("select * from (? union all ?) trades order by timestamp desc, block_height desc limit ? offset ?",
+		dealQuery.QueryExpr(), swapQuery.QueryExpr(), limit, offset).Scan(&trades)
+	return trades, total
+}
+
+// getTradeKlineRecords returns the prices & the quantities of the product traded from the source in
+// [startTS, endTS), in the order of the time
+func (orm *ORM) getTradeKlineRecords(product, source string, startTS, endTS int64) ([]klineRecord, error) {
+	var dealRecords, swapRecords []klineRecord
+	if source != types.TradeSourceAMM {
+		var err error
+		if dealRecords, err = orm.getDealKlineRecords(product, startTS, endTS); err != nil {
+			return nil, err
+		}
+	}
+	if source == types.TradeSourceAMM || source == types.TradeSourceAll {
+		err := orm.db.Table("swap_trades").Select("product, timestamp, price, quantity").
+			Where("product = ? and timestamp >= ? and timestamp < ?", product, startTS, endTS).
+			Order("timestamp asc, block_height asc, sequence asc").Scan(&swapRecords).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return mergeKlineRecords(swapRecords, dealRecords), nil
+}
+
+// mergeKlineRecords merges the records of the swap trades & the deals in the order of the time. The swaps
+// are executed by the txs of a block, so they come before the deals made at the end of the block
+func mergeKlineRecords(swapRecords, dealRecords []klineRecord) []klineRecord {
+	records := make([]klineRecord, 0, len(swapRecords)+len(dealRecords))
+	i, j := 0, 0
+	for i < len(swapRecords) && j < len(dealRecords) {
+		if swapRecords[i].Timestamp <= dealRecords[j].Timestamp {
+			records = append(records, swapRecords[i])
+			i++
+		} else {
+			records = append(records, dealRecords[j])
+			j++
+		}
+	}
+	records = append(records, swapRecords[i:]...)
+	return append(records, dealRecords[j:]...)
+}
+
+// GetKlinesFromTrades aggregates the latest limit klines of the product before anchorTS from the trades of the
+// source, in the order of the time asc. The periods without any trade are padded with the last close, up to the
+// period of anchorTS. The limit is cut to the periods in MaxTradeKlineRange, one period at least
+func (orm *ORM) GetKlinesFromTrades(product, source string, kline types.IKline, limit int, anchorTS int64) (
+	[]types.IKline, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	freq := int64(kline.GetFreqInSecond())
+	maxLimit := int(types.MaxTradeKlineRange / freq)
+	if maxLimit < 1 {
+		maxLimit = 1
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	lastPeriodTS := anchorTS / freq * freq
+	firstPeriodTS := lastPeriodTS - int64(limit-1)*freq
+	records, err := orm.getTradeKlineRecords(product, source, firstPeriodTS, anchorTS)
+	if err != nil {
+		return nil, err
+	}
+
+	var klines []types.IKline
+	var last *types.BaseKline
+	// the periods before the first trade in the range are padded with the close of the trade before them
+	previous, err := orm.getLatestTradeKlineRecord(product, source, firstPeriodTS)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil {
+		last = &types.BaseKline{Product: product, Timestamp: firstPeriodTS - freq, Close: previous.Price}
+	} else if len(records) == 0 {
+		return nil, nil
+	}
+
+	for i := 0; i < len(records); {
+		ts := records[i].Timestamp / freq * freq
+		// pad the periods without any trade
+		for last != nil && last.Timestamp+freq < ts {
+			last = &types.BaseKline{Product: product, Timestamp: last.Timestamp + freq, Open: last.Close,
+				Close: last.Close, High: last.Close, Low: last.Close, Volume: types.ZeroDecimal()}
+			klines = append(klines, types.MustNewKlineFactory(kline.GetTableName(), last).(types.IKline))
+		}
+
+		j := i
+		for j < len(records) && records[j].Timestamp/freq*freq == ts {
+			j++
+		}
+		last = aggregateKlineRecords(records[i:j], ts)[product]
+		klines = append(klines, types.MustNewKlineFactory(kline.GetTableName(), last).(types.IKline))
+		i = j
+	}
+	for last.Timestamp < lastPeriodTS {
+		last = &types.BaseKline{Product: product, Timestamp: last.Timestamp + freq, Open: last.Close,
+			Close: last.Close, High: last.Close, Low: last.Close, Volume: types.ZeroDecimal()}
+		klines = append(klines, types.MustNewKlineFactory(kline.GetTableName(), last).(types.IKline))
+	}
+	return klines, nil
+}
+
+// GetTickersFromTrades computes the tickers of the products from their trades of the source in the 24 hours before
+// endTS. The ticker of a product without any trade in the 24 hours keeps the price of its latest trade
+func (orm *ORM) GetTickersFromTrades(products []string, source string, endTS int64) (map[string]*types.Ticker, error) {
+	tickerMap := map[string]*types.Ticker{}
+	for _, product := range products {
+		records, err := orm.getTradeKlineRecords(product, source, endTS-types.SecondsInADay, endTS)
+		if err != nil {
+			return nil, err
+		}
+		if len(records) == 0 {
+			latest, err := orm.getLatestTradeKlineRecord(product, source, endTS)
+			if err != nil {
+				return nil, err
+			}
+			if latest == nil {
+				continue
+			}
+			latest.Quantity = types.ZeroDecimal()
+			records = append(records, *latest)
+		}
+
+		b := aggregateKlineRecords(records, endTS)[product]
+		t := types.Ticker{
+			Symbol:           product,
+			Product:          product,
+			Timestamp:        endTS,
+			Open:             b.Open,
+			Close:            b.Close,
+			High:             b.High,
+			Low:              b.Low,
+			Price:            b.Close,
+			Volume:           b.Volume,
+			Change:           b.Close.Sub(b.Open),
+			ChangePercentage: "0.00%",
+		}
+		if !t.Open.IsZero() {
+			t.ChangePercentage = t.Change.Mul(types.NewDecimal(100)).Div(t.Open).StringFixed(2) + "%"
+		}
+		tickerMap[product] = &t
+	}
+	return tickerMap, nil
+}
+
+// getLatestTradeKlineRecord returns the latest trade of the product from the source before endTS, nil if there's none
+func (orm *ORM) getLatestTradeKlineRecord(product, source string, endTS int64) (*klineRecord, error) {
+	var latest *klineRecord
+	if source == types.TradeSourceAMM || source == types.TradeSourceAll {
+		var records []klineRecord
+		err := orm.db.Table("swap_trades").Select("product, timestamp, price, quantity").
+			Where("product = ? and timestamp < ?", product, endTS).
+			Order("timestamp desc, block_height desc, sequence desc").Limit(1).Scan(&records).Error
+		if err != nil {
+			return nil, err
+		}
+		if len(records) > 0 {
+			latest = &records[0]
+		}
+	}
+	if source != types.TradeSourceAMM {
+		var records []klineRecord
+		err := orm.db.Table("deals").Select("product, timestamp, price, quantity").
+			Where("product = ? and side = ? and timestamp < ?", product, types.BuyOrder, endTS).
+			Order("timestamp desc, block_height desc, sequence desc").Limit(1).Scan(&records).Error
+		if err != nil {
+			return nil, err
+		}
+		// the deals of a block come after its swaps
+		if len(records) > 0 && (latest == nil || records[0].Timestamp >= latest.Timestamp) {
+			latest = &records[0]
+		}
+	}
+	return latest, nil
+}
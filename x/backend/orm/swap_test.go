@@ -0,0 +1,107 @@
+package orm
+
+import (
+	"testing"
+
+	"github.com/okex/okexchain/x/backend/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestORM_SwapTrades(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+
+	ts := int64(1800000000)
+	newSwapTrade := func(height, sequence int64, side string, price int64) *types.SwapTrade {
+		return &types.SwapTrade{Timestamp: ts + height, BlockHeight: height, Sequence: sequence, TxHash: "TX",
+			Pool: "okt_xxb", Product: types.TestTokenPair, Sender: "addr1", Side: side,
+			Price: types.NewDecimal(price), Quantity: types.NewDecimal(1), QuoteQuantity: types.NewDecimal(price),
+			Fee: "0.003okt"}
+	}
+	blocks := []*types.BlockData{
+		{Height: 1, Timestamp: ts + 1, SwapTrades: []*types.SwapTrade{
+			newSwapTrade(1, 0, types.BuyOrder, 10), newSwapTrade(1, 1, types.SellOrder, 9)}},
+		// the match result of a block comes after its swaps
+		{Height: 2, Timestamp: ts + 2, SwapTrades: []*types.SwapTrade{newSwapTrade(2, 0, types.BuyOrder, 11)},
+			MatchResults: []*types.MatchResult{{Timestamp: ts + 2, BlockHeight: 2, Product: types.TestTokenPair,
+				Price: types.NewDecimal(12), Quantity: types.NewDecimal(2)}},
+			Deals: []*types.Deal{{Timestamp: ts + 2, BlockHeight: 2, OrderID: "ID2-1", Sender: "addr1",
+				Product: types.TestTokenPair, Side: types.BuyOrder, Price: types.NewDecimal(12),
+				Quantity: types.NewDecimal(2)}}},
+		{Height: 200, Timestamp: ts + 200, SwapTrades: []*types.SwapTrade{newSwapTrade(200, 0, types.SellOrder, 8)}},
+	}
+	for _, block := range blocks {
+		resultMap, err := orm.StoreBlock(block)
+		require.Nil(t, err)
+		require.Equal(t, len(block.SwapTrades), resultMap["swapTrades"])
+	}
+
+	swapTrades, total := orm.GetSwapTrades("addr1", "", "", ts, ts+300, 0, 10)
+	require.Equal(t, 4, total)
+	require.EqualValues(t, 200, swapTrades[0].BlockHeight)
+	require.EqualValues(t, 1, swapTrades[2].Sequence)
+	require.Equal(t, "0.003okt", swapTrades[2].Fee)
+	_, total = orm.GetSwapTrades("addr1", "", types.SellOrder, ts, ts+300, 0, 10)
+	require.Equal(t, 2, total)
+
+	trades, total := orm.GetTrades("addr1", types.TestTokenPair, "", ts, ts+300, 1, 2)
+	require.Equal(t, 5, total)
+	require.Equal(t, 2, len(trades))
+	require.EqualValues(t, 2, trades[0].BlockHeight)
+	sources := map[string]string{trades[0].Source: trades[0].ID, trades[1].Source: trades[1].ID}
+	require.Equal(t, "ID2-1", sources[types.TradeSourceOrderBook])
+	require.Equal(t, "TX", sources[types.TradeSourceAMM])
+
+	// the klines of the swaps are padded up to the period of the anchor
+	klineM1 := types.MustNewKlineFactory(types.KlineTypeM1, nil).(types.IKline)
+	klines, err := orm.GetKlinesFromTrades(types.TestTokenPair, types.TradeSourceAMM, klineM1, 10, ts+300)
+	require.Nil(t, err)
+	require.Equal(t, 6, len(klines))
+	require.EqualValues(t, (ts+1)/60*60, klines[0].GetTimestamp())
+	require.Equal(t, "10", klines[0].GetOpen().String())
+	require.Equal(t, "11", klines[0].GetClose().String())
+	require.Equal(t, "9", klines[0].GetLow().String())
+	require.Equal(t, "3", klines[0].GetVolume().String())
+	require.Equal(t, "11", klines[1].GetOpen().String())
+	require.Equal(t, "0", klines[1].GetVolume().String())
+	require.Equal(t, "8", klines[5].GetClose().String())
+	require.EqualValues(t, (ts+300)/60*60, klines[5].GetTimestamp())
+
+	// the match results are merged with the swaps
+	klines, err = orm.GetKlinesFromTrades(types.TestTokenPair, types.TradeSourceAll, klineM1, 10, ts+300)
+	require.Nil(t, err)
+	require.Equal(t, "12", klines[0].GetClose().String())
+	require.Equal(t, "5", klines[0].GetVolume().String())
+	klines, err = orm.GetKlinesFromTrades(types.TestTokenPair, types.TradeSourceAMM, klineM1, 2, ts+300)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(klines))
+	// the trades are scanned in 30 days at most, so 30 daily klines are returned
+	klineM1440 := types.MustNewKlineFactory(types.KlineTypeM1440, nil).(types.IKline)
+	klines, err = orm.GetKlinesFromTrades(types.TestTokenPair, types.TradeSourceAMM, klineM1440, 100,
+		ts+100*types.SecondsInADay)
+	require.Nil(t, err)
+	require.Equal(t, 30, len(klines))
+	require.Equal(t, "8", klines[0].GetClose().String())
+
+	tickers, err := orm.GetTickersFromTrades([]string{types.TestTokenPair, "not_exist"}, types.TradeSourceAll, ts+300)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(tickers))
+	ticker := tickers[types.TestTokenPair]
+	require.Equal(t, "10", ticker.Open.String())
+	require.Equal(t, "8", ticker.Close.String())
+	require.Equal(t, "12", ticker.High.String())
+	require.Equal(t, "6", ticker.Volume.String())
+	require.Equal(t, "-20.00%", ticker.ChangePercentage)
+
+	// the price of the latest trade is kept without any trade in 24 hours
+	tickers, err = orm.GetTickersFromTrades([]string{types.TestTokenPair}, types.TradeSourceAMM,
+		ts+200+types.SecondsInADay+1)
+	require.Nil(t, err)
+	require.Equal(t, "8", tickers[types.TestTokenPair].Price.String())
+	require.Equal(t, "0", tickers[types.TestTokenPair].Volume.String())
+
+	// the swap trades are deleted with the blocks
+	require.Nil(t, orm.DeleteBlocksBetween(1, 2))
+	_, total = orm.GetSwapTrades("", "", "", ts, ts+300, 0, 10)
+	require.Equal(t, 1, total)
+}
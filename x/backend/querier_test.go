@@ -92,7 +92,7 @@ func TestQuerier_queryMatchList(t *testing.T) {
 
 func TestQuerier_queryDealsList(t *testing.T) {
 	_, ctx, querier, _ := mockQuerier(t)
-	params := types.NewQueryDealsParams("NotExists", types.TestTokenPair, 0, 0, 1, 100, "")
+	params := types.NewQueryDealsParams("NotExists", types.TestTokenPair, 0, 0, 1, 100, "", "")
 	request := abci.RequestQuery{}
 	requestData, errMarshal := amino.MarshalJSON(params)
 	require.Nil(t, errMarshal)
@@ -106,7 +106,7 @@ func TestQuerier_queryDealsList(t *testing.T) {
 	fmt.Println(fmt.Sprintf("finalResult: %+v, bytes: %s", finalResult, bytesBuffer))
 	require.NotNil(t, err)
 
-	params = types.NewQueryDealsParams("NotExists", types.TestTokenPair, 0, 0, 1, 100, types.BuyOrder)
+	params = types.NewQueryDealsParams("NotExists", types.TestTokenPair, 0, 0, 1, 100, types.BuyOrder, "")
 	request = abci.RequestQuery{}
 	request.Data, errMarshal = amino.MarshalJSON(params)
 	require.Nil(t, errMarshal)
@@ -115,12 +115,41 @@ func TestQuerier_queryDealsList(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestQuerier_QueryTradeSource(t *testing.T) {
+	_, ctx, querier, orders := mockQuerier(t)
+	sender := orders[0].Sender.String()
+
+	params := types.NewQueryDealsParams(sender, types.TestTokenPair, 0, 0, 1, 100, "", "unknown")
+	request := abci.RequestQuery{}
+	request.Data, _ = amino.MarshalJSON(params)
+	_, err := querier(ctx, []string{types.QueryDealList}, request)
+	require.NotNil(t, err)
+
+	// the deals of the order book are listed with the swaps
+	params.Source = types.TradeSourceAll
+	request.Data, _ = amino.MarshalJSON(params)
+	bytesBuffer, err := querier(ctx, []string{types.QueryDealList}, request)
+	require.Nil(t, err)
+	var response common.ListResponse
+	require.Nil(t, json.Unmarshal(bytesBuffer, &response))
+	require.True(t, response.Data.ParamPage.Total > 0)
+	require.Contains(t, string(bytesBuffer), `"source":"orderbook"`)
+
+	klinesParams := types.NewQueryKlinesParams(types.TestTokenPair, 60, 100, types.TradeSourceAll)
+	request.Data, _ = amino.MarshalJSON(klinesParams)
+	bytesBuffer, err = querier(ctx, []string{types.QueryCandleList}, request)
+	require.Nil(t, err)
+	finalResult := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal(bytesBuffer, &finalResult))
+	require.True(t, len(finalResult["data"].([]interface{})) > 0)
+}
+
 func TestQuerier_queryCandleList(t *testing.T) {
 
 	_, ctx, querier, _ := mockQuerier(t)
 	//time.Sleep(time.Second * 60)
 
-	params := types.NewQueryKlinesParams(types.TestTokenPair, 60, 100)
+	params := types.NewQueryKlinesParams(types.TestTokenPair, 60, 100, "")
 	request := abci.RequestQuery{}
 	requestData, errMarshal := amino.MarshalJSON(params)
 	require.Nil(t, errMarshal)
@@ -203,3 +232,42 @@ func TestQuerier_QueryTxList(t *testing.T) {
 	}
 
 }
+
+func TestQuerier_QueryIndexerStatus(t *testing.T) {
+	_, ctx, querier, _ := mockQuerier(t)
+
+	res, err := querier(ctx.WithBlockHeight(12), []string{types.QueryIndexerStatus}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var response struct {
+		Data types.IndexerStatus `json:"data"`
+	}
+	require.Nil(t, json.Unmarshal(res, &response))
+	require.EqualValues(t, 10, response.Data.LastIndexedHeight)
+	require.EqualValues(t, 12, response.Data.LatestHeight)
+	require.EqualValues(t, 2, response.Data.Lag)
+}
+
+func TestQuerier_QueryPortfolioV2(t *testing.T) {
+	_, ctx, querier, orders := mockQuerier(t)
+	sender := orders[0].Sender.String()
+
+	params := types.QueryRealizedPnLParamsV2{Address: sender, Method: "lifo", Limit: 10}
+	request := abci.RequestQuery{}
+	request.Data, _ = amino.MarshalJSON(params)
+	_, err := querier(ctx, []string{types.QueryRealizedPnLV2}, request)
+	require.NotNil(t, err)
+
+	params.Method = types.PnLMethodAverage
+	request.Data, _ = amino.MarshalJSON(params)
+	_, err = querier(ctx, []string{types.QueryRealizedPnLV2}, request)
+	require.Nil(t, err)
+
+	snapshotsParams := types.QueryBalanceSnapshotsParamsV2{Address: "NotExists", Limit: 10}
+	request.Data, _ = amino.MarshalJSON(snapshotsParams)
+	_, err = querier(ctx, []string{types.QueryBalanceSnapshotsV2}, request)
+	require.NotNil(t, err)
+	snapshotsParams.Address = sender
+	request.Data, _ = amino.MarshalJSON(snapshotsParams)
+	_, err = querier(ctx, []string{types.QueryBalanceSnapshotsV2}, request)
+	require.Nil(t, err)
+}
@@ -26,12 +26,12 @@ func prepareKlineMx(product string, refreshInterval int, open, close, low, high
 
 		b := types.BaseKline{
 			Product:   product,
-			High:      high,
-			Low:       low,
-			Volume:    volumes[i],
+			High:      types.NewDecimalFromFloat(high),
+			Low:       types.NewDecimalFromFloat(low),
+			Volume:    types.NewDecimalFromFloat(volumes[i]),
 			Timestamp: ts,
-			Open:      open,
-			Close:     close,
+			Open:      types.NewDecimalFromFloat(open),
+			Close:     types.NewDecimalFromFloat(close),
 		}
 
 		newDestK, _ := types.NewKlineFactory(destIKline.GetTableName(), &b)
@@ -49,8 +49,8 @@ func prepareMatches(product string, prices []float64, quantities []float64, endT
 			Timestamp:   endTS - int64(len(prices)) + int64(i),
 			BlockHeight: endTS + int64(i),
 			Product:     product,
-			Price:       prices[i],
-			Quantity:    quantities[i],
+			Price:       types.NewDecimalFromFloat(prices[i]),
+			Quantity:    types.NewDecimalFromFloat(quantities[i]),
 		}
 
 		matchResults = append(matchResults, match)
@@ -63,12 +63,12 @@ func aTicker(product string, open, close, high, low, price, volume float64) *typ
 	t := types.Ticker{
 		Timestamp: time.Now().Unix(),
 		Product:   product,
-		Open:      open,
-		Close:     close,
-		High:      high,
-		Low:       low,
-		Price:     price,
-		Volume:    volume,
+		Open:      types.NewDecimalFromFloat(open),
+		Close:     types.NewDecimalFromFloat(close),
+		High:      types.NewDecimalFromFloat(high),
+		Low:       types.NewDecimalFromFloat(low),
+		Price:     types.NewDecimalFromFloat(price),
+		Volume:    types.NewDecimalFromFloat(volume),
 		Symbol:    product,
 	}
 	return &t
@@ -131,13 +131,13 @@ func baseCaseRunner(t *testing.T, product string, productBuffer []string, startT
 	if expectedTicker == nil {
 		assert.True(t, gotTicker == nil && nil == expectedTicker)
 	} else {
-		assert.Equal(t, gotTicker.Price, expectedTicker.Price, gotTicker.PrettyString(), expectedTicker.PrettyString())
+		assert.Equal(t, gotTicker.Price.String(), expectedTicker.Price.String(), gotTicker.PrettyString(), expectedTicker.PrettyString())
 		assert.Equal(t, gotTicker.Product, expectedTicker.Product, gotTicker.PrettyString(), expectedTicker.PrettyString())
-		assert.Equal(t, gotTicker.Open, expectedTicker.Open, gotTicker.PrettyString(), expectedTicker.PrettyString())
-		assert.Equal(t, gotTicker.Close, expectedTicker.Close, gotTicker.PrettyString(), expectedTicker.PrettyString())
-		assert.Equal(t, gotTicker.High, expectedTicker.High, gotTicker.PrettyString(), expectedTicker.PrettyString())
-		assert.Equal(t, gotTicker.Low, expectedTicker.Low, gotTicker.PrettyString(), expectedTicker.PrettyString())
-		assert.Equal(t, gotTicker.Volume, expectedTicker.Volume, gotTicker.PrettyString(), expectedTicker.PrettyString())
+		assert.Equal(t, gotTicker.Open.String(), expectedTicker.Open.String(), gotTicker.PrettyString(), expectedTicker.PrettyString())
+		assert.Equal(t, gotTicker.Close.String(), expectedTicker.Close.String(), gotTicker.PrettyString(), expectedTicker.PrettyString())
+		assert.Equal(t, gotTicker.High.String(), expectedTicker.High.String(), gotTicker.PrettyString(), expectedTicker.PrettyString())
+		assert.Equal(t, gotTicker.Low.String(), expectedTicker.Low.String(), gotTicker.PrettyString(), expectedTicker.PrettyString())
+		assert.Equal(t, gotTicker.Volume.String(), expectedTicker.Volume.String(), gotTicker.PrettyString(), expectedTicker.PrettyString())
 	}
 
 	return nil
@@ -472,12 +472,12 @@ func TestTicker_C1(t *testing.T) {
 	assert.True(t, err == nil)
 
 	oldTicker := latestTickers["not_exist"]
-	assert.True(t, oldTicker.Open == 230.0)
-	assert.True(t, oldTicker.Close == 230.0)
-	assert.True(t, oldTicker.High == 230.0)
-	assert.True(t, oldTicker.Low == 230.0)
-	assert.True(t, oldTicker.Price == 230.0)
-	assert.True(t, oldTicker.Volume == 0)
+	assert.True(t, oldTicker.Open.Equal(types.NewDecimal(230)))
+	assert.True(t, oldTicker.Close.Equal(types.NewDecimal(230)))
+	assert.True(t, oldTicker.High.Equal(types.NewDecimal(230)))
+	assert.True(t, oldTicker.Low.Equal(types.NewDecimal(230)))
+	assert.True(t, oldTicker.Price.Equal(types.NewDecimal(230)))
+	assert.True(t, oldTicker.Volume.IsZero())
 }
 
 func TestTicker_C3(t *testing.T) {
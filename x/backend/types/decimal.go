@@ -0,0 +1,115 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/shopspring/decimal"
+)
+
+// Decimal is an exact decimal of the prices & the volumes, which is persisted in a string column & marshaled into a
+// json number. The legacy DOUBLE columns are scanned into it as well
+type Decimal struct {
+	decimal.Decimal
+}
+
+// ZeroDecimal returns a zero Decimal
+func ZeroDecimal() Decimal {
+	return Decimal{decimal.Zero}
+}
+
+// NewDecimal returns a Decimal of the integer
+func NewDecimal(i int64) Decimal {
+	return Decimal{decimal.New(i, 0)}
+}
+
+// NewDecimalFromDec returns the Decimal of the sdk.Dec exactly
+func NewDecimalFromDec(dec sdk.Dec) Decimal {
+	if dec.IsNil() {
+		return ZeroDecimal()
+	}
+	return MustNewDecimalFromStr(dec.String())
+}
+
+// NewDecimalFromFloat returns the Decimal of the float, with the fewest digits representing the float
+func NewDecimalFromFloat(f float64) Decimal {
+	return Decimal{decimal.NewFromFloat(f)}
+}
+
+// NewDecimalFromStr parses the Decimal from the string
+func NewDecimalFromStr(s string) (Decimal, error) {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{d}, nil
+}
+
+// MustNewDecimalFromStr parses the Decimal from the string, it panics on the error
+func MustNewDecimalFromStr(s string) Decimal {
+	d, err := NewDecimalFromStr(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Add returns d + d2
+func (d Decimal) Add(d2 Decimal) Decimal {
+	return Decimal{d.Decimal.Add(d2.Decimal)}
+}
+
+// Sub returns d - d2
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	return Decimal{d.Decimal.Sub(d2.Decimal)}
+}
+
+// Mul returns d * d2
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return Decimal{d.Decimal.Mul(d2.Decimal)}
+}
+
+// Div returns d / d2, rounded to decimal.DivisionPrecision digits
+func (d Decimal) Div(d2 Decimal) Decimal {
+	return Decimal{d.Decimal.Div(d2.Decimal)}
+}
+
+// Equal returns whether d == d2
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Decimal.Equal(d2.Decimal)
+}
+
+// GreaterThan returns whether d > d2
+func (d Decimal) GreaterThan(d2 Decimal) bool {
+	return d.Decimal.GreaterThan(d2.Decimal)
+}
+
+// LessThan returns whether d < d2
+func (d Decimal) LessThan(d2 Decimal) bool {
+	return d.Decimal.LessThan(d2.Decimal)
+}
+
+// MaxDecimal returns the larger of the two decimals
+func MaxDecimal(d1, d2 Decimal) Decimal {
+	if d2.GreaterThan(d1) {
+		return d2
+	}
+	return d1
+}
+
+// MinDecimal returns the smaller of the two decimals
+func MinDecimal(d1, d2 Decimal) Decimal {
+	if d2.LessThan(d1) {
+		return d2
+	}
+	return d1
+}
+
+// Float64 returns the nearest float of the decimal, for the consumers of floats only
+func (d Decimal) Float64() float64 {
+	f, _ := d.Decimal.Float64()
+	return f
+}
+
+// MarshalJSON marshals the decimal into a json number without losing any digit
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecimal(t *testing.T) {
+	d := NewDecimalFromDec(sdk.MustNewDecFromStr("0.1"))
+	require.Equal(t, "0.3", d.Add(d).Add(d).String())
+	require.Equal(t, "0.1", NewDecimalFromFloat(0.1).String())
+	require.True(t, NewDecimalFromDec(sdk.Dec{}).IsZero())
+	require.Equal(t, "0.12345679", MustNewDecimalFromStr("0.123456789").StringFixed(8))
+	require.Equal(t, NewDecimal(3), MaxDecimal(NewDecimal(2), NewDecimal(3)))
+	require.Equal(t, NewDecimal(2), MinDecimal(NewDecimal(2), NewDecimal(3)))
+	_, err := NewDecimalFromStr("1.2.3")
+	require.NotNil(t, err)
+
+	// the json of the decimal stays a number
+	deal := Deal{Product: "xxb_" + common.NativeToken, Price: MustNewDecimalFromStr("0.30000001"),
+		Quantity: NewDecimal(100)}
+	bz, err := json.Marshal(deal)
+	require.Nil(t, err)
+	require.Contains(t, string(bz), `"price":0.30000001,"volume":100`)
+	var got Deal
+	require.Nil(t, json.Unmarshal(bz, &got))
+	require.True(t, got.Price.Equal(deal.Price))
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	ammswaptypes "github.com/okex/okexchain/x/ammswap/types"
 	dextypes "github.com/okex/okexchain/x/dex/types"
 	"github.com/okex/okexchain/x/order"
 	ordertypes "github.com/okex/okexchain/x/order/types"
@@ -25,6 +26,7 @@ type OrderKeeper interface {
 type TokenKeeper interface {
 	GetFeeDetailList() []*token.FeeDetail
 	GetParams(ctx sdk.Context) (params token.Params)
+	GetCoinsInfo(ctx sdk.Context, addr sdk.AccAddress) (coinsInfo token.CoinsInfo)
 }
 
 // DexKeeper expected dex keeper
@@ -34,6 +36,12 @@ type DexKeeper interface {
 	SetObserverKeeper(keeper exported.StreamKeeper)
 }
 
+// SwapKeeper expected swap keeper
+type SwapKeeper interface {
+	GetSwapExecutions(ctx sdk.Context) []ammswaptypes.SwapExecution
+	GetSwapTokenPairs(ctx sdk.Context) []ammswaptypes.SwapTokenPair
+}
+
 // MarketKeeper expected market keeper which would get data from pulsar & redis
 type MarketKeeper interface {
 	GetTickerByProducts(products []string) ([]map[string]string, error)
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"github.com/okex/okexchain/x/token"
+)
+
+const (
+	// CheckpointLastIndexedHeight is the name of the checkpoint of the last block stored in the backend database
+	CheckpointLastIndexedHeight = "last_indexed_height"
+	// CheckpointFirstMissedHeight is the name of the checkpoint of the first block missed in the backend database,
+	// which is filled by the reindex before the next start
+	CheckpointFirstMissedHeight = "first_missed_height"
+
+	// MaxPendingBlocks is the max number of the blocks kept in memory when they fail to be stored
+	MaxPendingBlocks = 1000
+)
+
+// Checkpoint records the progress of the backend database
+type Checkpoint struct {
+	Name      string `gorm:"PRIMARY_KEY;type:varchar(40)" json:"name"`
+	Height    int64  `gorm:"type:bigint" json:"height"`
+	Timestamp int64  `gorm:"type:bigint" json:"timestamp"`
+}
+
+// BlockData is all of the backend data of a block, which is stored atomically
+type BlockData struct {
+	Height        int64
+	Timestamp     int64
+	NewOrders     []*Order
+	UpdatedOrders []*Order
+	Deals         []*Deal
+	MatchResults  []*MatchResult
+	FeeDetails    []*token.FeeDetail
+	Transactions  []*Transaction
+	SwapTrades    []*SwapTrade
+	// the balances of the accounts changed since the last snapshot, taken at the heights of the snapshot interval
+	BalanceSnapshots []*BalanceSnapshot
+}
+
+// HeightRange is the block heights in [From, To]
+type HeightRange struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// IndexerStatus shows how far the backend database is behind the chain
+type IndexerStatus struct {
+	LastIndexedHeight    int64         `json:"last_indexed_height"`
+	LastIndexedTimestamp int64         `json:"last_indexed_timestamp"`
+	LatestHeight         int64         `json:"latest_height"`
+	Lag                  int64         `json:"lag"`
+	PendingBlocks        int           `json:"pending_blocks"`
+	Gaps                 []HeightRange `json:"gaps"`
+}
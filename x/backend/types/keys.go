@@ -10,26 +10,29 @@ const (
 	RouterKey = ""
 
 	// query endpoints supported by the backend querier
-	QueryMatchResults = "matches"
-	QueryDealList     = "deals"
-	QueryFeeDetails   = "fees"
-	QueryOrderList    = "orders"
-	QueryTxList       = "txs"
-	QueryCandleList   = "candles"
-	QueryTickerList   = "tickers"
-	QueryDexFeesList  = "dexFees"
+	QueryMatchResults  = "matches"
+	QueryDealList      = "deals"
+	QueryFeeDetails    = "fees"
+	QueryOrderList     = "orders"
+	QueryTxList        = "txs"
+	QueryCandleList    = "candles"
+	QueryTickerList    = "tickers"
+	QueryDexFeesList   = "dexFees"
+	QueryIndexerStatus = "indexerStatus"
 
 	// v2
-	QueryTickerListV2   = "tickerListV2"
-	QueryTickerV2       = "tickerV2"
-	QueryInstrumentsV2  = "instrumentsV2"
-	QueryOrderListV2    = "orderListV2"
-	QueryOrderV2        = "orderV2"
-	QueryCandleListV2   = "candlesV2"
-	QueryMatchResultsV2 = "matchesV2"
-	QueryFeeDetailsV2   = "feesV2"
-	QueryDealListV2     = "dealsV2"
-	QueryTxListV2       = "txsV2"
+	QueryTickerListV2       = "tickerListV2"
+	QueryTickerV2           = "tickerV2"
+	QueryInstrumentsV2      = "instrumentsV2"
+	QueryOrderListV2        = "orderListV2"
+	QueryOrderV2            = "orderV2"
+	QueryCandleListV2       = "candlesV2"
+	QueryMatchResultsV2     = "matchesV2"
+	QueryFeeDetailsV2       = "feesV2"
+	QueryDealListV2         = "dealsV2"
+	QueryTxListV2           = "txsV2"
+	QueryBalanceSnapshotsV2 = "balanceSnapshotsV2"
+	QueryRealizedPnLV2      = "realizedPnLV2"
 
 	// kline const
 
@@ -37,4 +40,7 @@ const (
 	KlinexGoRoutineWaitInSecond = 10
 
 	SecondsInADay = 24 * 60 * 60
+	// MaxTradeKlineRange is the longest range of the trades scanned for the klines aggregated from the trades, in
+	// seconds. The klines of the order book alone are merged & persisted, the ones with the swaps aren't
+	MaxTradeKlineRange = 30 * SecondsInADay
 )
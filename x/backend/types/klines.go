@@ -34,11 +34,11 @@ type IKline interface {
 	GetTableName() string
 	GetProduct() string
 	GetTimestamp() int64
-	GetOpen() float64
-	GetClose() float64
-	GetHigh() float64
-	GetLow() float64
-	GetVolume() float64
+	GetOpen() Decimal
+	GetClose() Decimal
+	GetHigh() Decimal
+	GetLow() Decimal
+	GetVolume() Decimal
 	PrettyTimeString() string
 	GetBriefInfo() []string
 }
@@ -129,11 +129,11 @@ func (klines IKlinesDsc) Less(i, j int) bool {
 type BaseKline struct {
 	Product   string  `gorm:"PRIMARY_KEY;type:varchar(20)" json:"product"`
 	Timestamp int64   `gorm:"PRIMARY_KEY;type:bigint;" json:"timestamp"`
-	Open      float64 `gorm:"type:DOUBLE" json:"open"`
-	Close     float64 `gorm:"type:DOUBLE" json:"close"`
-	High      float64 `gorm:"type:DOUBLE" json:"high"`
-	Low       float64 `gorm:"type:DOUBLE" json:"low"`
-	Volume    float64 `gorm:"type:DOUBLE" json:"volume"`
+	Open      Decimal `gorm:"type:varchar(40)" json:"open"`
+	Close     Decimal `gorm:"type:varchar(40)" json:"close"`
+	High      Decimal `gorm:"type:varchar(40)" json:"high"`
+	Low       Decimal `gorm:"type:varchar(40)" json:"low"`
+	Volume    Decimal `gorm:"type:varchar(40)" json:"volume"`
 	impl      IKline
 }
 
@@ -193,27 +193,27 @@ func (b *BaseKline) GetTimestamp() int64 {
 }
 
 // GetOpen return open price
-func (b *BaseKline) GetOpen() float64 {
+func (b *BaseKline) GetOpen() Decimal {
 	return b.Open
 }
 
 // GetClose return close price
-func (b *BaseKline) GetClose() float64 {
+func (b *BaseKline) GetClose() Decimal {
 	return b.Close
 }
 
 // GetHigh return high price
-func (b *BaseKline) GetHigh() float64 {
+func (b *BaseKline) GetHigh() Decimal {
 	return b.High
 }
 
 // GetLow return low price
-func (b *BaseKline) GetLow() float64 {
+func (b *BaseKline) GetLow() Decimal {
 	return b.Low
 }
 
 // GetVolume return volume of trade quantity
-func (b *BaseKline) GetVolume() float64 {
+func (b *BaseKline) GetVolume() Decimal {
 	return b.Volume
 }
 
@@ -221,11 +221,11 @@ func (b *BaseKline) GetVolume() float64 {
 func (b *BaseKline) GetBriefInfo() []string {
 	m := []string{
 		time.Unix(b.GetTimestamp(), 0).UTC().Format("2006-01-02T15:04:05.000Z"),
-		fmt.Sprintf("%.4f", b.GetOpen()),
-		fmt.Sprintf("%.4f", b.GetHigh()),
-		fmt.Sprintf("%.4f", b.GetLow()),
-		fmt.Sprintf("%.4f", b.GetClose()),
-		fmt.Sprintf("%.8f", b.GetVolume()),
+		b.GetOpen().StringFixed(4),
+		b.GetHigh().StringFixed(4),
+		b.GetLow().StringFixed(4),
+		b.GetClose().StringFixed(4),
+		b.GetVolume().StringFixed(8),
 	}
 	return m
 }
@@ -244,8 +244,9 @@ func TimeString(ts int64) string {
 
 // PrettyTimeString  convert kline data to string
 func (b *BaseKline) PrettyTimeString() string {
-	return fmt.Sprintf("Product: %s, Freq: %d, Time: %s, OCHLV(%.4f, %.4f, %.4f, %.4f, %.4f)",
-		b.Product, b.GetFreqInSecond(), TimeString(b.Timestamp), b.Open, b.Close, b.High, b.Low, b.Volume)
+	return fmt.Sprintf("Product: %s, Freq: %d, Time: %s, OCHLV(%s, %s, %s, %s, %s)",
+		b.Product, b.GetFreqInSecond(), TimeString(b.Timestamp), b.Open.StringFixed(4), b.Close.StringFixed(4),
+		b.High.StringFixed(4), b.Low.StringFixed(4), b.Volume.StringFixed(4))
 }
 
 // KlineM1 define kline data in 1 minute
@@ -788,7 +789,7 @@ func ToIKlinesArray(klines interface{}, endTS int64, doPadding bool) []IKline {
 			Close:     lastKline.GetClose(),
 			High:      lastKline.GetClose(),
 			Low:       lastKline.GetClose(),
-			Volume:    0,
+			Volume:    ZeroDecimal(),
 		}
 		newKline := MustNewKlineFactory(lastKline.GetTableName(), &baseKline)
 		newKlines := []IKline{newKline.(IKline)}
@@ -811,7 +812,7 @@ func ToIKlinesArray(klines interface{}, endTS int64, doPadding bool) []IKline {
 				Close:     crrIKline.GetClose(),
 				High:      crrIKline.GetClose(),
 				Low:       crrIKline.GetClose(),
-				Volume:    0,
+				Volume:    ZeroDecimal(),
 			}
 
 			newKline := MustNewKlineFactory(crrIKline.GetTableName(), &baseKline)
@@ -95,11 +95,11 @@ func TestBaseKLine(t *testing.T) {
 	bk := BaseKline{
 		"flt_" + common.NativeToken,
 		time.Now().Unix(),
-		100,
-		101,
-		103,
-		99,
-		400,
+		NewDecimal(100),
+		NewDecimal(101),
+		NewDecimal(103),
+		NewDecimal(99),
+		NewDecimal(400),
 		nil,
 	}
 
@@ -111,8 +111,9 @@ func TestBaseKLine(t *testing.T) {
 	assert.True(t, bi[5] == "400.00000000")
 
 	require.Equal(t, bk.Product, bk.GetProduct())
-	str := fmt.Sprintf("Product: %s, Freq: %d, Time: %s, OCHLV(%.4f, %.4f, %.4f, %.4f, %.4f)",
-		bk.Product, bk.GetFreqInSecond(), TimeString(bk.Timestamp), bk.Open, bk.Close, bk.High, bk.Low, bk.Volume)
+	str := fmt.Sprintf("Product: %s, Freq: %d, Time: %s, OCHLV(%s, %s, %s, %s, %s)",
+		bk.Product, bk.GetFreqInSecond(), TimeString(bk.Timestamp), bk.Open.StringFixed(4), bk.Close.StringFixed(4),
+		bk.High.StringFixed(4), bk.Low.StringFixed(4), bk.Volume.StringFixed(4))
 	require.Equal(t, str, bk.PrettyTimeString())
 	require.Equal(t, -1, bk.GetFreqInSecond())
 	require.Equal(t, "base_kline", bk.GetTableName())
@@ -138,11 +139,11 @@ func TestToIKlinesArray(t *testing.T) {
 	bk := &BaseKline{
 		"flt_" + common.NativeToken,
 		time.Now().Unix(),
-		100,
-		101,
-		103,
-		99,
-		400,
+		NewDecimal(100),
+		NewDecimal(101),
+		NewDecimal(103),
+		NewDecimal(99),
+		NewDecimal(400),
 		nil,
 	}
 
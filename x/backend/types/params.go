@@ -17,10 +17,11 @@ type QueryDealsParams struct {
 	Page    int
 	PerPage int
 	Side    string
+	Source  string
 }
 
 // NewQueryDealsParams creates a new instance of QueryDealsParams
-func NewQueryDealsParams(addr, product string, start, end int64, page, perPage int, side string) QueryDealsParams {
+func NewQueryDealsParams(addr, product string, start, end int64, page, perPage int, side, source string) QueryDealsParams {
 	if page == 0 && perPage == 0 {
 		page = DefaultPage
 		perPage = DefaultPerPage
@@ -33,6 +34,7 @@ func NewQueryDealsParams(addr, product string, start, end int64, page, perPage i
 		Page:    page,
 		PerPage: perPage,
 		Side:    side,
+		Source:  source,
 	}
 }
 
@@ -85,14 +87,16 @@ type QueryKlinesParams struct {
 	Product     string
 	Granularity int
 	Size        int
+	Source      string
 }
 
 // NewQueryKlinesParams creates a new instance of QueryKlinesParams
-func NewQueryKlinesParams(product string, granularity, size int) QueryKlinesParams {
+func NewQueryKlinesParams(product string, granularity, size int, source string) QueryKlinesParams {
 	return QueryKlinesParams{
 		product,
 		granularity,
 		size,
+		source,
 	}
 }
 
@@ -101,6 +105,7 @@ type QueryTickerParams struct {
 	Product string `json:"product"`
 	Count   int    `json:"count"`
 	Sort    bool   `json:"sort"`
+	Source  string `json:"source"`
 }
 
 // nolint
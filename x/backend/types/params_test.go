@@ -16,9 +16,11 @@ func TestNewQueryDealsParams(t *testing.T) {
 		Page:    3,
 		PerPage: 17,
 		Side:    "side",
+		Source:  TradeSourceAMM,
 	}
 
-	got := NewQueryDealsParams(want.Address, want.Product, want.Start, want.End, want.Page, want.PerPage, want.Side)
+	got := NewQueryDealsParams(want.Address, want.Product, want.Start, want.End, want.Page, want.PerPage, want.Side,
+		want.Source)
 	require.Equal(t, want, got)
 
 	want = QueryDealsParams{
@@ -31,7 +33,7 @@ func TestNewQueryDealsParams(t *testing.T) {
 		Side:    "side",
 	}
 
-	got = NewQueryDealsParams(want.Address, want.Product, want.Start, want.End, 0, 0, want.Side)
+	got = NewQueryDealsParams(want.Address, want.Product, want.Start, want.End, 0, 0, want.Side, "")
 	require.Equal(t, want, got)
 }
 
@@ -58,8 +60,9 @@ func TestNewQueryKlinesParams(t *testing.T) {
 		Product:     "okb_btc",
 		Granularity: 3,
 		Size:        9,
+		Source:      TradeSourceAll,
 	}
-	got := NewQueryKlinesParams(want.Product, want.Granularity, want.Size)
+	got := NewQueryKlinesParams(want.Product, want.Granularity, want.Size, want.Source)
 	require.Equal(t, want, got)
 }
 
@@ -0,0 +1,217 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/token"
+)
+
+// nolint
+const (
+	PnLMethodFIFO    = "fifo"
+	PnLMethodAverage = "average"
+)
+
+// ValidatePnLMethod checks the method of the cost basis of the realized pnl, the empty one is fifo
+func ValidatePnLMethod(method string) error {
+	switch method {
+	case "", PnLMethodFIFO, PnLMethodAverage:
+		return nil
+	default:
+		return fmt.Errorf("method should be one of %s and %s instead of %s", PnLMethodFIFO, PnLMethodAverage, method)
+	}
+}
+
+// BalanceSnapshot is the balance of a currency of an account at the height of the snapshot, which is taken for the
+// accounts changed since the last snapshot
+type BalanceSnapshot struct {
+	Address     string  `gorm:"PRIMARY_KEY;type:varchar(80)" json:"address" v2:"address"`
+	Currency    string  `gorm:"PRIMARY_KEY;type:varchar(40)" json:"currency" v2:"currency"`
+	BlockHeight int64   `gorm:"PRIMARY_KEY;type:bigint" json:"block_height" v2:"block_height"`
+	Timestamp   int64   `gorm:"index;type:bigint" json:"timestamp" v2:"timestamp"`
+	Available   Decimal `gorm:"type:varchar(40)" json:"available" v2:"available"`
+	Locked      Decimal `gorm:"type:varchar(40)" json:"locked" v2:"locked"`
+}
+
+// NewBalanceSnapshots converts the coins info of the account into the snapshots at the height
+func NewBalanceSnapshots(address string, coinsInfo token.CoinsInfo, height, timestamp int64) []*BalanceSnapshot {
+	snapshots := make([]*BalanceSnapshot, 0, len(coinsInfo))
+	for _, coinInfo := range coinsInfo {
+		available, err := NewDecimalFromStr(coinInfo.Available)
+		if err != nil {
+			available = ZeroDecimal()
+		}
+		locked, err := NewDecimalFromStr(coinInfo.Locked)
+		if err != nil {
+			locked = ZeroDecimal()
+		}
+		snapshots = append(snapshots, &BalanceSnapshot{
+			Address:     address,
+			Currency:    coinInfo.Symbol,
+			Timestamp:   timestamp,
+			BlockHeight: height,
+			Available:   available,
+			Locked:      locked,
+		})
+	}
+	return snapshots
+}
+
+// RealizedPnL is the pnl realized by a sell deal of an account, in the quote token of the product. The cost of the
+// base token sold is the price of the buy deals before it by the method, the fees are not counted in
+type RealizedPnL struct {
+	Address       string  `gorm:"PRIMARY_KEY;type:varchar(80)" json:"address" v2:"address"`
+	Method        string  `gorm:"PRIMARY_KEY;type:varchar(10)" json:"method" v2:"method"`
+	BlockHeight   int64   `gorm:"PRIMARY_KEY;type:bigint" json:"block_height" v2:"block_height"`
+	OrderID       string  `gorm:"PRIMARY_KEY;type:varchar(30)" json:"order_id" v2:"order_id"`
+	Sequence      int64   `gorm:"PRIMARY_KEY;type:bigint;default:0" json:"sequence" v2:"sequence"` // the sequence of the sell deal
+	Timestamp     int64   `gorm:"index;type:bigint" json:"timestamp" v2:"timestamp"`
+	Product       string  `gorm:"index;type:varchar(40)" json:"product" v2:"product"`
+	Quantity      Decimal `gorm:"type:varchar(40)" json:"volume" v2:"volume"` // the quantity sold with a cost, the rest isn't counted in
+	Price         Decimal `gorm:"type:varchar(40)" json:"price" v2:"price"`
+	CostPrice     Decimal `gorm:"type:varchar(40)" json:"cost_price" v2:"cost_price"`
+	PnL           Decimal `gorm:"column:pnl;type:varchar(40)" json:"pnl" v2:"pnl"`
+	CumulativePnL Decimal `gorm:"column:cumulative_pnl;type:varchar(40)" json:"cumulative_pnl" v2:"cumulative_pnl"` // the pnl of the product realized so far
+}
+
+// TableName returns the name of the table of the realized pnl
+func (RealizedPnL) TableName() string {
+	return "realized_pnls"
+}
+
+// CostLots is the lots of the base token of a product held by an account after a block, which are the cost of the pnl
+// realized by the sell deals after it. It's kept for each block with the deals of the account, so that it's deleted &
+// rebuilt with the block
+type CostLots struct {
+	Address     string `gorm:"PRIMARY_KEY;type:varchar(80)"`
+	Product     string `gorm:"PRIMARY_KEY;type:varchar(40)"`
+	Method      string `gorm:"PRIMARY_KEY;type:varchar(10)"`
+	BlockHeight int64  `gorm:"PRIMARY_KEY;type:bigint"`
+	// the lots in the order of the time, each of which is quantity@price, joined by commas
+	Lots          string  `gorm:"type:text"`
+	CumulativePnL Decimal `gorm:"column:cumulative_pnl;type:varchar(40)"`
+}
+
+// NewCostLots returns the cost lots of the product held by the account before any deal
+func NewCostLots(address, product, method string) CostLots {
+	return CostLots{Address: address, Product: product, Method: method, CumulativePnL: ZeroDecimal()}
+}
+
+// costLot is the quantity bought at the price, which is sold later
+type costLot struct {
+	quantity Decimal
+	price    Decimal
+}
+
+// ApplyDeals applies the deals of the product in the order of the time to the lots, and returns the pnl realized by
+// the sell deals. The cost of the quantity sold is the earliest lots bought by fifo, or the average price of all of
+// the lots held by average. The quantity sold beyond the lots held has no cost and isn't counted in
+func (c *CostLots) ApplyDeals(deals []*Deal) ([]*RealizedPnL, error) {
+	lots, err := parseLots(c.Lots)
+	if err != nil {
+		return nil, err
+	}
+
+	var pnls []*RealizedPnL
+	for _, deal := range deals {
+		if strings.ToUpper(deal.Side) == BuyOrder {
+			lots = append(lots, costLot{quantity: deal.Quantity, price: deal.Price})
+			if c.Method == PnLMethodAverage {
+				lots = []costLot{averageLots(lots)}
+			}
+			continue
+		}
+
+		// sell
+		quantity, cost := ZeroDecimal(), ZeroDecimal()
+		remaining := deal.Quantity
+		for len(lots) > 0 && remaining.GreaterThan(ZeroDecimal()) {
+			closed := MinDecimal(lots[0].quantity, remaining)
+			quantity = quantity.Add(closed)
+			cost = cost.Add(closed.Mul(lots[0].price))
+			remaining = remaining.Sub(closed)
+			lots[0].quantity = lots[0].quantity.Sub(closed)
+			if lots[0].quantity.IsZero() {
+				lots = lots[1:]
+			}
+		}
+		if quantity.IsZero() {
+			continue
+		}
+
+		pnl := roundDecimal(deal.Price.Mul(quantity).Sub(cost))
+		c.CumulativePnL = c.CumulativePnL.Add(pnl)
+		pnls = append(pnls, &RealizedPnL{
+			Address:       c.Address,
+			Method:        c.Method,
+			BlockHeight:   deal.BlockHeight,
+			OrderID:       deal.OrderID,
+			Sequence:      deal.Sequence,
+			Timestamp:     deal.Timestamp,
+			Product:       deal.Product,
+			Quantity:      quantity,
+			Price:         deal.Price,
+			CostPrice:     roundDecimal(cost.Div(quantity)),
+			PnL:           pnl,
+			CumulativePnL: c.CumulativePnL,
+		})
+	}
+	c.Lots = formatLots(lots)
+	return pnls, nil
+}
+
+// parseLots parses the lots formatted by formatLots
+func parseLots(s string) ([]costLot, error) {
+	if s == "" {
+		return nil, nil
+	}
+	items := strings.Split(s, ",")
+	lots := make([]costLot, 0, len(items))
+	for _, item := range items {
+		fields := strings.Split(item, "@")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid cost lot %s", item)
+		}
+		quantity, err := NewDecimalFromStr(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		price, err := NewDecimalFromStr(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		lots = append(lots, costLot{quantity: quantity, price: price})
+	}
+	return lots, nil
+}
+
+// formatLots formats the lots into quantity@price joined by commas
+func formatLots(lots []costLot) string {
+	items := make([]string, 0, len(lots))
+	for _, lot := range lots {
+		if !lot.quantity.IsZero() {
+			items = append(items, fmt.Sprintf("%s@%s", lot.quantity, lot.price))
+		}
+	}
+	return strings.Join(items, ",")
+}
+
+// averageLots merges the lots into one at the average price
+func averageLots(lots []costLot) costLot {
+	quantity, cost := ZeroDecimal(), ZeroDecimal()
+	for _, lot := range lots {
+		quantity = quantity.Add(lot.quantity)
+		cost = cost.Add(lot.quantity.Mul(lot.price))
+	}
+	if quantity.IsZero() {
+		return costLot{quantity: quantity, price: ZeroDecimal()}
+	}
+	return costLot{quantity: quantity, price: cost.Div(quantity)}
+}
+
+// roundDecimal rounds the decimal to the precision of the chain
+func roundDecimal(d Decimal) Decimal {
+	return Decimal{d.Round(sdk.Precision)}
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostLots_ApplyDeals(t *testing.T) {
+	newDeal := func(height int64, side string, price, quantity int64) *Deal {
+		return &Deal{Timestamp: height, BlockHeight: height, OrderID: "ID", Product: TestTokenPair, Side: side,
+			Price: NewDecimal(price), Quantity: NewDecimal(quantity)}
+	}
+	deals := []*Deal{
+		newDeal(1, BuyOrder, 10, 1),
+		newDeal(2, BuyOrder, 20, 1),
+		newDeal(3, SellOrder, 30, 1),
+		// the quantity sold beyond the lots held isn't counted in
+		newDeal(4, SellOrder, 25, 3),
+		newDeal(5, SellOrder, 25, 1),
+	}
+
+	// fifo sells the lot at 10 first
+	costLots := NewCostLots("addr1", TestTokenPair, PnLMethodFIFO)
+	pnls, err := costLots.ApplyDeals(deals)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(pnls))
+	require.Equal(t, "10", pnls[0].CostPrice.String())
+	require.Equal(t, "20", pnls[0].PnL.String())
+	require.Equal(t, "1", pnls[1].Quantity.String())
+	require.Equal(t, "5", pnls[1].PnL.String())
+	require.Equal(t, "25", pnls[1].CumulativePnL.String())
+	require.Equal(t, "", costLots.Lots)
+
+	// average sells at the cost of 15
+	costLots = NewCostLots("addr1", TestTokenPair, PnLMethodAverage)
+	pnls, err = costLots.ApplyDeals(deals)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(pnls))
+	require.Equal(t, "15", pnls[0].CostPrice.String())
+	require.Equal(t, "15", pnls[0].PnL.String())
+	require.Equal(t, "10", pnls[1].PnL.String())
+	require.Equal(t, "25", pnls[1].CumulativePnL.String())
+
+	// the lots are kept between the blocks
+	costLots = NewCostLots("addr1", TestTokenPair, PnLMethodFIFO)
+	_, err = costLots.ApplyDeals(deals[:2])
+	require.Nil(t, err)
+	require.Equal(t, "1@10,1@20", costLots.Lots)
+	pnls, err = costLots.ApplyDeals(deals[2:])
+	require.Nil(t, err)
+	require.Equal(t, 2, len(pnls))
+	require.Equal(t, "25", pnls[1].CumulativePnL.String())
+
+	costLots.Lots = "1@"
+	_, err = costLots.ApplyDeals(deals)
+	require.NotNil(t, err)
+
+	require.Nil(t, ValidatePnLMethod(""))
+	require.NotNil(t, ValidatePnLMethod("lifo"))
+}
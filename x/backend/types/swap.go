@@ -0,0 +1,54 @@
+package types
+
+import (
+	"fmt"
+)
+
+// nolint
+const (
+	TradeSourceOrderBook = "orderbook"
+	TradeSourceAMM       = "amm"
+	TradeSourceAll       = "all"
+)
+
+// ValidateTradeSource checks the source of the trades, the empty one is the order book
+func ValidateTradeSource(source string) error {
+	switch source {
+	case "", TradeSourceOrderBook, TradeSourceAMM, TradeSourceAll:
+		return nil
+	default:
+		return fmt.Errorf("source should be one of %s, %s and %s instead of %s",
+			TradeSourceOrderBook, TradeSourceAMM, TradeSourceAll, source)
+	}
+}
+
+// SwapTrade is a hop of a swap executed by an ammswap pool, which trades the product at the price of the pool.
+// The product is the token pair of the dex if there's one of the two tokens, or the swap token pair otherwise
+type SwapTrade struct {
+	Timestamp     int64   `gorm:"index;" json:"timestamp" v2:"timestamp"`
+	BlockHeight   int64   `gorm:"PRIMARY_KEY;type:bigint" json:"block_height" v2:"block_height"`
+	Sequence      int64   `gorm:"PRIMARY_KEY;type:bigint" json:"sequence" v2:"sequence"` // the order of the hop in the block
+	TxHash        string  `gorm:"type:varchar(80)" json:"txhash" v2:"txhash"`
+	Pool          string  `gorm:"index;type:varchar(40)" json:"pool" v2:"pool"`
+	Product       string  `gorm:"index;type:varchar(40)" json:"product" v2:"product"`
+	Sender        string  `gorm:"index;type:varchar(80)" json:"sender" v2:"sender"`
+	Side          string  `gorm:"type:varchar(10)" json:"side" v2:"side"`
+	Price         Decimal `gorm:"type:varchar(40)" json:"price" v2:"price"`
+	Quantity      Decimal `gorm:"type:varchar(40)" json:"volume" v2:"volume"`             // the amount of the base token
+	QuoteQuantity Decimal `gorm:"type:varchar(40)" json:"quote_volume" v2:"quote_volume"` // the amount of the quote token
+	Fee           string  `gorm:"type:varchar(60)" json:"fee" v2:"fee"`                   // charged from the sold token
+}
+
+// Trade is a deal of the order book or a swap of the ammswap pools, which are listed together
+type Trade struct {
+	Source      string  `json:"source"`
+	Timestamp   int64   `json:"timestamp"`
+	BlockHeight int64   `json:"block_height"`
+	ID          string  `json:"id"` // the order id of the deal, or the tx hash of the swap
+	Sender      string  `json:"sender"`
+	Product     string  `json:"product"`
+	Side        string  `json:"side"`
+	Price       Decimal `json:"price"`
+	Quantity    Decimal `json:"volume"`
+	Fee         string  `json:"fee"`
+}
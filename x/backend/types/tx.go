@@ -9,30 +9,120 @@ import (
 	"github.com/willf/bitset"
 )
 
+// TxContext is the context of a tx delivered, in which the transactions of its msgs are built
+type TxContext struct {
+	Ctx         sdk.Context
+	Tx          *auth.StdTx
+	TxHash      string
+	Timestamp   int64
+	OrderKeeper OrderKeeper
+
+	orderHandlerTxResult []bitset.BitSet
+	orderHandlerIdx      int
+}
+
+// TxBuilder builds the transactions of a msg delivered successfully
+type TxBuilder func(txCtx *TxContext, msg sdk.Msg) []*Transaction
+
+// txBuilders are the TxBuilders registered, keyed by the route & the type of the msgs
+var txBuilders = make(map[string]TxBuilder)
+
+func txBuilderKey(route, msgType string) string {
+	return route + "/" + msgType
+}
+
+// RegisterTxBuilder registers the TxBuilder of the msgs with the route & the type, which replaces the one registered
+// before. The msgs without any TxBuilder are recorded by buildTransactionOther
+func RegisterTxBuilder(route, msgType string, builder TxBuilder) {
+	txBuilders[txBuilderKey(route, msgType)] = builder
+}
+
+// HasTxBuilder returns whether the msgs with the route & the type have a TxBuilder registered
+func HasTxBuilder(route, msgType string) bool {
+	_, ok := txBuilders[txBuilderKey(route, msgType)]
+	return ok
+}
+
 // GenerateTx return transaction, called at DeliverTx
 func GenerateTx(tx *auth.StdTx, txHash string, ctx sdk.Context, orderKeeper OrderKeeper, timestamp int64) []*Transaction {
-	orderHandlerTxResult := orderKeeper.GetTxHandlerMsgResult()
-	idx := int(0)
-	var txs []*Transaction
+	txCtx := &TxContext{
+		Ctx:                  ctx,
+		Tx:                   tx,
+		TxHash:               txHash,
+		Timestamp:            timestamp,
+		OrderKeeper:          orderKeeper,
+		orderHandlerTxResult: orderKeeper.GetTxHandlerMsgResult(),
+	}
 
+	var txs []*Transaction
 	for _, msg := range tx.GetMsgs() {
-		switch msg.Type() {
-		case "send": // token/send
-			txFrom, txTo := buildTransactionsTransfer(tx, msg.(tokenTypes.MsgSend), txHash, timestamp)
-			txs = append(txs, txFrom, txTo)
-		case "new": // order/new
-			transaction := buildTransactionNew(orderHandlerTxResult[idx], msg.(orderTypes.MsgNewOrders),
-				txHash, ctx, timestamp)
-			txs = append(txs, transaction...)
-			idx++
-		case "cancel": // order/cancel
-			transaction := buildTransactionCancel(orderHandlerTxResult[idx], msg.(orderTypes.MsgCancelOrders),
-				txHash, ctx, orderKeeper, timestamp)
-			txs = append(txs, transaction...)
-			idx++
-		default: // In other cases, do nothing
-			continue
+		builder, ok := txBuilders[txBuilderKey(msg.Route(), msg.Type())]
+		if !ok {
+			builder = buildTransactionOther
 		}
+		txs = append(txs, builder(txCtx, msg)...)
+	}
+	return txs
+}
+
+// nextOrderHandlerResult returns the result of the next order msg recorded by the order handler, whose bits are set
+// for the items handled successfully
+func (txCtx *TxContext) nextOrderHandlerResult() (bitset.BitSet, bool) {
+	if txCtx.orderHandlerIdx >= len(txCtx.orderHandlerTxResult) {
+		return bitset.BitSet{}, false
+	}
+	result := txCtx.orderHandlerTxResult[txCtx.orderHandlerIdx]
+	txCtx.orderHandlerIdx++
+	return result, true
+}
+
+// fee returns the fee of the tx
+func (txCtx *TxContext) fee() string {
+	return txCtx.Tx.Fee.Amount.String()
+}
+
+// newTransaction creates a transaction of the tx, the tx fee is only charged on the transactions of the signer
+func (txCtx *TxContext) newTransaction(txType, side int64, address sdk.AccAddress, symbol string, quantity string,
+	chargeFee bool) *Transaction {
+	fee := zeroFee()
+	if chargeFee {
+		fee = txCtx.fee()
+	}
+	return &Transaction{
+		TxHash:    txCtx.TxHash,
+		Address:   address.String(),
+		Type:      txType,
+		Side:      side,
+		Symbol:    symbol,
+		Quantity:  quantity,
+		Fee:       fee,
+		Timestamp: txCtx.Timestamp,
+	}
+}
+
+// newCoinsTransactions creates a transaction of the tx for each of the coins
+func (txCtx *TxContext) newCoinsTransactions(txType, side int64, address sdk.AccAddress, coins sdk.DecCoins,
+	chargeFee bool) []*Transaction {
+	if len(coins) == 0 {
+		return []*Transaction{txCtx.newTransaction(txType, side, address, "", sdk.ZeroDec().String(), chargeFee)}
+	}
+	txs := make([]*Transaction, 0, len(coins))
+	for i, coin := range coins {
+		txs = append(txs, txCtx.newTransaction(txType, side, address, coin.Denom, coin.Amount.String(),
+			chargeFee && i == 0))
+	}
+	return txs
+}
+
+func zeroFee() string {
+	return sdk.DecCoin{Denom: common.NativeToken, Amount: sdk.ZeroDec()}.String()
+}
+
+// buildTransactionOther records the msgs without any TxBuilder for their signers
+func buildTransactionOther(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+	var txs []*Transaction
+	for _, signer := range msg.GetSigners() {
+		txs = append(txs, txCtx.newTransaction(TxTypeOther, 0, signer, "", sdk.ZeroDec().String(), true))
 	}
 	return txs
 }
@@ -129,3 +219,8 @@ func buildTransactionCancel(handlerMsgResult bitset.BitSet, msg orderTypes.MsgCa
 
 	return result
 }
+
+// IsValidTxType returns whether the tx type is valid to filter the transactions by, 0 for all the types
+func IsValidTxType(txType int64) bool {
+	return txType >= 0 && txType <= TxTypeOther
+}
@@ -0,0 +1,365 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	swapTypes "github.com/okex/okexchain/x/ammswap/types"
+	dexTypes "github.com/okex/okexchain/x/dex/types"
+	distrTypes "github.com/okex/okexchain/x/distribution/types"
+	farmTypes "github.com/okex/okexchain/x/farm/types"
+	govTypes "github.com/okex/okexchain/x/gov/types"
+	orderTypes "github.com/okex/okexchain/x/order/types"
+	stakingTypes "github.com/okex/okexchain/x/staking/types"
+	tokenTypes "github.com/okex/okexchain/x/token/types"
+)
+
+func init() {
+	registerBankTxBuilders()
+	registerTokenTxBuilders()
+	registerOrderTxBuilders()
+	registerSwapTxBuilders()
+	registerStakingTxBuilders()
+	registerDexTxBuilders()
+	registerGovTxBuilders()
+	registerDistrTxBuilders()
+	registerFarmTxBuilders()
+}
+
+// registerMsgTxBuilder registers the TxBuilder of the msgs with the same route & type as the msg
+func registerMsgTxBuilder(msg sdk.Msg, builder TxBuilder) {
+	RegisterTxBuilder(msg.Route(), msg.Type(), builder)
+}
+
+// zeroQuantity is the quantity of the transactions without any coin
+var zeroQuantity = sdk.ZeroDec().String()
+
+func orderSide(side string) int64 {
+	switch side {
+	case orderTypes.BuyOrder:
+		return TxSideBuy
+	case orderTypes.SellOrder:
+		return TxSideSell
+	default:
+		return 0
+	}
+}
+
+func registerBankTxBuilders() {
+	registerMsgTxBuilder(bank.MsgSend{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		sendMsg := msg.(bank.MsgSend)
+		txs := txCtx.newCoinsTransactions(TxTypeTransfer, TxSideFrom, sendMsg.FromAddress, sendMsg.Amount, true)
+		return append(txs, txCtx.newCoinsTransactions(TxTypeTransfer, TxSideTo, sendMsg.ToAddress, sendMsg.Amount,
+			false)...)
+	})
+	registerMsgTxBuilder(bank.MsgMultiSend{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		multiSendMsg := msg.(bank.MsgMultiSend)
+		var txs []*Transaction
+		for i, input := range multiSendMsg.Inputs {
+			txs = append(txs, txCtx.newCoinsTransactions(TxTypeTransfer, TxSideFrom, input.Address, input.Coins,
+				i == 0)...)
+		}
+		for _, output := range multiSendMsg.Outputs {
+			txs = append(txs, txCtx.newCoinsTransactions(TxTypeTransfer, TxSideTo, output.Address, output.Coins,
+				false)...)
+		}
+		return txs
+	})
+}
+
+func registerTokenTxBuilders() {
+	registerMsgTxBuilder(tokenTypes.MsgSend{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		txFrom, txTo := buildTransactionsTransfer(txCtx.Tx, msg.(tokenTypes.MsgSend), txCtx.TxHash, txCtx.Timestamp)
+		return []*Transaction{txFrom, txTo}
+	})
+	registerMsgTxBuilder(tokenTypes.MsgMultiSend{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		multiSendMsg := msg.(tokenTypes.MsgMultiSend)
+		var txs []*Transaction
+		for i, transfer := range multiSendMsg.Transfers {
+			txs = append(txs, txCtx.newCoinsTransactions(TxTypeTransfer, TxSideFrom, multiSendMsg.From,
+				transfer.Coins, i == 0)...)
+			txs = append(txs, txCtx.newCoinsTransactions(TxTypeTransfer, TxSideTo, transfer.To, transfer.Coins,
+				false)...)
+		}
+		return txs
+	})
+	registerMsgTxBuilder(tokenTypes.MsgTokenIssue{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		issueMsg := msg.(tokenTypes.MsgTokenIssue)
+		return []*Transaction{txCtx.newTransaction(TxTypeTokenIssue, TxSideTo, issueMsg.Owner, issueMsg.Symbol,
+			issueMsg.TotalSupply, true)}
+	})
+	registerMsgTxBuilder(tokenTypes.MsgTokenMint{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		mintMsg := msg.(tokenTypes.MsgTokenMint)
+		return []*Transaction{txCtx.newTransaction(TxTypeTokenMint, TxSideTo, mintMsg.Owner, mintMsg.Amount.Denom,
+			mintMsg.Amount.Amount.String(), true)}
+	})
+	registerMsgTxBuilder(tokenTypes.MsgTokenBurn{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		burnMsg := msg.(tokenTypes.MsgTokenBurn)
+		return []*Transaction{txCtx.newTransaction(TxTypeTokenBurn, TxSideFrom, burnMsg.Owner, burnMsg.Amount.Denom,
+			burnMsg.Amount.Amount.String(), true)}
+	})
+	registerMsgTxBuilder(tokenTypes.MsgTokenModify{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		modifyMsg := msg.(tokenTypes.MsgTokenModify)
+		return []*Transaction{txCtx.newTransaction(TxTypeTokenManage, 0, modifyMsg.Owner, modifyMsg.Symbol,
+			zeroQuantity, true)}
+	})
+	registerMsgTxBuilder(tokenTypes.MsgTransferOwnership{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		transferMsg := msg.(tokenTypes.MsgTransferOwnership)
+		return []*Transaction{
+			txCtx.newTransaction(TxTypeTokenManage, TxSideFrom, transferMsg.FromAddress, transferMsg.Symbol,
+				zeroQuantity, true),
+			txCtx.newTransaction(TxTypeTokenManage, TxSideTo, transferMsg.ToAddress, transferMsg.Symbol,
+				zeroQuantity, false),
+		}
+	})
+	registerMsgTxBuilder(tokenTypes.MsgConfirmOwnership{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		confirmMsg := msg.(tokenTypes.MsgConfirmOwnership)
+		return []*Transaction{txCtx.newTransaction(TxTypeTokenManage, TxSideTo, confirmMsg.Address,
+			confirmMsg.Symbol, zeroQuantity, true)}
+	})
+}
+
+func registerOrderTxBuilders() {
+	// only the order msgs handled successfully are recorded by the order handler
+	registerMsgTxBuilder(orderTypes.MsgNewOrders{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		handlerMsgResult, ok := txCtx.nextOrderHandlerResult()
+		if !ok {
+			return nil
+		}
+		return buildTransactionNew(handlerMsgResult, msg.(orderTypes.MsgNewOrders), txCtx.TxHash, txCtx.Ctx,
+			txCtx.Timestamp)
+	})
+	registerMsgTxBuilder(orderTypes.MsgCancelOrders{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		handlerMsgResult, ok := txCtx.nextOrderHandlerResult()
+		if !ok {
+			return nil
+		}
+		return buildTransactionCancel(handlerMsgResult, msg.(orderTypes.MsgCancelOrders), txCtx.TxHash, txCtx.Ctx,
+			txCtx.OrderKeeper, txCtx.Timestamp)
+	})
+	registerMsgTxBuilder(orderTypes.MsgAmendOrders{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		amendMsg := msg.(orderTypes.MsgAmendOrders)
+		var txs []*Transaction
+		for _, item := range amendMsg.OrderItems {
+			order := txCtx.OrderKeeper.GetOrder(txCtx.Ctx, item.OrderID)
+			if order == nil {
+				continue
+			}
+			txs = append(txs, txCtx.newTransaction(TxTypeOrderAmend, orderSide(order.Side), amendMsg.Sender,
+				order.Product, order.Quantity.String(), len(txs) == 0))
+		}
+		return txs
+	})
+	registerMsgTxBuilder(orderTypes.MsgCancelAllOrders{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		cancelMsg := msg.(orderTypes.MsgCancelAllOrders)
+		return []*Transaction{txCtx.newTransaction(TxTypeOrderCancel, orderSide(cancelMsg.Side), cancelMsg.Sender,
+			cancelMsg.Product, zeroQuantity, true)}
+	})
+	registerMsgTxBuilder(orderTypes.MsgNewTriggerOrder{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		triggerMsg := msg.(orderTypes.MsgNewTriggerOrder)
+		return []*Transaction{txCtx.newTransaction(TxTypeTriggerOrder, orderSide(triggerMsg.Side), triggerMsg.Sender,
+			triggerMsg.Product, triggerMsg.Quantity.String(), true)}
+	})
+	registerMsgTxBuilder(orderTypes.MsgCancelTriggerOrder{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		cancelMsg := msg.(orderTypes.MsgCancelTriggerOrder)
+		return []*Transaction{txCtx.newTransaction(TxTypeTriggerOrder, 0, cancelMsg.Sender, "", zeroQuantity, true)}
+	})
+	registerMsgTxBuilder(orderTypes.MsgSetFeeSchedule{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		feeScheduleMsg := msg.(orderTypes.MsgSetFeeSchedule)
+		return []*Transaction{txCtx.newTransaction(TxTypeOrderManage, 0, feeScheduleMsg.Owner,
+			feeScheduleMsg.Product, zeroQuantity, true)}
+	})
+	registerMsgTxBuilder(orderTypes.MsgSetAuctionType{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		auctionTypeMsg := msg.(orderTypes.MsgSetAuctionType)
+		return []*Transaction{txCtx.newTransaction(TxTypeOrderManage, 0, auctionTypeMsg.Owner,
+			auctionTypeMsg.Product, zeroQuantity, true)}
+	})
+	registerMsgTxBuilder(orderTypes.MsgHeartbeat{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		heartbeatMsg := msg.(orderTypes.MsgHeartbeat)
+		return []*Transaction{txCtx.newTransaction(TxTypeOrderManage, 0, heartbeatMsg.Sender, "", zeroQuantity,
+			true)}
+	})
+	registerMsgTxBuilder(orderTypes.MsgHybridSwap{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		swapMsg := msg.(orderTypes.MsgHybridSwap)
+		return []*Transaction{txCtx.newTransaction(TxTypeSwap, orderSide(swapMsg.Side), swapMsg.Sender,
+			swapMsg.Product, swapMsg.Amount.String(), true)}
+	})
+}
+
+func registerSwapTxBuilders() {
+	registerMsgTxBuilder(swapTypes.MsgTokenToToken{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		swapMsg := msg.(swapTypes.MsgTokenToToken)
+		return []*Transaction{txCtx.newTransaction(TxTypeSwap, TxSideSell, swapMsg.Sender,
+			swapMsg.SoldTokenAmount.Denom, swapMsg.SoldTokenAmount.Amount.String(), true)}
+	})
+	registerMsgTxBuilder(swapTypes.MsgTokenToExactToken{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		swapMsg := msg.(swapTypes.MsgTokenToExactToken)
+		return []*Transaction{txCtx.newTransaction(TxTypeSwap, TxSideBuy, swapMsg.Sender,
+			swapMsg.BoughtTokenAmount.Denom, swapMsg.BoughtTokenAmount.Amount.String(), true)}
+	})
+	registerMsgTxBuilder(swapTypes.MsgAddLiquidity{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		addMsg := msg.(swapTypes.MsgAddLiquidity)
+		return []*Transaction{
+			txCtx.newTransaction(TxTypeAddLiquidity, TxSideFrom, addMsg.Sender, addMsg.MaxBaseAmount.Denom,
+				addMsg.MaxBaseAmount.Amount.String(), true),
+			txCtx.newTransaction(TxTypeAddLiquidity, TxSideFrom, addMsg.Sender, addMsg.QuoteAmount.Denom,
+				addMsg.QuoteAmount.Amount.String(), false),
+		}
+	})
+	registerMsgTxBuilder(swapTypes.MsgRemoveLiquidity{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		removeMsg := msg.(swapTypes.MsgRemoveLiquidity)
+		poolTokenName := swapTypes.GetPoolTokenName(removeMsg.MinBaseAmount.Denom, removeMsg.MinQuoteAmount.Denom)
+		return []*Transaction{txCtx.newTransaction(TxTypeRemoveLiquidity, TxSideFrom, removeMsg.Sender,
+			poolTokenName, removeMsg.Liquidity.String(), true)}
+	})
+	registerMsgTxBuilder(swapTypes.MsgCreateExchange{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		createMsg := msg.(swapTypes.MsgCreateExchange)
+		return []*Transaction{txCtx.newTransaction(TxTypeCreateExchange, 0, createMsg.Sender,
+			swapTypes.GetSwapTokenPairName(createMsg.Token0Name, createMsg.Token1Name), zeroQuantity, true)}
+	})
+}
+
+func registerStakingTxBuilders() {
+	registerMsgTxBuilder(stakingTypes.MsgDeposit{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		depositMsg := msg.(stakingTypes.MsgDeposit)
+		return []*Transaction{txCtx.newTransaction(TxTypeStakingDeposit, TxSideFrom, depositMsg.DelegatorAddress,
+			depositMsg.Amount.Denom, depositMsg.Amount.Amount.String(), true)}
+	})
+	registerMsgTxBuilder(stakingTypes.MsgWithdraw{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		withdrawMsg := msg.(stakingTypes.MsgWithdraw)
+		return []*Transaction{txCtx.newTransaction(TxTypeStakingWithdraw, TxSideTo, withdrawMsg.DelegatorAddress,
+			withdrawMsg.Amount.Denom, withdrawMsg.Amount.Amount.String(), true)}
+	})
+	registerMsgTxBuilder(stakingTypes.MsgAddShares{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		addSharesMsg := msg.(stakingTypes.MsgAddShares)
+		return []*Transaction{txCtx.newTransaction(TxTypeStakingAddShares, 0, addSharesMsg.DelAddr, "",
+			zeroQuantity, true)}
+	})
+	registerMsgTxBuilder(stakingTypes.MsgCreateValidator{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		createMsg := msg.(stakingTypes.MsgCreateValidator)
+		return []*Transaction{txCtx.newTransaction(TxTypeStakingValidator, TxSideFrom, createMsg.DelegatorAddress,
+			createMsg.MinSelfDelegation.Denom, createMsg.MinSelfDelegation.Amount.String(), true)}
+	})
+	registerMsgTxBuilder(stakingTypes.MsgEditValidator{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		editMsg := msg.(stakingTypes.MsgEditValidator)
+		return []*Transaction{txCtx.newTransaction(TxTypeStakingValidator, 0, sdk.AccAddress(editMsg.ValidatorAddress),
+			"", zeroQuantity, true)}
+	})
+	registerMsgTxBuilder(stakingTypes.MsgDestroyValidator{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		destroyMsg := msg.(stakingTypes.MsgDestroyValidator)
+		return []*Transaction{txCtx.newTransaction(TxTypeStakingValidator, 0, destroyMsg.DelAddr, "", zeroQuantity,
+			true)}
+	})
+	registerMsgTxBuilder(slashing.MsgUnjail{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		unjailMsg := msg.(slashing.MsgUnjail)
+		return []*Transaction{txCtx.newTransaction(TxTypeStakingValidator, 0, sdk.AccAddress(unjailMsg.ValidatorAddr),
+			"", zeroQuantity, true)}
+	})
+	registerMsgTxBuilder(stakingTypes.MsgRegProxy{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		regMsg := msg.(stakingTypes.MsgRegProxy)
+		return []*Transaction{txCtx.newTransaction(TxTypeStakingProxy, 0, regMsg.ProxyAddress, "", zeroQuantity,
+			true)}
+	})
+	registerMsgTxBuilder(stakingTypes.MsgBindProxy{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		bindMsg := msg.(stakingTypes.MsgBindProxy)
+		return []*Transaction{txCtx.newTransaction(TxTypeStakingProxy, 0, bindMsg.DelAddr, "", zeroQuantity, true)}
+	})
+	registerMsgTxBuilder(stakingTypes.MsgUnbindProxy{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		unbindMsg := msg.(stakingTypes.MsgUnbindProxy)
+		return []*Transaction{txCtx.newTransaction(TxTypeStakingProxy, 0, unbindMsg.DelAddr, "", zeroQuantity, true)}
+	})
+}
+
+func registerDexTxBuilders() {
+	registerMsgTxBuilder(dexTypes.MsgList{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		listMsg := msg.(dexTypes.MsgList)
+		return []*Transaction{txCtx.newTransaction(TxTypeDexList, 0, listMsg.Owner,
+			fmt.Sprintf("%s_%s", listMsg.ListAsset, listMsg.QuoteAsset), zeroQuantity, true)}
+	})
+	registerMsgTxBuilder(dexTypes.MsgDeposit{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		depositMsg := msg.(dexTypes.MsgDeposit)
+		return []*Transaction{txCtx.newTransaction(TxTypeDexDeposit, TxSideFrom, depositMsg.Depositor,
+			depositMsg.Amount.Denom, depositMsg.Amount.Amount.String(), true)}
+	})
+	registerMsgTxBuilder(dexTypes.MsgWithdraw{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		withdrawMsg := msg.(dexTypes.MsgWithdraw)
+		return []*Transaction{txCtx.newTransaction(TxTypeDexWithdraw, TxSideTo, withdrawMsg.Depositor,
+			withdrawMsg.Amount.Denom, withdrawMsg.Amount.Amount.String(), true)}
+	})
+	registerMsgTxBuilder(dexTypes.MsgTransferOwnership{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		transferMsg := msg.(dexTypes.MsgTransferOwnership)
+		return []*Transaction{
+			txCtx.newTransaction(TxTypeDexManage, TxSideFrom, transferMsg.FromAddress, transferMsg.Product,
+				zeroQuantity, true),
+			txCtx.newTransaction(TxTypeDexManage, TxSideTo, transferMsg.ToAddress, transferMsg.Product,
+				zeroQuantity, false),
+		}
+	})
+	registerMsgTxBuilder(dexTypes.MsgCreateOperator{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		createMsg := msg.(dexTypes.MsgCreateOperator)
+		return []*Transaction{txCtx.newTransaction(TxTypeDexManage, 0, createMsg.Owner, "", zeroQuantity, true)}
+	})
+	registerMsgTxBuilder(dexTypes.MsgUpdateOperator{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		updateMsg := msg.(dexTypes.MsgUpdateOperator)
+		return []*Transaction{txCtx.newTransaction(TxTypeDexManage, 0, updateMsg.Owner, "", zeroQuantity, true)}
+	})
+}
+
+func registerGovTxBuilders() {
+	registerMsgTxBuilder(govTypes.MsgSubmitProposal{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		submitMsg := msg.(govTypes.MsgSubmitProposal)
+		return txCtx.newCoinsTransactions(TxTypeGovSubmitProposal, TxSideFrom, submitMsg.Proposer,
+			submitMsg.InitialDeposit, true)
+	})
+	registerMsgTxBuilder(govTypes.MsgDeposit{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		depositMsg := msg.(govTypes.MsgDeposit)
+		return txCtx.newCoinsTransactions(TxTypeGovDeposit, TxSideFrom, depositMsg.Depositor, depositMsg.Amount, true)
+	})
+	registerMsgTxBuilder(govTypes.MsgVote{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		voteMsg := msg.(govTypes.MsgVote)
+		return []*Transaction{txCtx.newTransaction(TxTypeGovVote, 0, voteMsg.Voter, "", zeroQuantity, true)}
+	})
+}
+
+func registerDistrTxBuilders() {
+	registerMsgTxBuilder(distrTypes.MsgSetWithdrawAddress{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		setMsg := msg.(distrTypes.MsgSetWithdrawAddress)
+		return []*Transaction{txCtx.newTransaction(TxTypeDistribution, 0, setMsg.DelegatorAddress, "", zeroQuantity,
+			true)}
+	})
+	registerMsgTxBuilder(distrTypes.MsgWithdrawValidatorCommission{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		withdrawMsg := msg.(distrTypes.MsgWithdrawValidatorCommission)
+		return []*Transaction{txCtx.newTransaction(TxTypeDistribution, TxSideTo,
+			sdk.AccAddress(withdrawMsg.ValidatorAddress), "", zeroQuantity, true)}
+	})
+}
+
+func registerFarmTxBuilders() {
+	registerMsgTxBuilder(farmTypes.MsgCreatePool{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		createMsg := msg.(farmTypes.MsgCreatePool)
+		return []*Transaction{txCtx.newTransaction(TxTypeFarmManage, 0, createMsg.Owner, createMsg.PoolName,
+			zeroQuantity, true)}
+	})
+	registerMsgTxBuilder(farmTypes.MsgProvide{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		provideMsg := msg.(farmTypes.MsgProvide)
+		return []*Transaction{txCtx.newTransaction(TxTypeFarmManage, TxSideFrom, provideMsg.Owner,
+			provideMsg.Amount.Denom, provideMsg.Amount.Amount.String(), true)}
+	})
+	registerMsgTxBuilder(farmTypes.MsgStake{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		stakeMsg := msg.(farmTypes.MsgStake)
+		return []*Transaction{txCtx.newTransaction(TxTypeFarmStake, TxSideFrom, stakeMsg.Address,
+			stakeMsg.Amount.Denom, stakeMsg.Amount.Amount.String(), true)}
+	})
+	registerMsgTxBuilder(farmTypes.MsgUnstake{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		unstakeMsg := msg.(farmTypes.MsgUnstake)
+		return []*Transaction{txCtx.newTransaction(TxTypeFarmUnstake, TxSideTo, unstakeMsg.Address,
+			unstakeMsg.Amount.Denom, unstakeMsg.Amount.Amount.String(), true)}
+	})
+	registerMsgTxBuilder(farmTypes.MsgClaim{}, func(txCtx *TxContext, msg sdk.Msg) []*Transaction {
+		claimMsg := msg.(farmTypes.MsgClaim)
+		return []*Transaction{txCtx.newTransaction(TxTypeFarmClaim, TxSideTo, claimMsg.Address, claimMsg.PoolName,
+			zeroQuantity, true)}
+	})
+}
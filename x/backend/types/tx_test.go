@@ -11,8 +11,18 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	swap "github.com/okex/okexchain/x/ammswap/types"
+	dex "github.com/okex/okexchain/x/dex/types"
+	distr "github.com/okex/okexchain/x/distribution/types"
+	farm "github.com/okex/okexchain/x/farm/types"
+	gov "github.com/okex/okexchain/x/gov/types"
 	"github.com/okex/okexchain/x/order"
 	orderKeeper "github.com/okex/okexchain/x/order/keeper"
+	orderTypes "github.com/okex/okexchain/x/order/types"
+	staking "github.com/okex/okexchain/x/staking/types"
 	tokenKeeper "github.com/okex/okexchain/x/token"
 	token "github.com/okex/okexchain/x/token/types"
 	"github.com/stretchr/testify/require"
@@ -95,31 +105,31 @@ func TestTicker(t *testing.T) {
 		Symbol:           "btc",
 		Product:          "btc_" + common.NativeToken,
 		Timestamp:        0,
-		Open:             10.5,
-		Close:            53.5,
-		High:             100,
-		Low:              6.66,
-		Price:            2.46,
-		Volume:           3000,
-		Change:           43,
+		Open:             MustNewDecimalFromStr("10.5"),
+		Close:            MustNewDecimalFromStr("53.5"),
+		High:             MustNewDecimalFromStr("100"),
+		Low:              MustNewDecimalFromStr("6.66"),
+		Price:            MustNewDecimalFromStr("2.46"),
+		Volume:           MustNewDecimalFromStr("3000"),
+		Change:           MustNewDecimalFromStr("43"),
 		ChangePercentage: "409.52%",
 	}
 	tiker2 := Ticker{
 		Symbol:           "eth",
 		Product:          "eth_" + common.NativeToken,
 		Timestamp:        0,
-		Open:             3.8,
-		Close:            15.9,
-		High:             200,
-		Low:              2,
-		Price:            9.6,
-		Volume:           110,
-		Change:           12.1,
+		Open:             MustNewDecimalFromStr("3.8"),
+		Close:            MustNewDecimalFromStr("15.9"),
+		High:             MustNewDecimalFromStr("200"),
+		Low:              MustNewDecimalFromStr("2"),
+		Price:            MustNewDecimalFromStr("9.6"),
+		Volume:           MustNewDecimalFromStr("110"),
+		Change:           MustNewDecimalFromStr("12.1"),
 		ChangePercentage: "318.42%",
 	}
 
 	tikerStr := tiker1.PrettyString()
-	str := fmt.Sprintf("[Ticker] Symbol: %s, Price: %f, TStr: %s, Timestamp: %d, OCHLV(%f, %f, %f, %f, %f) [%f, %s])",
+	str := fmt.Sprintf("[Ticker] Symbol: %s, Price: %s, TStr: %s, Timestamp: %d, OCHLV(%s, %s, %s, %s, %s) [%s, %s])",
 		tiker1.Symbol, tiker1.Price, TimeString(tiker1.Timestamp), tiker1.Timestamp, tiker1.Open, tiker1.Close, tiker1.High, tiker1.Low, tiker1.Volume, tiker1.Change, tiker1.ChangePercentage)
 
 	require.Equal(t, str, tikerStr)
@@ -129,3 +139,68 @@ func TestTicker(t *testing.T) {
 	require.Equal(t, tiker2.Symbol, tikers[0].Symbol)
 	require.Equal(t, tiker1.Symbol, tikers[1].Symbol)
 }
+
+func TestGenerateTxOfAllMsgs(t *testing.T) {
+	routedMsgs := []sdk.Msg{
+		bank.MsgSend{}, bank.MsgMultiSend{},
+		token.MsgSend{}, token.MsgMultiSend{}, token.MsgTokenIssue{}, token.MsgTokenMint{}, token.MsgTokenBurn{},
+		token.MsgTokenModify{}, token.MsgTransferOwnership{}, token.MsgConfirmOwnership{},
+		orderTypes.MsgNewOrders{}, orderTypes.MsgCancelOrders{}, orderTypes.MsgAmendOrders{}, orderTypes.MsgCancelAllOrders{},
+		orderTypes.MsgNewTriggerOrder{}, orderTypes.MsgCancelTriggerOrder{}, orderTypes.MsgSetFeeSchedule{}, orderTypes.MsgHeartbeat{},
+		orderTypes.MsgSetAuctionType{}, orderTypes.MsgHybridSwap{},
+		swap.MsgTokenToToken{}, swap.MsgTokenToExactToken{}, swap.MsgAddLiquidity{}, swap.MsgRemoveLiquidity{},
+		swap.MsgCreateExchange{},
+		staking.MsgDeposit{}, staking.MsgWithdraw{}, staking.MsgAddShares{}, staking.MsgCreateValidator{},
+		staking.MsgEditValidator{}, staking.MsgDestroyValidator{}, staking.MsgRegProxy{}, staking.MsgBindProxy{},
+		staking.MsgUnbindProxy{}, slashing.MsgUnjail{},
+		dex.MsgList{}, dex.MsgDeposit{}, dex.MsgWithdraw{}, dex.MsgTransferOwnership{}, dex.MsgCreateOperator{},
+		dex.MsgUpdateOperator{},
+		gov.MsgSubmitProposal{}, gov.MsgDeposit{}, gov.MsgVote{},
+		distr.MsgSetWithdrawAddress{}, distr.MsgWithdrawValidatorCommission{},
+		farm.MsgCreatePool{}, farm.MsgProvide{}, farm.MsgStake{}, farm.MsgUnstake{}, farm.MsgClaim{},
+	}
+	for _, msg := range routedMsgs {
+		require.True(t, HasTxBuilder(msg.Route(), msg.Type()), "%s/%s", msg.Route(), msg.Type())
+	}
+
+	testInput := orderKeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	from, to := testInput.TestAddrs[0], testInput.TestAddrs[1]
+	fee := auth.NewStdFee(200000, sdk.NewDecCoinsFromDec(common.NativeToken, sdk.MustNewDecFromStr("0.01")))
+	tx := auth.NewStdTx([]sdk.Msg{
+		token.NewMsgMultiSend(from, []token.TransferUnit{
+			{To: to, Coins: sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(2))},
+		}),
+		swap.NewMsgTokenToToken(sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10)),
+			sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(1)), 0, from, from),
+		staking.NewMsgDeposit(from, sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(5))),
+		gov.NewMsgVote(from, 1, gov.OptionYes),
+		crisis.NewMsgVerifyInvariant(from, "token", "module-total-supply"),
+	}, fee, nil, "")
+	txs := GenerateTx(&tx, "hash", testInput.Ctx, keeper, 100)
+	require.Equal(t, 6, len(txs))
+
+	require.EqualValues(t, TxTypeTransfer, txs[0].Type)
+	require.EqualValues(t, TxSideFrom, txs[0].Side)
+	require.Equal(t, fee.Amount.String(), txs[0].Fee)
+	require.EqualValues(t, TxSideTo, txs[1].Side)
+	require.Equal(t, to.String(), txs[1].Address)
+	require.Equal(t, sdk.NewDec(2).String(), txs[1].Quantity)
+	require.Equal(t, zeroFee(), txs[1].Fee)
+
+	require.EqualValues(t, TxTypeSwap, txs[2].Type)
+	require.EqualValues(t, TxSideSell, txs[2].Side)
+	require.Equal(t, common.NativeToken, txs[2].Symbol)
+	require.EqualValues(t, TxTypeStakingDeposit, txs[3].Type)
+	require.EqualValues(t, TxTypeGovVote, txs[4].Type)
+	// the msgs without any TxBuilder are recorded for their signers
+	require.EqualValues(t, TxTypeOther, txs[5].Type)
+	require.Equal(t, from.String(), txs[5].Address)
+	for _, tx := range txs {
+		require.Equal(t, "hash", tx.TxHash)
+		require.EqualValues(t, 100, tx.Timestamp)
+		require.True(t, IsValidTxType(tx.Type))
+	}
+	require.False(t, IsValidTxType(TxTypeOther+1))
+	require.False(t, IsValidTxType(-1))
+}
@@ -12,6 +12,36 @@ const (
 	TxTypeOrderNew    = 2
 	TxTypeOrderCancel = 3
 
+	TxTypeTokenIssue        = 4
+	TxTypeTokenMint         = 5
+	TxTypeTokenBurn         = 6
+	TxTypeTokenManage       = 7 // token modification, ownership transfer & confirmation
+	TxTypeOrderAmend        = 8
+	TxTypeTriggerOrder      = 9
+	TxTypeOrderManage       = 10 // fee schedules & heartbeats
+	TxTypeSwap              = 11 // ammswap & hybrid swaps
+	TxTypeAddLiquidity      = 12
+	TxTypeRemoveLiquidity   = 13
+	TxTypeCreateExchange    = 14
+	TxTypeStakingDeposit    = 15
+	TxTypeStakingWithdraw   = 16
+	TxTypeStakingAddShares  = 17
+	TxTypeStakingValidator  = 18 // validator creation, modification, destruction & unjailing
+	TxTypeStakingProxy      = 19
+	TxTypeDexList           = 20
+	TxTypeDexDeposit        = 21
+	TxTypeDexWithdraw       = 22
+	TxTypeDexManage         = 23 // token pair ownership transfer & operators
+	TxTypeGovSubmitProposal = 24
+	TxTypeGovDeposit        = 25
+	TxTypeGovVote           = 26
+	TxTypeDistribution      = 27
+	TxTypeFarmManage        = 28 // farm pool creation & reward provision
+	TxTypeFarmStake         = 29
+	TxTypeFarmUnstake       = 30
+	TxTypeFarmClaim         = 31
+	TxTypeOther             = 32 // the msgs without any TxBuilder
+
 	TxSideBuy  = 1
 	TxSideSell = 2
 	TxSideFrom = 3
@@ -32,13 +62,13 @@ type Ticker struct {
 	Symbol           string  `json:"symbol"`
 	Product          string  `json:"product"`
 	Timestamp        int64   `json:"timestamp"`
-	Open             float64 `json:"open"`  // Open In 24h
-	Close            float64 `json:"close"` // Close in 24h
-	High             float64 `json:"high"`  // High in 24h
-	Low              float64 `json:"low"`   // Low in 24h
-	Price            float64 `json:"price"`
-	Volume           float64 `json:"volume"`            // Volume in 24h
-	Change           float64 `json:"change"`            // (Close - Open)
+	Open             Decimal `json:"open"`  // Open In 24h
+	Close            Decimal `json:"close"` // Close in 24h
+	High             Decimal `json:"high"`  // High in 24h
+	Low              Decimal `json:"low"`   // Low in 24h
+	Price            Decimal `json:"price"`
+	Volume           Decimal `json:"volume"`            // Volume in 24h
+	Change           Decimal `json:"change"`            // (Close - Open)
 	ChangePercentage string  `json:"change_percentage"` // Change / Open * 100%
 }
 
@@ -60,19 +90,19 @@ func (t *Ticker) FormatResult() interface{} {
 		"product":   t.Product,
 		"symbol":    t.Symbol,
 		"timestamp": time.Unix(t.Timestamp, 0).UTC().Format("2006-01-02T15:04:05.000Z"),
-		"open":      fmt.Sprintf("%.4f", t.Open),
-		"high":      fmt.Sprintf("%.4f", t.High),
-		"low":       fmt.Sprintf("%.4f", t.Low),
-		"close":     fmt.Sprintf("%.4f", t.Close),
-		"volume":    fmt.Sprintf("%.8f", t.Volume),
-		"price":     fmt.Sprintf("%.4f", t.Price),
+		"open":      t.Open.StringFixed(4),
+		"high":      t.High.StringFixed(4),
+		"low":       t.Low.StringFixed(4),
+		"close":     t.Close.StringFixed(4),
+		"volume":    t.Volume.StringFixed(8),
+		"price":     t.Price.StringFixed(4),
 	}
 	return result
 }
 
 // PrettyString return string of ticker data
 func (t *Ticker) PrettyString() string {
-	return fmt.Sprintf("[Ticker] Symbol: %s, Price: %f, TStr: %s, Timestamp: %d, OCHLV(%f, %f, %f, %f, %f) [%f, %s])",
+	return fmt.Sprintf("[Ticker] Symbol: %s, Price: %s, TStr: %s, Timestamp: %d, OCHLV(%s, %s, %s, %s, %s) [%s, %s])",
 		t.Symbol, t.Price, TimeString(t.Timestamp), t.Timestamp, t.Open, t.Close, t.High, t.Low, t.Volume, t.Change, t.ChangePercentage)
 }
 
@@ -87,7 +117,7 @@ func (c Tickers) Swap(i, j int) {
 }
 
 func (tickers Tickers) Less(i, j int) bool {
-	return tickers[i].Change < tickers[j].Change
+	return tickers[i].Change.LessThan(tickers[j].Change)
 }
 
 type Order struct {
@@ -102,15 +132,19 @@ type Order struct {
 	FilledAvgPrice string `gorm:"type:varchar(40)" json:"filled_avg_price" v2:"filled_avg_price"`
 	RemainQuantity string `gorm:"type:varchar(40)" json:"remain_quantity" v2:"remain_quantity"`
 	Timestamp      int64  `gorm:"index;" json:"timestamp" v2:"timestamp"`
+	OrderType      string `gorm:"type:varchar(20)" json:"order_type" v2:"order_type"`
+	CloseReason    string `gorm:"type:varchar(80)" json:"close_reason" v2:"close_reason"`
 }
 
 type Transaction struct {
 	TxHash    string `gorm:"type:varchar(80)" json:"txhash" v2:"txhash"`
-	Type      int64  `gorm:"index;" json:"type" v2:"type"` // 1:Transfer, 2:NewOrder, 3:CancelOrder
+	Type      int64  `gorm:"index;" json:"type" v2:"type"` // TxType*
 	Address   string `gorm:"index;type:varchar(80)" json:"address" v2:"address"`
-	Symbol    string `gorm:"type:varchar(20)" json:"symbol" v2:"symbol"`
-	Side      int64  `gorm:"" json:"side"` // 1:buy, 2:sell, 3:from, 4:to
+	Symbol    string `gorm:"type:varchar(40)" json:"symbol" v2:"symbol"`
+	Side      int64  `gorm:"" json:"side"` // 0:none, 1:buy, 2:sell, 3:from, 4:to
 	Quantity  string `gorm:"type:varchar(40)" json:"quantity" v2:"quantity"`
 	Fee       string `gorm:"type:varchar(40)" json:"fee" v2:"fee"`
 	Timestamp int64  `gorm:"index" json:"timestamp" v2:"timestamp"`
+	// the height of the block, by which the transactions are replaced when the block is stored again
+	BlockHeight int64 `gorm:"index;type:bigint" json:"block_height" v2:"block_height"`
 }
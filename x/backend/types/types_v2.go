@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/okex/okexchain/x/dex"
+	orderTypes "github.com/okex/okexchain/x/order/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -17,19 +18,20 @@ type MatchResult struct {
 	Timestamp   int64   `gorm:"index;" json:"timestamp" v2:"timestamp"`
 	BlockHeight int64   `gorm:"PRIMARY_KEY;type:bigint" json:"block_height" v2:"block_height"`
 	Product     string  `gorm:"PRIMARY_KEY;type:varchar(20)" json:"product" v2:"product"`
-	Price       float64 `gorm:"type:DOUBLE" json:"price" v2:"price"`
-	Quantity    float64 `gorm:"type:DOUBLE" json:"volume" v2:"volume"`
+	Price       Decimal `gorm:"type:varchar(40)" json:"price" v2:"price"`
+	Quantity    Decimal `gorm:"type:varchar(40)" json:"volume" v2:"volume"`
 }
 
 type Deal struct {
 	Timestamp   int64   `gorm:"index;" json:"timestamp" v2:"timestamp"`
 	BlockHeight int64   `gorm:"PRIMARY_KEY;type:bigint" json:"block_height" v2:"block_height"`
 	OrderID     string  `gorm:"PRIMARY_KEY;type:varchar(30)" json:"order_id" v2:"order_id"`
+	Sequence    int64   `gorm:"PRIMARY_KEY;type:bigint;default:0" json:"sequence" v2:"sequence"` // the order of the deal of the product in the block
 	Sender      string  `gorm:"index;type:varchar(80)" json:"sender" v2:"sender"`
 	Product     string  `gorm:"index;type:varchar(20)" json:"product" v2:"product"`
 	Side        string  `gorm:"type:varchar(10)" json:"side" v2:"side"`
-	Price       float64 `gorm:"type:DOUBLE" json:"price" v2:"price"`
-	Quantity    float64 `gorm:"type:DOUBLE" json:"volume" v2:"volume"`
+	Price       Decimal `gorm:"type:varchar(40)" json:"price" v2:"price"`
+	Quantity    Decimal `gorm:"type:varchar(40)" json:"volume" v2:"volume"`
 	Fee         string  `gorm:"type:varchar(20)" json:"fee" v2:"fee"`
 	FeeReceiver string  `gorm:"index;type:varchar(80)" json:"fee_receiver" v2:"fee_receiver"`
 }
@@ -102,16 +104,30 @@ type OrderV2 struct {
 	State          string `json:"state"`
 }
 
+// convertOrderTypeToV2 converts the time in force of an order to the v2 order_type and type,
+// order_type: 0 normal, 1 post only, 2 fill or kill, 3 immediate or cancel
+func convertOrderTypeToV2(orderType string) (string, string) {
+	switch orderType {
+	case orderTypes.OrderTypePostOnly:
+		return "1", "limit"
+	case orderTypes.OrderTypeFOK:
+		return "2", "limit"
+	case orderTypes.OrderTypeIOC:
+		return "3", "limit"
+	default:
+		return "0", "limit"
+	}
+}
+
 func ConvertOrderToOrderV2(order Order) OrderV2 {
 	res := OrderV2{}
 	res.OrderID = order.OrderID
 	res.Price = order.Price
 	res.Size = order.Quantity
-	res.OrderType = "0"
+	res.OrderType, res.Type = convertOrderTypeToV2(order.OrderType)
 	res.Notional = order.FilledAvgPrice
 	res.InstrumentID = order.Product
 	res.Side = order.Side
-	res.Type = "limit"
 	res.Timestamp = time.Unix(order.Timestamp, 0).UTC().Format("2006-01-02T15:04:05.000Z")
 	res.State = strconv.FormatInt(order.Status, 10)
 
@@ -166,6 +182,23 @@ type QueryTxListParamsV2 struct {
 	Limit   int
 }
 
+type QueryBalanceSnapshotsParamsV2 struct {
+	Address  string
+	Currency string
+	After    string
+	Before   string
+	Limit    int
+}
+
+type QueryRealizedPnLParamsV2 struct {
+	Address string
+	Product string
+	Method  string
+	After   string
+	Before  string
+	Limit   int
+}
+
 type DexFees struct {
 	Timestamp       int64  `json:"timestamp"`
 	OrderID         string `json:"order_id"`
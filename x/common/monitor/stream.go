@@ -10,6 +10,11 @@ import (
 // StreamMetrics is the struct of metric in stream module
 type StreamMetrics struct {
 	CacheSize metrics.Gauge
+	// the metrics of the websocket bridge
+	WSConnections          metrics.Gauge
+	WSSubscriptions        metrics.Gauge
+	WSDroppedEvents        metrics.Counter
+	WSSlowConsumerClosings metrics.Counter
 }
 
 // DefaultStreamMetrics returns Metrics build using Prometheus client library if Prometheus is enabled
@@ -34,6 +39,30 @@ func NewStreamMetrics(labelsAndValues ...string) *StreamMetrics {
 			Name:      "cache_size",
 			Help:      "the excuting cache queue size in stream module.",
 		}, labels).With(labelsAndValues...),
+		WSConnections: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: xNameSpace,
+			Subsystem: streamSubSystem,
+			Name:      "websocket_connections",
+			Help:      "the number of the websocket connections.",
+		}, labels).With(labelsAndValues...),
+		WSSubscriptions: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: xNameSpace,
+			Subsystem: streamSubSystem,
+			Name:      "websocket_subscriptions",
+			Help:      "the number of the channels subscribed by the websocket connections.",
+		}, labels).With(labelsAndValues...),
+		WSDroppedEvents: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: xNameSpace,
+			Subsystem: streamSubSystem,
+			Name:      "websocket_dropped_events",
+			Help:      "the number of the events dropped for the full buffers of the websocket connections.",
+		}, labels).With(labelsAndValues...),
+		WSSlowConsumerClosings: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: xNameSpace,
+			Subsystem: streamSubSystem,
+			Name:      "websocket_slow_consumer_closings",
+			Help:      "the number of the websocket connections closed for consuming the events too slowly.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -41,6 +70,10 @@ func NewStreamMetrics(labelsAndValues ...string) *StreamMetrics {
 func NopStreamMetrics() *StreamMetrics {
 	return &StreamMetrics{
 		//PulsarSendNum: discard.NewGauge(),
-		CacheSize: discard.NewGauge(),
+		CacheSize:              discard.NewGauge(),
+		WSConnections:          discard.NewGauge(),
+		WSSubscriptions:        discard.NewGauge(),
+		WSDroppedEvents:        discard.NewCounter(),
+		WSSlowConsumerClosings: discard.NewCounter(),
 	}
 }
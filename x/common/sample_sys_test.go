@@ -11,6 +11,7 @@ func TestEnableSampleSystestAll(t *testing.T) {
 
 	e := os.Setenv("SYS_TEST_ALL", "1")
 	require.Nil(t, e)
+	defer os.Unsetenv("SYS_TEST_ALL")
 
 	SkipSysTestChecker(t)
 	require.True(t, true)
@@ -22,6 +23,7 @@ func TestEnableSampleSystestSingle(t *testing.T) {
 	require.Nil(t, e)
 	e = os.Setenv("SAMPLE_SYS_TEST", "1")
 	require.Nil(t, e)
+	defer os.Unsetenv("SAMPLE_SYS_TEST")
 
 	SkipSysTestChecker(t)
 	require.True(t, true)
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"net/http"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -91,13 +92,14 @@ func HasSufficientCoins(addr sdk.AccAddress, availableCoins, amt sdk.Coins) (err
 // if System environment variables "SYS_TEST_ALL" is set to 1, all of the system test will be enable. \n
 // if System environment variables "ORM_MYSQL_SYS_TEST" is set to 1,
 // 				all of the system test in orm_mysql_sys_test.go will be enble.
+// The file is the one calling SkipSysTestChecker.
 func SkipSysTestChecker(t *testing.T) {
-	_, fname, _, ok := runtime.Caller(0)
+	_, fname, _, ok := runtime.Caller(1)
 	enable := ok
 	if enable {
 		enableAllEnv := "SYS_TEST_ALL"
 
-		sysTestName := strings.Split(fname, ".go")[0]
+		sysTestName := strings.TrimSuffix(filepath.Base(fname), ".go")
 		enableCurrent := strings.ToUpper(sysTestName)
 
 		enable = os.Getenv(enableAllEnv) == "1" ||
@@ -0,0 +1,21 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFundCommunityPool(t *testing.T) {
+	ctx, ak, k, _, _ := CreateTestInputDefault(t, false, 1000)
+	balance := ak.GetAccount(ctx, delAddr1).GetCoins()
+	amount := NewTestDecCoins(123, 2)
+
+	require.Nil(t, k.FundCommunityPool(ctx, amount, delAddr1))
+	require.Equal(t, amount, k.GetFeePoolCommunityCoins(ctx))
+	require.Equal(t, balance.Sub(amount), ak.GetAccount(ctx, delAddr1).GetCoins())
+
+	// insufficient coins
+	require.NotNil(t, k.FundCommunityPool(ctx, balance, delAddr1))
+	require.Equal(t, amount, k.GetFeePoolCommunityCoins(ctx))
+}
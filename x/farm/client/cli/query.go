@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/okex/okexchain/x/farm/types"
+	"github.com/spf13/cobra"
+)
+
+// GetQueryCmd returns the cli query commands for this module
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	// Group farm queries under a subcommand
+	farmQueryCmd := &cobra.Command{
+		Use:                        "farm",
+		Short:                      fmt.Sprintf("Querying commands for the %s module", types.ModuleName),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	farmQueryCmd.AddCommand(
+		flags.GetCommands(
+			GetCmdQueryPool(queryRoute, cdc),
+			GetCmdQueryPools(queryRoute, cdc),
+			GetCmdQueryEarnings(queryRoute, cdc),
+			GetCmdQueryAccount(queryRoute, cdc),
+		)...,
+	)
+
+	return farmQueryCmd
+}
+
+// GetCmdQueryPool queries a farm pool by its name
+func GetCmdQueryPool(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pool [pool-name]",
+		Short: "Query a farm pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query a farm pool with the rewards emitted till the latest height.
+
+Example:
+$ %s query farm pool eth-farm`, version.ClientName),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryPool, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			var pool types.FarmPool
+			cdc.MustUnmarshalJSON(res, &pool)
+			return cliCtx.PrintOutput(pool)
+		},
+	}
+}
+
+// GetCmdQueryPools queries all the farm pools
+func GetCmdQueryPools(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pools",
+		Short: "Query all the farm pools",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryPools), nil)
+			if err != nil {
+				return err
+			}
+
+			var pools types.FarmPools
+			cdc.MustUnmarshalJSON(res, &pools)
+			return cliCtx.PrintOutput(pools)
+		},
+	}
+}
+
+// GetCmdQueryEarnings queries the stake & the pending rewards of an address in a farm pool
+func GetCmdQueryEarnings(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "earnings [pool-name] [address]",
+		Short: "Query the pending rewards of an address in a farm pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the pool tokens staked & the pending rewards of an address in a farm pool.
+
+Example:
+$ %s query farm earnings eth-farm okexchain1hw4r48aww06ldrfeuq2v438ujnl6alsz0685a0`, version.ClientName),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			if _, err := sdk.AccAddressFromBech32(args[1]); err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/%s/%s/%s", queryRoute, types.QueryEarnings, args[0], args[1]), nil)
+			if err != nil {
+				return err
+			}
+
+			var earnings types.Earnings
+			cdc.MustUnmarshalJSON(res, &earnings)
+			return cliCtx.PrintOutput(earnings)
+		},
+	}
+}
+
+// GetCmdQueryAccount queries the pool tokens staked by an address in all the farm pools
+func GetCmdQueryAccount(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "account [address]",
+		Short: "Query the pool tokens staked by an address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			if _, err := sdk.AccAddressFromBech32(args[0]); err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryAccount, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			var lockInfos types.LockInfos
+			cdc.MustUnmarshalJSON(res, &lockInfos)
+			return cliCtx.PrintOutput(lockInfos)
+		},
+	}
+}
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/okex/okexchain/x/farm/types"
+	"github.com/spf13/cobra"
+)
+
+// flags
+const (
+	flagRewardPerBlock = "reward-per-block"
+	flagStartHeight    = "start-height"
+)
+
+// GetTxCmd returns the transaction commands for this module
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:   "farm",
+		Short: "Farm transactions subcommands",
+	}
+
+	txCmd.AddCommand(client.PostCommands(
+		getCmdCreatePool(cdc),
+		getCmdProvide(cdc),
+		getCmdStake(cdc),
+		getCmdUnstake(cdc),
+		getCmdClaim(cdc),
+	)...)
+
+	return txCmd
+}
+
+func getCmdCreatePool(cdc *codec.Codec) *cobra.Command {
+	// flags
+	var rewardPerBlock string
+	var startHeight int64
+	cmd := &cobra.Command{
+		Use:   "create-pool [pool-name] [locked-symbol] [reward]",
+		Short: "create a farm pool rewarding the stakers of a pool token",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Create a farm pool rewarding the stakers of an ammswap pool token with the reward locked into it,
+which is emitted by blocks after the start height. Only the owner of the reward token can create the farm pool.
+
+Example:
+$ %s tx farm create-pool eth-farm ammswap_eth-355_okt 10000eth-355 --reward-per-block 1 --start-height 100
+
+`, version.ClientName),
+		),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			reward, err := sdk.ParseDecCoin(args[2])
+			if err != nil {
+				return err
+			}
+			rewardPerBlockDec, err := sdk.NewDecFromStr(rewardPerBlock)
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgCreatePool(cliCtx.FromAddress, args[0], args[1], reward, rewardPerBlockDec, startHeight)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVar(&rewardPerBlock, flagRewardPerBlock, "", "the reward tokens emitted every block")
+	cmd.Flags().Int64Var(&startHeight, flagStartHeight, 0, "the height after which the rewards are emitted")
+	cmd.MarkFlagRequired(flagRewardPerBlock)
+	return cmd
+}
+
+func getCmdProvide(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "provide [pool-name] [amount]",
+		Short: "lock more reward tokens into a farm pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Lock more reward tokens into a farm pool owned by the sender, which extends its emission.
+
+Example:
+$ %s tx farm provide eth-farm 1000eth-355
+
+`, version.ClientName),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			amount, err := sdk.ParseDecCoin(args[1])
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgProvide(cliCtx.FromAddress, args[0], amount)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+func getCmdStake(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stake [pool-name] [amount]",
+		Short: "stake pool tokens into a farm pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Stake pool tokens into a farm pool, the pending rewards of the farm pool are claimed.
+
+Example:
+$ %s tx farm stake eth-farm 10ammswap_eth-355_okt
+
+`, version.ClientName),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			amount, err := sdk.ParseDecCoin(args[1])
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgStake(cliCtx.FromAddress, args[0], amount)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+func getCmdUnstake(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unstake [pool-name] [amount]",
+		Short: "unstake pool tokens from a farm pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Unstake pool tokens from a farm pool, the pending rewards of the farm pool are claimed.
+
+Example:
+$ %s tx farm unstake eth-farm 10ammswap_eth-355_okt
+
+`, version.ClientName),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			amount, err := sdk.ParseDecCoin(args[1])
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgUnstake(cliCtx.FromAddress, args[0], amount)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+func getCmdClaim(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "claim [pool-name]",
+		Short: "claim the pending rewards of a farm pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Claim the pending rewards of a farm pool.
+
+Example:
+$ %s tx farm claim eth-farm
+
+`, version.ClientName),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			msg := types.NewMsgClaim(cliCtx.FromAddress, args[0])
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/gorilla/mux"
+	"github.com/okex/okexchain/x/common"
+	"github.com/okex/okexchain/x/farm/types"
+)
+
+func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
+	r = r.PathPrefix("/" + types.ModuleName).Subrouter()
+	r.HandleFunc("/pools", queryPoolsHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/pool/{poolName}", queryPoolHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/earnings/{poolName}/{address}", queryEarningsHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/account/{address}", queryAccountHandler(cliCtx)).Methods("GET")
+}
+
+func queryPoolsHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
+	return queryHandler(cliContext, func(_ map[string]string) string {
+		return fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryPools)
+	})
+}
+
+func queryPoolHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
+	return queryHandler(cliContext, func(vars map[string]string) string {
+		return fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute, types.QueryPool, vars["poolName"])
+	})
+}
+
+func queryEarningsHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
+	return queryHandler(cliContext, func(vars map[string]string) string {
+		return fmt.Sprintf("custom/%s/%s/%s/%s", types.QuerierRoute, types.QueryEarnings, vars["poolName"], vars["address"])
+	})
+}
+
+func queryAccountHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
+	return queryHandler(cliContext, func(vars map[string]string) string {
+		return fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute, types.QueryAccount, vars["address"])
+	})
+}
+
+func queryHandler(cliContext context.CLIContext, getRoute func(vars map[string]string) string) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliContext.QueryWithData(getRoute(mux.Vars(r)), nil)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		formatAndReturnResult(w, cliContext, res)
+	}
+}
+
+func formatAndReturnResult(w http.ResponseWriter, cliContext context.CLIContext, data []byte) {
+	replaceStr := "replaceHere"
+	result := common.GetBaseResponse(replaceStr)
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		common.HandleErrorMsg(w, cliContext, err.Error())
+		return
+	}
+	resultJSON = []byte(strings.Replace(string(resultJSON), "\""+replaceStr+"\"", string(data), 1))
+
+	rest.PostProcessResponse(w, cliContext, resultJSON)
+}
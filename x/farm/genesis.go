@@ -0,0 +1,79 @@
+package farm
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/farm/types"
+)
+
+// GenesisState stores genesis data of the farm pools & the pool tokens staked in them
+type GenesisState struct {
+	Pools     types.FarmPools `json:"pools"`
+	LockInfos types.LockInfos `json:"lock_infos"`
+}
+
+// NewGenesisState creates a new GenesisState object
+func NewGenesisState(pools types.FarmPools, lockInfos types.LockInfos) GenesisState {
+	return GenesisState{
+		Pools:     pools,
+		LockInfos: lockInfos,
+	}
+}
+
+// ValidateGenesis checks the farm pools, and that their total staked are the sums of their lock infos
+func ValidateGenesis(data GenesisState) error {
+	staked := make(map[string]sdk.Dec, len(data.Pools))
+	for _, pool := range data.Pools {
+		if err := pool.Validate(); err != nil {
+			return err
+		}
+		if _, ok := staked[pool.Name]; ok {
+			return fmt.Errorf("duplicated farm pool %s", pool.Name)
+		}
+		staked[pool.Name] = sdk.ZeroDec()
+	}
+	for _, lockInfo := range data.LockInfos {
+		amount, ok := staked[lockInfo.PoolName]
+		if !ok {
+			return fmt.Errorf("lock info of %s in non-existent farm pool %s", lockInfo.Owner, lockInfo.PoolName)
+		}
+		if lockInfo.Owner.Empty() || lockInfo.Amount.IsNil() || !lockInfo.Amount.IsPositive() ||
+			lockInfo.RewardPerShare.IsNil() || lockInfo.RewardPerShare.IsNegative() {
+			return fmt.Errorf("invalid lock info of %s in farm pool %s", lockInfo.Owner, lockInfo.PoolName)
+		}
+		staked[lockInfo.PoolName] = amount.Add(lockInfo.Amount)
+	}
+	for _, pool := range data.Pools {
+		if !staked[pool.Name].Equal(pool.TotalStaked) {
+			return fmt.Errorf("farm pool %s: total staked %s is not the sum of its lock infos %s",
+				pool.Name, pool.TotalStaked, staked[pool.Name])
+		}
+	}
+	return nil
+}
+
+// DefaultGenesisState returns the default genesis state without any farm pool
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis init genesis data to keeper
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	// the module account holding the locked coins is created if it doesn't exist
+	if moduleAcc := keeper.GetFarmAccount(ctx); moduleAcc == nil {
+		panic(fmt.Sprintf("%s module account has not been set", types.ModuleName))
+	}
+	for _, pool := range data.Pools {
+		keeper.SetFarmPool(ctx, pool)
+	}
+	for _, lockInfo := range data.LockInfos {
+		keeper.SetLockInfo(ctx, lockInfo)
+	}
+}
+
+// ExportGenesis exports genesis from keeper
+func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	return NewGenesisState(keeper.GetFarmPools(ctx), keeper.GetLockInfos(ctx, nil))
+}
@@ -0,0 +1,33 @@
+package farm
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/okex/okexchain/x/farm/types"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	owner := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	staker := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	require.Nil(t, ValidateGenesis(DefaultGenesisState()))
+
+	pool := types.NewFarmPool(testPoolName, owner, testLockedSymbol, testRewardSymbol, sdk.NewDec(10), 100)
+	pool.TotalStaked = sdk.NewDec(50)
+	lockInfo := types.NewLockInfo(staker, testPoolName, sdk.NewDec(50), sdk.ZeroDec())
+	require.Nil(t, ValidateGenesis(NewGenesisState(types.FarmPools{pool}, types.LockInfos{lockInfo})))
+
+	// duplicated farm pools
+	require.NotNil(t, ValidateGenesis(NewGenesisState(types.FarmPools{pool, pool}, types.LockInfos{lockInfo})))
+	// the total staked is not the sum of the lock infos
+	require.NotNil(t, ValidateGenesis(NewGenesisState(types.FarmPools{pool}, nil)))
+	// lock info in a non-existent farm pool
+	orphan := types.NewLockInfo(staker, "no-farm", sdk.NewDec(50), sdk.ZeroDec())
+	require.NotNil(t, ValidateGenesis(NewGenesisState(types.FarmPools{pool}, types.LockInfos{lockInfo, orphan})))
+	// invalid farm pool
+	pool.LockedSymbol = testRewardSymbol
+	require.NotNil(t, ValidateGenesis(NewGenesisState(types.FarmPools{pool}, types.LockInfos{lockInfo})))
+}
@@ -0,0 +1,89 @@
+package farm
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/farm/types"
+)
+
+// NewHandler creates an sdk.Handler for all the farm type messages
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		switch msg := msg.(type) {
+		case types.MsgCreatePool:
+			return handleMsgCreatePool(ctx, k, msg)
+		case types.MsgProvide:
+			return handleMsgProvide(ctx, k, msg)
+		case types.MsgStake:
+			return handleMsgStake(ctx, k, msg)
+		case types.MsgUnstake:
+			return handleMsgUnstake(ctx, k, msg)
+		case types.MsgClaim:
+			return handleMsgClaim(ctx, k, msg)
+		default:
+			errMsg := fmt.Sprintf("Invalid msg type: %v", msg.Type())
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+func handleMsgCreatePool(ctx sdk.Context, k Keeper, msg types.MsgCreatePool) sdk.Result {
+	if err := k.CreateFarmPool(ctx, msg); err != nil {
+		return err.Result()
+	}
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+	event = event.AppendAttributes(sdk.NewAttribute("pool_name", msg.PoolName),
+		sdk.NewAttribute("locked_symbol", msg.LockedSymbol), sdk.NewAttribute("reward", msg.Reward.String()))
+	ctx.EventManager().EmitEvent(event)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgProvide(ctx sdk.Context, k Keeper, msg types.MsgProvide) sdk.Result {
+	if err := k.Provide(ctx, msg.Owner, msg.PoolName, msg.Amount); err != nil {
+		return err.Result()
+	}
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+	event = event.AppendAttributes(sdk.NewAttribute("pool_name", msg.PoolName),
+		sdk.NewAttribute("amount", msg.Amount.String()))
+	ctx.EventManager().EmitEvent(event)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgStake(ctx sdk.Context, k Keeper, msg types.MsgStake) sdk.Result {
+	reward, err := k.Stake(ctx, msg.Address, msg.PoolName, msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+	event = event.AppendAttributes(sdk.NewAttribute("pool_name", msg.PoolName),
+		sdk.NewAttribute("amount", msg.Amount.String()), sdk.NewAttribute("claimed", reward.String()))
+	ctx.EventManager().EmitEvent(event)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgUnstake(ctx sdk.Context, k Keeper, msg types.MsgUnstake) sdk.Result {
+	reward, err := k.Unstake(ctx, msg.Address, msg.PoolName, msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+	event = event.AppendAttributes(sdk.NewAttribute("pool_name", msg.PoolName),
+		sdk.NewAttribute("amount", msg.Amount.String()), sdk.NewAttribute("claimed", reward.String()))
+	ctx.EventManager().EmitEvent(event)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgClaim(ctx sdk.Context, k Keeper, msg types.MsgClaim) sdk.Result {
+	reward, err := k.Claim(ctx, msg.Address, msg.PoolName)
+	if err != nil {
+		return err.Result()
+	}
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+	event = event.AppendAttributes(sdk.NewAttribute("pool_name", msg.PoolName),
+		sdk.NewAttribute("claimed", reward.String()))
+	ctx.EventManager().EmitEvent(event)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
@@ -0,0 +1,189 @@
+package farm
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/okex/okexchain/x/farm/keeper"
+	"github.com/okex/okexchain/x/farm/types"
+	token "github.com/okex/okexchain/x/token/types"
+)
+
+func initToken(name string, owner sdk.AccAddress) token.Token {
+	return token.Token{
+		Description:         name,
+		Symbol:              name,
+		OriginalSymbol:      name,
+		WholeName:           name,
+		OriginalTotalSupply: sdk.NewDec(0),
+		Owner:               owner,
+		Type:                1,
+		Mintable:            true,
+	}
+}
+
+func requireInvariants(t *testing.T, ctx sdk.Context, k Keeper) {
+	msg, broken := keeper.ModuleAccountInvariant(k)(ctx)
+	require.False(t, broken, msg)
+	msg, broken = keeper.StakedInvariant(k)(ctx)
+	require.False(t, broken, msg)
+}
+
+func TestHandleMsgCreatePool(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2, 10000)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(mapp.farmKeeper)
+	owner, other := addrKeysSlice[0].Address, addrKeysSlice[1].Address
+	mapp.tokenKeeper.NewToken(ctx, initToken(testRewardSymbol, owner))
+	mapp.tokenKeeper.NewToken(ctx, initToken(testLockedSymbol, supply.NewModuleAddress("ammswap")))
+
+	reward := sdk.NewDecCoinFromDec(testRewardSymbol, sdk.NewDec(1000))
+	tests := []struct {
+		testCase     string
+		msg          types.MsgCreatePool
+		expectedCode sdk.CodeType
+	}{
+		{
+			testCase:     "locked token does not exist",
+			msg:          types.NewMsgCreatePool(owner, testPoolName, "ammswap_xxb_okt", reward, sdk.NewDec(10), 20),
+			expectedCode: sdk.CodeUnknownRequest,
+		},
+		{
+			testCase:     "not the owner of the reward token",
+			msg:          types.NewMsgCreatePool(other, testPoolName, testLockedSymbol, reward, sdk.NewDec(10), 20),
+			expectedCode: sdk.CodeUnauthorized,
+		},
+		{
+			testCase: "insufficient reward token",
+			msg: types.NewMsgCreatePool(owner, testPoolName, testLockedSymbol,
+				sdk.NewDecCoinFromDec(testRewardSymbol, sdk.NewDec(20000)), sdk.NewDec(10), 20),
+			expectedCode: sdk.CodeInsufficientCoins,
+		},
+		{
+			testCase:     "success",
+			msg:          types.NewMsgCreatePool(owner, testPoolName, testLockedSymbol, reward, sdk.NewDec(10), 5),
+			expectedCode: sdk.CodeOK,
+		},
+		{
+			testCase:     "farm pool already exists",
+			msg:          types.NewMsgCreatePool(owner, testPoolName, testLockedSymbol, reward, sdk.NewDec(10), 20),
+			expectedCode: sdk.CodeUnknownRequest,
+		},
+	}
+	for _, test := range tests {
+		result := handler(ctx, test.msg)
+		require.Equal(t, test.expectedCode, result.Code, test.testCase)
+	}
+
+	pool, found := mapp.farmKeeper.GetFarmPool(ctx, testPoolName)
+	require.True(t, found)
+	// the start height in the past is the current height
+	require.Equal(t, int64(10), pool.StartHeight)
+	require.Equal(t, reward.Amount, pool.RewardBalance)
+	require.Equal(t, reward.Amount, pool.RemainingReward)
+	requireInvariants(t, ctx, mapp.farmKeeper)
+
+	// only the owner provides the reward token
+	result := handler(ctx, types.NewMsgProvide(other, testPoolName, reward))
+	require.Equal(t, sdk.CodeUnauthorized, result.Code)
+	result = handler(ctx, types.NewMsgProvide(owner, testPoolName, sdk.NewDecCoinFromDec(testLockedSymbol, sdk.NewDec(1))))
+	require.Equal(t, sdk.CodeInvalidCoins, result.Code)
+	result = handler(ctx, types.NewMsgProvide(owner, testPoolName, reward))
+	require.Equal(t, sdk.CodeOK, result.Code)
+	pool, _ = mapp.farmKeeper.GetFarmPool(ctx, testPoolName)
+	require.Equal(t, sdk.NewDec(2000), pool.RemainingReward)
+	requireInvariants(t, ctx, mapp.farmKeeper)
+}
+
+func TestHandleMsgStakeAndUnstake(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 3, 10000)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(mapp.farmKeeper)
+	querier := NewQuerier(mapp.farmKeeper)
+	owner, staker1, staker2 := addrKeysSlice[0].Address, addrKeysSlice[1].Address, addrKeysSlice[2].Address
+	mapp.tokenKeeper.NewToken(ctx, initToken(testRewardSymbol, owner))
+	mapp.tokenKeeper.NewToken(ctx, initToken(testLockedSymbol, supply.NewModuleAddress("ammswap")))
+
+	result := handler(ctx, types.NewMsgCreatePool(owner, testPoolName, testLockedSymbol,
+		sdk.NewDecCoinFromDec(testRewardSymbol, sdk.NewDec(1000)), sdk.NewDec(10), 12))
+	require.Equal(t, sdk.CodeOK, result.Code)
+	lockedCoin := func(amount int64) sdk.DecCoin { return sdk.NewDecCoinFromDec(testLockedSymbol, sdk.NewDec(amount)) }
+	requireEarnings := func(ctx sdk.Context, addr sdk.AccAddress, staked, pending int64) {
+		earnings, err := mapp.farmKeeper.GetEarnings(ctx, addr, testPoolName)
+		require.Nil(t, err)
+		require.Equal(t, sdk.NewDec(staked), earnings.Staked.Amount)
+		require.Equal(t, sdk.NewDec(pending), earnings.PendingReward.Amount)
+	}
+
+	// staking the wrong token & unstaking more than staked fail
+	result = handler(ctx, types.NewMsgStake(staker1, testPoolName, sdk.NewDecCoinFromDec(testRewardSymbol, sdk.NewDec(1))))
+	require.Equal(t, sdk.CodeInvalidCoins, result.Code)
+	result = handler(ctx, types.NewMsgStake(staker1, "no-farm", lockedCoin(100)))
+	require.Equal(t, sdk.CodeUnknownRequest, result.Code)
+	result = handler(ctx, types.NewMsgUnstake(staker1, testPoolName, lockedCoin(100)))
+	require.Equal(t, sdk.CodeInsufficientCoins, result.Code)
+
+	// nothing is emitted before the start height
+	result = handler(ctx, types.NewMsgStake(staker1, testPoolName, lockedCoin(100)))
+	require.Equal(t, sdk.CodeOK, result.Code)
+	requireEarnings(ctx.WithBlockHeight(12), staker1, 100, 0)
+
+	// staker1 earns all the rewards of the blocks 12 to 14, and a quarter of the ones of the blocks 14 to 18
+	ctx = ctx.WithBlockHeight(14)
+	result = handler(ctx, types.NewMsgStake(staker2, testPoolName, lockedCoin(300)))
+	require.Equal(t, sdk.CodeOK, result.Code)
+	ctx = ctx.WithBlockHeight(18)
+	requireEarnings(ctx, staker1, 100, 30)
+	requireEarnings(ctx, staker2, 300, 30)
+	requireInvariants(t, ctx, mapp.farmKeeper)
+
+	res, err := querier(ctx, []string{types.QueryEarnings, testPoolName, staker2.String()}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var earnings types.Earnings
+	mapp.Cdc.MustUnmarshalJSON(res, &earnings)
+	require.Equal(t, sdk.NewDec(30), earnings.PendingReward.Amount)
+	res, err = querier(ctx, []string{types.QueryPool, testPoolName}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var pool types.FarmPool
+	mapp.Cdc.MustUnmarshalJSON(res, &pool)
+	require.Equal(t, sdk.NewDec(940), pool.RemainingReward)
+
+	// claim & unstake pay the pending rewards
+	result = handler(ctx, types.NewMsgClaim(staker1, testPoolName))
+	require.Equal(t, sdk.CodeOK, result.Code)
+	result = handler(ctx, types.NewMsgUnstake(staker2, testPoolName, lockedCoin(300)))
+	require.Equal(t, sdk.CodeOK, result.Code)
+	requireEarnings(ctx, staker1, 100, 0)
+	requireEarnings(ctx, staker2, 0, 0)
+	require.Equal(t, sdk.NewDec(10030), mapp.AccountKeeper.GetAccount(ctx, staker1).GetCoins().AmountOf(testRewardSymbol))
+	require.Equal(t, sdk.NewDec(10030), mapp.AccountKeeper.GetAccount(ctx, staker2).GetCoins().AmountOf(testRewardSymbol))
+	require.Equal(t, sdk.NewDec(10000), mapp.AccountKeeper.GetAccount(ctx, staker2).GetCoins().AmountOf(testLockedSymbol))
+	_, found := mapp.farmKeeper.GetLockInfo(ctx, staker2, testPoolName)
+	require.False(t, found)
+	result = handler(ctx, types.NewMsgClaim(staker2, testPoolName))
+	require.Equal(t, sdk.CodeUnknownRequest, result.Code)
+	requireInvariants(t, ctx, mapp.farmKeeper)
+
+	// the emission stops once the reward runs out
+	ctx = ctx.WithBlockHeight(1000)
+	requireEarnings(ctx, staker1, 100, 940)
+	result = handler(ctx, types.NewMsgUnstake(staker1, testPoolName, lockedCoin(100)))
+	require.Equal(t, sdk.CodeOK, result.Code)
+	pool, _ = mapp.farmKeeper.GetFarmPool(ctx, testPoolName)
+	require.True(t, pool.RewardBalance.IsZero())
+	require.True(t, pool.TotalStaked.IsZero())
+	requireInvariants(t, ctx, mapp.farmKeeper)
+
+	exported := ExportGenesis(ctx, mapp.farmKeeper)
+	require.Nil(t, ValidateGenesis(exported))
+	require.Len(t, exported.Pools, 1)
+	require.Len(t, exported.LockInfos, 0)
+}
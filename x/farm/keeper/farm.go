@@ -0,0 +1,214 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/farm/types"
+)
+
+// CreateFarmPool creates a farm pool and locks the reward tokens of its owner into it,
+// only the owner of the reward token can create a farm pool of it
+func (k Keeper) CreateFarmPool(ctx sdk.Context, msg types.MsgCreatePool) sdk.Error {
+	if _, found := k.GetFarmPool(ctx, msg.PoolName); found {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("farm pool %s already exists", msg.PoolName))
+	}
+	if !k.tokenKeeper.TokenExist(ctx, msg.LockedSymbol) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("locked token %s does not exist", msg.LockedSymbol))
+	}
+	rewardToken := k.tokenKeeper.GetTokenInfo(ctx, msg.Reward.Denom)
+	if !rewardToken.Owner.Equals(msg.Owner) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the owner of the reward token %s",
+			msg.Owner, msg.Reward.Denom))
+	}
+	startHeight := msg.StartHeight
+	if startHeight < ctx.BlockHeight() {
+		startHeight = ctx.BlockHeight()
+	}
+
+	pool := types.NewFarmPool(msg.PoolName, msg.Owner, msg.LockedSymbol, msg.Reward.Denom, msg.RewardPerBlock,
+		startHeight)
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Owner, types.ModuleName,
+		sdk.DecCoins{msg.Reward}); err != nil {
+		return err
+	}
+	pool.RewardBalance = msg.Reward.Amount
+	pool.RemainingReward = msg.Reward.Amount
+	k.SetFarmPool(ctx, pool)
+	return nil
+}
+
+// Provide locks more reward tokens of the owner into the farm pool, which extends its emission
+func (k Keeper) Provide(ctx sdk.Context, owner sdk.AccAddress, poolName string, amount sdk.DecCoin) sdk.Error {
+	pool, err := k.getFarmPoolAtHeight(ctx, poolName)
+	if err != nil {
+		return err
+	}
+	if !pool.Owner.Equals(owner) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the owner of farm pool %s", owner, poolName))
+	}
+	if amount.Denom != pool.RewardSymbol {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("farm pool %s rewards %s", poolName, pool.RewardSymbol))
+	}
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, owner, types.ModuleName,
+		sdk.DecCoins{amount}); err != nil {
+		return err
+	}
+	pool.RewardBalance = pool.RewardBalance.Add(amount.Amount)
+	pool.RemainingReward = pool.RemainingReward.Add(amount.Amount)
+	k.SetFarmPool(ctx, pool)
+	return nil
+}
+
+// Stake stakes the pool tokens of the address into the farm pool, the pending rewards are claimed first
+func (k Keeper) Stake(ctx sdk.Context, addr sdk.AccAddress, poolName string, amount sdk.DecCoin) (
+	reward sdk.DecCoin, err sdk.Error) {
+	pool, err := k.getFarmPoolAtHeight(ctx, poolName)
+	if err != nil {
+		return reward, err
+	}
+	if amount.Denom != pool.LockedSymbol {
+		return reward, sdk.ErrInvalidCoins(fmt.Sprintf("farm pool %s locks %s", poolName, pool.LockedSymbol))
+	}
+	lockInfo, found := k.GetLockInfo(ctx, addr, poolName)
+	if !found {
+		lockInfo = types.NewLockInfo(addr, poolName, sdk.ZeroDec(), pool.AccRewardPerShare)
+	}
+	if reward, err = k.settleReward(ctx, &pool, &lockInfo); err != nil {
+		return reward, err
+	}
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, addr, types.ModuleName,
+		sdk.DecCoins{amount}); err != nil {
+		return reward, err
+	}
+	lockInfo.Amount = lockInfo.Amount.Add(amount.Amount)
+	pool.TotalStaked = pool.TotalStaked.Add(amount.Amount)
+	k.SetLockInfo(ctx, lockInfo)
+	k.SetFarmPool(ctx, pool)
+	return reward, nil
+}
+
+// Unstake unstakes the pool tokens of the address from the farm pool, the pending rewards are claimed first
+func (k Keeper) Unstake(ctx sdk.Context, addr sdk.AccAddress, poolName string, amount sdk.DecCoin) (
+	reward sdk.DecCoin, err sdk.Error) {
+	pool, err := k.getFarmPoolAtHeight(ctx, poolName)
+	if err != nil {
+		return reward, err
+	}
+	if amount.Denom != pool.LockedSymbol {
+		return reward, sdk.ErrInvalidCoins(fmt.Sprintf("farm pool %s locks %s", poolName, pool.LockedSymbol))
+	}
+	lockInfo, found := k.GetLockInfo(ctx, addr, poolName)
+	if !found || lockInfo.Amount.LT(amount.Amount) {
+		return reward, sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient %s staked in farm pool %s",
+			amount.Denom, poolName))
+	}
+	if reward, err = k.settleReward(ctx, &pool, &lockInfo); err != nil {
+		return reward, err
+	}
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, addr,
+		sdk.DecCoins{amount}); err != nil {
+		return reward, err
+	}
+	lockInfo.Amount = lockInfo.Amount.Sub(amount.Amount)
+	pool.TotalStaked = pool.TotalStaked.Sub(amount.Amount)
+	if lockInfo.Amount.IsZero() {
+		k.DeleteLockInfo(ctx, addr, poolName)
+	} else {
+		k.SetLockInfo(ctx, lockInfo)
+	}
+	k.SetFarmPool(ctx, pool)
+	return reward, nil
+}
+
+// Claim pays the pending rewards of the address in the farm pool
+func (k Keeper) Claim(ctx sdk.Context, addr sdk.AccAddress, poolName string) (reward sdk.DecCoin, err sdk.Error) {
+	pool, err := k.getFarmPoolAtHeight(ctx, poolName)
+	if err != nil {
+		return reward, err
+	}
+	lockInfo, found := k.GetLockInfo(ctx, addr, poolName)
+	if !found {
+		return reward, sdk.ErrUnknownRequest(fmt.Sprintf("%s has nothing staked in farm pool %s", addr, poolName))
+	}
+	if reward, err = k.settleReward(ctx, &pool, &lockInfo); err != nil {
+		return reward, err
+	}
+	k.SetLockInfo(ctx, lockInfo)
+	k.SetFarmPool(ctx, pool)
+	return reward, nil
+}
+
+// GetEarnings returns the stake & the pending rewards of the address in the farm pool at the current height
+func (k Keeper) GetEarnings(ctx sdk.Context, addr sdk.AccAddress, poolName string) (types.Earnings, sdk.Error) {
+	pool, err := k.getFarmPoolAtHeight(ctx, poolName)
+	if err != nil {
+		return types.Earnings{}, err
+	}
+	earnings := types.Earnings{
+		PoolName:      poolName,
+		Owner:         addr,
+		Staked:        sdk.NewDecCoinFromDec(pool.LockedSymbol, sdk.ZeroDec()),
+		PendingReward: sdk.NewDecCoinFromDec(pool.RewardSymbol, sdk.ZeroDec()),
+		Height:        ctx.BlockHeight(),
+	}
+	if lockInfo, found := k.GetLockInfo(ctx, addr, poolName); found {
+		earnings.Staked.Amount = lockInfo.Amount
+		earnings.PendingReward.Amount = calculatePendingReward(pool, lockInfo)
+	}
+	return earnings, nil
+}
+
+// getFarmPoolAtHeight gets the farm pool with the rewards emitted till the current height
+func (k Keeper) getFarmPoolAtHeight(ctx sdk.Context, poolName string) (types.FarmPool, sdk.Error) {
+	pool, found := k.GetFarmPool(ctx, poolName)
+	if !found {
+		return pool, sdk.ErrUnknownRequest(fmt.Sprintf("farm pool %s does not exist", poolName))
+	}
+	return emitReward(pool, ctx.BlockHeight()), nil
+}
+
+// settleReward pays the pending rewards of the lock info, which then accrues from the current rewards per share
+func (k Keeper) settleReward(ctx sdk.Context, pool *types.FarmPool, lockInfo *types.LockInfo) (sdk.DecCoin, sdk.Error) {
+	reward := sdk.NewDecCoinFromDec(pool.RewardSymbol, calculatePendingReward(*pool, *lockInfo))
+	lockInfo.RewardPerShare = pool.AccRewardPerShare
+	if reward.IsZero() {
+		return reward, nil
+	}
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, lockInfo.Owner,
+		sdk.DecCoins{reward}); err != nil {
+		return reward, err
+	}
+	pool.RewardBalance = pool.RewardBalance.Sub(reward.Amount)
+	return reward, nil
+}
+
+// emitReward emits the rewards of the blocks since the last emission to the stakers of the farm pool,
+// nothing is emitted to an empty pool, the rewards of those blocks are left to be emitted later
+func emitReward(pool types.FarmPool, height int64) types.FarmPool {
+	fromHeight := pool.LastRewardHeight
+	if fromHeight < pool.StartHeight {
+		fromHeight = pool.StartHeight
+	}
+	if height <= fromHeight {
+		return pool
+	}
+	if pool.TotalStaked.IsPositive() && pool.RemainingReward.IsPositive() {
+		reward := pool.RewardPerBlock.MulInt64(height - fromHeight)
+		if reward.GT(pool.RemainingReward) {
+			reward = pool.RemainingReward
+		}
+		pool.RemainingReward = pool.RemainingReward.Sub(reward)
+		pool.AccRewardPerShare = pool.AccRewardPerShare.Add(
+			reward.MulTruncate(types.RewardPerShareScale).QuoTruncate(pool.TotalStaked))
+	}
+	pool.LastRewardHeight = height
+	return pool
+}
+
+// calculatePendingReward returns the rewards accrued by the lock info since its last settlement
+func calculatePendingReward(pool types.FarmPool, lockInfo types.LockInfo) sdk.Dec {
+	return lockInfo.Amount.MulTruncate(pool.AccRewardPerShare.Sub(lockInfo.RewardPerShare)).
+		QuoTruncate(types.RewardPerShareScale)
+}
@@ -0,0 +1,78 @@
+package keeper
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/farm/types"
+)
+
+// RegisterInvariants registers all farm invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, keeper Keeper) {
+	ir.RegisterRoute(types.ModuleName, "module-account", ModuleAccountInvariant(keeper))
+	ir.RegisterRoute(types.ModuleName, "staked", StakedInvariant(keeper))
+}
+
+// ModuleAccountInvariant checks that the module account coins reflects the sum of
+// the reward balances & the staked pool tokens of the farm pools
+func ModuleAccountInvariant(keeper Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var lockedCoins sdk.DecCoins
+		for _, pool := range keeper.GetFarmPools(ctx) {
+			lockedCoins = lockedCoins.Add(sdk.DecCoins{
+				sdk.NewDecCoinFromDec(pool.RewardSymbol, pool.RewardBalance),
+			}).Add(sdk.DecCoins{
+				sdk.NewDecCoinFromDec(pool.LockedSymbol, pool.TotalStaked),
+			})
+		}
+
+		macc := keeper.GetFarmAccount(ctx)
+		broken := !macc.GetCoins().IsEqual(lockedCoins)
+		return sdk.FormatInvariant(types.ModuleName, "module-account",
+			fmt.Sprintf("\tfarm ModuleAccount coins: %s\n\tsum of farm pool locked amounts:  %s\n",
+				macc.GetCoins(), lockedCoins)), broken
+	}
+}
+
+// StakedInvariant checks that the total staked of every farm pool is the sum of its lock infos,
+// and the reward balance covers the rewards left to be emitted
+func StakedInvariant(keeper Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		staked := make(map[string]sdk.Dec)
+		for _, lockInfo := range keeper.GetLockInfos(ctx, nil) {
+			if amount, ok := staked[lockInfo.PoolName]; ok {
+				staked[lockInfo.PoolName] = amount.Add(lockInfo.Amount)
+			} else {
+				staked[lockInfo.PoolName] = lockInfo.Amount
+			}
+		}
+
+		var msg string
+		for _, pool := range keeper.GetFarmPools(ctx) {
+			amount, ok := staked[pool.Name]
+			if !ok {
+				amount = sdk.ZeroDec()
+			}
+			if !amount.Equal(pool.TotalStaked) {
+				msg += fmt.Sprintf("\tfarm pool %s total staked: %s, sum of lock infos: %s\n",
+					pool.Name, pool.TotalStaked, amount)
+			}
+			if pool.RemainingReward.GT(pool.RewardBalance) {
+				msg += fmt.Sprintf("\tfarm pool %s remaining reward: %s, reward balance: %s\n",
+					pool.Name, pool.RemainingReward, pool.RewardBalance)
+			}
+			delete(staked, pool.Name)
+		}
+		var poolNames []string
+		for poolName := range staked {
+			poolNames = append(poolNames, poolName)
+		}
+		sort.Strings(poolNames)
+		for _, poolName := range poolNames {
+			msg += fmt.Sprintf("\t%s staked in non-existent farm pool %s\n", staked[poolName], poolName)
+		}
+		return sdk.FormatInvariant(types.ModuleName, "staked", msg), msg != ""
+	}
+}
@@ -0,0 +1,102 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	supplyexported "github.com/cosmos/cosmos-sdk/x/supply/exported"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/okex/okexchain/x/farm/types"
+)
+
+// Keeper of the farm store
+type Keeper struct {
+	supplyKeeper types.SupplyKeeper
+	tokenKeeper  types.TokenKeeper
+
+	storeKey sdk.StoreKey
+	cdc      *codec.Codec
+}
+
+// NewKeeper creates a farm keeper
+func NewKeeper(supplyKeeper types.SupplyKeeper, tokenKeeper types.TokenKeeper, cdc *codec.Codec,
+	key sdk.StoreKey) Keeper {
+	return Keeper{
+		supplyKeeper: supplyKeeper,
+		tokenKeeper:  tokenKeeper,
+		storeKey:     key,
+		cdc:          cdc,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
+
+// GetFarmAccount returns the module account holding the staked pool tokens & the reward tokens
+func (k Keeper) GetFarmAccount(ctx sdk.Context) supplyexported.ModuleAccountI {
+	return k.supplyKeeper.GetModuleAccount(ctx, types.ModuleName)
+}
+
+// GetFarmPool gets the farm pool by its name
+func (k Keeper) GetFarmPool(ctx sdk.Context, poolName string) (pool types.FarmPool, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetFarmPoolKey(poolName))
+	if bz == nil {
+		return pool, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &pool)
+	return pool, true
+}
+
+// SetFarmPool sets the farm pool
+func (k Keeper) SetFarmPool(ctx sdk.Context, pool types.FarmPool) {
+	ctx.KVStore(k.storeKey).Set(types.GetFarmPoolKey(pool.Name), k.cdc.MustMarshalBinaryLengthPrefixed(pool))
+}
+
+// GetFarmPools gets all the farm pools
+func (k Keeper) GetFarmPools(ctx sdk.Context) (pools types.FarmPools) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.FarmPoolPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var pool types.FarmPool
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &pool)
+		pools = append(pools, pool)
+	}
+	return pools
+}
+
+// GetLockInfo gets the pool tokens staked by the address in the farm pool
+func (k Keeper) GetLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName string) (lockInfo types.LockInfo, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetLockInfoKey(addr, poolName))
+	if bz == nil {
+		return lockInfo, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &lockInfo)
+	return lockInfo, true
+}
+
+// SetLockInfo sets the lock info
+func (k Keeper) SetLockInfo(ctx sdk.Context, lockInfo types.LockInfo) {
+	ctx.KVStore(k.storeKey).Set(types.GetLockInfoKey(lockInfo.Owner, lockInfo.PoolName),
+		k.cdc.MustMarshalBinaryLengthPrefixed(lockInfo))
+}
+
+// DeleteLockInfo deletes the lock info of the address in the farm pool
+func (k Keeper) DeleteLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName string) {
+	ctx.KVStore(k.storeKey).Delete(types.GetLockInfoKey(addr, poolName))
+}
+
+// GetLockInfos gets the lock infos of the address, all the lock infos if the address is empty
+func (k Keeper) GetLockInfos(ctx sdk.Context, addr sdk.AccAddress) (lockInfos types.LockInfos) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.GetLockInfoPrefix(addr))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var lockInfo types.LockInfo
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &lockInfo)
+		lockInfos = append(lockInfos, lockInfo)
+	}
+	return lockInfos
+}
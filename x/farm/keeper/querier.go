@@ -0,0 +1,83 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/okex/okexchain/x/farm/types"
+)
+
+// NewQuerier creates a new querier for farm clients.
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case types.QueryPool:
+			return queryPool(ctx, path[1:], k)
+		case types.QueryPools:
+			return queryPools(ctx, k)
+		case types.QueryEarnings:
+			return queryEarnings(ctx, path[1:], k)
+		case types.QueryAccount:
+			return queryAccount(ctx, path[1:], k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown farm query endpoint")
+		}
+	}
+}
+
+// queryPool returns the farm pool with the rewards emitted till the current height
+func queryPool(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	if len(path) != 1 {
+		return nil, sdk.ErrUnknownRequest("the name of the farm pool is required")
+	}
+	pool, err := k.getFarmPoolAtHeight(ctx, path[0])
+	if err != nil {
+		return nil, err
+	}
+	return k.cdc.MustMarshalJSON(pool), nil
+}
+
+func queryPools(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
+	pools := k.GetFarmPools(ctx)
+	for i, pool := range pools {
+		pools[i] = emitReward(pool, ctx.BlockHeight())
+	}
+	if pools == nil {
+		pools = types.FarmPools{}
+	}
+	return k.cdc.MustMarshalJSON(pools), nil
+}
+
+// queryEarnings returns the stake & the pending rewards of the address in the farm pool
+func queryEarnings(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	if len(path) != 2 {
+		return nil, sdk.ErrUnknownRequest("the name of the farm pool & the address are required")
+	}
+	addr, err := sdk.AccAddressFromBech32(path[1])
+	if err != nil {
+		return nil, sdk.ErrInvalidAddress(fmt.Sprintf("invalid address: %s", path[1]))
+	}
+	earnings, sdkErr := k.GetEarnings(ctx, addr, path[0])
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+	return k.cdc.MustMarshalJSON(earnings), nil
+}
+
+// queryAccount returns the lock infos of the address in all the farm pools
+func queryAccount(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	if len(path) != 1 {
+		return nil, sdk.ErrUnknownRequest("the address is required")
+	}
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, sdk.ErrInvalidAddress(fmt.Sprintf("invalid address: %s", path[0]))
+	}
+	lockInfos := k.GetLockInfos(ctx, addr)
+	if lockInfos == nil {
+		lockInfos = types.LockInfos{}
+	}
+	return k.cdc.MustMarshalJSON(lockInfos), nil
+}
@@ -0,0 +1,24 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// RegisterCodec registers concrete types on codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgCreatePool{}, "okexchain/farm/MsgCreatePool", nil)
+	cdc.RegisterConcrete(MsgProvide{}, "okexchain/farm/MsgProvide", nil)
+	cdc.RegisterConcrete(MsgStake{}, "okexchain/farm/MsgStake", nil)
+	cdc.RegisterConcrete(MsgUnstake{}, "okexchain/farm/MsgUnstake", nil)
+	cdc.RegisterConcrete(MsgClaim{}, "okexchain/farm/MsgClaim", nil)
+}
+
+// ModuleCdc defines the module codec
+var ModuleCdc *codec.Codec
+
+func init() {
+	ModuleCdc = codec.New()
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
@@ -0,0 +1,22 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	supplyexported "github.com/cosmos/cosmos-sdk/x/supply/exported"
+	token "github.com/okex/okexchain/x/token/types"
+)
+
+// SupplyKeeper defines the expected supply interface
+type SupplyKeeper interface {
+	GetModuleAccount(ctx sdk.Context, moduleName string) supplyexported.ModuleAccountI
+	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string,
+		recipientAddr sdk.AccAddress, amt sdk.Coins) sdk.Error
+	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress,
+		recipientModule string, amt sdk.Coins) sdk.Error
+}
+
+// TokenKeeper defines the expected token interface
+type TokenKeeper interface {
+	GetTokenInfo(ctx sdk.Context, symbol string) token.Token
+	TokenExist(ctx sdk.Context, symbol string) bool
+}
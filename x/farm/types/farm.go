@@ -0,0 +1,183 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	swap "github.com/okex/okexchain/x/ammswap/types"
+)
+
+var (
+	poolNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_\-]{0,31}$`)
+
+	// RewardPerShareScale scales the rewards accumulated per staked token up,
+	// so that the small rewards per block on a large stake aren't truncated by the precision of sdk.Dec
+	RewardPerShareScale = sdk.NewDec(100000000)
+)
+
+// FarmPool is a pool emitting the reward token locked by its owner to the stakers of a pool token by blocks
+type FarmPool struct {
+	Name           string         `json:"name"`             // The name of the farm pool
+	Owner          sdk.AccAddress `json:"owner"`            // The owner of the reward token who created the farm pool
+	LockedSymbol   string         `json:"locked_symbol"`    // The pool token of ammswap staked in the farm pool
+	RewardSymbol   string         `json:"reward_symbol"`    // The token rewarded to the stakers
+	RewardPerBlock sdk.Dec        `json:"reward_per_block"` // The reward tokens emitted every block
+	StartHeight    int64          `json:"start_height"`     // The height after which the rewards are emitted
+
+	TotalStaked       sdk.Dec `json:"total_staked"`         // The amount of the pool token staked
+	RewardBalance     sdk.Dec `json:"reward_balance"`       // The reward tokens held for the pool, to be emitted or emitted but unclaimed
+	RemainingReward   sdk.Dec `json:"remaining_reward"`     // The reward tokens to be emitted
+	AccRewardPerShare sdk.Dec `json:"acc_reward_per_share"` // The rewards emitted per staked token, scaled up by RewardPerShareScale
+	LastRewardHeight  int64   `json:"last_reward_height"`   // The height till which the rewards are emitted
+}
+
+// NewFarmPool creates a farm pool without any reward or stake
+func NewFarmPool(name string, owner sdk.AccAddress, lockedSymbol, rewardSymbol string, rewardPerBlock sdk.Dec,
+	startHeight int64) FarmPool {
+	return FarmPool{
+		Name:              name,
+		Owner:             owner,
+		LockedSymbol:      lockedSymbol,
+		RewardSymbol:      rewardSymbol,
+		RewardPerBlock:    rewardPerBlock,
+		StartHeight:       startHeight,
+		TotalStaked:       sdk.ZeroDec(),
+		RewardBalance:     sdk.ZeroDec(),
+		RemainingReward:   sdk.ZeroDec(),
+		AccRewardPerShare: sdk.ZeroDec(),
+		LastRewardHeight:  startHeight,
+	}
+}
+
+// String implement fmt.Stringer
+func (p FarmPool) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Name: %s
+Owner: %s
+LockedSymbol: %s
+RewardSymbol: %s
+RewardPerBlock: %s
+StartHeight: %d
+TotalStaked: %s
+RewardBalance: %s
+RemainingReward: %s
+AccRewardPerShare: %s
+LastRewardHeight: %d`, p.Name, p.Owner, p.LockedSymbol, p.RewardSymbol, p.RewardPerBlock, p.StartHeight,
+		p.TotalStaked, p.RewardBalance, p.RemainingReward, p.AccRewardPerShare, p.LastRewardHeight))
+}
+
+// Validate checks the farm pool
+func (p FarmPool) Validate() error {
+	if err := ValidatePoolName(p.Name); err != nil {
+		return err
+	}
+	if p.Owner.Empty() {
+		return fmt.Errorf("farm pool %s: empty owner", p.Name)
+	}
+	if err := ValidateLockedSymbol(p.LockedSymbol); err != nil {
+		return err
+	}
+	if sdk.ValidateDenom(p.RewardSymbol) != nil {
+		return fmt.Errorf("farm pool %s: invalid reward symbol: %s", p.Name, p.RewardSymbol)
+	}
+	for _, amount := range []sdk.Dec{p.RewardPerBlock, p.TotalStaked, p.RewardBalance, p.RemainingReward,
+		p.AccRewardPerShare} {
+		if amount.IsNil() || amount.IsNegative() {
+			return fmt.Errorf("farm pool %s: invalid amounts", p.Name)
+		}
+	}
+	if !p.RewardPerBlock.IsPositive() {
+		return fmt.Errorf("farm pool %s: the reward per block should be positive", p.Name)
+	}
+	if p.RemainingReward.GT(p.RewardBalance) {
+		return fmt.Errorf("farm pool %s: the remaining reward is greater than the reward balance", p.Name)
+	}
+	return nil
+}
+
+// FarmPools is a slice of FarmPool
+type FarmPools []FarmPool
+
+// String implement fmt.Stringer
+func (p FarmPools) String() string {
+	poolsJSON, err := json.Marshal(p)
+	if err != nil {
+		panic(err)
+	}
+	return string(poolsJSON)
+}
+
+// LockInfo is the pool tokens staked by an address in a farm pool
+type LockInfo struct {
+	Owner          sdk.AccAddress `json:"owner"`            // The staker
+	PoolName       string         `json:"pool_name"`        // The name of the farm pool
+	Amount         sdk.Dec        `json:"amount"`           // The amount of the pool token staked
+	RewardPerShare sdk.Dec        `json:"reward_per_share"` // The AccRewardPerShare of the farm pool when the rewards were last settled
+}
+
+// NewLockInfo creates a lock info
+func NewLockInfo(owner sdk.AccAddress, poolName string, amount, rewardPerShare sdk.Dec) LockInfo {
+	return LockInfo{
+		Owner:          owner,
+		PoolName:       poolName,
+		Amount:         amount,
+		RewardPerShare: rewardPerShare,
+	}
+}
+
+// String implement fmt.Stringer
+func (l LockInfo) String() string {
+	lockInfoJSON, err := json.Marshal(l)
+	if err != nil {
+		panic(err)
+	}
+	return string(lockInfoJSON)
+}
+
+// LockInfos is a slice of LockInfo
+type LockInfos []LockInfo
+
+// String implement fmt.Stringer
+func (l LockInfos) String() string {
+	lockInfosJSON, err := json.Marshal(l)
+	if err != nil {
+		panic(err)
+	}
+	return string(lockInfosJSON)
+}
+
+// Earnings is the stake & the pending rewards of an address in a farm pool at the height
+type Earnings struct {
+	PoolName      string         `json:"pool_name"`
+	Owner         sdk.AccAddress `json:"owner"`
+	Staked        sdk.DecCoin    `json:"staked"`
+	PendingReward sdk.DecCoin    `json:"pending_reward"`
+	Height        int64          `json:"height"`
+}
+
+// String implement fmt.Stringer
+func (e Earnings) String() string {
+	earningsJSON, err := json.Marshal(e)
+	if err != nil {
+		panic(err)
+	}
+	return string(earningsJSON)
+}
+
+// ValidatePoolName checks the name of a farm pool
+func ValidatePoolName(poolName string) error {
+	if !poolNameRegex.MatchString(poolName) {
+		return fmt.Errorf("invalid farm pool name: %s", poolName)
+	}
+	return nil
+}
+
+// ValidateLockedSymbol checks the token staked in a farm pool is a pool token of ammswap
+func ValidateLockedSymbol(lockedSymbol string) error {
+	if !strings.HasPrefix(lockedSymbol, swap.PoolTokenPrefix) || sdk.ValidateDenom(lockedSymbol) != nil {
+		return fmt.Errorf("the locked symbol should be a pool token of ammswap: %s", lockedSymbol)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// ModuleName is the name of the module
+	ModuleName = "farm"
+
+	// StoreKey to be used when creating the KVStore
+	StoreKey = ModuleName
+
+	// RouterKey to be used for routing msgs
+	RouterKey = ModuleName
+
+	// QuerierRoute to be used for querier msgs
+	QuerierRoute = ModuleName
+
+	// QueryPool query endpoints supported by the farm Querier
+	QueryPool = "pool"
+
+	QueryPools = "pools"
+
+	QueryEarnings = "earnings"
+
+	QueryAccount = "account"
+)
+
+var (
+	// FarmPoolPrefix is the prefix of the farm pools
+	FarmPoolPrefix = []byte{0x01}
+	// LockInfoPrefix is the prefix of the lock infos of the stakers
+	LockInfoPrefix = []byte{0x02}
+)
+
+// GetFarmPoolKey returns the key of the farm pool
+func GetFarmPoolKey(poolName string) []byte {
+	return append(FarmPoolPrefix, []byte(poolName)...)
+}
+
+// GetLockInfoPrefix returns the prefix of the lock infos of the staker
+func GetLockInfoPrefix(addr sdk.AccAddress) []byte {
+	return append(LockInfoPrefix, addr.Bytes()...)
+}
+
+// GetLockInfoKey returns the key of the lock info of the staker in the farm pool
+func GetLockInfoKey(addr sdk.AccAddress, poolName string) []byte {
+	return append(GetLockInfoPrefix(addr), []byte(poolName)...)
+}
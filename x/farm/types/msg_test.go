@@ -0,0 +1,70 @@
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+const addrStr = "1212121212121212123412121212121212121234"
+
+func TestMsgCreatePool(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	reward := sdk.NewDecCoinFromDec("wwb", sdk.NewDec(1000))
+	msg := NewMsgCreatePool(addr, "wwb-farm", "ammswap_aab_okt", reward, sdk.NewDec(10), 100)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, "create_pool", msg.Type())
+
+	resMsg := &MsgCreatePool{}
+	require.Nil(t, json.Unmarshal(msg.GetSignBytes(), resMsg))
+	require.EqualValues(t, addr, msg.GetSigners()[0])
+
+	tests := []struct {
+		msg            MsgCreatePool
+		expectedResult bool
+	}{
+		{NewMsgCreatePool(nil, "wwb-farm", "ammswap_aab_okt", reward, sdk.NewDec(10), 100), false},
+		{NewMsgCreatePool(addr, "WWB farm", "ammswap_aab_okt", reward, sdk.NewDec(10), 100), false},
+		{NewMsgCreatePool(addr, "wwb-farm", "aab", reward, sdk.NewDec(10), 100), false},
+		{NewMsgCreatePool(addr, "wwb-farm", "ammswap_aab_okt",
+			sdk.NewDecCoinFromDec("wwb", sdk.ZeroDec()), sdk.NewDec(10), 100), false},
+		{NewMsgCreatePool(addr, "wwb-farm", "ammswap_aab_okt", reward, sdk.ZeroDec(), 100), false},
+		{NewMsgCreatePool(addr, "wwb-farm", "ammswap_aab_okt", reward, sdk.NewDec(10), -1), false},
+		{NewMsgCreatePool(addr, "wwb-farm", "ammswap_aab_okt", reward, sdk.NewDec(10), 0), true},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expectedResult, test.msg.ValidateBasic() == nil, test.msg)
+	}
+}
+
+func TestMsgStake(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	amount := sdk.NewDecCoinFromDec("ammswap_aab_okt", sdk.NewDec(10))
+	tests := []struct {
+		msg            sdk.Msg
+		expectedResult bool
+	}{
+		{NewMsgProvide(addr, "wwb-farm", amount), true},
+		{NewMsgProvide(nil, "wwb-farm", amount), false},
+		{NewMsgStake(addr, "wwb-farm", amount), true},
+		{NewMsgStake(addr, "", amount), false},
+		{NewMsgStake(addr, "wwb-farm", sdk.NewDecCoinFromDec("ammswap_aab_okt", sdk.ZeroDec())), false},
+		{NewMsgUnstake(addr, "wwb-farm", amount), true},
+		{NewMsgUnstake(nil, "wwb-farm", amount), false},
+		{NewMsgClaim(addr, "wwb-farm"), true},
+		{NewMsgClaim(addr, "-farm"), false},
+	}
+	for _, test := range tests {
+		require.Equal(t, RouterKey, test.msg.Route())
+		require.Equal(t, test.expectedResult, test.msg.ValidateBasic() == nil, test.msg)
+		if test.expectedResult {
+			require.EqualValues(t, addr, test.msg.GetSigners()[0])
+		}
+	}
+}
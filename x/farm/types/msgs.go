@@ -0,0 +1,238 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MsgCreatePool creates a farm pool rewarding the stakers of the locked symbol with the reward locked by its owner
+type MsgCreatePool struct {
+	Owner          sdk.AccAddress `json:"owner"`
+	PoolName       string         `json:"pool_name"`
+	LockedSymbol   string         `json:"locked_symbol"`
+	Reward         sdk.DecCoin    `json:"reward"`
+	RewardPerBlock sdk.Dec        `json:"reward_per_block"`
+	StartHeight    int64          `json:"start_height"`
+}
+
+// NewMsgCreatePool creates a new MsgCreatePool
+func NewMsgCreatePool(owner sdk.AccAddress, poolName, lockedSymbol string, reward sdk.DecCoin,
+	rewardPerBlock sdk.Dec, startHeight int64) MsgCreatePool {
+	return MsgCreatePool{
+		Owner:          owner,
+		PoolName:       poolName,
+		LockedSymbol:   lockedSymbol,
+		Reward:         reward,
+		RewardPerBlock: rewardPerBlock,
+		StartHeight:    startHeight,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgCreatePool) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCreatePool) Type() string { return "create_pool" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgCreatePool) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if err := ValidatePoolName(msg.PoolName); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if err := ValidateLockedSymbol(msg.LockedSymbol); err != nil {
+		return sdk.ErrInvalidCoins(err.Error())
+	}
+	if !msg.Reward.IsValid() || !msg.Reward.IsPositive() {
+		return sdk.ErrInvalidCoins("the reward should be positive")
+	}
+	if msg.RewardPerBlock.IsNil() || !msg.RewardPerBlock.IsPositive() {
+		return sdk.ErrUnknownRequest("the reward per block should be positive")
+	}
+	if msg.StartHeight < 0 {
+		return sdk.ErrUnknownRequest("the start height should not be negative")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCreatePool) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCreatePool) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgProvide locks more reward tokens into a farm pool, which extends its emission
+type MsgProvide struct {
+	Owner    sdk.AccAddress `json:"owner"`
+	PoolName string         `json:"pool_name"`
+	Amount   sdk.DecCoin    `json:"amount"`
+}
+
+// NewMsgProvide creates a new MsgProvide
+func NewMsgProvide(owner sdk.AccAddress, poolName string, amount sdk.DecCoin) MsgProvide {
+	return MsgProvide{
+		Owner:    owner,
+		PoolName: poolName,
+		Amount:   amount,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgProvide) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgProvide) Type() string { return "provide" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgProvide) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	return validatePoolNameAndAmount(msg.PoolName, msg.Amount)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgProvide) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgProvide) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgStake stakes the pool tokens into a farm pool
+type MsgStake struct {
+	Address  sdk.AccAddress `json:"address"`
+	PoolName string         `json:"pool_name"`
+	Amount   sdk.DecCoin    `json:"amount"`
+}
+
+// NewMsgStake creates a new MsgStake
+func NewMsgStake(addr sdk.AccAddress, poolName string, amount sdk.DecCoin) MsgStake {
+	return MsgStake{
+		Address:  addr,
+		PoolName: poolName,
+		Amount:   amount,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgStake) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgStake) Type() string { return "stake" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgStake) ValidateBasic() sdk.Error {
+	if msg.Address.Empty() {
+		return sdk.ErrInvalidAddress(msg.Address.String())
+	}
+	return validatePoolNameAndAmount(msg.PoolName, msg.Amount)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgStake) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgStake) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Address}
+}
+
+// MsgUnstake unstakes the pool tokens from a farm pool
+type MsgUnstake struct {
+	Address  sdk.AccAddress `json:"address"`
+	PoolName string         `json:"pool_name"`
+	Amount   sdk.DecCoin    `json:"amount"`
+}
+
+// NewMsgUnstake creates a new MsgUnstake
+func NewMsgUnstake(addr sdk.AccAddress, poolName string, amount sdk.DecCoin) MsgUnstake {
+	return MsgUnstake{
+		Address:  addr,
+		PoolName: poolName,
+		Amount:   amount,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgUnstake) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgUnstake) Type() string { return "unstake" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgUnstake) ValidateBasic() sdk.Error {
+	if msg.Address.Empty() {
+		return sdk.ErrInvalidAddress(msg.Address.String())
+	}
+	return validatePoolNameAndAmount(msg.PoolName, msg.Amount)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgUnstake) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgUnstake) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Address}
+}
+
+// MsgClaim claims the pending rewards of a farm pool
+type MsgClaim struct {
+	Address  sdk.AccAddress `json:"address"`
+	PoolName string         `json:"pool_name"`
+}
+
+// NewMsgClaim creates a new MsgClaim
+func NewMsgClaim(addr sdk.AccAddress, poolName string) MsgClaim {
+	return MsgClaim{
+		Address:  addr,
+		PoolName: poolName,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgClaim) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgClaim) Type() string { return "claim" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgClaim) ValidateBasic() sdk.Error {
+	if msg.Address.Empty() {
+		return sdk.ErrInvalidAddress(msg.Address.String())
+	}
+	if err := ValidatePoolName(msg.PoolName); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgClaim) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgClaim) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Address}
+}
+
+func validatePoolNameAndAmount(poolName string, amount sdk.DecCoin) sdk.Error {
+	if err := ValidatePoolName(poolName); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if !amount.IsValid() || !amount.IsPositive() {
+		return sdk.ErrInvalidCoins("the amount should be positive")
+	}
+	return nil
+}
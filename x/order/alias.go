@@ -19,17 +19,25 @@ const (
 // nolint
 // types aliases
 type (
-	Keeper           = keeper.Keeper
-	Order            = types.Order
-	DepthBook        = types.DepthBook
-	MatchResult      = types.MatchResult
-	Deal             = types.Deal
-	Params           = types.Params
-	MsgNewOrder      = types.MsgNewOrder
-	MsgCancelOrder   = types.MsgCancelOrder
-	MsgNewOrders     = types.MsgNewOrders
-	MsgCancelOrders  = types.MsgCancelOrders
-	BlockMatchResult = types.BlockMatchResult
+	Keeper             = keeper.Keeper
+	Order              = types.Order
+	DepthBook          = types.DepthBook
+	MatchResult        = types.MatchResult
+	Deal               = types.Deal
+	Params             = types.Params
+	MsgNewOrder        = types.MsgNewOrder
+	MsgCancelOrder     = types.MsgCancelOrder
+	MsgNewOrders       = types.MsgNewOrders
+	MsgCancelOrders    = types.MsgCancelOrders
+	MsgAmendOrders     = types.MsgAmendOrders
+	MsgCancelAllOrders = types.MsgCancelAllOrders
+	MsgHeartbeat       = types.MsgHeartbeat
+	MsgHybridSwap      = types.MsgHybridSwap
+	TriggerOrder       = types.TriggerOrder
+	FeeSchedule        = types.FeeSchedule
+	DeadManSwitch      = types.DeadManSwitch
+	HybridRoute        = types.HybridRoute
+	BlockMatchResult   = types.BlockMatchResult
 )
 
 // nolint
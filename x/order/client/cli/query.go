@@ -7,6 +7,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -27,6 +28,10 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdDepthBook(queryRoute, cdc),
 		GetCmdQueryStore(queryRoute, cdc),
 		GetCmdQueryParams(queryRoute, cdc),
+		GetCmdQueryTriggerOrders(queryRoute, cdc),
+		GetCmdQueryFeeSchedule(queryRoute, cdc),
+		GetCmdQueryDeadManSwitch(queryRoute, cdc),
+		GetCmdQueryHybridRoute(queryRoute, cdc),
 	)...)
 
 	queryCmd.Flags().StringP(client.FlagNode, "n", "tcp://localhost:26657", "Node to connect to")
@@ -170,3 +175,118 @@ $ okexchaincli query order params
 		},
 	}
 }
+
+// GetCmdQueryTriggerOrders queries the pending trigger orders
+func GetCmdQueryTriggerOrders(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "triggers",
+		Short: "Query the pending trigger orders",
+		Long: strings.TrimSpace(`Query the pending trigger orders, filtered by address and product if specified:
+
+$ okexchaincli query order triggers --address okexchain1... --product mytoken_okt
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var sender sdk.AccAddress
+			if address := viper.GetString("address"); address != "" {
+				var err error
+				if sender, err = sdk.AccAddressFromBech32(address); err != nil {
+					return err
+				}
+			}
+			bz, err := cdc.MarshalJSON(keeper.NewQueryTriggerOrdersParams(sender, viper.GetString("product")))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryTriggers), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().String("address", "", "the owner address of the trigger orders")
+	cmd.Flags().String("product", "", "the product of the trigger orders")
+	return cmd
+}
+
+// GetCmdQueryFeeSchedule queries the fee schedule of a product
+func GetCmdQueryFeeSchedule(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "fee-schedule [product]",
+		Short: "Query the maker/taker fee tiers of a product",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryFeeSchedule, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			var schedule types.FeeSchedule
+			cdc.MustUnmarshalJSON(res, &schedule)
+			return cliCtx.PrintOutput(schedule)
+		},
+	}
+}
+
+// GetCmdQueryDeadManSwitch queries the dead man's switch of an account
+func GetCmdQueryDeadManSwitch(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "dead-man-switch [address]",
+		Short: "Query the dead man's switch armed by an account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryDeadManSwitch, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			var deadManSwitch types.DeadManSwitch
+			cdc.MustUnmarshalJSON(res, &deadManSwitch)
+			return cliCtx.PrintOutput(deadManSwitch)
+		},
+	}
+}
+
+// GetCmdQueryHybridRoute quotes selling an amount across the depth book & the ammswap pool of a product
+func GetCmdQueryHybridRoute(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "hybrid-route [product] [side] [amount]",
+		Short: "Quote selling an amount across the depth book & the ammswap pool of a product",
+		Long: strings.TrimSpace(`Quote the split of selling an amount of the quote token buying, or of the base token selling,
+between the depth book & the ammswap pool of the product:
+
+$ okexchaincli query order hybrid-route mycoin_okt BUY 100
+`),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			amount, parseErr := sdk.NewDecFromStr(args[2])
+			if parseErr != nil {
+				return fmt.Errorf("invalid amount %s: %v", args[2], parseErr)
+			}
+			bz, err := cdc.MarshalJSON(keeper.NewQueryHybridRouteParams(args[0], args[1], amount))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryHybridRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			var route types.HybridRoute
+			cdc.MustUnmarshalJSON(res, &route)
+			return cliCtx.PrintOutput(route)
+		},
+	}
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client"
@@ -25,6 +26,14 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	txCmd.AddCommand(client.PostCommands(
 		getCmdNewOrder(cdc),
 		getCmdCancelOrder(cdc),
+		getCmdAmendOrder(cdc),
+		getCmdNewTriggerOrder(cdc),
+		getCmdCancelTriggerOrder(cdc),
+		getCmdSetFeeSchedule(cdc),
+		getCmdSetAuctionType(cdc),
+		getCmdCancelAllOrders(cdc),
+		getCmdHeartbeat(cdc),
+		getCmdHybridSwap(cdc),
 	)...)
 
 	return txCmd
@@ -36,6 +45,7 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 	var side string
 	var price string
 	var quantity string
+	var orderType string
 	cmd := &cobra.Command{
 		Use:   "new",
 		Short: "place a new order",
@@ -52,7 +62,7 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 				return errors.New("invalid param counts")
 			}
 
-			err := handleNewOrder(cdc, product, side, price, quantity)
+			err := handleNewOrder(cdc, product, side, price, quantity, orderType)
 			return err
 
 		},
@@ -62,15 +72,22 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().StringVarP(&side, "side", "s", "", "BUY or SELL (default \"SELL\")")
 	cmd.Flags().StringVarP(&price, "price", "p", "", "The price of the order")
 	cmd.Flags().StringVarP(&quantity, "quantity", "q", "", "The quantity of the order")
+	cmd.Flags().StringVarP(&orderType, "order-type", "t", "",
+		"LIMIT, IOC, FOK or POST_ONLY (default \"LIMIT\")")
 	return cmd
 }
 
-func handleNewOrder(cdc *codec.Codec, product string, side string, price string, quantity string) error {
+func handleNewOrder(cdc *codec.Codec, product string, side string, price string, quantity string,
+	orderType string) error {
 	var items []types.OrderItem
 	productArr := strings.Split(product, ",")
 	sideArr := strings.Split(side, ",")
 	priceArr := strings.Split(price, ",")
 	quantityArr := strings.Split(quantity, ",")
+	orderTypeArr := make([]string, len(productArr))
+	if len(orderType) > 0 {
+		orderTypeArr = strings.Split(orderType, ",")
+	}
 	if len(productArr) != len(sideArr) {
 		return errors.New("invalid param side counts")
 	}
@@ -83,6 +100,10 @@ func handleNewOrder(cdc *codec.Codec, product string, side string, price string,
 		return errors.New("invalid param quantity counts")
 	}
 
+	if len(productArr) != len(orderTypeArr) {
+		return errors.New("invalid param order-type counts")
+	}
+
 	for i := 0; i < len(productArr); i++ {
 		product := productArr[i]
 		side := sideArr[i]
@@ -95,10 +116,11 @@ func handleNewOrder(cdc *codec.Codec, product string, side string, price string,
 			return errors.New(err.Error())
 		}
 		items = append(items, types.OrderItem{
-			Product:  product,
-			Side:     side,
-			Price:    price,
-			Quantity: quantity,
+			Product:   product,
+			Side:      side,
+			Price:     price,
+			Quantity:  quantity,
+			OrderType: orderTypeArr[i],
 		})
 	}
 
@@ -130,3 +152,291 @@ func getCmdCancelOrder(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+func getCmdAmendOrder(cdc *codec.Codec) *cobra.Command {
+	var price string
+	var quantity string
+	cmd := &cobra.Command{
+		Use:   "amend [order-id]",
+		Short: "change the price and/or the quantity of open orders",
+		Long: strings.TrimSpace(`Change the price and/or the quantity of open orders, the quantity includes the filled quantity.
+An order only reducing its quantity keeps its place in the queue of its price:
+
+$ okexchaincli tx order amend ID0000000010-1 --quantity 5 --from mykey
+$ okexchaincli tx order amend ID0000000010-1,ID0000000010-2 --price 0.1,0.2 --quantity 5, --from mykey
+
+Use comma "," to amend multi orders, an empty price or quantity is left unchanged.
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			orderIDs := strings.Split(args[0], ",")
+			priceArr := make([]string, len(orderIDs))
+			if len(price) > 0 {
+				priceArr = strings.Split(price, ",")
+			}
+			quantityArr := make([]string, len(orderIDs))
+			if len(quantity) > 0 {
+				quantityArr = strings.Split(quantity, ",")
+			}
+			if len(orderIDs) != len(priceArr) {
+				return errors.New("invalid param price counts")
+			}
+			if len(orderIDs) != len(quantityArr) {
+				return errors.New("invalid param quantity counts")
+			}
+
+			items := make([]types.AmendOrderItem, 0, len(orderIDs))
+			for i, orderID := range orderIDs {
+				for _, dec := range []string{priceArr[i], quantityArr[i]} {
+					if _, err := sdk.NewDecFromStr(dec); dec != "" && err != nil {
+						return err
+					}
+				}
+				items = append(items, types.NewAmendOrderItem(orderID, priceArr[i], quantityArr[i]))
+			}
+
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgAmendOrders(cliCtx.GetFromAddress(), items)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&price, "price", "p", "", "The new price of the orders")
+	cmd.Flags().StringVarP(&quantity, "quantity", "q", "", "The new quantity of the orders, including the filled quantity")
+	return cmd
+}
+
+func getCmdNewTriggerOrder(cdc *codec.Codec) *cobra.Command {
+	// new trigger order flags
+	var product string
+	var side string
+	var triggerType string
+	var triggerPrice string
+	var price string
+	var quantity string
+	var orderType string
+	cmd := &cobra.Command{
+		Use:   "new-trigger",
+		Short: "place a stop-loss or take-profit trigger order",
+		Long: strings.TrimSpace(`Place a trigger order, which is placed as a normal order once the last price crosses the trigger price:
+
+$ okexchaincli tx order new-trigger --product mycoin_okt --side SELL --trigger-type STOP_LOSS --trigger-price 0.9 --price 0.8 --quantity 10 --from mykey
+
+A SELL STOP_LOSS or a BUY TAKE_PROFIT order is triggered when the last price falls to the trigger price,
+a SELL TAKE_PROFIT or a BUY STOP_LOSS order is triggered when the last price rises to the trigger price.
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(product) == 0 || len(side) == 0 || len(triggerPrice) == 0 || len(price) == 0 ||
+				len(quantity) == 0 {
+				return errors.New("invalid param format")
+			}
+			for _, dec := range []string{triggerPrice, price, quantity} {
+				if _, err := sdk.NewDecFromStr(dec); err != nil {
+					return err
+				}
+			}
+
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgNewTriggerOrder(cliCtx.GetFromAddress(), product, side, triggerType, triggerPrice,
+				price, quantity, orderType)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&product, "product", "", "", "Trading pair in full name of the tokens: ${baseAssetSymbol}_${quoteAssetSymbol}, for example \"mycoin_okt\".")
+	cmd.Flags().StringVarP(&side, "side", "s", "", "BUY or SELL")
+	cmd.Flags().StringVarP(&triggerType, "trigger-type", "", types.TriggerTypeStopLoss, "STOP_LOSS or TAKE_PROFIT")
+	cmd.Flags().StringVarP(&triggerPrice, "trigger-price", "", "", "The last price which triggers the order")
+	cmd.Flags().StringVarP(&price, "price", "p", "", "The price of the order placed when triggered")
+	cmd.Flags().StringVarP(&quantity, "quantity", "q", "", "The quantity of the order placed when triggered")
+	cmd.Flags().StringVarP(&orderType, "order-type", "t", "",
+		"LIMIT or IOC (default \"LIMIT\"), the price of an IOC order is the worst acceptable price")
+	return cmd
+}
+
+func getCmdCancelTriggerOrder(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel-trigger [trigger-id]",
+		Short: "cancel a pending trigger order",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgCancelTriggerOrder(cliCtx.GetFromAddress(), args[0])
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func getCmdSetFeeSchedule(cdc *codec.Codec) *cobra.Command {
+	var tiers string
+	var volumePeriodBlocks int64
+	cmd := &cobra.Command{
+		Use:   "set-fee-schedule [product]",
+		Short: "set the maker/taker fee tiers of a token pair, by its owner",
+		Long: strings.TrimSpace(`Set the fee tiers of a token pair, each tier is "minVolume:makerRate:takerRate".
+The volume is counted in quote asset, a negative maker rate is a rebate paid by the fee receiver of the token pair:
+
+$ okexchaincli tx order set-fee-schedule mycoin_okt --tiers "0:0.001:0.002,10000:-0.0001:0.0015" --volume-period-blocks 100000 --from mykey
+
+Set no tiers to charge the trade fee rate of the order params again:
+
+$ okexchaincli tx order set-fee-schedule mycoin_okt --from mykey
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			feeTiers, err := parseFeeTiers(tiers)
+			if err != nil {
+				return err
+			}
+
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgSetFeeSchedule(cliCtx.GetFromAddress(), args[0], feeTiers, volumePeriodBlocks)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&tiers, "tiers", "", "", "Fee tiers separated by comma, each one is \"minVolume:makerRate:takerRate\"")
+	cmd.Flags().Int64VarP(&volumePeriodBlocks, "volume-period-blocks", "", 0,
+		"The number of blocks the traded volume is counted in, 0 means forever")
+	return cmd
+}
+
+func getCmdSetAuctionType(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-auction-type [product] [auction-type]",
+		Short: "set the match engine of a token pair, by its owner",
+		Long: strings.TrimSpace(fmt.Sprintf(`Set the match engine filling the orders of a token pair, "%s" or "%s".
+The new engine fills the orders of the token pair from the end of the current block on:
+
+$ okexchaincli tx order set-auction-type mycoin_okt %s --from mykey
+`, types.AuctionTypePeriodic, types.AuctionTypeContinuous, types.AuctionTypeContinuous)),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgSetAuctionType(cliCtx.GetFromAddress(), args[0], args[1])
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func getCmdCancelAllOrders(cdc *codec.Codec) *cobra.Command {
+	var product string
+	var side string
+	cmd := &cobra.Command{
+		Use:   "cancel-all",
+		Short: "cancel all the open orders of the sender, optionally filtered by product and side",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgCancelAllOrders(cliCtx.GetFromAddress(), product, side)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&product, "product", "", "", "Only cancel the orders of the trading pair")
+	cmd.Flags().StringVarP(&side, "side", "s", "", "Only cancel the orders of the side, BUY or SELL")
+	return cmd
+}
+
+func getCmdHeartbeat(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "heartbeat [timeout-blocks]",
+		Short: "arm or refresh the dead man's switch cancelling all the orders of the sender",
+		Long: strings.TrimSpace(`Arm or refresh the dead man's switch of the sender. All its open & trigger orders are cancelled
+if no other heartbeat is sent within the timeout blocks:
+
+$ okexchaincli tx order heartbeat 100 --from mykey
+
+Set the timeout to 0 to disarm the switch:
+
+$ okexchaincli tx order heartbeat 0 --from mykey
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			timeoutBlocks, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid timeout blocks %s: %v", args[0], err)
+			}
+
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgHeartbeat(cliCtx.GetFromAddress(), timeoutBlocks)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func getCmdHybridSwap(cdc *codec.Codec) *cobra.Command {
+	var minBought string
+	cmd := &cobra.Command{
+		Use:   "hybrid-swap [product] [side] [amount]",
+		Short: "sell an amount across the depth book & the ammswap pool of a product",
+		Long: strings.TrimSpace(`Sell an amount of the quote token buying, or of the base token selling, split between
+the depth book & the ammswap pool of the product to buy the most. The ammswap leg is swapped immediately,
+the order-book leg is placed as a limit order at the worst price it takes from the depth book:
+
+$ okexchaincli tx order hybrid-swap mycoin_okt BUY 100 --min-bought 95 --from mykey
+`),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			amount, err := sdk.NewDecFromStr(args[2])
+			if err != nil {
+				return fmt.Errorf("invalid amount %s: %v", args[2], err)
+			}
+			minBoughtAmount, err := sdk.NewDecFromStr(minBought)
+			if err != nil {
+				return fmt.Errorf("invalid min bought amount %s: %v", minBought, err)
+			}
+
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgHybridSwap(cliCtx.GetFromAddress(), args[0], args[1], amount, minBoughtAmount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVar(&minBought, "min-bought", "0",
+		"The least expected to be bought by the ammswap leg, the order-book leg isn't guaranteed")
+	return cmd
+}
+
+func parseFeeTiers(tiers string) ([]types.FeeTier, error) {
+	var feeTiers []types.FeeTier
+	if len(strings.TrimSpace(tiers)) == 0 {
+		return feeTiers, nil
+	}
+	for _, tier := range strings.Split(tiers, ",") {
+		items := strings.Split(strings.TrimSpace(tier), ":")
+		if len(items) != 3 {
+			return nil, fmt.Errorf("invalid fee tier: %s", tier)
+		}
+		var decs [3]sdk.Dec
+		for i, item := range items {
+			dec, err := sdk.NewDecFromStr(item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid fee tier %s: %v", tier, err)
+			}
+			decs[i] = dec
+		}
+		feeTiers = append(feeTiers, types.NewFeeTier(decs[0], decs[1], decs[2]))
+	}
+	return feeTiers, nil
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 	"github.com/gorilla/mux"
 
 	"github.com/okex/okexchain/x/common"
@@ -19,6 +21,11 @@ import (
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/order/depthbook", orderBookHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/order/triggers", triggerOrdersHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/order/triggers/{triggerID}/cancel", cancelTriggerOrderHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/order/feeschedule/{product}", feeScheduleHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/order/deadmanswitch/{address}", deadManSwitchHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/order/hybridroute", hybridRouteHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/order/{orderID}", orderDetailHandler(cliCtx)).Methods("GET")
 }
 
@@ -91,3 +98,150 @@ func orderBookHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		rest.PostProcessResponse(w, cliCtx, resBytes)
 	}
 }
+
+func triggerOrdersHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		addressStr := r.URL.Query().Get("address")
+		product := r.URL.Query().Get("product")
+
+		var sender sdk.AccAddress
+		if addressStr != "" {
+			var err error
+			if sender, err = sdk.AccAddressFromBech32(addressStr); err != nil {
+				common.HandleErrorMsg(w, cliCtx, err.Error())
+				return
+			}
+		}
+		bz, err := cliCtx.Codec.MarshalJSON(keeper.NewQueryTriggerOrdersParams(sender, product))
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/order/%s", types.QueryTriggers), bz)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+
+		var triggers []types.TriggerOrder
+		codec.Cdc.MustUnmarshalJSON(res, &triggers)
+		response := common.GetBaseResponse(triggers)
+		resBytes, err2 := json.Marshal(response)
+		if err2 != nil {
+			common.HandleErrorMsg(w, cliCtx, err2.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, resBytes)
+	}
+}
+
+type cancelTriggerOrderReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+}
+
+// cancelTriggerOrderHandler generates an unsigned tx to cancel the trigger order
+func cancelTriggerOrderHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req cancelTriggerOrderReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		sender, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCancelTriggerOrder(sender, mux.Vars(r)["triggerID"])
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func feeScheduleHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		product := mux.Vars(r)["product"]
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/order/%s/%s", types.QueryFeeSchedule, product), nil)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+
+		var schedule types.FeeSchedule
+		codec.Cdc.MustUnmarshalJSON(res, &schedule)
+		response := common.GetBaseResponse(schedule)
+		resBytes, err2 := json.Marshal(response)
+		if err2 != nil {
+			common.HandleErrorMsg(w, cliCtx, err2.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, resBytes)
+	}
+}
+
+func deadManSwitchHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address := mux.Vars(r)["address"]
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/order/%s/%s", types.QueryDeadManSwitch, address), nil)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+
+		var deadManSwitch types.DeadManSwitch
+		codec.Cdc.MustUnmarshalJSON(res, &deadManSwitch)
+		response := common.GetBaseResponse(deadManSwitch)
+		resBytes, err2 := json.Marshal(response)
+		if err2 != nil {
+			common.HandleErrorMsg(w, cliCtx, err2.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, resBytes)
+	}
+}
+
+func hybridRouteHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		product := r.URL.Query().Get("product")
+		side := r.URL.Query().Get("side")
+		amount, parseErr := sdk.NewDecFromStr(r.URL.Query().Get("amount"))
+		if parseErr != nil {
+			common.HandleErrorMsg(w, cliCtx, parseErr.Error())
+			return
+		}
+		bz, err := cliCtx.Codec.MarshalJSON(keeper.NewQueryHybridRouteParams(product, side, amount))
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/order/%s", types.QueryHybridRoute), bz)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, err.Error())
+			return
+		}
+
+		var route types.HybridRoute
+		codec.Cdc.MustUnmarshalJSON(res, &route)
+		response := common.GetBaseResponse(route)
+		resBytes, err2 := json.Marshal(response)
+		if err2 != nil {
+			common.HandleErrorMsg(w, cliCtx, err2.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, resBytes)
+	}
+}
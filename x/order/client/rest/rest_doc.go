@@ -78,3 +78,104 @@ type BookResponse struct {
 //     Schemes: http, https
 //     Responses:
 //       200: BookResponse
+
+// TriggerOrdersParam : pending trigger orders param
+// swagger:parameters getTriggerOrders
+type TriggerOrdersParam struct {
+	// owner address of the trigger orders
+	// in: query
+	Address string `json:"address"`
+	// token pair string
+	// in: query
+	Product string `json:"product"`
+}
+
+// TriggerOrdersResponse : pending trigger orders
+// swagger:response TriggerOrdersResponse
+type TriggerOrdersResponse struct {
+	// in: body
+	Body []types.TriggerOrder
+}
+
+// swagger:route GET /order/triggers order getTriggerOrders
+//
+// Get the pending trigger orders
+//
+//     Schemes: http, https
+//     Responses:
+//       200: TriggerOrdersResponse
+
+// FeeScheduleParam : fee schedule param
+// swagger:parameters getFeeSchedule
+type FeeScheduleParam struct {
+	// token pair string
+	// in: path
+	Product string `json:"product"`
+}
+
+// FeeScheduleResponse : the maker/taker fee tiers of a product
+// swagger:response FeeScheduleResponse
+type FeeScheduleResponse struct {
+	// in: body
+	Body types.FeeSchedule
+}
+
+// swagger:route GET /order/feeschedule/{product} order getFeeSchedule
+//
+// Get the fee schedule of a product, the trade fee rate of the order params if its owner never set one
+//
+//     Schemes: http, https
+//     Responses:
+//       200: FeeScheduleResponse
+
+// DeadManSwitchParam : dead man's switch param
+// swagger:parameters getDeadManSwitch
+type DeadManSwitchParam struct {
+	// the address arming the switch
+	// in: path
+	Address string `json:"address"`
+}
+
+// DeadManSwitchResponse : the dead man's switch armed by an account
+// swagger:response DeadManSwitchResponse
+type DeadManSwitchResponse struct {
+	// in: body
+	Body types.DeadManSwitch
+}
+
+// swagger:route GET /order/deadmanswitch/{address} order getDeadManSwitch
+//
+// Get the dead man's switch armed by an account
+//
+//     Schemes: http, https
+//     Responses:
+//       200: DeadManSwitchResponse
+
+// HybridRouteParam : hybrid route param
+// swagger:parameters getHybridRoute
+type HybridRouteParam struct {
+	// the trading pair
+	// in: query
+	Product string `json:"product"`
+	// BUY or SELL
+	// in: query
+	Side string `json:"side"`
+	// the amount of the quote token buying, or of the base token selling
+	// in: query
+	Amount string `json:"amount"`
+}
+
+// HybridRouteResponse : the split of an amount between the depth book & the ammswap pool
+// swagger:response HybridRouteResponse
+type HybridRouteResponse struct {
+	// in: body
+	Body types.HybridRoute
+}
+
+// swagger:route GET /order/hybridroute order getHybridRoute
+//
+// Quote selling an amount across the depth book & the ammswap pool of a product
+//
+//     Schemes: http, https
+//     Responses:
+//       200: HybridRouteResponse
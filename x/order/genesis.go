@@ -10,8 +10,13 @@ import (
 
 // GenesisState - all order state that must be provided at genesis
 type GenesisState struct {
-	Params     types.Params   `json:"params"`
-	OpenOrders []*types.Order `json:"open_orders"`
+	Params          types.Params               `json:"params"`
+	OpenOrders      []*types.Order             `json:"open_orders"`
+	AuctionTypes    []types.ProductAuctionType `json:"auction_types"`
+	TriggerOrders   []*types.TriggerOrder      `json:"trigger_orders"`
+	FeeSchedules    []types.FeeSchedule        `json:"fee_schedules"`
+	AccountVolumes  []types.AccountVolume      `json:"account_volumes"`
+	DeadManSwitches []types.DeadManSwitch      `json:"dead_man_switches"`
 }
 
 // DefaultGenesisState - default GenesisState used by Cosmos Hub
@@ -24,6 +29,42 @@ func DefaultGenesisState() GenesisState {
 
 // ValidateGenesis validates the slashing genesis parameters
 func ValidateGenesis(data GenesisState) error {
+	for _, item := range data.AuctionTypes {
+		if !types.IsValidAuctionType(item.AuctionType) {
+			return fmt.Errorf("invalid auction type %s of product %s", item.AuctionType, item.Product)
+		}
+	}
+
+	triggerIDs := make(map[string]bool, len(data.TriggerOrders))
+	for _, trigger := range data.TriggerOrders {
+		if trigger == nil {
+			return fmt.Errorf("the nil pointer is not expected")
+		}
+		if types.GetSeqFromTriggerID(trigger.TriggerID) == 0 || triggerIDs[trigger.TriggerID] {
+			return fmt.Errorf("invalid or duplicated trigger id %s", trigger.TriggerID)
+		}
+		triggerIDs[trigger.TriggerID] = true
+		if !types.IsValidTriggerType(trigger.TriggerType) || !types.IsValidTriggerOrderType(trigger.OrderType) {
+			return fmt.Errorf("invalid trigger order %s", trigger)
+		}
+	}
+
+	for _, schedule := range data.FeeSchedules {
+		if err := schedule.Validate(); err != nil {
+			return fmt.Errorf("invalid fee schedule of product %s: %v", schedule.Product, err)
+		}
+	}
+	for _, volume := range data.AccountVolumes {
+		if volume.Address.Empty() || volume.Volume.IsNil() || volume.Volume.IsNegative() ||
+			volume.LastPeriodVolume.IsNil() || volume.LastPeriodVolume.IsNegative() {
+			return fmt.Errorf("invalid account volume of product %s: %s", volume.Product, volume.Address)
+		}
+	}
+	for _, deadManSwitch := range data.DeadManSwitches {
+		if deadManSwitch.Address.Empty() || deadManSwitch.TimeoutBlocks <= 0 {
+			return fmt.Errorf("invalid dead man's switch %s", deadManSwitch)
+		}
+	}
 	return nil
 }
 
@@ -32,6 +73,37 @@ func ValidateGenesis(data GenesisState) error {
 func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data GenesisState) {
 	keeper.SetParams(ctx, &data.Params)
 
+	for _, item := range data.AuctionTypes {
+		if err := keeper.SetAuctionType(ctx, item.Product, item.AuctionType); err != nil {
+			panic(err)
+		}
+	}
+
+	// the coins of pending trigger orders are locked in the token genesis
+	var triggerSeq uint64
+	for _, trigger := range data.TriggerOrders {
+		if trigger.ExpireHeight == 0 {
+			trigger.ExpireHeight = ctx.BlockHeight() + trigger.OrderExpireBlocks
+		}
+		keeper.SetTriggerOrder(ctx, trigger)
+		if seq := types.GetSeqFromTriggerID(trigger.TriggerID); seq > triggerSeq {
+			triggerSeq = seq
+		}
+	}
+	keeper.SetTriggerOrderSeq(ctx, triggerSeq)
+
+	for _, schedule := range data.FeeSchedules {
+		if err := keeper.SetFeeSchedule(ctx, schedule); err != nil {
+			panic(err)
+		}
+	}
+	for _, volume := range data.AccountVolumes {
+		keeper.SetAccountVolume(ctx, volume)
+	}
+	for _, deadManSwitch := range data.DeadManSwitches {
+		keeper.SetDeadManSwitch(ctx, deadManSwitch)
+	}
+
 	// reset open order& depth book
 	for _, order := range data.OpenOrders {
 		if order == nil {
@@ -54,6 +126,8 @@ func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data GenesisState) {
 	if len(data.OpenOrders) > 0 {
 		keeper.Cache2Disk(ctx)
 	}
+	// the open orders are indexed by sender as they are set, mark the index as complete
+	keeper.BackfillOpenOrderSenderIndex(ctx)
 }
 
 // ExportGenesis writes the current store values
@@ -64,6 +138,7 @@ func ExportGenesis(ctx sdk.Context, keeper keeper.Keeper) (data GenesisState) {
 	tokenPairs := keeper.GetDexKeeper().GetTokenPairs(ctx)
 
 	var openOrders []*types.Order
+	var auctionTypes []types.ProductAuctionType
 	var num int64 = 1
 	for _, pair := range tokenPairs {
 		if pair == nil {
@@ -74,6 +149,10 @@ func ExportGenesis(ctx sdk.Context, keeper keeper.Keeper) (data GenesisState) {
 		pair.InitPrice = keeper.GetLastPrice(ctx, product)
 		keeper.GetDexKeeper().UpdateTokenPair(ctx, product, pair)
 
+		if auctionType := keeper.GetAuctionType(ctx, product); auctionType != types.DefaultAuctionType {
+			auctionTypes = append(auctionTypes, types.ProductAuctionType{Product: product, AuctionType: auctionType})
+		}
+
 		// get open orders
 		depthBook := keeper.GetDepthBookFromDB(ctx, product)
 		var openIDs []string
@@ -110,7 +189,12 @@ func ExportGenesis(ctx sdk.Context, keeper keeper.Keeper) (data GenesisState) {
 	}
 
 	return GenesisState{
-		Params:     *params,
-		OpenOrders: openOrders,
+		Params:          *params,
+		OpenOrders:      openOrders,
+		AuctionTypes:    auctionTypes,
+		TriggerOrders:   keeper.GetTriggerOrders(ctx),
+		FeeSchedules:    keeper.GetFeeSchedules(ctx),
+		AccountVolumes:  keeper.GetAccountVolumes(ctx),
+		DeadManSwitches: keeper.GetDeadManSwitches(ctx),
 	}
 }
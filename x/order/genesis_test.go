@@ -19,6 +19,33 @@ func TestValidateGenesis(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestValidateGenesisAuctionTypes(t *testing.T) {
+	genesisState := DefaultGenesisState()
+	genesisState.AuctionTypes = []types.ProductAuctionType{
+		{Product: types.TestTokenPair, AuctionType: types.AuctionTypeContinuous},
+	}
+	require.NoError(t, ValidateGenesis(genesisState))
+
+	genesisState.AuctionTypes[0].AuctionType = "unknown"
+	require.Error(t, ValidateGenesis(genesisState))
+}
+
+func TestValidateGenesisTriggerOrders(t *testing.T) {
+	genesisState := DefaultGenesisState()
+	trigger := types.NewTriggerOrder("", nil, types.TestTokenPair, types.SellOrder, types.TriggerTypeStopLoss,
+		sdk.NewDec(9), sdk.NewDec(8), sdk.NewDec(1), "", 0, 1, types.DefaultFeePerBlock)
+	trigger.TriggerID = types.FormatTriggerID(1)
+	genesisState.TriggerOrders = []*types.TriggerOrder{trigger}
+	require.NoError(t, ValidateGenesis(genesisState))
+
+	genesisState.TriggerOrders = []*types.TriggerOrder{trigger, trigger}
+	require.Error(t, ValidateGenesis(genesisState))
+
+	trigger.TriggerID = "invalid"
+	genesisState.TriggerOrders = []*types.TriggerOrder{trigger}
+	require.Error(t, ValidateGenesis(genesisState))
+}
+
 func TestExportGenesis(t *testing.T) {
 	testInput := keeper.CreateTestInput(t)
 	ctx := testInput.Ctx
@@ -147,4 +174,7 @@ func TestExportGenesis(t *testing.T) {
 	require.Equal(t, int64(2), newOrderKeeper.GetOpenOrderNum(newCtx))
 	// 0x20
 	require.Equal(t, int64(2), newOrderKeeper.GetStoreOrderNum(newCtx))
+	// the open orders are indexed by sender once the chain is upgraded from the genesis
+	require.Equal(t, 2, newOrderKeeper.GetOpenOrderNumOfSender(newCtx, testInput.TestAddrs[0]))
+	require.Equal(t, 0, newOrderKeeper.BackfillOpenOrderSenderIndex(newCtx))
 }
@@ -24,6 +24,23 @@ func CalculateGas(msg sdk.Msg, params *types.Params) (gas uint64) {
 		gas = msg.CalculateGas(params.NewOrderMsgGasUnit)
 	case types.MsgCancelOrders:
 		gas = msg.CalculateGas(params.CancelOrderMsgGasUnit)
+	case types.MsgAmendOrders:
+		gas = msg.CalculateGas(params.CancelOrderMsgGasUnit)
+	case types.MsgNewTriggerOrder:
+		gas = params.NewOrderMsgGasUnit
+	case types.MsgCancelTriggerOrder:
+		gas = params.CancelOrderMsgGasUnit
+	case types.MsgSetFeeSchedule:
+		gas = params.NewOrderMsgGasUnit
+	case types.MsgSetAuctionType:
+		gas = params.NewOrderMsgGasUnit
+	case types.MsgCancelAllOrders:
+		// the orders touched are charged by calculateCancelAllGas
+		gas = params.CancelOrderMsgGasUnit
+	case types.MsgHeartbeat:
+		gas = params.CancelOrderMsgGasUnit
+	case types.MsgHybridSwap:
+		gas = params.NewOrderMsgGasUnit
 	default:
 		gas = math.MaxUint64
 	}
@@ -39,11 +56,25 @@ func NewOrderHandler(keeper keeper.Keeper) sdk.Handler {
 		// consume gas that msg required, it will panic if gas is insufficient
 		ctx.GasMeter().ConsumeGas(gas, storetypes.GasWriteCostFlatDesc)
 
+		var cancelAllOrders []*types.Order
+		if msg, ok := msg.(types.MsgCancelAllOrders); ok {
+			var touched int64
+			cancelAllOrders, touched = keeper.GetOpenOrdersOfSender(ctx, msg.Sender, msg.Product, msg.Side)
+			ctx.GasMeter().ConsumeGas(calculateCancelAllGas(keeper.GetParams(ctx), len(cancelAllOrders), touched),
+				storetypes.GasReadCostFlatDesc)
+		}
+		if msg, ok := msg.(types.MsgHeartbeat); ok && msg.TimeoutBlocks > 0 {
+			// the orders cancelled once the switch fires are charged to the heartbeat arming it
+			num := keeper.GetOpenOrderNumOfSender(ctx, msg.Sender) + keeper.GetTriggerOrderNumOfSender(ctx, msg.Sender)
+			ctx.GasMeter().ConsumeGas(keeper.GetParams(ctx).CancelOrderMsgGasUnit*uint64(num),
+				storetypes.GasReadCostFlatDesc)
+		}
+
+		gasMeter := ctx.GasMeter()
 		if ctx.IsCheckTx() {
 			return sdk.Result{}
 		} else {
 			// set an infinite gas meter and recovery it when return
-			gasMeter := ctx.GasMeter()
 			ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
 			defer func() { ctx = ctx.WithGasMeter(gasMeter) }()
 		}
@@ -63,6 +94,49 @@ func NewOrderHandler(keeper keeper.Keeper) sdk.Handler {
 			handlerFun = func() sdk.Result {
 				return handleMsgCancelOrders(ctx, keeper, msg, logger)
 			}
+		case types.MsgAmendOrders:
+			name = "handleMsgAmendOrders"
+			handlerFun = func() sdk.Result {
+				return handleMsgAmendOrders(ctx, keeper, msg, logger)
+			}
+		case types.MsgNewTriggerOrder:
+			name = "handleMsgNewTriggerOrder"
+			handlerFun = func() sdk.Result {
+				return handleMsgNewTriggerOrder(ctx, keeper, msg, logger)
+			}
+		case types.MsgCancelTriggerOrder:
+			name = "handleMsgCancelTriggerOrder"
+			handlerFun = func() sdk.Result {
+				return handleMsgCancelTriggerOrder(ctx, keeper, msg, logger)
+			}
+		case types.MsgSetFeeSchedule:
+			name = "handleMsgSetFeeSchedule"
+			handlerFun = func() sdk.Result {
+				return handleMsgSetFeeSchedule(ctx, keeper, msg, logger)
+			}
+		case types.MsgSetAuctionType:
+			name = "handleMsgSetAuctionType"
+			handlerFun = func() sdk.Result {
+				return handleMsgSetAuctionType(ctx, keeper, msg, logger)
+			}
+		case types.MsgCancelAllOrders:
+			name = "handleMsgCancelAllOrders"
+			handlerFun = func() sdk.Result {
+				return handleMsgCancelAllOrders(ctx, keeper, msg, cancelAllOrders, logger)
+			}
+		case types.MsgHeartbeat:
+			name = "handleMsgHeartbeat"
+			handlerFun = func() sdk.Result {
+				return handleMsgHeartbeat(ctx, keeper, msg, logger)
+			}
+		case types.MsgHybridSwap:
+			name = "handleMsgHybridSwap"
+			handlerFun = func() sdk.Result {
+				// the steps splitting the amount are charged to the tx while quoting the route
+				route, err := keeper.QuoteHybridRoute(ctx.WithGasMeter(gasMeter), keeper.GetDepthBookCopy(msg.Product),
+					msg.Product, msg.Side, msg.Amount)
+				return handleMsgHybridSwap(ctx, keeper, msg, route, err, logger)
+			}
 		default:
 			errMsg := fmt.Sprintf("Invalid msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -103,6 +177,10 @@ func checkOrderNewMsg(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgNewOrd
 	if msg.Quantity.LT(tokenPair.MinQuantity) {
 		return fmt.Errorf("quantity should be greater than %s", tokenPair.MinQuantity)
 	}
+
+	if !types.IsValidOrderType(msg.OrderType) {
+		return fmt.Errorf("invalid order type \"%s\"", msg.OrderType)
+	}
 	return nil
 }
 
@@ -110,7 +188,7 @@ func getOrderFromMsg(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrder, ra
 	feeParams := k.GetParams(ctx)
 	feePerBlockAmount := feeParams.FeePerBlock.Amount.Mul(sdk.MustNewDecFromStr(ratio))
 	feePerBlock := sdk.NewDecCoinFromDec(feeParams.FeePerBlock.Denom, feePerBlockAmount)
-	return types.NewOrder(
+	order := types.NewOrder(
 		fmt.Sprintf("%X", tmhash.Sum(ctx.TxBytes())),
 		msg.Sender,
 		msg.Product,
@@ -121,6 +199,8 @@ func getOrderFromMsg(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrder, ra
 		feeParams.OrderExpireBlocks,
 		feePerBlock,
 	)
+	order.OrderType = msg.OrderType
+	return order
 }
 
 func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
@@ -129,11 +209,12 @@ func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
 	cacheItem := ctx.MultiStore().CacheMultiStore()
 	ctxItem := ctx.WithMultiStore(cacheItem)
 	msg := MsgNewOrder{
-		Sender:   sender,
-		Product:  item.Product,
-		Side:     item.Side,
-		Price:    item.Price,
-		Quantity: item.Quantity,
+		Sender:    sender,
+		Product:   item.Product,
+		Side:      item.Side,
+		Price:     item.Price,
+		Quantity:  item.Quantity,
+		OrderType: item.OrderType,
 	}
 	order := getOrderFromMsg(ctxItem, k, msg, ratio)
 	code := sdk.CodeOK
@@ -141,6 +222,10 @@ func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
 
 	if err != nil {
 		code = sdk.CodeUnknownRequest
+	} else if msg.OrderType == types.OrderTypePostOnly &&
+		k.GetDepthBookCopy(msg.Product).IsCrossed(msg.Side, msg.Price) {
+		code = sdk.CodeUnknownRequest
+		err = fmt.Errorf("post-only order would take liquidity at price %s", msg.Price)
 	} else {
 		if k.IsProductLocked(ctx, msg.Product) {
 			code = sdk.CodeInternal
@@ -216,11 +301,12 @@ func ValidateMsgNewOrders(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrde
 
 	for _, item := range msg.OrderItems {
 		msg := MsgNewOrder{
-			Sender:   msg.Sender,
-			Product:  item.Product,
-			Side:     item.Side,
-			Price:    item.Price,
-			Quantity: item.Quantity,
+			Sender:    msg.Sender,
+			Product:   item.Product,
+			Side:      item.Side,
+			Price:     item.Price,
+			Quantity:  item.Quantity,
+			OrderType: item.OrderType,
 		}
 		err := checkOrderNewMsg(ctx, k, msg)
 		if err != nil {
@@ -366,3 +452,355 @@ func ValidateMsgCancelOrders(ctx sdk.Context, keeper keeper.Keeper, msg types.Ms
 
 	return sdk.Result{}
 }
+
+// validateAmendOrder checks the amended order, and returns the order with its new price & quantity
+func validateAmendOrder(ctx sdk.Context, k keeper.Keeper, sender sdk.AccAddress,
+	item types.AmendOrderItem) (order *types.Order, price, quantity sdk.Dec, res sdk.Result) {
+	res = validateCancelOrder(ctx, k, MsgCancelOrder{Sender: sender, OrderID: item.OrderID})
+	if !res.IsOK() {
+		return nil, price, quantity, res
+	}
+	order = k.GetOrder(ctx, item.OrderID)
+	if order.IsImmediate() {
+		return nil, price, quantity, sdk.ErrUnknownRequest(
+			fmt.Sprintf("cannot amend the %s order(%s)", order.GetOrderType(), order.OrderID)).Result()
+	}
+
+	price, quantity = order.Price, order.Quantity
+	if item.GetPrice().IsPositive() {
+		price = item.GetPrice()
+	}
+	if item.GetQuantity().IsPositive() {
+		quantity = item.GetQuantity()
+	}
+	err := checkOrderNewMsg(ctx, k, MsgNewOrder{
+		Sender:    sender,
+		Product:   order.Product,
+		Side:      order.Side,
+		Price:     price,
+		Quantity:  quantity,
+		OrderType: order.OrderType,
+	})
+	if err != nil {
+		return nil, price, quantity, sdk.ErrUnknownRequest(err.Error()).Result()
+	}
+
+	// an order moved across the book would take liquidity, which only the auction or a new order can do
+	if !price.Equal(order.Price) && (order.GetOrderType() == types.OrderTypePostOnly ||
+		k.GetAuctionType(ctx, order.Product) == types.AuctionTypeContinuous) &&
+		k.GetDepthBookCopy(order.Product).IsCrossed(order.Side, price) {
+		return nil, price, quantity, sdk.ErrUnknownRequest(
+			fmt.Sprintf("amended order(%s) would take liquidity at price %s", order.OrderID, price)).Result()
+	}
+	return order, price, quantity, sdk.Result{}
+}
+
+func handleAmendOrder(context sdk.Context, k Keeper, sender sdk.AccAddress, item types.AmendOrderItem,
+	logger log.Logger) (types.OrderResult, sdk.CacheMultiStore) {
+
+	cacheItem := context.MultiStore().CacheMultiStore()
+	ctx := context.WithMultiStore(cacheItem)
+
+	order, price, quantity, res := validateAmendOrder(ctx, k, sender, item)
+	if res.IsOK() {
+		if err := k.AmendOrder(ctx, order, price, quantity); err != nil {
+			res = sdk.ErrInsufficientCoins(err.Error()).Result()
+		} else {
+			logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>, order amended: %s",
+				ctx.BlockHeight(), "handleMsgAmendOrders", order))
+		}
+	}
+
+	return types.OrderResult{
+		Code:    res.Code,
+		Message: res.Log,
+		OrderID: item.OrderID,
+	}, cacheItem
+}
+
+func handleMsgAmendOrders(ctx sdk.Context, k Keeper, msg types.MsgAmendOrders, logger log.Logger) sdk.Result {
+	amendRes := make([]types.OrderResult, 0, len(msg.OrderItems))
+	succeeded := false
+	for _, item := range msg.OrderItems {
+		res, cacheItem := handleAmendOrder(ctx, k, msg.Sender, item, logger)
+		if res.Code == sdk.CodeOK {
+			cacheItem.Write()
+			succeeded = true
+		}
+		amendRes = append(amendRes, res)
+	}
+	rss, err := json.Marshal(&amendRes)
+	if err != nil {
+		rss = []byte(fmt.Sprintf("failed to marshal result to JSON: %s", err))
+	}
+
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+	event = event.AppendAttributes(sdk.NewAttribute("orders", string(rss)))
+	ctx.EventManager().EmitEvent(event)
+
+	if !succeeded {
+		return sdk.Result{Code: sdk.CodeInternal}
+	}
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+// ValidateMsgAmendOrders validates whether the msg of amendOrders is valid.
+func ValidateMsgAmendOrders(ctx sdk.Context, k keeper.Keeper, msg types.MsgAmendOrders) sdk.Result {
+	for _, item := range msg.OrderItems {
+		if _, _, _, res := validateAmendOrder(ctx, k, msg.Sender, item); !res.IsOK() {
+			return res
+		}
+	}
+	return sdk.Result{}
+}
+
+func handleMsgNewTriggerOrder(ctx sdk.Context, k Keeper, msg types.MsgNewTriggerOrder,
+	logger log.Logger) sdk.Result {
+	err := checkOrderNewMsg(ctx, k, MsgNewOrder{
+		Sender:    msg.Sender,
+		Product:   msg.Product,
+		Side:      msg.Side,
+		Price:     msg.Price,
+		Quantity:  msg.Quantity,
+		OrderType: msg.OrderType,
+	})
+	if err != nil {
+		return sdk.ErrUnknownRequest(err.Error()).Result()
+	}
+	tokenPair := k.GetDexKeeper().GetTokenPair(ctx, msg.Product)
+	if !msg.TriggerPrice.RoundDecimal(tokenPair.MaxPriceDigit).Equal(msg.TriggerPrice) {
+		return sdk.ErrUnknownRequest(
+			fmt.Sprintf("trigger price(%v) over accuracy(%d)", msg.TriggerPrice, tokenPair.MaxPriceDigit)).Result()
+	}
+
+	if k.GetTriggerOrderNumOfSender(ctx, msg.Sender) >= types.TriggerOrderMaxPerAddress {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("the number of pending trigger orders reaches the limit %d",
+			types.TriggerOrderMaxPerAddress)).Result()
+	}
+
+	feeParams := k.GetParams(ctx)
+	trigger := types.NewTriggerOrder(
+		fmt.Sprintf("%X", tmhash.Sum(ctx.TxBytes())),
+		msg.Sender,
+		msg.Product,
+		msg.Side,
+		msg.TriggerType,
+		msg.TriggerPrice,
+		msg.Price,
+		msg.Quantity,
+		msg.OrderType,
+		ctx.BlockHeader().Time.Unix(),
+		feeParams.OrderExpireBlocks,
+		feeParams.FeePerBlock,
+	)
+	if err := k.PlaceTriggerOrder(ctx, trigger); err != nil {
+		return sdk.ErrInsufficientCoins(err.Error()).Result()
+	}
+
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>, trigger order placed: %s",
+		ctx.BlockHeight(), "handleMsgNewTriggerOrder", trigger))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute("trigger_id", trigger.TriggerID),
+	))
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func handleMsgCancelTriggerOrder(ctx sdk.Context, k Keeper, msg types.MsgCancelTriggerOrder,
+	logger log.Logger) sdk.Result {
+	trigger := k.GetTriggerOrder(ctx, msg.TriggerID)
+	if trigger == nil {
+		return sdk.ErrUnknownRequest(
+			fmt.Sprintf("trigger order(%s) does not exist or already triggered", msg.TriggerID)).Result()
+	}
+	if !trigger.Sender.Equals(msg.Sender) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("not the owner of trigger order(%s)", msg.TriggerID)).Result()
+	}
+	k.CancelTriggerOrder(ctx, trigger)
+
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>, trigger order cancelled: %s",
+		ctx.BlockHeight(), "handleMsgCancelTriggerOrder", msg.TriggerID))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute("trigger_id", msg.TriggerID),
+	))
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func handleMsgSetFeeSchedule(ctx sdk.Context, k Keeper, msg types.MsgSetFeeSchedule,
+	logger log.Logger) sdk.Result {
+	tokenPair := k.GetDexKeeper().GetTokenPair(ctx, msg.Product)
+	if tokenPair == nil {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("token pair(%s) does not exist", msg.Product)).Result()
+	}
+	if !tokenPair.Owner.Equals(msg.Owner) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("not the owner of token pair(%s)", msg.Product)).Result()
+	}
+
+	if len(msg.Tiers) == 0 {
+		k.DeleteFeeSchedule(ctx, msg.Product)
+	} else if err := k.SetFeeSchedule(ctx, msg.GetFeeSchedule()); err != nil {
+		return sdk.ErrUnknownRequest(err.Error()).Result()
+	}
+
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>, fee schedule of %s set: %v",
+		ctx.BlockHeight(), "handleMsgSetFeeSchedule", msg.Product, msg.Tiers))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute("product", msg.Product),
+	))
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+// handleMsgSetAuctionType switches the match engine of a product, which fills its orders from the EndBlocker of the
+// current block on. The open orders are kept, since neither engine leaves the depth book crossed.
+func handleMsgSetAuctionType(ctx sdk.Context, k Keeper, msg types.MsgSetAuctionType,
+	logger log.Logger) sdk.Result {
+	tokenPair := k.GetDexKeeper().GetTokenPair(ctx, msg.Product)
+	if tokenPair == nil {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("token pair(%s) does not exist", msg.Product)).Result()
+	}
+	if !tokenPair.Owner.Equals(msg.Owner) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("not the owner of token pair(%s)", msg.Product)).Result()
+	}
+
+	if err := k.SetAuctionType(ctx, msg.Product, msg.AuctionType); err != nil {
+		return sdk.ErrUnknownRequest(err.Error()).Result()
+	}
+
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>, auction type of %s set: %s",
+		ctx.BlockHeight(), "handleMsgSetAuctionType", msg.Product, msg.AuctionType))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute("product", msg.Product),
+		sdk.NewAttribute("auction_type", msg.AuctionType),
+	))
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+// calculateCancelAllGas charges every order cancelled as a cancel order msg,
+// and every order read from the orderIDs of the depth books as a store read
+func calculateCancelAllGas(params *types.Params, cancelled int, touched int64) uint64 {
+	return params.CancelOrderMsgGasUnit*uint64(cancelled) + storetypes.KVGasConfig().ReadCostFlat*uint64(touched)
+}
+
+func handleMsgCancelAllOrders(ctx sdk.Context, k Keeper, msg types.MsgCancelAllOrders, orders []*types.Order,
+	logger log.Logger) sdk.Result {
+	if len(orders) == 0 {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("no open orders of %s to cancel", msg.Sender)).Result()
+	}
+
+	cancelRes := make([]types.OrderResult, 0, len(orders))
+	succeeded := false
+	for _, order := range orders {
+		res, cacheItem := handleCancelOrder(ctx, k, msg.Sender, order.OrderID, logger)
+		cancelRes = append(cancelRes, res)
+		cacheItem.Write()
+		if res.Code == sdk.CodeOK {
+			succeeded = true
+		}
+	}
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>, %d orders of %s cancelled",
+		ctx.BlockHeight(), "handleMsgCancelAllOrders", len(orders), msg.Sender))
+
+	rss, err := json.Marshal(&cancelRes)
+	if err != nil {
+		rss = []byte(fmt.Sprintf("failed to marshal result to JSON: %s", err))
+	}
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+	event = event.AppendAttributes(sdk.NewAttribute("orders", string(rss)))
+	ctx.EventManager().EmitEvent(event)
+
+	if !succeeded {
+		return sdk.Result{Code: sdk.CodeInternal}
+	}
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func handleMsgHeartbeat(ctx sdk.Context, k Keeper, msg types.MsgHeartbeat, logger log.Logger) sdk.Result {
+	if msg.TimeoutBlocks == 0 {
+		k.DeleteDeadManSwitch(ctx, msg.Sender)
+	} else {
+		if k.GetDeadManSwitch(ctx, msg.Sender) == nil && k.GetDeadManSwitchNum(ctx) >= types.DeadManSwitchMaxArmed {
+			return sdk.ErrUnknownRequest(
+				fmt.Sprintf("%d dead man's switches are armed already", types.DeadManSwitchMaxArmed)).Result()
+		}
+		k.SetDeadManSwitch(ctx, types.NewDeadManSwitch(msg.Sender, msg.TimeoutBlocks, ctx.BlockHeight()))
+	}
+
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>, heartbeat of %s with timeout %d blocks",
+		ctx.BlockHeight(), "handleMsgHeartbeat", msg.Sender, msg.TimeoutBlocks))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+	))
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+// handleMsgHybridSwap swaps the ammswap leg of the hybrid route quoted & places its order-book leg as a limit order,
+// nothing is done unless both the legs succeed
+func handleMsgHybridSwap(ctx sdk.Context, k Keeper, msg types.MsgHybridSwap, route types.HybridRoute, err error,
+	logger log.Logger) sdk.Result {
+	if k.IsProductLocked(ctx, msg.Product) {
+		return sdk.ErrInternal(fmt.Sprintf("the trading pair (%s) is locked, please retry later", msg.Product)).Result()
+	}
+	if err != nil {
+		return sdk.ErrUnknownRequest(err.Error()).Result()
+	}
+	// only the ammswap leg is bought at once, the order-book leg rests in the depth book until it's filled
+	if route.SwapBoughtToken.Amount.LT(msg.MinBoughtAmount) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("expected minimum token to buy from the ammswap pool is %s but got %s",
+			msg.MinBoughtAmount, route.SwapBoughtToken)).Result()
+	}
+
+	cacheMs := ctx.MultiStore().CacheMultiStore()
+	cacheCtx := ctx.WithMultiStore(cacheMs)
+	if err := k.SwapHybridRoute(cacheCtx, msg.Sender, route); err != nil {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("failed to swap the ammswap leg: %s", err)).Result()
+	}
+	orderRes := types.OrderResult{}
+	if route.OrderQuantity.IsPositive() {
+		item := types.NewOrderItemWithType(msg.Product, msg.Side, route.OrderPrice.String(),
+			route.OrderQuantity.String(), types.OrderTypeLimit)
+		res, cacheItem, err := handleNewOrder(cacheCtx, k, msg.Sender, item, "1", logger)
+		if err != nil {
+			return sdk.Result{Code: res.Code, Log: fmt.Sprintf("failed to place the order-book leg: %s", res.Message)}
+		}
+		cacheItem.Write()
+		orderRes = res
+	}
+	cacheMs.Write()
+
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>, hybrid route of %s: %s",
+		ctx.BlockHeight(), "handleMsgHybridSwap", msg.Sender, route))
+
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+	event = event.AppendAttributes(
+		sdk.NewAttribute("swap_bought_token", route.SwapBoughtToken.String()),
+		sdk.NewAttribute("order_id", orderRes.OrderID),
+		sdk.NewAttribute("order_price", route.OrderPrice.String()),
+		sdk.NewAttribute("order_quantity", route.OrderQuantity.String()),
+	)
+	ctx.EventManager().EmitEvent(event)
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
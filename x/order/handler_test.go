@@ -1022,3 +1022,379 @@ func getHash(t *testing.T, orderIdList []string, order *Order) {
 	fmt.Println(orderIdList)
 	fmt.Println(res)
 }
+
+func TestHandleMsgNewOrderPostOnly(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	keeper := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(keeper)
+	msg := types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+	})
+	result := handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+
+	// a post-only buy order crossing the resting sell order is rejected
+	msg = types.NewMsgNewOrders(addrKeysSlice[1].Address, []types.OrderItem{
+		types.NewOrderItemWithType(types.TestTokenPair, types.BuyOrder, "10.0", "1.0", types.OrderTypePostOnly),
+	})
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeInternal, result.Code)
+
+	// a post-only buy order below the resting sell order rests in depth book
+	msg = types.NewMsgNewOrders(addrKeysSlice[1].Address, []types.OrderItem{
+		types.NewOrderItemWithType(types.TestTokenPair, types.BuyOrder, "9.0", "1.0", types.OrderTypePostOnly),
+	})
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	order := keeper.GetOrder(ctx, getOrderID(result))
+	require.EqualValues(t, types.OrderTypePostOnly, order.OrderType)
+	require.EqualValues(t, types.OrderStatusOpen, order.Status)
+}
+
+func TestHandleMsgTriggerOrder(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	keeper := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(keeper)
+
+	// invalid product
+	msg := types.NewMsgNewTriggerOrder(addrKeysSlice[1].Address, "nobb_okt", types.BuyOrder,
+		types.TriggerTypeStopLoss, "11.0", "11.0", "1.0", "")
+	result := handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeUnknownRequest, result.Code)
+
+	// a buy stop-loss is triggered when the last price rises to 11
+	msg = types.NewMsgNewTriggerOrder(addrKeysSlice[1].Address, types.TestTokenPair, types.BuyOrder,
+		types.TriggerTypeStopLoss, "11.0", "11.0", "1.0", types.OrderTypeLimit)
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	triggerID := types.FormatTriggerID(1)
+	require.NotNil(t, keeper.GetTriggerOrder(ctx, triggerID))
+
+	// only the owner can cancel the trigger order
+	cancelMsg := types.NewMsgCancelTriggerOrder(addrKeysSlice[0].Address, triggerID)
+	result = handler(ctx, cancelMsg)
+	require.EqualValues(t, sdk.CodeUnauthorized, result.Code)
+	cancelMsg = types.NewMsgCancelTriggerOrder(addrKeysSlice[1].Address, "TR9999")
+	result = handler(ctx, cancelMsg)
+	require.EqualValues(t, sdk.CodeUnknownRequest, result.Code)
+
+	// the auction at 11 doesn't trigger the order in the same block
+	orderMsg := types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.SellOrder, "11.0", "2.0"),
+	})
+	result = handler(ctx, orderMsg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	sellOrderID := getOrderID(result)
+	orderMsg = types.NewMsgNewOrders(addrKeysSlice[1].Address, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "11.0", "1.0"),
+	})
+	result = handler(ctx, orderMsg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	EndBlocker(ctx, keeper)
+	require.EqualValues(t, sdk.MustNewDecFromStr("11.0"), keeper.GetLastPrice(ctx, types.TestTokenPair))
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, sellOrderID).Status)
+	require.NotNil(t, keeper.GetTriggerOrder(ctx, triggerID))
+
+	// the triggered order is filled by the auction of the next block
+	ctx = mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(11)
+	keeper.ResetCache(ctx)
+	EndBlocker(ctx, keeper)
+	require.Nil(t, keeper.GetTriggerOrder(ctx, triggerID))
+	order := keeper.GetOrder(ctx, types.FormatOrderID(11, 1))
+	require.NotNil(t, order)
+	require.EqualValues(t, triggerID, order.TriggerID)
+	require.EqualValues(t, types.OrderStatusFilled, order.Status)
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, sellOrderID).Status)
+
+	// cancel a pending trigger order
+	msg = types.NewMsgNewTriggerOrder(addrKeysSlice[0].Address, types.TestTokenPair, types.SellOrder,
+		types.TriggerTypeStopLoss, "9.0", "8.0", "1.0", types.OrderTypeIOC)
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	cancelMsg = types.NewMsgCancelTriggerOrder(addrKeysSlice[0].Address, types.FormatTriggerID(2))
+	result = handler(ctx, cancelMsg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	require.Nil(t, keeper.GetTriggerOrder(ctx, types.FormatTriggerID(2)))
+}
+
+func TestHandleMsgSetFeeSchedule(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 1)
+	keeper := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(keeper)
+	tiers := []types.FeeTier{
+		types.NewFeeTier(sdk.ZeroDec(), sdk.MustNewDecFromStr("0.001"), sdk.MustNewDecFromStr("0.002")),
+		types.NewFeeTier(sdk.NewDec(1000), sdk.MustNewDecFromStr("-0.0001"), sdk.MustNewDecFromStr("0.001")),
+	}
+
+	// only the owner of the token pair can set its fee schedule
+	msg := types.NewMsgSetFeeSchedule(addrKeysSlice[0].Address, types.TestTokenPair, tiers, 100)
+	result := handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeUnauthorized, result.Code)
+	msg = types.NewMsgSetFeeSchedule(tokenPair.Owner, "nobb_okt", tiers, 100)
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeUnknownRequest, result.Code)
+
+	msg = types.NewMsgSetFeeSchedule(tokenPair.Owner, types.TestTokenPair, tiers, 100)
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	require.EqualValues(t, msg.GetFeeSchedule(), *keeper.GetFeeSchedule(ctx, types.TestTokenPair))
+
+	// no tiers restores the trade fee rate of params
+	msg = types.NewMsgSetFeeSchedule(tokenPair.Owner, types.TestTokenPair, nil, 0)
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	require.Nil(t, keeper.GetFeeSchedule(ctx, types.TestTokenPair))
+}
+
+func TestHandleMsgSetAuctionType(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	keeper := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(keeper)
+
+	// only the owner of the token pair can set its auction type
+	msg := types.NewMsgSetAuctionType(addrKeysSlice[0].Address, types.TestTokenPair, types.AuctionTypeContinuous)
+	result := handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeUnauthorized, result.Code)
+	msg = types.NewMsgSetAuctionType(tokenPair.Owner, "nobb_okt", types.AuctionTypeContinuous)
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeUnknownRequest, result.Code)
+	require.EqualValues(t, types.AuctionTypePeriodic, keeper.GetAuctionType(ctx, types.TestTokenPair))
+
+	msg = types.NewMsgSetAuctionType(tokenPair.Owner, types.TestTokenPair, types.AuctionTypeContinuous)
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	require.EqualValues(t, types.AuctionTypeContinuous, keeper.GetAuctionType(ctx, types.TestTokenPair))
+
+	// the orders are filled at the price of the maker by the continuous auction engine from then on
+	orderMsg := types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.SellOrder, "9.0", "1.0"),
+	})
+	result = handler(ctx, orderMsg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	orderMsg = types.NewMsgNewOrders(addrKeysSlice[1].Address, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+	})
+	result = handler(ctx, orderMsg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	buyOrderID := getOrderID(result)
+	EndBlocker(ctx, keeper)
+
+	buyOrder := keeper.GetOrder(ctx, buyOrderID)
+	require.EqualValues(t, types.OrderStatusFilled, buyOrder.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("9.0"), keeper.GetLastPrice(ctx, types.TestTokenPair))
+
+	// switch back to the periodic auction
+	msg = types.NewMsgSetAuctionType(tokenPair.Owner, types.TestTokenPair, types.AuctionTypePeriodic)
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	require.EqualValues(t, types.AuctionTypePeriodic, keeper.GetAuctionType(ctx, types.TestTokenPair))
+}
+
+func TestHandleMsgAmendOrders(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	keeper := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(keeper)
+	orderMsg := types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "9.0", "2.0"),
+	})
+	result := handler(ctx, orderMsg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	buyOrderID := getOrderID(result)
+	orderMsg = types.NewMsgNewOrders(addrKeysSlice[1].Address, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+	})
+	result = handler(ctx, orderMsg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	EndBlocker(ctx, keeper)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, buyOrderID).Status)
+
+	ctx = ctx.WithBlockHeight(11)
+	// only the owner can amend an existing order
+	msg := types.NewMsgAmendOrders(addrKeysSlice[1].Address, []types.AmendOrderItem{
+		types.NewAmendOrderItem(buyOrderID, "10.0", ""),
+	})
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeInternal, result.Code)
+	msg = types.NewMsgAmendOrders(addrKeysSlice[0].Address, []types.AmendOrderItem{
+		types.NewAmendOrderItem(types.FormatOrderID(10, 9), "10.0", ""),
+	})
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeInternal, result.Code)
+
+	// the amended order is matched in the auction without paying the new order fee again
+	acc := mapp.AccountKeeper.GetAccount(ctx, addrKeysSlice[0].Address)
+	balance := acc.GetCoins().AmountOf(common.NativeToken)
+	msg = types.NewMsgAmendOrders(addrKeysSlice[0].Address, []types.AmendOrderItem{
+		types.NewAmendOrderItem(buyOrderID, "10.0", "1.0"),
+	})
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	acc = mapp.AccountKeeper.GetAccount(ctx, addrKeysSlice[0].Address)
+	require.EqualValues(t, balance.Add(sdk.MustNewDecFromStr("8.0")), acc.GetCoins().AmountOf(common.NativeToken))
+	require.Contains(t, keeper.GetUpdatedOrderIDs(), buyOrderID)
+
+	EndBlocker(ctx, keeper)
+	order := keeper.GetOrder(ctx, buyOrderID)
+	require.EqualValues(t, types.OrderStatusFilled, order.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.0"), order.FilledAvgPrice)
+
+	// closed orders can't be amended
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeInternal, result.Code)
+}
+
+func TestHandleMsgCancelAllOrders(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	keeper := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(keeper)
+	orderMsg := types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "9.0", "1.0"),
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "8.0", "1.0"),
+		types.NewOrderItem(types.TestTokenPair, types.SellOrder, "11.0", "1.0"),
+	})
+	result := handler(ctx, orderMsg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	orderMsg = types.NewMsgNewOrders(addrKeysSlice[1].Address, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "9.0", "1.0"),
+	})
+	result = handler(ctx, orderMsg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	otherOrderID := getOrderID(result)
+	EndBlocker(ctx, keeper)
+
+	// only the sell order is cancelled, the gas is charged by the orders touched
+	ctx = ctx.WithBlockHeight(11).WithGasMeter(sdk.NewInfiniteGasMeter())
+	msg := types.NewMsgCancelAllOrders(addrKeysSlice[0].Address, types.TestTokenPair, types.SellOrder)
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	require.True(t, ctx.GasMeter().GasConsumed() >= feeParams.CancelOrderMsgGasUnit*2)
+	orders, _ := keeper.GetOpenOrdersOfSender(ctx, addrKeysSlice[0].Address, "", "")
+	require.EqualValues(t, 2, len(orders))
+
+	// the orders of the others are kept
+	msg = types.NewMsgCancelAllOrders(addrKeysSlice[0].Address, "", "")
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	orders, _ = keeper.GetOpenOrdersOfSender(ctx, addrKeysSlice[0].Address, "", "")
+	require.EqualValues(t, 0, len(orders))
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, otherOrderID).Status)
+
+	// nothing left to cancel
+	result = handler(ctx, msg)
+	require.EqualValues(t, sdk.CodeUnknownRequest, result.Code)
+}
+
+func TestHandleMsgHeartbeat(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 1)
+	keeper := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(keeper)
+	orderMsg := types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "9.0", "1.0"),
+	})
+	result := handler(ctx, orderMsg)
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	orderID := getOrderID(result)
+	result = handler(ctx, types.NewMsgHeartbeat(addrKeysSlice[0].Address, 10))
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	require.EqualValues(t, int64(20), keeper.GetDeadManSwitch(ctx, addrKeysSlice[0].Address).Deadline)
+	EndBlocker(ctx, keeper)
+
+	// a heartbeat refreshes the deadline, the open orders the switch would cancel are charged
+	ctx = ctx.WithBlockHeight(14)
+	gasMeter := sdk.NewInfiniteGasMeter()
+	result = handler(ctx.WithGasMeter(gasMeter), types.NewMsgHeartbeat(addrKeysSlice[0].Address, 10))
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	require.True(t, gasMeter.GasConsumed() >= feeParams.CancelOrderMsgGasUnit*2)
+	EndBlocker(ctx, keeper)
+	ctx = ctx.WithBlockHeight(20)
+	EndBlocker(ctx, keeper)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orderID).Status)
+
+	// no heartbeat until the deadline
+	ctx = ctx.WithBlockHeight(24)
+	EndBlocker(ctx, keeper)
+	order := keeper.GetOrder(ctx, orderID)
+	require.EqualValues(t, types.OrderStatusCancelled, order.Status)
+	require.EqualValues(t, types.CloseReasonDeadManSwitch, order.CloseReason)
+	require.Nil(t, keeper.GetDeadManSwitch(ctx, addrKeysSlice[0].Address))
+
+	// a zero timeout disarms the switch
+	result = handler(ctx, types.NewMsgHeartbeat(addrKeysSlice[0].Address, 10))
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	result = handler(ctx, types.NewMsgHeartbeat(addrKeysSlice[0].Address, 0))
+	require.EqualValues(t, sdk.CodeOK, result.Code)
+	require.Nil(t, keeper.GetDeadManSwitch(ctx, addrKeysSlice[0].Address))
+	require.EqualValues(t, 0, len(keeper.GetDeadManSwitches(ctx)))
+
+	// no more switches are armed beyond the max
+	for i := 0; i < types.DeadManSwitchMaxArmed; i++ {
+		addr := sdk.AccAddress([]byte(fmt.Sprintf("addr%016d", i)))
+		keeper.SetDeadManSwitch(ctx, types.NewDeadManSwitch(addr, 10, ctx.BlockHeight()))
+	}
+	result = handler(ctx, types.NewMsgHeartbeat(addrKeysSlice[0].Address, 10))
+	require.NotEqual(t, sdk.CodeOK, result.Code)
+	require.Nil(t, keeper.GetDeadManSwitch(ctx, addrKeysSlice[0].Address))
+}
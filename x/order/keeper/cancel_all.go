@@ -0,0 +1,170 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/common"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+// GetOpenOrdersOfSender gets the open orders of the sender from the open order index,
+// filtered by product and side if they are not empty. It also returns the number of the orders touched,
+// which includes the open orders of the sender on the other products and sides.
+func (k Keeper) GetOpenOrdersOfSender(ctx sdk.Context, sender sdk.AccAddress, product, side string) (
+	orders []*types.Order, touched int64) {
+	store := ctx.KVStore(k.orderStoreKey)
+	prefix := types.GetOpenOrderSenderPrefix(sender)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		touched++
+		order := k.GetOrder(ctx, string(iter.Key()[len(prefix):]))
+		if order == nil || order.Status != types.OrderStatusOpen ||
+			product != "" && order.Product != product || side != "" && order.Side != side {
+			continue
+		}
+		orders = append(orders, order)
+	}
+	return orders, touched
+}
+
+// GetOpenOrderNumOfSender gets the number of the open orders of the sender
+func (k Keeper) GetOpenOrderNumOfSender(ctx sdk.Context, sender sdk.AccAddress) (num int) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.GetOpenOrderSenderPrefix(sender))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		num++
+	}
+	return num
+}
+
+// BackfillOpenOrderSenderIndex indexes the open orders placed before the open order index existed by their sender.
+// It's run by InitGenesis when the chain is upgraded from an exported genesis, it visits the orders in the depth books
+// once, and marks the store as indexed so that later calls return at once.
+func (k Keeper) BackfillOpenOrderSenderIndex(ctx sdk.Context) (num int) {
+	store := ctx.KVStore(k.orderStoreKey)
+	if store.Has(types.OpenOrderSenderIndexedKey) {
+		return 0
+	}
+
+	var orderIDs []string
+	iter := sdk.KVStorePrefixIterator(store, types.OrderIDsKey)
+	for ; iter.Valid(); iter.Next() {
+		var ids []string
+		k.cdc.MustUnmarshalJSON(iter.Value(), &ids)
+		orderIDs = append(orderIDs, ids...)
+	}
+	iter.Close()
+
+	for _, orderID := range orderIDs {
+		if order := k.GetOrder(ctx, orderID); order != nil && order.Status == types.OrderStatusOpen {
+			store.Set(types.GetOpenOrderSenderKey(order.Sender, orderID), []byte{})
+			num++
+		}
+	}
+	store.Set(types.OpenOrderSenderIndexedKey, []byte{1})
+	return num
+}
+
+// GetDeadManSwitch gets the dead man's switch of the account, nil if it's not armed
+func (k Keeper) GetDeadManSwitch(ctx sdk.Context, addr sdk.AccAddress) *types.DeadManSwitch {
+	store := ctx.KVStore(k.orderStoreKey)
+	bz := store.Get(types.GetDeadManSwitchKey(addr))
+	if bz == nil {
+		return nil
+	}
+	deadManSwitch := &types.DeadManSwitch{}
+	k.cdc.MustUnmarshalBinaryBare(bz, deadManSwitch)
+	return deadManSwitch
+}
+
+// SetDeadManSwitch arms or refreshes the dead man's switch of the account
+func (k Keeper) SetDeadManSwitch(ctx sdk.Context, deadManSwitch types.DeadManSwitch) {
+	k.DeleteDeadManSwitch(ctx, deadManSwitch.Address)
+	k.setDeadManSwitchNum(ctx, k.GetDeadManSwitchNum(ctx)+1)
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Set(types.GetDeadManSwitchKey(deadManSwitch.Address), k.cdc.MustMarshalBinaryBare(deadManSwitch))
+	store.Set(types.GetDeadManSwitchDeadlineKey(deadManSwitch.Deadline, deadManSwitch.Address), []byte{})
+}
+
+// DeleteDeadManSwitch disarms the dead man's switch of the account
+func (k Keeper) DeleteDeadManSwitch(ctx sdk.Context, addr sdk.AccAddress) {
+	deadManSwitch := k.GetDeadManSwitch(ctx, addr)
+	if deadManSwitch == nil {
+		return
+	}
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Delete(types.GetDeadManSwitchKey(addr))
+	store.Delete(types.GetDeadManSwitchDeadlineKey(deadManSwitch.Deadline, addr))
+	k.setDeadManSwitchNum(ctx, k.GetDeadManSwitchNum(ctx)-1)
+}
+
+// GetDeadManSwitchNum gets the number of the armed dead man's switches
+func (k Keeper) GetDeadManSwitchNum(ctx sdk.Context) int64 {
+	store := ctx.KVStore(k.orderStoreKey)
+	bz := store.Get(types.DeadManSwitchNumKey)
+	if bz == nil {
+		return 0
+	}
+	return common.BytesToInt64(bz)
+}
+
+func (k Keeper) setDeadManSwitchNum(ctx sdk.Context, num int64) {
+	store := ctx.KVStore(k.orderStoreKey)
+	if num <= 0 {
+		store.Delete(types.DeadManSwitchNumKey)
+		return
+	}
+	store.Set(types.DeadManSwitchNumKey, common.Int64ToBytes(num))
+}
+
+// GetDeadManSwitches gets all the armed dead man's switches
+func (k Keeper) GetDeadManSwitches(ctx sdk.Context) (switches []types.DeadManSwitch) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.DeadManSwitchKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var deadManSwitch types.DeadManSwitch
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &deadManSwitch)
+		switches = append(switches, deadManSwitch)
+	}
+	return switches
+}
+
+// FireDeadManSwitches cancels all the open & trigger orders of the accounts whose dead man's switch
+// reaches its deadline in this block, and disarms the switches.
+// The open orders of locked products can't be cancelled, they're left to expire.
+func (k Keeper) FireDeadManSwitches(ctx sdk.Context) (fired []sdk.AccAddress) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := store.Iterator(types.DeadManSwitchDeadlineKey, types.GetDeadManSwitchDeadlinePrefix(ctx.BlockHeight()+1))
+	for ; iter.Valid(); iter.Next() {
+		fired = append(fired, sdk.AccAddress(iter.Key()[len(types.GetDeadManSwitchDeadlinePrefix(0)):]))
+	}
+	iter.Close()
+	if len(fired) == 0 {
+		return nil
+	}
+
+	logger := ctx.Logger().With("module", "order")
+	for _, addr := range fired {
+		k.DeleteDeadManSwitch(ctx, addr)
+
+		orders, _ := k.GetOpenOrdersOfSender(ctx, addr, "", "")
+		cancelled := 0
+		for _, order := range orders {
+			if k.IsProductLocked(ctx, order.Product) {
+				continue
+			}
+			k.CancelOrderWithReason(ctx, order, types.CloseReasonDeadManSwitch, logger)
+			cancelled++
+		}
+		for _, trigger := range k.GetTriggerOrdersOfSender(ctx, addr) {
+			k.CancelTriggerOrder(ctx, trigger)
+		}
+		logger.Info(fmt.Sprintf("dead man's switch of %s fired, %d orders cancelled", addr, cancelled))
+	}
+	return fired
+}
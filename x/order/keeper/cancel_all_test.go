@@ -0,0 +1,106 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/okexchain/x/dex"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+func TestGetOpenOrdersOfSender(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	require.Nil(t, testInput.DexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair()))
+
+	var orders []*types.Order
+	for _, item := range []struct {
+		sender sdk.AccAddress
+		side   string
+	}{
+		{testInput.TestAddrs[0], types.BuyOrder},
+		{testInput.TestAddrs[1], types.BuyOrder},
+		{testInput.TestAddrs[0], types.SellOrder},
+		{testInput.TestAddrs[0], types.BuyOrder},
+	} {
+		order := mockOrder("", types.TestTokenPair, item.side, "10.0", "1.0")
+		order.Sender = item.sender
+		require.Nil(t, keeper.PlaceOrder(ctx, order))
+		orders = append(orders, order)
+	}
+
+	// only the open orders of the sender are touched
+	got, touched := keeper.GetOpenOrdersOfSender(ctx, testInput.TestAddrs[0], "", "")
+	require.EqualValues(t, []*types.Order{orders[0], orders[2], orders[3]}, got)
+	require.EqualValues(t, 3, touched)
+	got, touched = keeper.GetOpenOrdersOfSender(ctx, testInput.TestAddrs[0], types.TestTokenPair, types.BuyOrder)
+	require.EqualValues(t, []*types.Order{orders[0], orders[3]}, got)
+	require.EqualValues(t, 3, touched)
+	got, _ = keeper.GetOpenOrdersOfSender(ctx, testInput.TestAddrs[0], "btc_okt", "")
+	require.Empty(t, got)
+	require.EqualValues(t, 3, keeper.GetOpenOrderNumOfSender(ctx, testInput.TestAddrs[0]))
+
+	// the closed orders are removed from the index
+	keeper.CancelOrder(ctx, orders[0], ctx.Logger())
+	got, touched = keeper.GetOpenOrdersOfSender(ctx, testInput.TestAddrs[0], "", "")
+	require.EqualValues(t, []*types.Order{orders[2], orders[3]}, got)
+	require.EqualValues(t, 2, touched)
+	keeper.DropOrder(ctx, orders[3].OrderID)
+	require.EqualValues(t, 1, keeper.GetOpenOrderNumOfSender(ctx, testInput.TestAddrs[0]))
+	require.EqualValues(t, 1, keeper.GetOpenOrderNumOfSender(ctx, testInput.TestAddrs[1]))
+}
+
+func TestBackfillOpenOrderSenderIndex(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	require.Nil(t, testInput.DexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair()))
+
+	var orders []*types.Order
+	for _, sender := range []sdk.AccAddress{testInput.TestAddrs[0], testInput.TestAddrs[1], testInput.TestAddrs[0]} {
+		order := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+		order.Sender = sender
+		require.Nil(t, keeper.PlaceOrder(ctx, order))
+		orders = append(orders, order)
+	}
+	keeper.CancelOrder(ctx, orders[2], ctx.Logger())
+	keeper.Cache2Disk(ctx)
+
+	// the orders placed before the upgrade are not indexed
+	store := ctx.KVStore(keeper.orderStoreKey)
+	for _, order := range orders {
+		store.Delete(types.GetOpenOrderSenderKey(order.Sender, order.OrderID))
+	}
+	require.EqualValues(t, 0, keeper.GetOpenOrderNumOfSender(ctx, testInput.TestAddrs[0]))
+
+	// only the open orders are indexed, once
+	require.EqualValues(t, 2, keeper.BackfillOpenOrderSenderIndex(ctx))
+	got, _ := keeper.GetOpenOrdersOfSender(ctx, testInput.TestAddrs[0], "", "")
+	require.EqualValues(t, []*types.Order{orders[0]}, got)
+	require.EqualValues(t, 1, keeper.GetOpenOrderNumOfSender(ctx, testInput.TestAddrs[1]))
+	require.EqualValues(t, 0, keeper.BackfillOpenOrderSenderIndex(ctx))
+}
+
+func TestDeadManSwitchNum(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	// a refreshed switch is counted once
+	keeper.SetDeadManSwitch(ctx, types.NewDeadManSwitch(testInput.TestAddrs[0], 10, 10))
+	keeper.SetDeadManSwitch(ctx, types.NewDeadManSwitch(testInput.TestAddrs[0], 10, 12))
+	keeper.SetDeadManSwitch(ctx, types.NewDeadManSwitch(testInput.TestAddrs[1], 10, 12))
+	require.EqualValues(t, 2, keeper.GetDeadManSwitchNum(ctx))
+
+	keeper.DeleteDeadManSwitch(ctx, testInput.TestAddrs[0])
+	keeper.DeleteDeadManSwitch(ctx, testInput.TestAddrs[0])
+	require.EqualValues(t, 1, keeper.GetDeadManSwitchNum(ctx))
+
+	// the fired switches are not counted
+	fired := keeper.FireDeadManSwitches(ctx.WithBlockHeight(22))
+	require.EqualValues(t, []sdk.AccAddress{testInput.TestAddrs[1]}, fired)
+	require.EqualValues(t, 0, keeper.GetDeadManSwitchNum(ctx))
+}
@@ -234,3 +234,39 @@ func (c *DiskCache) removeOrder(order *types.Order) {
 
 	c.closeOrder(order.OrderID)
 }
+
+// amendOrder moves the remaining quantity of an open order to the new price in the depth book.
+// The order keeps its place in the orderIDs queue if keepPriority, otherwise it's queued at the end of the new price.
+func (c *DiskCache) amendOrder(order *types.Order, price, remainQuantity sdk.Dec, keepPriority bool) {
+	// update depth book map
+	depthBook := c.getDepthBook(order.Product)
+	if depthBook == nil {
+		depthBook = &types.DepthBook{}
+	}
+	depthBook.RemoveOrder(order)
+	amended := *order
+	amended.Price = price
+	amended.RemainQuantity = remainQuantity
+	depthBook.InsertOrder(&amended)
+	c.setDepthBook(order.Product, depthBook)
+
+	if keepPriority {
+		return
+	}
+	// the order may cross the book at its new price, match the product in this block
+	c.depthBookMap.newItems[order.Product] = struct{}{}
+
+	// update order id map
+	key := types.FormatOrderIDsKey(order.Product, order.Price, order.Side)
+	orderIDs := make([]string, 0, len(c.orderIDsMap.Data[key]))
+	for _, orderID := range c.orderIDsMap.Data[key] {
+		if orderID != order.OrderID {
+			orderIDs = append(orderIDs, orderID)
+		}
+	}
+	c.setOrderIDs(key, orderIDs)
+
+	newKey := types.FormatOrderIDsKey(order.Product, price, order.Side)
+	newOrderIDs := append([]string{}, c.orderIDsMap.Data[newKey]...)
+	c.setOrderIDs(newKey, append(newOrderIDs, order.OrderID))
+}
@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/okexchain/x/common"
 	"github.com/okex/okexchain/x/order/types"
@@ -45,11 +47,20 @@ func (k Keeper) DropExpireBlockHeight(ctx sdk.Context, blockHeight int64) {
 func (k Keeper) SetOrder(ctx sdk.Context, orderID string, order *types.Order) {
 	store := ctx.KVStore(k.orderStoreKey)
 	store.Set(types.GetOrderKey(orderID), k.cdc.MustMarshalBinaryBare(order))
+	// only the open orders are indexed by sender
+	if order.Status == types.OrderStatusOpen {
+		store.Set(types.GetOpenOrderSenderKey(order.Sender, orderID), []byte{})
+	} else {
+		store.Delete(types.GetOpenOrderSenderKey(order.Sender, orderID))
+	}
 }
 
 // nolint
 func (k Keeper) DropOrder(ctx sdk.Context, orderID string) {
 	store := ctx.KVStore(k.orderStoreKey)
+	if order := k.GetOrder(ctx, orderID); order != nil {
+		store.Delete(types.GetOpenOrderSenderKey(order.Sender, orderID))
+	}
 	store.Delete(types.GetOrderKey(orderID))
 }
 
@@ -73,6 +84,18 @@ func (k Keeper) SetLastPrice(ctx sdk.Context, product string, price sdk.Dec) {
 	k.diskCache.setLastPrice(product, price)
 }
 
+// ===============================================
+// AuctionType decides which match engine fills the orders of a given Product
+// nolint
+func (k Keeper) SetAuctionType(ctx sdk.Context, product string, auctionType string) error {
+	if !types.IsValidAuctionType(auctionType) {
+		return fmt.Errorf("invalid auction type: %s", auctionType)
+	}
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Set(types.GetAuctionTypeKey(product), []byte(auctionType))
+	return nil
+}
+
 // ===============================================
 // nolint
 func (k Keeper) StoreOrderIDsMap(ctx sdk.Context, key string, orderIDs []string) {
@@ -4,6 +4,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/supply/exported"
 
+	swap "github.com/okex/okexchain/x/ammswap/types"
 	dex "github.com/okex/okexchain/x/dex/types"
 	"github.com/okex/okexchain/x/order/types"
 	token "github.com/okex/okexchain/x/token/types"
@@ -19,6 +20,8 @@ type TokenKeeper interface {
 	SendCoinsFromAccountToAccount(ctx sdk.Context, from, to sdk.AccAddress, amt sdk.DecCoins) error
 	// Fee detail
 	AddFeeDetail(ctx sdk.Context, from string, fee sdk.DecCoins, feeType string, receiver string)
+	AddFeeDetailWithTier(ctx sdk.Context, from string, fee sdk.DecCoins, feeType string, receiver string,
+		feeTier string)
 	GetAllLockedCoins(ctx sdk.Context) (locks []token.AccCoins)
 	IterateLockedFees(ctx sdk.Context, cb func(acc sdk.AccAddress, coins sdk.DecCoins) (stop bool))
 }
@@ -50,3 +53,13 @@ type DexKeeper interface {
 	IsAnyProductLocked(ctx sdk.Context) bool
 	GetOperator(ctx sdk.Context, addr sdk.AccAddress) (operator dex.DEXOperator, isExist bool)
 }
+
+// SwapKeeper : expected ammswap keeper, which swaps the ammswap leg of the hybrid routes
+type SwapKeeper interface {
+	GetSwapTokenPair(ctx sdk.Context, tokenPairName string) (swap.SwapTokenPair, error)
+	GetParams(ctx sdk.Context) (params swap.Params)
+	CalculateSwapRoute(tokenPairs []swap.SwapTokenPair, soldToken sdk.DecCoin,
+		params swap.Params) (swap.SwapRoute, error)
+	SwapByRoute(ctx sdk.Context, sender, recipient sdk.AccAddress,
+		tokenPairs []swap.SwapTokenPair, route swap.SwapRoute) error
+}
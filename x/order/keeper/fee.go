@@ -41,6 +41,29 @@ func GetOrderCostFee(order *types.Order, ctx sdk.Context) sdk.DecCoins {
 
 }
 
+// GetImmediateOrderCostFee is used to calculate the handling fee when closing the remainder of an IOC/FOK order,
+// which is charged the fee of one block at least even though it's closed in the block it's placed
+func GetImmediateOrderCostFee(order *types.Order, ctx sdk.Context) sdk.DecCoins {
+	costFee := GetOrderCostFee(order, ctx)
+	if costFee.AmountOf(order.FeePerBlock.Denom).LT(order.FeePerBlock.Amount) {
+		return sdk.DecCoins{order.FeePerBlock}
+	}
+	return costFee
+}
+
+// GetTriggerOrderCostFee is used to calculate the handling fee when cancelling a trigger order, which is charged for
+// the blocks it's pending the same as an order cancelled
+func GetTriggerOrderCostFee(trigger *types.TriggerOrder, ctx sdk.Context) sdk.DecCoins {
+	blockNum := ctx.BlockHeight() - (trigger.ExpireHeight - trigger.OrderExpireBlocks)
+	if blockNum < 0 {
+		blockNum = 0
+	} else if blockNum > trigger.OrderExpireBlocks {
+		blockNum = trigger.OrderExpireBlocks
+	}
+	costFee := trigger.FeePerBlock.Amount.Mul(sdk.NewDec(blockNum))
+	return sdk.DecCoins{sdk.NewDecCoinFromDec(trigger.FeePerBlock.Denom, costFee)}
+}
+
 // GetZeroFee returns zeroFee
 func GetZeroFee() sdk.DecCoins {
 	return sdk.DecCoins{sdk.ZeroFee()}
@@ -49,6 +72,14 @@ func GetZeroFee() sdk.DecCoins {
 // GetDealFee is used to calculate the handling fee when matching an order
 func GetDealFee(order *types.Order, fillAmt sdk.Dec, ctx sdk.Context, keeper GetFeeKeeper,
 	feeParams *types.Params) sdk.DecCoins {
+	fee, _ := GetDealFeeByRate(order, fillAmt, ctx, keeper, feeParams.TradeFeeRate)
+	return fee
+}
+
+// GetDealFeeByRate is used to calculate the handling fee when matching an order with the specified rate,
+// a negative rate returns the rebate to the order sender instead of the fee
+func GetDealFeeByRate(order *types.Order, fillAmt sdk.Dec, ctx sdk.Context, keeper GetFeeKeeper,
+	rate sdk.Dec) (fee sdk.DecCoins, rebate sdk.DecCoins) {
 	symbols := strings.Split(order.Product, "_")
 	symbol := symbols[0]
 	quantity := fillAmt
@@ -57,9 +88,17 @@ func GetDealFee(order *types.Order, fillAmt sdk.Dec, ctx sdk.Context, keeper Get
 		quantity = fillAmt.Mul(keeper.GetLastPrice(ctx, order.Product))
 	}
 
-	feeAmt := quantity.Mul(feeParams.TradeFeeRate)
+	if rate.IsNegative() {
+		rebateAmt := quantity.Mul(rate.Neg())
+		if rebateAmt.IsPositive() {
+			rebate = sdk.DecCoins{sdk.NewDecCoinFromDec(symbol, rebateAmt)}
+		}
+		return sdk.DecCoins{sdk.NewDecCoinFromDec(symbol, sdk.ZeroDec())}, rebate
+	}
+
+	feeAmt := quantity.Mul(rate)
 	if feeAmt.IsPositive() {
-		return sdk.DecCoins{sdk.NewDecCoinFromDec(symbol, feeAmt)}
+		return sdk.DecCoins{sdk.NewDecCoinFromDec(symbol, feeAmt)}, nil
 	}
-	return sdk.DecCoins{sdk.NewDecCoinFromDec(symbol, sdk.MustNewDecFromStr(minFee))}
+	return sdk.DecCoins{sdk.NewDecCoinFromDec(symbol, sdk.MustNewDecFromStr(minFee))}, nil
 }
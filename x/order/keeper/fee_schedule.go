@@ -0,0 +1,208 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/order/types"
+)
+
+// GetFeeSchedule gets the fee schedule set by the owner of the product, nil if it was never set
+func (k Keeper) GetFeeSchedule(ctx sdk.Context, product string) *types.FeeSchedule {
+	store := ctx.KVStore(k.orderStoreKey)
+	bz := store.Get(types.GetFeeScheduleKey(product))
+	if bz == nil {
+		return nil
+	}
+	schedule := &types.FeeSchedule{}
+	k.cdc.MustUnmarshalBinaryBare(bz, schedule)
+	return schedule
+}
+
+// SetFeeSchedule sets the fee schedule of the product.
+// The volumes are counted afresh if the volume period changes, since the periods they were counted in are gone.
+func (k Keeper) SetFeeSchedule(ctx sdk.Context, schedule types.FeeSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	old := k.GetFeeSchedule(ctx, schedule.Product)
+	if old != nil && old.VolumePeriodBlocks != schedule.VolumePeriodBlocks {
+		k.deleteProductAccountVolumes(ctx, schedule.Product)
+	}
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Set(types.GetFeeScheduleKey(schedule.Product), k.cdc.MustMarshalBinaryBare(schedule))
+	return nil
+}
+
+// DeleteFeeSchedule deletes the fee schedule of the product, Params.TradeFeeRate is charged from then on.
+// The volumes of the product are deleted as well, since they're only counted by the fee schedule.
+func (k Keeper) DeleteFeeSchedule(ctx sdk.Context, product string) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Delete(types.GetFeeScheduleKey(product))
+	k.deleteProductAccountVolumes(ctx, product)
+}
+
+// GetFeeSchedules gets all the fee schedules set by the product owners
+func (k Keeper) GetFeeSchedules(ctx sdk.Context) (schedules []types.FeeSchedule) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.FeeScheduleKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var schedule types.FeeSchedule
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &schedule)
+		schedules = append(schedules, schedule)
+	}
+	return schedules
+}
+
+// GetEffectiveFeeSchedule gets the fee schedule charged on the product,
+// the default one made of Params.TradeFeeRate if the owner never set one
+func (k Keeper) GetEffectiveFeeSchedule(ctx sdk.Context, product string) types.FeeSchedule {
+	if schedule := k.GetFeeSchedule(ctx, product); schedule != nil {
+		return *schedule
+	}
+	return types.DefaultFeeSchedule(product, k.GetParams(ctx).TradeFeeRate)
+}
+
+// GetAccountVolume gets the traded volume of the account on the product
+func (k Keeper) GetAccountVolume(ctx sdk.Context, product string, addr sdk.AccAddress) types.AccountVolume {
+	store := ctx.KVStore(k.orderStoreKey)
+	bz := store.Get(types.GetAccountVolumeKey(product, addr))
+	if bz == nil {
+		return types.AccountVolume{
+			Product:          product,
+			Address:          addr,
+			Volume:           sdk.ZeroDec(),
+			LastPeriodVolume: sdk.ZeroDec(),
+		}
+	}
+	var volume types.AccountVolume
+	k.cdc.MustUnmarshalBinaryBare(bz, &volume)
+	return volume
+}
+
+// SetAccountVolume sets the traded volume of the account on the product, indexed by its period
+func (k Keeper) SetAccountVolume(ctx sdk.Context, volume types.AccountVolume) {
+	store := ctx.KVStore(k.orderStoreKey)
+	key := types.GetAccountVolumeKey(volume.Product, volume.Address)
+	if bz := store.Get(key); bz != nil {
+		var old types.AccountVolume
+		k.cdc.MustUnmarshalBinaryBare(bz, &old)
+		store.Delete(types.GetAccountVolumePeriodKey(old.Product, old.Period, old.Address))
+	}
+	store.Set(key, k.cdc.MustMarshalBinaryBare(volume))
+	store.Set(types.GetAccountVolumePeriodKey(volume.Product, volume.Period, volume.Address), []byte{})
+}
+
+// deleteAccountVolume deletes the traded volume of the account on the product and its index
+func (k Keeper) deleteAccountVolume(ctx sdk.Context, product string, period int64, addr sdk.AccAddress) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Delete(types.GetAccountVolumeKey(product, addr))
+	store.Delete(types.GetAccountVolumePeriodKey(product, period, addr))
+}
+
+// deleteProductAccountVolumes deletes the traded volumes of all the accounts on the product
+func (k Keeper) deleteProductAccountVolumes(ctx sdk.Context, product string) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.GetAccountVolumePrefix(product))
+	var volumes []types.AccountVolume
+	for ; iter.Valid(); iter.Next() {
+		var volume types.AccountVolume
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &volume)
+		volumes = append(volumes, volume)
+	}
+	iter.Close()
+	for _, volume := range volumes {
+		k.deleteAccountVolume(ctx, volume.Product, volume.Period, volume.Address)
+	}
+}
+
+// PruneAccountVolumes deletes the volumes outside the fee tier window of their product, which are the ones counted
+// before the previous period. Only the volumes to delete are visited through the period index.
+func (k Keeper) PruneAccountVolumes(ctx sdk.Context) (pruned int) {
+	store := ctx.KVStore(k.orderStoreKey)
+	for _, schedule := range k.GetFeeSchedules(ctx) {
+		period := schedule.GetPeriod(ctx.BlockHeight())
+		if period < 2 {
+			continue
+		}
+
+		start := types.GetAccountVolumePeriodPrefix(schedule.Product, 0)
+		iter := store.Iterator(start, types.GetAccountVolumePeriodPrefix(schedule.Product, period-1))
+		var keys [][]byte
+		for ; iter.Valid(); iter.Next() {
+			keys = append(keys, iter.Key())
+		}
+		iter.Close()
+
+		for _, key := range keys {
+			volumePeriod := int64(binary.BigEndian.Uint64(key[len(start)-8 : len(start)]))
+			k.deleteAccountVolume(ctx, schedule.Product, volumePeriod, sdk.AccAddress(key[len(start):]))
+		}
+		pruned += len(keys)
+	}
+	return pruned
+}
+
+// GetAccountVolumes gets the traded volumes of all the accounts
+func (k Keeper) GetAccountVolumes(ctx sdk.Context) (volumes []types.AccountVolume) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.AccountVolumeKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var volume types.AccountVolume
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &volume)
+		volumes = append(volumes, volume)
+	}
+	return volumes
+}
+
+// GetDealFeeWithTier calculates the deal fee of the order by the fee schedule of its product.
+// The tier is decided by the volume the sender traded before this deal, the volume of the deal is counted afterwards
+// if the product has its own fee schedule. It returns the fee, the rebate and the tier applied.
+func (k Keeper) GetDealFeeWithTier(ctx sdk.Context, order *types.Order, fillPrice, fillQuantity sdk.Dec,
+	isMaker bool, feeParams *types.Params) (fee sdk.DecCoins, rebate sdk.DecCoins, feeTier string) {
+	role := types.FeeRoleTaker
+	if isMaker {
+		role = types.FeeRoleMaker
+	}
+
+	schedule := k.GetFeeSchedule(ctx, order.Product)
+	if schedule == nil {
+		rate := feeParams.TradeFeeRate
+		fee, rebate = GetDealFeeByRate(order, fillQuantity, ctx, k, rate)
+		return fee, rebate, types.FormatFeeTier(role, types.FeeTierDefault, rate)
+	}
+
+	period := schedule.GetPeriod(ctx.BlockHeight())
+	volume := k.GetAccountVolume(ctx, order.Product, order.Sender)
+	index, tier := schedule.GetTier(volume.TierVolume(period))
+	rate := tier.TakerRate
+	if isMaker {
+		rate = tier.MakerRate
+	}
+	fee, rebate = GetDealFeeByRate(order, fillQuantity, ctx, k, rate)
+
+	volume.Add(period, fillPrice.Mul(fillQuantity))
+	k.SetAccountVolume(ctx, volume)
+	return fee, rebate, types.FormatFeeTier(role, types.FeeTierName(index), rate)
+}
+
+// SendRebateFromProductOwner pays the rebate of a deal from the fee receiver of the product
+func (k Keeper) SendRebateFromProductOwner(ctx sdk.Context, coins sdk.DecCoins, to sdk.AccAddress,
+	product string, feeTier string) error {
+	if coins.IsZero() {
+		return nil
+	}
+	from, err := k.GetProductFeeReceiver(ctx, product)
+	if err != nil {
+		return err
+	}
+	if err := k.tokenKeeper.SendCoinsFromAccountToAccount(ctx, from, to, coins); err != nil {
+		return fmt.Errorf("failed to pay rebate(%s) from %s: %v", coins, from, err)
+	}
+	k.tokenKeeper.AddFeeDetailWithTier(ctx, to.String(), coins, types.FeeTypeOrderRebate, from.String(), feeTier)
+	return nil
+}
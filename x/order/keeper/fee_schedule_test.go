@@ -0,0 +1,162 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/okexchain/x/common"
+	"github.com/okex/okexchain/x/dex"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+func mockFeeSchedule() types.FeeSchedule {
+	return types.NewFeeSchedule(types.TestTokenPair, []types.FeeTier{
+		types.NewFeeTier(sdk.ZeroDec(), sdk.MustNewDecFromStr("0.001"), sdk.MustNewDecFromStr("0.002")),
+		types.NewFeeTier(sdk.NewDec(100), sdk.MustNewDecFromStr("-0.0005"), sdk.MustNewDecFromStr("0.0015")),
+	}, 1000)
+}
+
+func TestSetFeeSchedule(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+
+	// the trade fee rate of params is charged by default
+	require.Nil(t, keeper.GetFeeSchedule(ctx, types.TestTokenPair))
+	schedule := keeper.GetEffectiveFeeSchedule(ctx, types.TestTokenPair)
+	require.EqualValues(t, types.DefaultFeeSchedule(types.TestTokenPair, keeper.GetParams(ctx).TradeFeeRate), schedule)
+
+	require.NoError(t, keeper.SetFeeSchedule(ctx, mockFeeSchedule()))
+	require.EqualValues(t, mockFeeSchedule(), keeper.GetEffectiveFeeSchedule(ctx, types.TestTokenPair))
+	require.EqualValues(t, []types.FeeSchedule{mockFeeSchedule()}, keeper.GetFeeSchedules(ctx))
+
+	invalid := mockFeeSchedule()
+	invalid.Tiers[1].MakerRate = sdk.MustNewDecFromStr("-0.002")
+	require.Error(t, keeper.SetFeeSchedule(ctx, invalid))
+
+	keeper.DeleteFeeSchedule(ctx, types.TestTokenPair)
+	require.Nil(t, keeper.GetFeeSchedule(ctx, types.TestTokenPair))
+}
+
+func TestGetDealFeeWithTier(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	fillPrice := sdk.NewDec(10)
+
+	order := &types.Order{
+		Sender:  testInput.TestAddrs[0],
+		Product: types.TestTokenPair,
+		Side:    types.BuyOrder,
+	}
+
+	// no fee schedule, the volume isn't counted
+	fee, rebate, feeTier := keeper.GetDealFeeWithTier(ctx, order, fillPrice, sdk.NewDec(5), false, &feeParams)
+	require.EqualValues(t, GetDealFee(order, sdk.NewDec(5), ctx, keeper, &feeParams), fee)
+	require.Nil(t, rebate)
+	require.EqualValues(t, types.FormatFeeTier(types.FeeRoleTaker, types.FeeTierDefault, feeParams.TradeFeeRate), feeTier)
+	require.EqualValues(t, 0, len(keeper.GetAccountVolumes(ctx)))
+
+	require.NoError(t, keeper.SetFeeSchedule(ctx, mockFeeSchedule()))
+
+	// the taker rate of tier0
+	fee, rebate, feeTier = keeper.GetDealFeeWithTier(ctx, order, fillPrice, sdk.NewDec(5), false, &feeParams)
+	require.EqualValues(t, "0.01000000"+common.TestToken, fee.String())
+	require.Nil(t, rebate)
+	require.EqualValues(t, types.FormatFeeTier(types.FeeRoleTaker, types.FeeTierName(0),
+		sdk.MustNewDecFromStr("0.002")), feeTier)
+
+	// the volume of the deal above isn't counted before it
+	fee, _, _ = keeper.GetDealFeeWithTier(ctx, order, fillPrice, sdk.NewDec(6), false, &feeParams)
+	require.EqualValues(t, "0.01200000"+common.TestToken, fee.String())
+	require.EqualValues(t, sdk.NewDec(110), keeper.GetAccountVolume(ctx, types.TestTokenPair, order.Sender).Volume)
+
+	// tier1 is reached, the maker gets a rebate
+	fee, rebate, feeTier = keeper.GetDealFeeWithTier(ctx, order, fillPrice, sdk.NewDec(5), true, &feeParams)
+	require.True(t, fee.IsZero())
+	require.EqualValues(t, "0.00250000"+common.TestToken, rebate.String())
+	require.EqualValues(t, types.FormatFeeTier(types.FeeRoleMaker, types.FeeTierName(1),
+		sdk.MustNewDecFromStr("-0.0005")), feeTier)
+
+	// the volume of the previous period keeps the tier for one more period
+	ctx = ctx.WithBlockHeight(1010)
+	_, _, feeTier = keeper.GetDealFeeWithTier(ctx, order, fillPrice, sdk.NewDec(1), false, &feeParams)
+	require.EqualValues(t, types.FormatFeeTier(types.FeeRoleTaker, types.FeeTierName(1),
+		sdk.MustNewDecFromStr("0.0015")), feeTier)
+	ctx = ctx.WithBlockHeight(3010)
+	_, _, feeTier = keeper.GetDealFeeWithTier(ctx, order, fillPrice, sdk.NewDec(1), false, &feeParams)
+	require.EqualValues(t, types.FormatFeeTier(types.FeeRoleTaker, types.FeeTierName(0),
+		sdk.MustNewDecFromStr("0.002")), feeTier)
+}
+
+func TestSendRebateFromProductOwner(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	testInput.DexKeeper.SetOperator(ctx, dex.DEXOperator{
+		Address:            tokenPair.Owner,
+		HandlingFeeAddress: testInput.TestAddrs[1],
+	})
+
+	rebate := sdk.DecCoins{sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("0.5"))}
+	err = keeper.SendRebateFromProductOwner(ctx, rebate, testInput.TestAddrs[0], types.TestTokenPair, "")
+	require.Nil(t, err)
+	require.EqualValues(t, sdk.MustNewDecFromStr("100.5"),
+		testInput.AccountKeeper.GetAccount(ctx, testInput.TestAddrs[0]).GetCoins().AmountOf(common.TestToken))
+	require.EqualValues(t, sdk.MustNewDecFromStr("99.5"),
+		testInput.AccountKeeper.GetAccount(ctx, testInput.TestAddrs[1]).GetCoins().AmountOf(common.TestToken))
+
+	// the fee receiver can't afford the rebate
+	rebate = sdk.DecCoins{sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(1000))}
+	err = keeper.SendRebateFromProductOwner(ctx, rebate, testInput.TestAddrs[0], types.TestTokenPair, "")
+	require.NotNil(t, err)
+}
+
+func TestPruneAccountVolumes(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	require.NoError(t, keeper.SetFeeSchedule(ctx, mockFeeSchedule()))
+
+	// the volumes are counted in the periods of 1000 blocks
+	addrs := append(testInput.TestAddrs, sdk.AccAddress([]byte("addr2_______________")))
+	for i, addr := range addrs {
+		volume := keeper.GetAccountVolume(ctx, types.TestTokenPair, addr)
+		volume.Add(int64(i), sdk.NewDec(100))
+		keeper.SetAccountVolume(ctx, volume)
+	}
+	// the volume moved to a later period is indexed by the new period
+	volume := keeper.GetAccountVolume(ctx, types.TestTokenPair, testInput.TestAddrs[0])
+	volume.Add(1, sdk.NewDec(100))
+	keeper.SetAccountVolume(ctx, volume)
+
+	// the volumes of the current & the previous period decide the fee tier, the older ones are pruned
+	require.EqualValues(t, 0, keeper.PruneAccountVolumes(ctx.WithBlockHeight(1999)))
+	require.EqualValues(t, 0, keeper.PruneAccountVolumes(ctx.WithBlockHeight(2000)))
+	require.EqualValues(t, 2, keeper.PruneAccountVolumes(ctx.WithBlockHeight(3000)))
+	volumes := keeper.GetAccountVolumes(ctx)
+	require.EqualValues(t, 1, len(volumes))
+	require.EqualValues(t, addrs[2], volumes[0].Address)
+	require.EqualValues(t, 0, keeper.PruneAccountVolumes(ctx.WithBlockHeight(3000)))
+
+	// the volumes are counted afresh after the volume period changes
+	schedule := mockFeeSchedule()
+	require.NoError(t, keeper.SetFeeSchedule(ctx, schedule))
+	require.EqualValues(t, 1, len(keeper.GetAccountVolumes(ctx)))
+	schedule.VolumePeriodBlocks = 100
+	require.NoError(t, keeper.SetFeeSchedule(ctx, schedule))
+	require.Empty(t, keeper.GetAccountVolumes(ctx))
+
+	// and deleted with the fee schedule
+	keeper.SetAccountVolume(ctx, keeper.GetAccountVolume(ctx, types.TestTokenPair, testInput.TestAddrs[0]))
+	keeper.DeleteFeeSchedule(ctx, types.TestTokenPair)
+	require.Empty(t, keeper.GetAccountVolumes(ctx))
+	require.EqualValues(t, 0, keeper.PruneAccountVolumes(ctx.WithBlockHeight(100000)))
+}
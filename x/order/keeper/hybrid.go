@@ -0,0 +1,235 @@
+package keeper
+
+import (
+	"fmt"
+
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	swap "github.com/okex/okexchain/x/ammswap/types"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+// SetSwapKeeper sets the ammswap keeper the hybrid routes swap through
+func (k *Keeper) SetSwapKeeper(swapKeeper SwapKeeper) {
+	k.swapKeeper = swapKeeper
+}
+
+// QuoteHybridRoute splits selling the amount on the side of the product between the depth book & the ammswap pool
+// of the product, so that the most is bought by both the legs. The depth book is taken at the prices of its resting
+// orders, the order-book leg is quoted as a limit order at the worst price taken, and its unfilled part rests there
+func (k Keeper) QuoteHybridRoute(ctx sdk.Context, book *types.DepthBook, product, side string,
+	amount sdk.Dec) (types.HybridRoute, error) {
+	tokenPair := k.dexKeeper.GetTokenPair(ctx, product)
+	if tokenPair == nil {
+		return types.HybridRoute{}, fmt.Errorf("trading pair '%s' does not exist", product)
+	}
+	if side != types.BuyOrder && side != types.SellOrder {
+		return types.HybridRoute{}, fmt.Errorf("invalid side \"%s\"", side)
+	}
+	if !amount.IsPositive() {
+		return types.HybridRoute{}, fmt.Errorf("the amount to sell should be positive")
+	}
+	soldTokenName, boughtTokenName := tokenPair.QuoteAssetSymbol, tokenPair.BaseAssetSymbol
+	if side == types.SellOrder {
+		soldTokenName, boughtTokenName = boughtTokenName, soldTokenName
+	}
+
+	swapTokenPair, hasPool := k.getHybridSwapTokenPair(ctx, tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol)
+	var swapParams swap.Params
+	if hasPool {
+		swapParams = k.swapKeeper.GetParams(ctx)
+	}
+	swapOutput := func(soldAmount sdk.Dec) sdk.Dec {
+		if !hasPool || !soldAmount.IsPositive() {
+			return sdk.ZeroDec()
+		}
+		route, err := k.swapKeeper.CalculateSwapRoute([]swap.SwapTokenPair{swapTokenPair},
+			sdk.NewDecCoinFromDec(soldTokenName, soldAmount), swapParams)
+		if err != nil {
+			return sdk.ZeroDec()
+		}
+		return route.BoughtToken().Amount
+	}
+	bookOutput := func(soldAmount sdk.Dec) sdk.Dec {
+		bought, _, _ := quoteBookLeg(book, side, soldAmount)
+		return bought
+	}
+
+	swapAmount := sdk.ZeroDec()
+	if hasPool {
+		var steps int64
+		swapAmount, steps = splitHybridAmount(amount, swapOutput, bookOutput)
+		// every step walks the depth book & prices the pool, so the steps are charged rather than a flat fee
+		gasConfig := storetypes.KVGasConfig()
+		stepGas := gasConfig.ReadCostFlat + gasConfig.IterNextCostFlat*uint64(len(book.Items))
+		ctx.GasMeter().ConsumeGas(stepGas*uint64(steps), "hybrid route split")
+		if !swapOutput(swapAmount).IsPositive() {
+			swapAmount = sdk.ZeroDec()
+		}
+	}
+
+	route := types.HybridRoute{
+		Product:         product,
+		Side:            side,
+		SoldToken:       sdk.NewDecCoinFromDec(soldTokenName, amount),
+		SwapSoldToken:   sdk.NewDecCoinFromDec(soldTokenName, sdk.ZeroDec()),
+		SwapBoughtToken: sdk.NewDecCoinFromDec(boughtTokenName, sdk.ZeroDec()),
+		BookSoldToken:   sdk.NewDecCoinFromDec(soldTokenName, sdk.ZeroDec()),
+		BookBoughtToken: sdk.NewDecCoinFromDec(boughtTokenName, sdk.ZeroDec()),
+		OrderPrice:      sdk.ZeroDec(),
+		OrderQuantity:   sdk.ZeroDec(),
+	}
+	if bookAmount := amount.Sub(swapAmount); bookAmount.IsPositive() {
+		bought, quantity, price := quoteBookLeg(book, side, bookAmount)
+		quantity = truncateDecimal(quantity, tokenPair.MaxQuantityDigit)
+		if price.IsPositive() && quantity.IsPositive() && quantity.GTE(tokenPair.MinQuantity) {
+			route.BookSoldToken.Amount = bookAmount
+			route.BookBoughtToken.Amount = sdk.MinDec(bought, quantity)
+			route.OrderPrice = price
+			route.OrderQuantity = quantity
+		} else if hasPool {
+			// the order-book leg is too small to be placed, everything is swapped instead
+			swapAmount = amount
+		} else {
+			return types.HybridRoute{}, fmt.Errorf("no liquidity in the depth book or the ammswap pool of %s", product)
+		}
+	}
+	if swapAmount.IsPositive() {
+		route.SwapSoldToken.Amount = swapAmount
+		route.SwapBoughtToken.Amount = swapOutput(swapAmount)
+		if !route.SwapBoughtToken.IsPositive() {
+			return types.HybridRoute{}, fmt.Errorf("amount(%s) is too small to swap", route.SwapSoldToken)
+		}
+		route.SwapPath = swapTokenPair.TokenPairName()
+	}
+	route.BoughtToken = sdk.NewDecCoinFromDec(boughtTokenName,
+		route.SwapBoughtToken.Amount.Add(route.BookBoughtToken.Amount))
+	return route, nil
+}
+
+// SwapHybridRoute swaps the ammswap leg of the hybrid route for the sender
+func (k Keeper) SwapHybridRoute(ctx sdk.Context, sender sdk.AccAddress, route types.HybridRoute) error {
+	if !route.SwapSoldToken.IsPositive() {
+		return nil
+	}
+	if k.swapKeeper == nil {
+		return fmt.Errorf("ammswap is not supported")
+	}
+	swapTokenPair, err := k.swapKeeper.GetSwapTokenPair(ctx, route.SwapPath)
+	if err != nil {
+		return err
+	}
+	tokenPairs := []swap.SwapTokenPair{swapTokenPair}
+	swapRoute, err := k.swapKeeper.CalculateSwapRoute(tokenPairs, route.SwapSoldToken, k.swapKeeper.GetParams(ctx))
+	if err != nil {
+		return err
+	}
+	if swapRoute.BoughtToken().Amount.LT(route.SwapBoughtToken.Amount) {
+		return fmt.Errorf("expected to buy %s from the ammswap pool but got %s",
+			route.SwapBoughtToken, swapRoute.BoughtToken())
+	}
+	return k.swapKeeper.SwapByRoute(ctx, sender, sender, tokenPairs, swapRoute)
+}
+
+// getHybridSwapTokenPair gets the non-empty ammswap pool of the two tokens
+func (k Keeper) getHybridSwapTokenPair(ctx sdk.Context, baseTokenName, quoteTokenName string) (
+	swap.SwapTokenPair, bool) {
+	if k.swapKeeper == nil {
+		return swap.SwapTokenPair{}, false
+	}
+	tokenPair, err := k.swapKeeper.GetSwapTokenPair(ctx, swap.GetSwapTokenPairName(baseTokenName, quoteTokenName))
+	if err != nil || tokenPair.BasePooledCoin.IsZero() || tokenPair.QuotePooledCoin.IsZero() {
+		return swap.SwapTokenPair{}, false
+	}
+	return tokenPair, true
+}
+
+// splitHybridAmount returns the part of the amount to swap, which buys the most with the rest sold to the depth book,
+// and the number of the steps pricing both the legs.
+// Both the outputs are concave in the amount sold, so is their sum, whose max is found by a ternary search
+func splitHybridAmount(amount sdk.Dec, swapOutput, bookOutput func(sdk.Dec) sdk.Dec) (sdk.Dec, int64) {
+	steps := int64(0)
+	total := func(swapAmount sdk.Dec) sdk.Dec {
+		steps++
+		return swapOutput(swapAmount).Add(bookOutput(amount.Sub(swapAmount)))
+	}
+	minUnit := sdk.NewDecWithPrec(1, sdk.Precision)
+	low, high := sdk.ZeroDec(), amount
+	for high.Sub(low).GT(minUnit.MulInt64(2)) {
+		third := high.Sub(low).QuoInt64(3)
+		if total(low.Add(third)).LT(total(high.Sub(third))) {
+			low = low.Add(third)
+		} else {
+			high = high.Sub(third)
+		}
+	}
+
+	// the truncation of the outputs may break the concavity slightly, the ends are checked as well
+	best, bestTotal := low, total(low)
+	for _, swapAmount := range []sdk.Dec{high, sdk.ZeroDec(), amount} {
+		if t := total(swapAmount); t.GT(bestTotal) {
+			best, bestTotal = swapAmount, t
+		}
+	}
+	return best, steps
+}
+
+// quoteBookLeg quotes selling the amount to the depth book as a limit order at the worst price taken.
+// It returns the amount bought, the quantity & the price of the order. A buy order locks its quantity at its price,
+// which is capped at the amount, so it buys no more than the amount affords at the worst price
+func quoteBookLeg(book *types.DepthBook, side string, amount sdk.Dec) (bought, quantity, price sdk.Dec) {
+	bought, unsold, price := takeDepthBook(book, side, amount)
+	if side == types.SellOrder || !price.IsPositive() {
+		return bought, amount, price
+	}
+	quantity = sdk.MinDec(bought.Add(unsold.QuoTruncate(price)), amount.QuoTruncate(price))
+	return sdk.MinDec(bought, quantity), quantity, price
+}
+
+// takeDepthBook sells the amount to the opposite side of the depth book from its best price, at the prices of the
+// resting orders. It returns the amount bought, the amount left unsold and the worst price taken
+func takeDepthBook(book *types.DepthBook, side string, amount sdk.Dec) (bought, unsold, price sdk.Dec) {
+	bought, unsold, price = sdk.ZeroDec(), amount, sdk.ZeroDec()
+	if side == types.BuyOrder {
+		// the asks are taken from the lowest price, the quote token is sold
+		for i := len(book.Items) - 1; i >= 0 && unsold.IsPositive(); i-- {
+			item := book.Items[i]
+			if !item.SellQuantity.IsPositive() {
+				continue
+			}
+			price = item.Price
+			if cost := item.SellQuantity.Mul(item.Price); unsold.GTE(cost) {
+				bought = bought.Add(item.SellQuantity)
+				unsold = unsold.Sub(cost)
+			} else {
+				quantity := unsold.QuoTruncate(item.Price)
+				bought = bought.Add(quantity)
+				unsold = sdk.ZeroDec()
+			}
+		}
+		return bought, unsold, price
+	}
+
+	// the bids are taken from the highest price, the base token is sold
+	for i := 0; i < len(book.Items) && unsold.IsPositive(); i++ {
+		item := book.Items[i]
+		if !item.BuyQuantity.IsPositive() {
+			continue
+		}
+		price = item.Price
+		quantity := sdk.MinDec(unsold, item.BuyQuantity)
+		bought = bought.Add(quantity.MulTruncate(item.Price))
+		unsold = unsold.Sub(quantity)
+	}
+	return bought, unsold, price
+}
+
+// truncateDecimal truncates the dec to the precision
+func truncateDecimal(dec sdk.Dec, precision int64) sdk.Dec {
+	precisionMul := sdk.OneDec()
+	for i := int64(0); i < precision; i++ {
+		precisionMul = precisionMul.MulInt64(10)
+	}
+	return dec.Mul(precisionMul).TruncateDec().Quo(precisionMul)
+}
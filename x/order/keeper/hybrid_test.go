@@ -0,0 +1,130 @@
+package keeper
+
+import (
+	"fmt"
+	"testing"
+
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	swapkeeper "github.com/okex/okexchain/x/ammswap/keeper"
+	swap "github.com/okex/okexchain/x/ammswap/types"
+	"github.com/okex/okexchain/x/common"
+	"github.com/okex/okexchain/x/dex"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+type mockSwapKeeper struct {
+	tokenPairs map[string]swap.SwapTokenPair
+}
+
+func (k mockSwapKeeper) GetSwapTokenPair(ctx sdk.Context, tokenPairName string) (swap.SwapTokenPair, error) {
+	tokenPair, ok := k.tokenPairs[tokenPairName]
+	if !ok {
+		return swap.SwapTokenPair{}, fmt.Errorf("non-exist swap token pair %s", tokenPairName)
+	}
+	return tokenPair, nil
+}
+
+func (k mockSwapKeeper) GetParams(ctx sdk.Context) swap.Params {
+	return swap.DefaultParams()
+}
+
+func (k mockSwapKeeper) CalculateSwapRoute(tokenPairs []swap.SwapTokenPair, soldToken sdk.DecCoin,
+	params swap.Params) (swap.SwapRoute, error) {
+	return swapkeeper.CalculateSwapRoute(tokenPairs, soldToken, params)
+}
+
+func (k mockSwapKeeper) SwapByRoute(ctx sdk.Context, sender, recipient sdk.AccAddress,
+	tokenPairs []swap.SwapTokenPair, route swap.SwapRoute) error {
+	return nil
+}
+
+func newHybridDepthBook() *types.DepthBook {
+	depthBook := &types.DepthBook{}
+	depthBook.InsertOrder(mockOrder("", types.TestTokenPair, types.SellOrder, "10", "10"))
+	depthBook.InsertOrder(mockOrder("", types.TestTokenPair, types.SellOrder, "11", "10"))
+	depthBook.InsertOrder(mockOrder("", types.TestTokenPair, types.BuyOrder, "9", "10"))
+	depthBook.InsertOrder(mockOrder("", types.TestTokenPair, types.BuyOrder, "8", "10"))
+	return depthBook
+}
+
+func TestTakeDepthBook(t *testing.T) {
+	depthBook := newHybridDepthBook()
+
+	bought, unsold, price := takeDepthBook(depthBook, types.BuyOrder, sdk.NewDec(155))
+	require.Equal(t, sdk.NewDec(15), bought)
+	require.True(t, unsold.IsZero())
+	require.Equal(t, sdk.NewDec(11), price)
+
+	bought, unsold, price = takeDepthBook(depthBook, types.SellOrder, sdk.NewDec(25))
+	require.Equal(t, sdk.NewDec(170), bought)
+	require.Equal(t, sdk.NewDec(5), unsold)
+	require.Equal(t, sdk.NewDec(8), price)
+
+	require.Equal(t, sdk.MustNewDecFromStr("1.23"), truncateDecimal(sdk.MustNewDecFromStr("1.23999"), 2))
+}
+
+func TestQuoteHybridRoute(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	depthBook := newHybridDepthBook()
+	amount := sdk.NewDec(200)
+
+	// without any pool, everything goes to the depth book
+	route, err := keeper.QuoteHybridRoute(ctx, depthBook, types.TestTokenPair, types.BuyOrder, amount)
+	require.Nil(t, err)
+	require.True(t, route.SwapSoldToken.IsZero())
+	require.Equal(t, amount, route.BookSoldToken.Amount)
+	require.Equal(t, sdk.NewDec(11), route.OrderPrice)
+	// the buy order locks no more than the amount at the worst price
+	require.True(t, route.OrderQuantity.Mul(route.OrderPrice).LTE(amount))
+	require.Equal(t, route.OrderQuantity, route.BoughtToken.Amount)
+	bookBought := route.BoughtToken.Amount
+
+	swapTokenPair := swap.NewSwapPair(common.TestToken, sdk.DefaultBondDenom)
+	swapTokenPair.BasePooledCoin.Amount = sdk.NewDec(10000)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(1000)
+	keeper.SetSwapKeeper(mockSwapKeeper{tokenPairs: map[string]swap.SwapTokenPair{
+		swapTokenPair.TokenPairName(): swapTokenPair,
+	}})
+
+	// the split buys more than the depth book or the pool alone, and its steps are charged
+	gasMeter := sdk.NewInfiniteGasMeter()
+	route, err = keeper.QuoteHybridRoute(ctx.WithGasMeter(gasMeter), depthBook, types.TestTokenPair, types.BuyOrder,
+		amount)
+	require.Nil(t, err)
+	require.True(t, gasMeter.GasConsumed() > storetypes.KVGasConfig().ReadCostFlat*10)
+	require.True(t, route.OrderQuantity.Mul(route.OrderPrice).LTE(route.BookSoldToken.Amount))
+	require.True(t, route.SwapSoldToken.IsPositive())
+	require.True(t, route.BookSoldToken.IsPositive())
+	require.Equal(t, amount, route.SwapSoldToken.Amount.Add(route.BookSoldToken.Amount))
+	require.Equal(t, common.TestToken, route.BoughtToken.Denom)
+	require.True(t, route.BoughtToken.Amount.GT(bookBought))
+	swapRoute, err := keeper.QuoteHybridRoute(ctx, &types.DepthBook{}, types.TestTokenPair, types.BuyOrder, amount)
+	require.Nil(t, err)
+	require.Equal(t, amount, swapRoute.SwapSoldToken.Amount)
+	require.True(t, route.BoughtToken.Amount.GT(swapRoute.BoughtToken.Amount))
+	require.Nil(t, keeper.SwapHybridRoute(ctx, testInput.TestAddrs[0], route))
+
+	// selling the base token
+	route, err = keeper.QuoteHybridRoute(ctx, depthBook, types.TestTokenPair, types.SellOrder, sdk.NewDec(20))
+	require.Nil(t, err)
+	require.Equal(t, sdk.DefaultBondDenom, route.BoughtToken.Denom)
+	require.Equal(t, route.OrderQuantity, route.BookSoldToken.Amount)
+
+	// no liquidity at all
+	keeper.SetSwapKeeper(nil)
+	_, err = keeper.QuoteHybridRoute(ctx, &types.DepthBook{}, types.TestTokenPair, types.BuyOrder, amount)
+	require.NotNil(t, err)
+	_, err = keeper.QuoteHybridRoute(ctx, depthBook, "nil_pair", types.BuyOrder, amount)
+	require.NotNil(t, err)
+	_, err = keeper.QuoteHybridRoute(ctx, depthBook, types.TestTokenPair, "NONE", amount)
+	require.NotNil(t, err)
+}
@@ -45,6 +45,11 @@ func ModuleAccountInvariant(keeper Keeper) sdk.Invariant {
 			}
 		}
 
+		// get pending trigger orders lock fee
+		for _, trigger := range keeper.GetTriggerOrders(ctx) {
+			orderLockedFees = orderLockedFees.Add(trigger.NeedLockFee())
+		}
+
 		if !lockedFees.IsEqual(orderLockedFees) {
 			return sdk.FormatInvariant(types.ModuleName, "locks",
 				fmt.Sprintf("\ttoken LockedFee coins: %s\n\tsum of order locked fee amounts:  %s\n",
@@ -28,6 +28,8 @@ type Keeper struct {
 	paramSpace params.Subspace
 
 	dexKeeper DexKeeper
+	// the ammswap keeper is set after the construction, nil if the hybrid routes aren't supported
+	swapKeeper SwapKeeper
 
 	supplyKeeper     SupplyKeeper
 	feeCollectorName string
@@ -219,6 +221,16 @@ func (k Keeper) GetLastPrice(ctx sdk.Context, product string) sdk.Dec {
 	return price
 }
 
+// GetAuctionType gets the match engine type of the product, DefaultAuctionType if it was never set
+func (k Keeper) GetAuctionType(ctx sdk.Context, product string) string {
+	store := ctx.KVStore(k.orderStoreKey)
+	bz := store.Get(types.GetAuctionTypeKey(product))
+	if bz == nil {
+		return types.DefaultAuctionType
+	}
+	return string(bz)
+}
+
 // GetDepthBookCopy gets depth book copy from cache, you are supposed to update the Depthbook if you change it
 // create if not exist
 func (k Keeper) GetDepthBookCopy(product string) *types.DepthBook {
@@ -419,9 +431,9 @@ func (k Keeper) AddFeeDetail(ctx sdk.Context, from sdk.AccAddress, coins sdk.Dec
 	k.tokenKeeper.AddFeeDetail(ctx, from.String(), coins, feeType, "")
 }
 
-// SendFeesToProductOwner sends fees from the specified address to productOwner
+// SendFeesToProductOwner sends fees from the specified address to productOwner, feeTier is the tier the fees are charged by
 func (k Keeper) SendFeesToProductOwner(ctx sdk.Context, coins sdk.DecCoins, from sdk.AccAddress,
-	feeType string, product string) (feeReceiver string, err error) {
+	feeType string, product string, feeTier string) (feeReceiver string, err error) {
 	if coins.IsZero() {
 		return "", nil
 	}
@@ -429,7 +441,7 @@ func (k Keeper) SendFeesToProductOwner(ctx sdk.Context, coins sdk.DecCoins, from
 	if err != nil {
 		return "", err
 	}
-	k.tokenKeeper.AddFeeDetail(ctx, from.String(), coins, feeType, "")
+	k.tokenKeeper.AddFeeDetailWithTier(ctx, from.String(), coins, feeType, "", feeTier)
 	if err := k.tokenKeeper.SendCoinsFromAccountToAccount(ctx, from, to, coins); err != nil {
 		log.Printf("Send fee(%s) to address(%s) failed\n", coins.String(), to.String())
 		return "", err
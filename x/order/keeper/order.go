@@ -54,25 +54,92 @@ func (k Keeper) PlaceOrder(ctx sdk.Context, order *types.Order) error {
 	return nil
 }
 
+// AmendOrder changes the price and the quantity of the open order without charging the new or cancel fee.
+// quantity includes the filled quantity. The locked coins are rebalanced to the new remaining quantity.
+// The order keeps its place in the price level queue if only its quantity is reduced, otherwise it's queued again.
+func (k Keeper) AmendOrder(ctx sdk.Context, order *types.Order, price, quantity sdk.Dec) error {
+	filledQuantity := order.Quantity.Sub(order.RemainQuantity)
+	remainQuantity := quantity.Sub(filledQuantity)
+	if !remainQuantity.IsPositive() {
+		return fmt.Errorf("quantity(%s) should be greater than the filled quantity(%s)", quantity, filledQuantity)
+	}
+	remainLocked := remainQuantity
+	if order.Side == types.BuyOrder {
+		remainLocked = price.Mul(remainQuantity)
+	}
+
+	// rebalance the locked coins, it's the only step which may fail
+	denom := order.NeedUnlockCoins()[0].Denom
+	if remainLocked.GT(order.RemainLocked) {
+		needLockCoins := sdk.DecCoins{{Denom: denom, Amount: remainLocked.Sub(order.RemainLocked)}}
+		if err := k.LockCoins(ctx, order.Sender, needLockCoins, token.LockCoinsTypeQuantity); err != nil {
+			return err
+		}
+	} else if remainLocked.LT(order.RemainLocked) {
+		needUnlockCoins := sdk.DecCoins{{Denom: denom, Amount: order.RemainLocked.Sub(remainLocked)}}
+		k.UnlockCoins(ctx, order.Sender, needUnlockCoins, token.LockCoinsTypeQuantity)
+	}
+
+	keepPriority := price.Equal(order.Price) && remainQuantity.LTE(order.RemainQuantity)
+	k.diskCache.amendOrder(order, price, remainQuantity, keepPriority)
+
+	order.Price = price
+	order.Quantity = quantity
+	order.RemainQuantity = remainQuantity
+	order.RemainLocked = remainLocked
+	k.SetOrder(ctx, order.OrderID, order)
+	k.addUpdatedOrderID(order.OrderID)
+	return nil
+}
+
 // ExpireOrder quits the specified order with the expired state
 func (k Keeper) ExpireOrder(ctx sdk.Context, order *types.Order, logger log.Logger) {
-	k.quitOrder(ctx, order, types.FeeTypeOrderExpire, logger)
+	k.quitOrder(ctx, order, types.FeeTypeOrderExpire, GetOrderCostFee(order, ctx), logger)
 }
 
 // CancelOrder quits the specified order with the canceled state
 func (k Keeper) CancelOrder(ctx sdk.Context, order *types.Order, logger log.Logger) sdk.DecCoins {
-	return k.quitOrder(ctx, order, types.FeeTypeOrderCancel, logger)
+	return k.quitOrder(ctx, order, types.FeeTypeOrderCancel, GetOrderCostFee(order, ctx), logger)
+}
+
+// CancelOrderWithReason quits the specified order with the canceled state, and records why it's closed
+func (k Keeper) CancelOrderWithReason(ctx sdk.Context, order *types.Order, reason string,
+	logger log.Logger) sdk.DecCoins {
+	order.CloseReason = reason
+	return k.quitOrder(ctx, order, types.FeeTypeOrderCancel, GetOrderCostFee(order, ctx), logger)
+}
+
+// CancelImmediateOrder quits the specified order with the canceled state, it closes what the order type of an order
+// leaves unfilled and charges the fee of one block at least, the same as a limit order cancelled in the next block
+func (k Keeper) CancelImmediateOrder(ctx sdk.Context, order *types.Order, reason string, logger log.Logger) {
+	order.CloseReason = reason
+	k.quitOrder(ctx, order, types.FeeTypeOrderCancel, GetImmediateOrderCostFee(order, ctx), logger)
+}
+
+// CancelImmediateOrders cancels the remainder of the IOC/FOK orders placed at blockHeight in the products
+func (k Keeper) CancelImmediateOrders(ctx sdk.Context, blockHeight int64, products map[string]bool) {
+	logger := ctx.Logger().With("module", "order")
+	orderNum := k.GetBlockOrderNum(ctx, blockHeight)
+	for num := int64(1); num <= orderNum; num++ {
+		order := k.GetOrder(ctx, types.FormatOrderID(blockHeight, num))
+		if order == nil || order.Status != types.OrderStatusOpen || !order.IsImmediate() || !products[order.Product] {
+			continue
+		}
+		k.CancelImmediateOrder(ctx, order, order.RemainderCloseReason(), logger)
+		logger.Info(fmt.Sprintf("order (%s) %s", order.OrderID, order.CloseReason))
+	}
 }
 
 // quitOrder unlocks & charges fee, unlocks coins, updates order, and updates DepthBook
-func (k Keeper) quitOrder(ctx sdk.Context, order *types.Order, feeType string, logger log.Logger) (fee sdk.DecCoins) {
+func (k Keeper) quitOrder(ctx sdk.Context, order *types.Order, feeType string, fee sdk.DecCoins,
+	logger log.Logger) sdk.DecCoins {
 	switch feeType {
 	case types.FeeTypeOrderCancel:
 		order.Cancel()
 	case types.FeeTypeOrderExpire:
 		order.Expire()
 	default:
-		return
+		return nil
 	}
 
 	// unlock coins in this order & charge fee
@@ -80,7 +147,6 @@ func (k Keeper) quitOrder(ctx sdk.Context, order *types.Order, feeType string, l
 	k.UnlockCoins(ctx, order.Sender, needUnlockCoins, token.LockCoinsTypeQuantity)
 
 	lockedFee := GetOrderNewFee(order)
-	fee = GetOrderCostFee(order, ctx)
 	receiveFee := lockedFee.Sub(fee)
 
 	k.UnlockCoins(ctx, order.Sender, lockedFee, token.LockCoinsTypeFee)
@@ -110,6 +176,12 @@ func (k Keeper) DropExpiredOrdersByBlockHeight(ctx sdk.Context, expiredBlockHeig
 		var order types.Order
 		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &order)
 		if order.Status == types.OrderStatusOpen && !k.IsProductLocked(ctx, order.Product) {
+			// the remainder of an immediate order is cancelled instead of expired
+			if order.IsImmediate() {
+				k.CancelImmediateOrder(ctx, &order, order.RemainderCloseReason(), logger)
+				logger.Info(fmt.Sprintf("order (%s) %s", order.OrderID, order.CloseReason))
+				continue
+			}
 			k.ExpireOrder(ctx, &order, logger)
 			logger.Info(fmt.Sprintf("order (%s) expired", order.OrderID))
 		}
@@ -206,3 +206,98 @@ func TestPlaceOrderAndExpireOrder(t *testing.T) {
 	require.EqualValues(t, 0, keeper.diskCache.openNum)
 	require.EqualValues(t, 1, keeper.cache.expireNum)
 }
+
+func TestCancelImmediateOrders(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	order := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	order.OrderType = types.OrderTypeIOC
+	order.Sender = testInput.TestAddrs[0]
+	err = keeper.PlaceOrder(ctx, order)
+	require.Nil(t, err)
+
+	// the remainder is closed a block later, charged the fee of the block
+	ctx = ctx.WithBlockHeight(11)
+	keeper.CancelImmediateOrders(ctx, 10, map[string]bool{types.TestTokenPair: true})
+	order = keeper.GetOrder(ctx, order.OrderID)
+	require.EqualValues(t, types.OrderStatusCancelled, order.Status)
+	require.EqualValues(t, types.CloseReasonIOCRemainder, order.CloseReason)
+	// check account balance
+	acc := testInput.AccountKeeper.GetAccount(ctx, testInput.TestAddrs[0])
+	expectCoins := sdk.DecCoins{
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr("99.999999")),
+		sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("100")),
+	}
+	require.EqualValues(t, expectCoins.String(), acc.GetCoins().String())
+	// check fee pool
+	feeCollector := testInput.SupplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName)
+	require.EqualValues(t, "0.00000100"+common.NativeToken, feeCollector.GetCoins().String())
+	require.EqualValues(t, 0, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+	require.EqualValues(t, 1, keeper.cache.cancelNum)
+	require.EqualValues(t, 0, keeper.cache.expireNum)
+}
+
+func TestAmendOrder(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	order := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "2.0")
+	order.Sender = testInput.TestAddrs[0]
+	require.Nil(t, keeper.PlaceOrder(ctx, order))
+	other := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	other.Sender = testInput.TestAddrs[1]
+	require.Nil(t, keeper.PlaceOrder(ctx, other))
+
+	checkBalance := func(expect string) {
+		acc := testInput.AccountKeeper.GetAccount(ctx, testInput.TestAddrs[0])
+		require.EqualValues(t, sdk.MustNewDecFromStr(expect), acc.GetCoins().AmountOf(common.NativeToken))
+	}
+	checkOrderIDs := func(price string, expect []string) {
+		key := types.FormatOrderIDsKey(types.TestTokenPair, sdk.MustNewDecFromStr(price), types.BuyOrder)
+		require.EqualValues(t, expect, keeper.GetProductPriceOrderIDs(key))
+	}
+
+	// reducing the quantity keeps the queue priority
+	require.Nil(t, keeper.AmendOrder(ctx, order, sdk.MustNewDecFromStr("10.0"), sdk.MustNewDecFromStr("1.0")))
+	checkBalance("89.7408")
+	checkOrderIDs("10.0", []string{order.OrderID, other.OrderID})
+	require.EqualValues(t, sdk.MustNewDecFromStr("2.0"), keeper.GetDepthBookCopy(types.TestTokenPair).Items[0].BuyQuantity)
+
+	// a price change queues the order again
+	require.Nil(t, keeper.AmendOrder(ctx, order, sdk.MustNewDecFromStr("11.0"), sdk.MustNewDecFromStr("1.0")))
+	checkBalance("88.7408")
+	checkOrderIDs("10.0", []string{other.OrderID})
+	checkOrderIDs("11.0", []string{order.OrderID})
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 2, len(book.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("11.0"), book.Items[0].Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), book.Items[1].BuyQuantity)
+
+	// increasing the quantity locks more coins
+	require.Nil(t, keeper.AmendOrder(ctx, order, sdk.MustNewDecFromStr("11.0"), sdk.MustNewDecFromStr("3.0")))
+	checkBalance("66.7408")
+	stored := keeper.GetOrder(ctx, order.OrderID)
+	require.EqualValues(t, sdk.MustNewDecFromStr("3.0"), stored.RemainQuantity)
+	require.EqualValues(t, sdk.MustNewDecFromStr("33.0"), stored.RemainLocked)
+	require.EqualValues(t, 0, len(keeper.GetDiskCache().GetClosedOrderIDs()))
+
+	// not enough balance or nothing left
+	require.NotNil(t, keeper.AmendOrder(ctx, order, sdk.MustNewDecFromStr("11.0"), sdk.MustNewDecFromStr("100.0")))
+	require.NotNil(t, keeper.AmendOrder(ctx, order, sdk.MustNewDecFromStr("11.0"), sdk.ZeroDec()))
+	checkBalance("66.7408")
+
+	invariant := ModuleAccountInvariant(keeper)
+	_, broken := invariant(ctx)
+	require.False(t, broken)
+}
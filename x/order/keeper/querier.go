@@ -31,6 +31,14 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 
 		case types.QueryDepthBookV2:
 			return queryDepthBookV2(ctx, path[1:], req, keeper)
+		case types.QueryTriggers:
+			return queryTriggerOrders(ctx, req, keeper)
+		case types.QueryFeeSchedule:
+			return queryFeeSchedule(ctx, path[1:], keeper)
+		case types.QueryDeadManSwitch:
+			return queryDeadManSwitch(ctx, path[1:], keeper)
+		case types.QueryHybridRoute:
+			return queryHybridRoute(ctx, req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown order query endpoint")
 		}
@@ -222,3 +230,101 @@ func queryDepthBookV2(ctx sdk.Context, path []string, req abci.RequestQuery, kee
 	}
 	return res, nil
 }
+
+// QueryTriggerOrdersParams as input parameters when querying the pending trigger orders
+type QueryTriggerOrdersParams struct {
+	Sender  sdk.AccAddress
+	Product string
+}
+
+// NewQueryTriggerOrdersParams creates a new instance of QueryTriggerOrdersParams
+func NewQueryTriggerOrdersParams(sender sdk.AccAddress, product string) QueryTriggerOrdersParams {
+	return QueryTriggerOrdersParams{
+		Sender:  sender,
+		Product: product,
+	}
+}
+
+// queryTriggerOrders returns the pending trigger orders, filtered by sender and product if specified
+func queryTriggerOrders(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryTriggerOrdersParams
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(
+			sdk.AppendMsgToErr("incorrectly formatted request Data", err.Error()))
+	}
+
+	pending := keeper.GetTriggerOrders(ctx)
+	if !params.Sender.Empty() {
+		pending = keeper.GetTriggerOrdersOfSender(ctx, params.Sender)
+	}
+	triggers := []*types.TriggerOrder{}
+	for _, trigger := range pending {
+		if params.Product != "" && trigger.Product != params.Product {
+			continue
+		}
+		triggers = append(triggers, trigger)
+	}
+	bz := keeper.cdc.MustMarshalJSON(triggers)
+	return bz, nil
+}
+
+// queryFeeSchedule returns the fee schedule charged on the product
+func queryFeeSchedule(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 || path[0] == "" {
+		return nil, sdk.ErrUnknownRequest("product cannot be empty")
+	}
+	if keeper.GetDexKeeper().GetTokenPair(ctx, path[0]) == nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("token pair(%s) does not exist", path[0]))
+	}
+	bz := keeper.cdc.MustMarshalJSON(keeper.GetEffectiveFeeSchedule(ctx, path[0]))
+	return bz, nil
+}
+
+// queryDeadManSwitch returns the armed dead man's switch of the account
+func queryDeadManSwitch(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 || path[0] == "" {
+		return nil, sdk.ErrUnknownRequest("address cannot be empty")
+	}
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, sdk.ErrInvalidAddress(err.Error())
+	}
+	deadManSwitch := keeper.GetDeadManSwitch(ctx, addr)
+	if deadManSwitch == nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("dead man's switch of %s is not armed", path[0]))
+	}
+	bz := keeper.cdc.MustMarshalJSON(deadManSwitch)
+	return bz, nil
+}
+
+// QueryHybridRouteParams as input parameters when quoting a hybrid route
+type QueryHybridRouteParams struct {
+	Product string
+	Side    string
+	Amount  sdk.Dec
+}
+
+// NewQueryHybridRouteParams creates a new instance of QueryHybridRouteParams
+func NewQueryHybridRouteParams(product, side string, amount sdk.Dec) QueryHybridRouteParams {
+	return QueryHybridRouteParams{
+		Product: product,
+		Side:    side,
+		Amount:  amount,
+	}
+}
+
+// queryHybridRoute quotes selling the amount across the depth book & the ammswap pool of the product
+func queryHybridRoute(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryHybridRouteParams
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(
+			sdk.AppendMsgToErr("incorrectly formatted request Data", err.Error()))
+	}
+	route, err := keeper.QuoteHybridRoute(ctx, keeper.GetDepthBookFromDB(ctx, params.Product),
+		params.Product, params.Side, params.Amount)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+	bz := keeper.cdc.MustMarshalJSON(route)
+	return bz, nil
+}
@@ -251,3 +251,36 @@ func TestQueryInvalidPath(t *testing.T) {
 	require.NotNil(t, err)
 	require.EqualValues(t, sdk.CodeUnknownRequest, err.Code())
 }
+
+func TestQueryTriggerOrders(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+	querier := NewQuerier(keeper)
+	params := keeper.GetParams(ctx)
+
+	require.NoError(t, keeper.PlaceTriggerOrder(ctx, mockTriggerOrder(params, testInput.TestAddrs[0],
+		types.SellOrder, types.TriggerTypeStopLoss, "9.0", "8.0", "1.0")))
+	require.NoError(t, keeper.PlaceTriggerOrder(ctx, mockTriggerOrder(params, testInput.TestAddrs[1],
+		types.SellOrder, types.TriggerTypeTakeProfit, "11.0", "11.0", "1.0")))
+
+	queryTriggers := func(sender sdk.AccAddress, product string) []types.TriggerOrder {
+		bz, err := keeper.cdc.MarshalJSON(NewQueryTriggerOrdersParams(sender, product))
+		require.Nil(t, err)
+		res, err := querier(ctx, []string{types.QueryTriggers}, abci.RequestQuery{Data: bz})
+		require.Nil(t, err)
+		var triggers []types.TriggerOrder
+		keeper.cdc.MustUnmarshalJSON(res, &triggers)
+		return triggers
+	}
+
+	require.EqualValues(t, 2, len(queryTriggers(nil, "")))
+	require.EqualValues(t, 2, len(queryTriggers(nil, types.TestTokenPair)))
+	require.EqualValues(t, 0, len(queryTriggers(nil, "abc_okt")))
+	triggers := queryTriggers(testInput.TestAddrs[1], "")
+	require.EqualValues(t, 1, len(triggers))
+	require.EqualValues(t, types.FormatTriggerID(2), triggers[0].TriggerID)
+
+	_, err := querier(ctx, []string{types.QueryTriggers}, abci.RequestQuery{Data: []byte("invalid")})
+	require.NotNil(t, err)
+}
@@ -0,0 +1,326 @@
+package keeper
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/common"
+	"github.com/okex/okexchain/x/order/types"
+	token "github.com/okex/okexchain/x/token/types"
+)
+
+// GetTriggerOrder gets the pending trigger order from KVStore
+func (k Keeper) GetTriggerOrder(ctx sdk.Context, triggerID string) *types.TriggerOrder {
+	store := ctx.KVStore(k.orderStoreKey)
+	bz := store.Get(types.GetTriggerOrderKey(triggerID))
+	if bz == nil {
+		return nil
+	}
+	trigger := &types.TriggerOrder{}
+	k.cdc.MustUnmarshalBinaryBare(bz, trigger)
+	return trigger
+}
+
+// SetTriggerOrder sets the pending trigger order to KVStore, indexed by its trigger price, expire height and sender
+func (k Keeper) SetTriggerOrder(ctx sdk.Context, trigger *types.TriggerOrder) {
+	k.DropTriggerOrder(ctx, trigger.TriggerID)
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Set(types.GetTriggerOrderKey(trigger.TriggerID), k.cdc.MustMarshalBinaryBare(trigger))
+	store.Set(types.GetTriggerPriceKey(trigger), []byte{})
+	store.Set(types.GetTriggerExpireKey(trigger.ExpireHeight, trigger.TriggerID), []byte{})
+	store.Set(types.GetTriggerSenderKey(trigger.Sender, trigger.TriggerID), []byte{})
+	k.addTriggerProductNum(ctx, trigger.Product, 1)
+}
+
+// DropTriggerOrder deletes the trigger order and its indexes from KVStore
+func (k Keeper) DropTriggerOrder(ctx sdk.Context, triggerID string) {
+	trigger := k.GetTriggerOrder(ctx, triggerID)
+	if trigger == nil {
+		return
+	}
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Delete(types.GetTriggerOrderKey(triggerID))
+	store.Delete(types.GetTriggerPriceKey(trigger))
+	store.Delete(types.GetTriggerExpireKey(trigger.ExpireHeight, triggerID))
+	store.Delete(types.GetTriggerSenderKey(trigger.Sender, triggerID))
+	store.Delete(types.GetTriggerFiredKey(triggerID))
+	k.addTriggerProductNum(ctx, trigger.Product, -1)
+}
+
+// fireTriggerOrder moves the trigger order from the trigger price index to the fired index,
+// so that its order is placed in the next block instead of being evaluated again
+func (k Keeper) fireTriggerOrder(ctx sdk.Context, trigger *types.TriggerOrder) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Delete(types.GetTriggerPriceKey(trigger))
+	store.Set(types.GetTriggerFiredKey(trigger.TriggerID), []byte{})
+}
+
+// GetFiredTriggerOrders gets the fired trigger orders whose orders are not placed yet, in the order they were placed
+func (k Keeper) GetFiredTriggerOrders(ctx sdk.Context) (triggers []*types.TriggerOrder) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.TriggerFiredKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if trigger := k.GetTriggerOrder(ctx, string(iter.Key()[len(types.TriggerFiredKey):])); trigger != nil {
+			triggers = append(triggers, trigger)
+		}
+	}
+	sort.Slice(triggers, func(i, j int) bool {
+		return types.GetSeqFromTriggerID(triggers[i].TriggerID) < types.GetSeqFromTriggerID(triggers[j].TriggerID)
+	})
+	return triggers
+}
+
+// addTriggerProductNum updates the number of the pending trigger orders of the product,
+// so that only the products with pending trigger orders are visited every block
+func (k Keeper) addTriggerProductNum(ctx sdk.Context, product string, delta int64) {
+	store := ctx.KVStore(k.orderStoreKey)
+	key := types.GetTriggerProductKey(product)
+	var num int64
+	if bz := store.Get(key); bz != nil {
+		num = common.BytesToInt64(bz)
+	}
+	num += delta
+	if num <= 0 {
+		store.Delete(key)
+		return
+	}
+	store.Set(key, common.Int64ToBytes(num))
+}
+
+// getTriggerProducts gets the products with pending trigger orders
+func (k Keeper) getTriggerProducts(ctx sdk.Context) (products []string) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.TriggerProductKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		products = append(products, string(iter.Key()[len(types.TriggerProductKey):]))
+	}
+	return products
+}
+
+// getTriggerOrdersByPriceKeys gets the trigger orders indexed by the trigger price keys in [start, end)
+func (k Keeper) getTriggerOrdersByPriceKeys(ctx sdk.Context, prefix, start, end []byte) (
+	triggers []*types.TriggerOrder) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := store.Iterator(start, end)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		// the key is made of the prefix, the sortable price prefixed by its length and the trigger id
+		priceLen := int(iter.Key()[len(prefix)])
+		triggerID := string(iter.Key()[len(prefix)+1+priceLen:])
+		if trigger := k.GetTriggerOrder(ctx, triggerID); trigger != nil {
+			triggers = append(triggers, trigger)
+		}
+	}
+	return triggers
+}
+
+// getTriggeredOrders gets the trigger orders of the product whose trigger price is crossed by the last price,
+// only the crossed ones are visited through the trigger price index
+func (k Keeper) getTriggeredOrders(ctx sdk.Context, product string, lastPrice sdk.Dec) []*types.TriggerOrder {
+	if !lastPrice.IsPositive() {
+		return nil
+	}
+	price := types.SortableTriggerPriceBytes(lastPrice)
+
+	// the ones triggered when the price falls to the trigger price, whose trigger price >= lastPrice
+	prefix := types.GetTriggerPricePrefix(product, types.TriggerDirectionFall)
+	triggers := k.getTriggerOrdersByPriceKeys(ctx, prefix, append(prefix, price...), sdk.PrefixEndBytes(prefix))
+
+	// the ones triggered when the price rises to the trigger price, whose trigger price <= lastPrice
+	prefix = types.GetTriggerPricePrefix(product, types.TriggerDirectionRise)
+	return append(triggers, k.getTriggerOrdersByPriceKeys(ctx, prefix, prefix,
+		sdk.PrefixEndBytes(append(prefix, price...)))...)
+}
+
+// getProductTriggerOrders gets all the pending trigger orders of the product
+func (k Keeper) getProductTriggerOrders(ctx sdk.Context, product string) (triggers []*types.TriggerOrder) {
+	for _, direction := range []byte{types.TriggerDirectionFall, types.TriggerDirectionRise} {
+		prefix := types.GetTriggerPricePrefix(product, direction)
+		triggers = append(triggers, k.getTriggerOrdersByPriceKeys(ctx, prefix, prefix, sdk.PrefixEndBytes(prefix))...)
+	}
+	return triggers
+}
+
+// GetTriggerOrders gets all the pending trigger orders, in the order they were placed
+func (k Keeper) GetTriggerOrders(ctx sdk.Context) (triggers []*types.TriggerOrder) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.TriggerOrderKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		trigger := &types.TriggerOrder{}
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), trigger)
+		triggers = append(triggers, trigger)
+	}
+	return triggers
+}
+
+// GetTriggerOrdersOfSender gets the pending trigger orders of the sender, in the order they were placed
+func (k Keeper) GetTriggerOrdersOfSender(ctx sdk.Context, sender sdk.AccAddress) (triggers []*types.TriggerOrder) {
+	store := ctx.KVStore(k.orderStoreKey)
+	prefix := types.GetTriggerSenderPrefix(sender)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if trigger := k.GetTriggerOrder(ctx, string(iter.Key()[len(prefix):])); trigger != nil {
+			triggers = append(triggers, trigger)
+		}
+	}
+	return triggers
+}
+
+// GetTriggerOrderNumOfSender gets the number of the pending trigger orders of the sender
+func (k Keeper) GetTriggerOrderNumOfSender(ctx sdk.Context, sender sdk.AccAddress) (num int) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.GetTriggerSenderPrefix(sender))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		num++
+	}
+	return num
+}
+
+// GetTriggerOrderSeq gets the sequence of the last placed trigger order
+func (k Keeper) GetTriggerOrderSeq(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.orderStoreKey)
+	bz := store.Get(types.TriggerOrderSeqKey)
+	if bz == nil {
+		return 0
+	}
+	return uint64(common.BytesToInt64(bz))
+}
+
+// SetTriggerOrderSeq sets the sequence of the last placed trigger order
+func (k Keeper) SetTriggerOrderSeq(ctx sdk.Context, seq uint64) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Set(types.TriggerOrderSeqKey, common.Int64ToBytes(int64(seq)))
+}
+
+// PlaceTriggerOrder locks the coins & fee of the order placed when triggered, and saves the trigger order.
+// The trigger order expires after OrderExpireBlocks as the order placed when triggered does.
+func (k Keeper) PlaceTriggerOrder(ctx sdk.Context, trigger *types.TriggerOrder) error {
+	if k.GetTriggerOrderNumOfSender(ctx, trigger.Sender) >= types.TriggerOrderMaxPerAddress {
+		return fmt.Errorf("%s already has %d pending trigger orders", trigger.Sender, types.TriggerOrderMaxPerAddress)
+	}
+	if err := k.LockCoins(ctx, trigger.Sender, trigger.NeedLockCoins(), token.LockCoinsTypeQuantity); err != nil {
+		return err
+	}
+	if err := k.LockCoins(ctx, trigger.Sender, trigger.NeedLockFee(), token.LockCoinsTypeFee); err != nil {
+		return err
+	}
+
+	seq := k.GetTriggerOrderSeq(ctx) + 1
+	trigger.TriggerID = types.FormatTriggerID(seq)
+	trigger.ExpireHeight = ctx.BlockHeight() + trigger.OrderExpireBlocks
+	k.SetTriggerOrderSeq(ctx, seq)
+	k.SetTriggerOrder(ctx, trigger)
+	return nil
+}
+
+// CancelTriggerOrder cancels the trigger order and charges the fee of the blocks it's pending, as an order cancelled
+func (k Keeper) CancelTriggerOrder(ctx sdk.Context, trigger *types.TriggerOrder) {
+	k.quitTriggerOrder(ctx, trigger, types.FeeTypeOrderCancel, GetTriggerOrderCostFee(trigger, ctx))
+}
+
+// quitTriggerOrder releases the trigger order & charges the fee from the fee unlocked
+func (k Keeper) quitTriggerOrder(ctx sdk.Context, trigger *types.TriggerOrder, feeType string, fee sdk.DecCoins) {
+	k.releaseTriggerOrder(ctx, trigger)
+	if err := k.AddCollectedFees(ctx, fee, trigger.Sender, feeType, true); err != nil {
+		ctx.Logger().With("module", "order").Error(fmt.Sprintf("failed to charge trigger order(%s) %s fee: %v",
+			trigger.TriggerID, feeType, err))
+	}
+}
+
+// releaseTriggerOrder unlocks the coins & fee of the trigger order, and deletes it
+func (k Keeper) releaseTriggerOrder(ctx sdk.Context, trigger *types.TriggerOrder) {
+	k.UnlockCoins(ctx, trigger.Sender, trigger.NeedLockCoins(), token.LockCoinsTypeQuantity)
+	k.UnlockCoins(ctx, trigger.Sender, trigger.NeedLockFee(), token.LockCoinsTypeFee)
+	k.DropTriggerOrder(ctx, trigger.TriggerID)
+}
+
+// FireTriggerOrders fires the trigger orders whose trigger price is crossed by the last price, which is the clearing
+// price of the auction just run. Only the crossed trigger orders are visited through the trigger price index.
+// The trigger orders of locked products stay pending, the ones of delisted products are cancelled.
+func (k Keeper) FireTriggerOrders(ctx sdk.Context) (fired []*types.TriggerOrder) {
+	logger := ctx.Logger().With("module", "order")
+	for _, product := range k.getTriggerProducts(ctx) {
+		if k.dexKeeper.GetTokenPair(ctx, product) == nil {
+			for _, trigger := range k.getProductTriggerOrders(ctx, product) {
+				k.CancelTriggerOrder(ctx, trigger)
+				logger.Info(fmt.Sprintf("trigger order (%s) cancelled: product %s delisted",
+					trigger.TriggerID, trigger.Product))
+			}
+			continue
+		}
+		if k.IsProductLocked(ctx, product) {
+			continue
+		}
+		fired = append(fired, k.getTriggeredOrders(ctx, product, k.GetLastPrice(ctx, product))...)
+	}
+	sort.Slice(fired, func(i, j int) bool {
+		return types.GetSeqFromTriggerID(fired[i].TriggerID) < types.GetSeqFromTriggerID(fired[j].TriggerID)
+	})
+
+	for _, trigger := range fired {
+		k.fireTriggerOrder(ctx, trigger)
+		logger.Info(fmt.Sprintf("trigger order (%s) fired at %s", trigger.TriggerID,
+			k.GetLastPrice(ctx, trigger.Product)))
+	}
+	return fired
+}
+
+// ActivateTriggerOrders converts the fired trigger orders into orders, in the order the trigger orders were placed,
+// so that the orders join the auction of this block. The fired trigger orders of locked products wait for unlocking.
+func (k Keeper) ActivateTriggerOrders(ctx sdk.Context) (orders []*types.Order) {
+	logger := ctx.Logger().With("module", "order")
+	feeParams := k.GetParams(ctx)
+	for _, trigger := range k.GetFiredTriggerOrders(ctx) {
+		if k.IsProductLocked(ctx, trigger.Product) {
+			continue
+		}
+		// the order placed locks the coins & fee again, and is charged as an order
+		k.releaseTriggerOrder(ctx, trigger)
+		order := types.NewOrder(trigger.TxHash, trigger.Sender, trigger.Product, trigger.Side, trigger.Price,
+			trigger.Quantity, ctx.BlockHeader().Time.Unix(), feeParams.OrderExpireBlocks, trigger.FeePerBlock)
+		order.OrderType = trigger.OrderType
+		order.TriggerID = trigger.TriggerID
+
+		// the locked coins were released above, place the order in a cache store in case it can't lock them again
+		cacheItem := ctx.MultiStore().CacheMultiStore()
+		if err := k.PlaceOrder(ctx.WithMultiStore(cacheItem), order); err != nil {
+			logger.Info(fmt.Sprintf("trigger order (%s) dropped: %v", trigger.TriggerID, err))
+			continue
+		}
+		cacheItem.Write()
+		logger.Info(fmt.Sprintf("trigger order (%s) placed order (%s)", trigger.TriggerID, order.OrderID))
+		orders = append(orders, order)
+	}
+	return orders
+}
+
+// ExpireTriggerOrders cancels the trigger orders expiring in this block and charges their locked fee,
+// as an order resting until expired is charged, which is the fee of all the blocks they're pending
+func (k Keeper) ExpireTriggerOrders(ctx sdk.Context) (expired []*types.TriggerOrder) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := store.Iterator(types.TriggerExpireKey, types.GetTriggerExpirePrefix(ctx.BlockHeight()+1))
+	var triggerIDs []string
+	for ; iter.Valid(); iter.Next() {
+		triggerIDs = append(triggerIDs, string(iter.Key()[len(types.GetTriggerExpirePrefix(0)):]))
+	}
+	iter.Close()
+
+	logger := ctx.Logger().With("module", "order")
+	for _, triggerID := range triggerIDs {
+		trigger := k.GetTriggerOrder(ctx, triggerID)
+		if trigger == nil {
+			continue
+		}
+		k.quitTriggerOrder(ctx, trigger, types.FeeTypeOrderExpire, trigger.NeedLockFee())
+		logger.Info(fmt.Sprintf("trigger order (%s) expired", triggerID))
+		expired = append(expired, trigger)
+	}
+	return expired
+}
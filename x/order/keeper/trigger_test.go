@@ -0,0 +1,248 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/okexchain/x/common"
+	"github.com/okex/okexchain/x/dex"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+func mockTriggerOrder(params *types.Params, sender sdk.AccAddress, side, triggerType, triggerPrice, price,
+	quantity string) *types.TriggerOrder {
+	return types.NewTriggerOrder("", sender, types.TestTokenPair, side, triggerType,
+		sdk.MustNewDecFromStr(triggerPrice), sdk.MustNewDecFromStr(price), sdk.MustNewDecFromStr(quantity),
+		types.OrderTypeLimit, 0, params.OrderExpireBlocks, params.FeePerBlock)
+}
+
+func TestPlaceAndCancelTriggerOrder(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	params := keeper.GetParams(ctx)
+
+	trigger := mockTriggerOrder(params, testInput.TestAddrs[0], types.BuyOrder, types.TriggerTypeStopLoss,
+		"11.0", "12.0", "1.0")
+	require.NoError(t, keeper.PlaceTriggerOrder(ctx, trigger))
+	require.EqualValues(t, types.FormatTriggerID(1), trigger.TriggerID)
+	require.EqualValues(t, trigger, keeper.GetTriggerOrder(ctx, trigger.TriggerID))
+
+	// coins & fee are locked, the trigger order stays outside the depth book
+	acc := testInput.AccountKeeper.GetAccount(ctx, testInput.TestAddrs[0])
+	expectCoins := sdk.DecCoins{
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr("87.7408")),
+		sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("100")),
+	}
+	require.EqualValues(t, expectCoins.String(), acc.GetCoins().String())
+	require.EqualValues(t, 0, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+
+	other := mockTriggerOrder(params, testInput.TestAddrs[1], types.SellOrder, types.TriggerTypeTakeProfit,
+		"11.0", "11.0", "1.0")
+	require.NoError(t, keeper.PlaceTriggerOrder(ctx, other))
+	require.EqualValues(t, types.FormatTriggerID(2), other.TriggerID)
+	require.EqualValues(t, []*types.TriggerOrder{trigger, other}, keeper.GetTriggerOrders(ctx))
+
+	// all the locked coins are returned after cancelling in the block it's placed
+	keeper.CancelTriggerOrder(ctx, trigger)
+	require.Nil(t, keeper.GetTriggerOrder(ctx, trigger.TriggerID))
+	acc = testInput.AccountKeeper.GetAccount(ctx, testInput.TestAddrs[0])
+	expectCoins = sdk.DecCoins{
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr("100")),
+		sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("100")),
+	}
+	require.EqualValues(t, expectCoins.String(), acc.GetCoins().String())
+
+	// the fee of the blocks pending is charged as an order cancelled
+	keeper.CancelTriggerOrder(ctx.WithBlockHeight(12), other)
+	acc = testInput.AccountKeeper.GetAccount(ctx, testInput.TestAddrs[1])
+	expectCoins = sdk.DecCoins{
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr("99.999998")),
+		sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("100")),
+	}
+	require.EqualValues(t, expectCoins.String(), acc.GetCoins().String())
+
+	// not enough balance
+	trigger = mockTriggerOrder(params, testInput.TestAddrs[0], types.BuyOrder, types.TriggerTypeStopLoss,
+		"11.0", "12.0", "100.0")
+	require.Error(t, keeper.PlaceTriggerOrder(ctx, trigger))
+}
+
+func TestActivateTriggerOrders(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	params := keeper.GetParams(ctx)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	keeper.SetLastPrice(ctx, types.TestTokenPair, sdk.MustNewDecFromStr("10.0"))
+
+	triggers := []*types.TriggerOrder{
+		mockTriggerOrder(params, testInput.TestAddrs[0], types.SellOrder, types.TriggerTypeStopLoss, "9.0", "8.0", "1.0"),
+		mockTriggerOrder(params, testInput.TestAddrs[0], types.SellOrder, types.TriggerTypeTakeProfit, "11.0", "11.0", "1.0"),
+		mockTriggerOrder(params, testInput.TestAddrs[1], types.BuyOrder, types.TriggerTypeStopLoss, "11.0", "12.0", "1.0"),
+		mockTriggerOrder(params, testInput.TestAddrs[1], types.BuyOrder, types.TriggerTypeTakeProfit, "9.0", "9.0", "1.0"),
+	}
+	triggers[2].OrderType = types.OrderTypeIOC
+	for _, trigger := range triggers {
+		require.NoError(t, keeper.PlaceTriggerOrder(ctx, trigger))
+	}
+
+	// nothing is triggered at the price 10
+	require.EqualValues(t, 0, len(keeper.FireTriggerOrders(ctx)))
+	require.EqualValues(t, 0, len(keeper.ActivateTriggerOrders(ctx)))
+	require.EqualValues(t, 4, len(keeper.GetTriggerOrders(ctx)))
+
+	// the price rises to 11, the sell take-profit and the buy stop-loss are fired,
+	// they stay pending until their orders are placed
+	keeper.SetLastPrice(ctx, types.TestTokenPair, sdk.MustNewDecFromStr("11.0"))
+	require.EqualValues(t, []*types.TriggerOrder{triggers[1], triggers[2]}, keeper.FireTriggerOrders(ctx))
+	require.EqualValues(t, 0, len(keeper.FireTriggerOrders(ctx)))
+	require.EqualValues(t, []*types.TriggerOrder{triggers[1], triggers[2]}, keeper.GetFiredTriggerOrders(ctx))
+	require.EqualValues(t, 4, len(keeper.GetTriggerOrders(ctx)))
+	orders := keeper.ActivateTriggerOrders(ctx)
+	require.EqualValues(t, 2, len(orders))
+	require.EqualValues(t, triggers[1].TriggerID, orders[0].TriggerID)
+	require.EqualValues(t, types.SellOrder, orders[0].Side)
+	require.EqualValues(t, triggers[2].TriggerID, orders[1].TriggerID)
+	require.EqualValues(t, types.OrderTypeIOC, orders[1].OrderType)
+	require.EqualValues(t, types.FormatOrderID(10, 2), orders[1].OrderID)
+	require.EqualValues(t, triggers[2].TriggerID, keeper.GetOrder(ctx, orders[1].OrderID).TriggerID)
+	require.EqualValues(t, []*types.TriggerOrder{triggers[0], triggers[3]}, keeper.GetTriggerOrders(ctx))
+	require.Empty(t, keeper.GetFiredTriggerOrders(ctx))
+
+	// the triggered orders rest in the depth book
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 2, len(book.Items))
+
+	// coins stay locked by the placed orders
+	acc := testInput.AccountKeeper.GetAccount(ctx, testInput.TestAddrs[1])
+	expectCoins := sdk.DecCoins{
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr("78.4816")),
+		sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("100")),
+	}
+	require.EqualValues(t, expectCoins.String(), acc.GetCoins().String())
+
+	// locked products keep their trigger orders pending
+	keeper.SetLastPrice(ctx, types.TestTokenPair, sdk.MustNewDecFromStr("8.0"))
+	testInput.DexKeeper.LockTokenPair(ctx, types.TestTokenPair, &types.ProductLock{})
+	require.EqualValues(t, 0, len(keeper.FireTriggerOrders(ctx)))
+	testInput.DexKeeper.UnlockTokenPair(ctx, types.TestTokenPair)
+	require.EqualValues(t, 2, len(keeper.FireTriggerOrders(ctx)))
+
+	// the fired trigger orders of locked products wait for unlocking to place their orders
+	testInput.DexKeeper.LockTokenPair(ctx, types.TestTokenPair, &types.ProductLock{})
+	require.EqualValues(t, 0, len(keeper.ActivateTriggerOrders(ctx)))
+	testInput.DexKeeper.UnlockTokenPair(ctx, types.TestTokenPair)
+	require.EqualValues(t, 2, len(keeper.ActivateTriggerOrders(ctx)))
+	require.EqualValues(t, 0, len(keeper.GetTriggerOrders(ctx)))
+
+	invariant := ModuleAccountInvariant(keeper)
+	_, broken := invariant(ctx)
+	require.False(t, broken)
+}
+
+func TestModuleAccountInvariantWithTriggerOrders(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	params := keeper.GetParams(ctx)
+
+	trigger := mockTriggerOrder(params, testInput.TestAddrs[0], types.BuyOrder, types.TriggerTypeStopLoss,
+		"11.0", "12.0", "1.0")
+	require.NoError(t, keeper.PlaceTriggerOrder(ctx, trigger))
+
+	invariant := ModuleAccountInvariant(keeper)
+	_, broken := invariant(ctx)
+	require.False(t, broken)
+}
+
+func TestTriggerOrderPriceIndex(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	params := keeper.GetParams(ctx)
+
+	triggers := []*types.TriggerOrder{
+		mockTriggerOrder(params, testInput.TestAddrs[0], types.SellOrder, types.TriggerTypeStopLoss, "9.0", "8.0", "1.0"),
+		mockTriggerOrder(params, testInput.TestAddrs[0], types.SellOrder, types.TriggerTypeStopLoss, "10.0", "8.0", "1.0"),
+		mockTriggerOrder(params, testInput.TestAddrs[0], types.SellOrder, types.TriggerTypeTakeProfit,
+			"100000000000", "11.0", "1.0"),
+		mockTriggerOrder(params, testInput.TestAddrs[0], types.SellOrder, types.TriggerTypeTakeProfit,
+			"0.00000001", "11.0", "1.0"),
+		mockTriggerOrder(params, testInput.TestAddrs[0], types.SellOrder, types.TriggerTypeTakeProfit, "11.0", "11.0", "1.0"),
+	}
+	for _, trigger := range triggers {
+		require.NoError(t, keeper.PlaceTriggerOrder(ctx, trigger))
+	}
+	require.EqualValues(t, []string{types.TestTokenPair}, keeper.getTriggerProducts(ctx))
+
+	// only the trigger orders crossed by the last price are visited
+	require.Empty(t, keeper.getTriggeredOrders(ctx, types.TestTokenPair, sdk.ZeroDec()))
+	require.EqualValues(t, []*types.TriggerOrder{triggers[1], triggers[3]},
+		keeper.getTriggeredOrders(ctx, types.TestTokenPair, sdk.MustNewDecFromStr("10.0")))
+	require.EqualValues(t, []*types.TriggerOrder{triggers[0], triggers[1], triggers[3]},
+		keeper.getTriggeredOrders(ctx, types.TestTokenPair, sdk.MustNewDecFromStr("9.0")))
+	require.EqualValues(t, []*types.TriggerOrder{triggers[3], triggers[4], triggers[2]},
+		keeper.getTriggeredOrders(ctx, types.TestTokenPair, sdk.MustNewDecFromStr("100000000000")))
+	require.EqualValues(t, triggers, keeper.GetTriggerOrdersOfSender(ctx, testInput.TestAddrs[0]))
+	require.Empty(t, keeper.GetTriggerOrdersOfSender(ctx, testInput.TestAddrs[1]))
+
+	// the indexes are deleted with the trigger orders
+	for _, trigger := range triggers {
+		keeper.CancelTriggerOrder(ctx, trigger)
+	}
+	require.Empty(t, keeper.getTriggerProducts(ctx))
+	require.Empty(t, keeper.getProductTriggerOrders(ctx, types.TestTokenPair))
+	require.EqualValues(t, 0, keeper.GetTriggerOrderNumOfSender(ctx, testInput.TestAddrs[0]))
+}
+
+func TestExpireTriggerOrders(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	params := keeper.GetParams(ctx)
+
+	trigger := mockTriggerOrder(params, testInput.TestAddrs[0], types.BuyOrder, types.TriggerTypeStopLoss,
+		"11.0", "12.0", "1.0")
+	require.NoError(t, keeper.PlaceTriggerOrder(ctx, trigger))
+	require.EqualValues(t, 10+params.OrderExpireBlocks, trigger.ExpireHeight)
+
+	require.Empty(t, keeper.ExpireTriggerOrders(ctx.WithBlockHeight(trigger.ExpireHeight-1)))
+	require.EqualValues(t, []*types.TriggerOrder{trigger},
+		keeper.ExpireTriggerOrders(ctx.WithBlockHeight(trigger.ExpireHeight)))
+	require.Nil(t, keeper.GetTriggerOrder(ctx, trigger.TriggerID))
+
+	// the coins are unlocked, the locked fee is charged
+	acc := testInput.AccountKeeper.GetAccount(ctx, testInput.TestAddrs[0])
+	expectCoins := sdk.DecCoins{
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr("99.7408")),
+		sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("100")),
+	}
+	require.EqualValues(t, expectCoins.String(), acc.GetCoins().String())
+
+	invariant := ModuleAccountInvariant(keeper)
+	_, broken := invariant(ctx)
+	require.False(t, broken)
+}
+
+func TestTriggerOrderMaxPerAddress(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	params := keeper.GetParams(ctx)
+	params.FeePerBlock = sdk.NewDecCoinFromDec(common.NativeToken, sdk.ZeroDec())
+
+	for i := 0; i < types.TriggerOrderMaxPerAddress; i++ {
+		require.NoError(t, keeper.PlaceTriggerOrder(ctx, mockTriggerOrder(params, testInput.TestAddrs[0],
+			types.SellOrder, types.TriggerTypeStopLoss, "9.0", "8.0", "0.01")))
+	}
+	require.Error(t, keeper.PlaceTriggerOrder(ctx, mockTriggerOrder(params, testInput.TestAddrs[0],
+		types.SellOrder, types.TriggerTypeStopLoss, "9.0", "8.0", "0.01")))
+	require.NoError(t, keeper.PlaceTriggerOrder(ctx, mockTriggerOrder(params, testInput.TestAddrs[1],
+		types.SellOrder, types.TriggerTypeStopLoss, "9.0", "8.0", "0.01")))
+}
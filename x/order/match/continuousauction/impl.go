@@ -6,11 +6,11 @@ import (
 	"github.com/okex/okexchain/x/order/keeper"
 )
 
-// nolint
+// CaEngine is the continuous auction match engine
 type CaEngine struct {
 }
 
 // nolint
 func (e *CaEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {
-	// TODO
+	matchOrders(ctx, keeper)
 }
@@ -0,0 +1,169 @@
+package continuousauction
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/dex"
+	orderkeeper "github.com/okex/okexchain/x/order/keeper"
+	"github.com/okex/okexchain/x/order/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaEngine_Run(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	require.EqualValues(t, types.AuctionTypePeriodic, keeper.GetAuctionType(ctx, types.TestTokenPair))
+	require.Error(t, keeper.SetAuctionType(ctx, types.TestTokenPair, "unknown"))
+	require.NoError(t, keeper.SetAuctionType(ctx, types.TestTokenPair, types.AuctionTypeContinuous))
+
+	// mock orders, the buy order rests before the sell orders arrive
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "9.0", "0.5"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "9.5", "2.5"),
+	}
+	orders[0].Sender = testInput.TestAddrs[0]
+	orders[1].Sender = testInput.TestAddrs[1]
+	orders[2].Sender = testInput.TestAddrs[1]
+	for i := 0; i < 3; i++ {
+		err := keeper.PlaceOrder(ctx, orders[i])
+		require.NoError(t, err)
+	}
+
+	engine := &CaEngine{}
+	engine.Run(ctx, keeper)
+
+	// makers are filled at their own price
+	order0 := keeper.GetOrder(ctx, orders[0].OrderID)
+	order1 := keeper.GetOrder(ctx, orders[1].OrderID)
+	order2 := keeper.GetOrder(ctx, orders[2].OrderID)
+	require.EqualValues(t, types.OrderStatusFilled, order0.Status)
+	require.EqualValues(t, types.OrderStatusFilled, order1.Status)
+	require.EqualValues(t, types.OrderStatusOpen, order2.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10"), order0.FilledAvgPrice)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10"), order1.FilledAvgPrice)
+	require.EqualValues(t, sdk.MustNewDecFromStr("2"), order2.RemainQuantity)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10"), keeper.GetLastPrice(ctx, types.TestTokenPair))
+
+	// depth book only keeps the remaining sell order
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(book.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("9.5"), book.Items[0].Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("2"), book.Items[0].SellQuantity)
+	require.EqualValues(t, []string{orders[2].OrderID}, keeper.GetProductPriceOrderIDs(
+		types.FormatOrderIDsKey(types.TestTokenPair, orders[2].Price, types.SellOrder)))
+	require.EqualValues(t, 0, len(keeper.GetProductPriceOrderIDs(
+		types.FormatOrderIDsKey(types.TestTokenPair, orders[0].Price, types.BuyOrder))))
+}
+
+func TestCaEngine_RunSkipPeriodicAuctionProducts(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+	}
+	orders[0].Sender = testInput.TestAddrs[0]
+	orders[1].Sender = testInput.TestAddrs[1]
+	for i := 0; i < 2; i++ {
+		err := keeper.PlaceOrder(ctx, orders[i])
+		require.NoError(t, err)
+	}
+
+	engine := &CaEngine{}
+	engine.Run(ctx, keeper)
+
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+}
+
+func TestIsEarlierOrder(t *testing.T) {
+	require.True(t, isEarlierOrder(types.FormatOrderID(9, 20), types.FormatOrderID(10, 1)))
+	require.True(t, isEarlierOrder(types.FormatOrderID(10, 2), types.FormatOrderID(10, 10)))
+	require.False(t, isEarlierOrder(types.FormatOrderID(10, 10), types.FormatOrderID(10, 10)))
+	require.False(t, isEarlierOrder("invalid", types.FormatOrderID(10, 10)))
+}
+
+func TestCaEngine_RunWithOrderTypes(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	require.NoError(t, keeper.SetAuctionType(ctx, types.TestTokenPair, types.AuctionTypeContinuous))
+
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "2.0"),
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+	}
+	orders[1].OrderType = types.OrderTypeIOC
+	orders[2].OrderType = types.OrderTypeFOK
+	orders[0].Sender = testInput.TestAddrs[1]
+	orders[1].Sender = testInput.TestAddrs[0]
+	orders[2].Sender = testInput.TestAddrs[0]
+	for i := 0; i < 3; i++ {
+		err := keeper.PlaceOrder(ctx, orders[i])
+		require.NoError(t, err)
+	}
+
+	engine := &CaEngine{}
+	engine.Run(ctx, keeper)
+
+	order1 := keeper.GetOrder(ctx, orders[1].OrderID)
+	order2 := keeper.GetOrder(ctx, orders[2].OrderID)
+	require.EqualValues(t, types.OrderStatusPartialFilledCancelled, order1.Status)
+	require.EqualValues(t, types.CloseReasonIOCRemainder, order1.CloseReason)
+	require.EqualValues(t, types.OrderStatusCancelled, order2.Status)
+	require.EqualValues(t, types.CloseReasonFOKUnfilled, order2.CloseReason)
+	require.EqualValues(t, 0, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+}
+
+func TestCaEngine_RunWalkingLevels(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	err := testInput.DexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair())
+	require.Nil(t, err)
+	require.NoError(t, keeper.SetAuctionType(ctx, types.TestTokenPair, types.AuctionTypeContinuous))
+
+	// the buy order walks the two sell levels
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "9.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "9.5", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "2.0"),
+	}
+	orders[0].Sender = testInput.TestAddrs[1]
+	orders[1].Sender = testInput.TestAddrs[1]
+	orders[2].Sender = testInput.TestAddrs[0]
+	for _, order := range orders {
+		require.NoError(t, keeper.PlaceOrder(ctx, order))
+	}
+
+	engine := &CaEngine{}
+	engine.Run(ctx, keeper)
+
+	// each deal keeps the price of its fill, the match result keeps the last one
+	matchResult := keeper.GetBlockMatchResult().ResultMap[types.TestTokenPair]
+	require.EqualValues(t, sdk.MustNewDecFromStr("9.5"), matchResult.Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("2"), matchResult.Quantity)
+	require.Equal(t, 4, len(matchResult.Deals))
+	expectedPrices := []string{"9", "9", "9.5", "9.5"}
+	for i, deal := range matchResult.Deals {
+		require.EqualValues(t, sdk.MustNewDecFromStr(expectedPrices[i]), deal.Price)
+	}
+	require.Equal(t, orders[2].OrderID, matchResult.Deals[1].OrderID)
+	require.Equal(t, orders[2].OrderID, matchResult.Deals[3].OrderID)
+	require.EqualValues(t, sdk.MustNewDecFromStr("9.25"), keeper.GetOrder(ctx, orders[2].OrderID).FilledAvgPrice)
+}
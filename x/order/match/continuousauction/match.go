@@ -0,0 +1,212 @@
+package continuousauction
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/order/keeper"
+	"github.com/okex/okexchain/x/order/match/periodicauction"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+// isEarlierOrder checks whether the order with orderID was placed before the order with otherID
+func isEarlierOrder(orderID, otherID string) bool {
+	var height, num, otherHeight, otherNum int64
+	if _, err := fmt.Sscanf(orderID, "ID%d-%d", &height, &num); err != nil {
+		return false
+	}
+	if _, err := fmt.Sscanf(otherID, "ID%d-%d", &otherHeight, &otherNum); err != nil {
+		return false
+	}
+	if height != otherHeight {
+		return height < otherHeight
+	}
+	return num < otherNum
+}
+
+// crossedPrices returns the opposite side prices which the order can be filled at, best price first
+func crossedPrices(book *types.DepthBook, order *types.Order) (prices []sdk.Dec) {
+	if order.Side == types.BuyOrder {
+		// sell orders, prices from low to high
+		for index := len(book.Items) - 1; index >= 0; index-- {
+			item := book.Items[index]
+			if item.Price.GT(order.Price) {
+				break
+			}
+			if item.SellQuantity.IsPositive() {
+				prices = append(prices, item.Price)
+			}
+		}
+		return prices
+	}
+
+	// buy orders, prices from high to low
+	for _, item := range book.Items {
+		if item.Price.LT(order.Price) {
+			break
+		}
+		if item.BuyQuantity.IsPositive() {
+			prices = append(prices, item.Price)
+		}
+	}
+	return prices
+}
+
+func oppositeSide(side string) string {
+	if side == types.BuyOrder {
+		return types.SellOrder
+	}
+	return types.BuyOrder
+}
+
+// fillableQuantity returns the quantity of earlier orders the taker order can be filled with
+func fillableQuantity(ctx sdk.Context, k keeper.Keeper, taker *types.Order) sdk.Dec {
+	quantity := sdk.ZeroDec()
+	book := k.GetDepthBookCopy(taker.Product)
+	for _, price := range crossedPrices(book, taker) {
+		key := types.FormatOrderIDsKey(taker.Product, price, oppositeSide(taker.Side))
+		for _, orderID := range k.GetProductPriceOrderIDs(key) {
+			if !isEarlierOrder(orderID, taker.OrderID) {
+				break
+			}
+			if maker := k.GetOrder(ctx, orderID); maker != nil {
+				quantity = quantity.Add(maker.RemainQuantity)
+			}
+		}
+	}
+	return quantity
+}
+
+// crossOrder fills the taker order against the orders resting in the depth book with price-time priority.
+// Makers are filled at their own price, orders placed after the taker are never used as makers.
+// It returns the deals of both sides, the last filled price and the filled quantity of the taker.
+func crossOrder(ctx sdk.Context, k keeper.Keeper, taker *types.Order,
+	feeParams *types.Params) (deals []types.Deal, lastPrice sdk.Dec, filledQuantity sdk.Dec) {
+
+	lastPrice = sdk.ZeroDec()
+	filledQuantity = sdk.ZeroDec()
+	book := k.GetDepthBookCopy(taker.Product)
+	makerSide := oppositeSide(taker.Side)
+
+	for _, price := range crossedPrices(book, taker) {
+		key := types.FormatOrderIDsKey(taker.Product, price, makerSide)
+		orderIDs := k.GetProductPriceOrderIDs(key)
+
+		index := 0
+		for index < len(orderIDs) && taker.Status == types.OrderStatusOpen &&
+			isEarlierOrder(orderIDs[index], taker.OrderID) {
+			maker := k.GetOrder(ctx, orderIDs[index])
+			if maker == nil {
+				ctx.Logger().Error("[Order] Not exist orderID: ", orderIDs[index])
+				index++
+				continue
+			}
+			fillQuantity := sdk.MinDec(maker.RemainQuantity, taker.RemainQuantity)
+			if deal := periodicauction.FillOrder(maker, ctx, k, price, fillQuantity, true, feeParams); deal != nil {
+				deals = append(deals, *deal)
+			}
+			if deal := periodicauction.FillOrder(taker, ctx, k, price, fillQuantity, false, feeParams); deal != nil {
+				deals = append(deals, *deal)
+			}
+
+			book.RemoveOrder(&types.Order{Side: makerSide, Price: price, RemainQuantity: fillQuantity})
+			book.RemoveOrder(&types.Order{Side: taker.Side, Price: taker.Price, RemainQuantity: fillQuantity})
+			k.SetLastPrice(ctx, taker.Product, price)
+			lastPrice = price
+			filledQuantity = filledQuantity.Add(fillQuantity)
+
+			if maker.Status == types.OrderStatusFilled {
+				index++
+			}
+		}
+
+		if index > 0 {
+			// Note: orderIDs cannot be nil, we will use empty slice to remove Data on keeper
+			unFilledOrderIDs := append([]string{}, orderIDs[index:]...)
+			k.SetOrderIDs(key, unFilledOrderIDs)
+		}
+		if taker.Status != types.OrderStatusOpen {
+			break
+		}
+	}
+
+	// a fully filled taker leaves its own price level
+	if taker.Status == types.OrderStatusFilled {
+		key := types.FormatOrderIDsKey(taker.Product, taker.Price, taker.Side)
+		remainOrderIDs := []string{}
+		for _, orderID := range k.GetProductPriceOrderIDs(key) {
+			if orderID != taker.OrderID {
+				remainOrderIDs = append(remainOrderIDs, orderID)
+			}
+		}
+		k.SetOrderIDs(key, remainOrderIDs)
+	}
+	k.SetDepthBook(taker.Product, book)
+
+	return deals, lastPrice, filledQuantity
+}
+
+// matchOrders crosses the new orders of continuous auction products one by one, in the order they were placed
+func matchOrders(ctx sdk.Context, k keeper.Keeper) {
+	blockHeight := ctx.BlockHeight()
+	orderNum := k.GetBlockOrderNum(ctx, blockHeight)
+	if orderNum == 0 {
+		return
+	}
+
+	logger := ctx.Logger().With("module", "order")
+	feeParams := k.GetParams(ctx)
+	resultMap := make(map[string]types.MatchResult)
+	for num := int64(1); num <= orderNum; num++ {
+		order := k.GetOrder(ctx, types.FormatOrderID(blockHeight, num))
+		if order == nil || order.Status != types.OrderStatusOpen ||
+			k.GetAuctionType(ctx, order.Product) != types.AuctionTypeContinuous ||
+			k.GetDexKeeper().GetTokenPair(ctx, order.Product) == nil {
+			continue
+		}
+
+		if order.GetOrderType() == types.OrderTypeFOK && fillableQuantity(ctx, k, order).LT(order.RemainQuantity) {
+			k.CancelImmediateOrder(ctx, order, types.CloseReasonFOKUnfilled, logger)
+			continue
+		}
+
+		deals, lastPrice, filledQuantity := crossOrder(ctx, k, order, feeParams)
+		// the remainder of an immediate order never rests in the depth book
+		if order.IsImmediate() && order.Status == types.OrderStatusOpen {
+			k.CancelImmediateOrder(ctx, order, order.RemainderCloseReason(), logger)
+		}
+		if len(deals) == 0 {
+			continue
+		}
+		matchResult, ok := resultMap[order.Product]
+		if !ok {
+			matchResult = types.MatchResult{BlockHeight: blockHeight, Quantity: sdk.ZeroDec(), Deals: []types.Deal{}}
+		}
+		matchResult.Price = lastPrice
+		matchResult.Quantity = matchResult.Quantity.Add(filledQuantity)
+		matchResult.Deals = append(matchResult.Deals, deals...)
+		resultMap[order.Product] = matchResult
+	}
+
+	for product, matchResult := range resultMap {
+		logger.Info(fmt.Sprintf("matchResult(%d-%s): price: %v, quantity: %v, dealsNum: %d",
+			matchResult.BlockHeight, product, matchResult.Price, matchResult.Quantity, len(matchResult.Deals)))
+	}
+
+	// save match results for querying, together with the results of the other engines
+	if len(resultMap) > 0 {
+		blockMatchResult := k.GetBlockMatchResult()
+		if blockMatchResult == nil || blockMatchResult.ResultMap == nil {
+			blockMatchResult = &types.BlockMatchResult{
+				BlockHeight: blockHeight,
+				ResultMap:   make(map[string]types.MatchResult),
+				TimeStamp:   ctx.BlockHeader().Time.Unix(),
+			}
+		}
+		for product, matchResult := range resultMap {
+			blockMatchResult.ResultMap[product] = matchResult
+		}
+		k.SetBlockMatchResult(blockMatchResult)
+	}
+}
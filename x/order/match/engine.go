@@ -8,25 +8,25 @@ import (
 	"github.com/okex/okexchain/x/order/keeper"
 	"github.com/okex/okexchain/x/order/match/continuousauction"
 	"github.com/okex/okexchain/x/order/match/periodicauction"
+	"github.com/okex/okexchain/x/order/types"
 )
 
 // nolint
-const DefaultAuctionType = "periodicauction"
+const DefaultAuctionType = types.DefaultAuctionType
 
 // nolint
 var (
-	once        sync.Once
-	engine      Engine
-	auctionType = DefaultAuctionType
+	once   sync.Once
+	engine Engine
 )
 
-// GetEngine : periodic auction only today
+// GetEngine returns the engine matching every product with its own auction type,
+// products whose auction type was never set are matched by the DefaultAuctionType engine
 func GetEngine() Engine {
 	once.Do(func() {
-		if auctionType == DefaultAuctionType {
-			engine = &periodicauction.PaEngine{}
-		} else {
-			engine = &continuousauction.CaEngine{}
+		engine = &productEngine{
+			periodic:   &periodicauction.PaEngine{},
+			continuous: &continuousauction.CaEngine{},
 		}
 	})
 	return engine
@@ -36,3 +36,16 @@ func GetEngine() Engine {
 type Engine interface {
 	Run(ctx sdk.Context, keeper keeper.Keeper)
 }
+
+// productEngine dispatches products to the engine of their auction type.
+// The periodic auction engine runs first, it also cleans up expired and delisted orders of all products.
+type productEngine struct {
+	periodic   Engine
+	continuous Engine
+}
+
+// nolint
+func (e *productEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {
+	e.periodic.Run(ctx, keeper)
+	e.continuous.Run(ctx, keeper)
+}
@@ -154,16 +154,19 @@ func fillOrderByKey(ctx sdk.Context, keeper orderkeeper.Keeper, key string,
 		if order == nil {
 			ctx.Logger().Error("[Order] Not exist orderID: ", orderIDs[index])
 		}
+		// the orders resting in the book since the previous blocks make the market
+		isMaker := types.GetBlockHeightFromOrderID(order.OrderID) < ctx.BlockHeight()
 		if filledAmount.Add(order.RemainQuantity).LTE(needFillAmount) {
 			filledAmount = filledAmount.Add(order.RemainQuantity)
-			if deal := fillOrder(order, ctx, keeper, fillPrice, order.RemainQuantity, feeParams); deal != nil {
+			if deal := fillOrder(order, ctx, keeper, fillPrice, order.RemainQuantity, isMaker, feeParams); deal != nil {
 				deals = append(deals, *deal)
 			}
 
 			filledDealsCnt++
 			index++
 		} else {
-			if deal := fillOrder(order, ctx, keeper, fillPrice, needFillAmount.Sub(filledAmount), feeParams); deal != nil {
+			if deal := fillOrder(order, ctx, keeper, fillPrice, needFillAmount.Sub(filledAmount), isMaker,
+				feeParams); deal != nil {
 				deals = append(deals, *deal)
 			}
 			filledAmount = needFillAmount
@@ -199,8 +202,8 @@ func balanceAccount(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keep
 	keeper.BalanceAccount(ctx, order.Sender, outputCoins, inputCoins)
 }
 
-func chargeFee(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper, fillQuantity sdk.Dec,
-	feeParams *types.Params) (dealFee sdk.DecCoins, feeReceiver string) {
+func chargeFee(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper, fillPrice, fillQuantity sdk.Dec,
+	isMaker bool, feeParams *types.Params) (dealFee sdk.DecCoins, feeReceiver string) {
 	// charge fee
 	fee := orderkeeper.GetZeroFee()
 	if order.Status == types.OrderStatusFilled {
@@ -217,18 +220,29 @@ func chargeFee(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper, f
 			ctx.Logger().Error(fmt.Sprintf("Send fee failed:%s\n", err.Error()))
 		}
 	}
-	dealFee = orderkeeper.GetDealFee(order, fillQuantity, ctx, keeper, feeParams)
-	feeReceiver, err := keeper.SendFeesToProductOwner(ctx, dealFee, order.Sender, types.FeeTypeOrderDeal, order.Product)
+	dealFee, rebate, feeTier := keeper.GetDealFeeWithTier(ctx, order, fillPrice, fillQuantity, isMaker, feeParams)
+	feeReceiver, err := keeper.SendFeesToProductOwner(ctx, dealFee, order.Sender, types.FeeTypeOrderDeal, order.Product,
+		feeTier)
 	if err == nil {
 		order.RecordOrderDealFee(fee)
 	}
+	if err := keeper.SendRebateFromProductOwner(ctx, rebate, order.Sender, order.Product, feeTier); err != nil {
+		ctx.Logger().Error(fmt.Sprintf("Send rebate failed:%s\n", err.Error()))
+	}
 	return
 }
 
+// FillOrder fills an order with the specified price and quantity, it's shared with the other match engines.
+// isMaker decides whether the maker or the taker fee rate is charged.
+func FillOrder(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper,
+	fillPrice, fillQuantity sdk.Dec, isMaker bool, feeParams *types.Params) *types.Deal {
+	return fillOrder(order, ctx, keeper, fillPrice, fillQuantity, isMaker, feeParams)
+}
+
 // Fill an order. Update order, charge fee and transfer tokens. Return a deal.
 // If an order is fully filled but still lock some coins, unlock it.
 func fillOrder(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper,
-	fillPrice, fillQuantity sdk.Dec, feeParams *types.Params) *types.Deal {
+	fillPrice, fillQuantity sdk.Dec, isMaker bool, feeParams *types.Params) *types.Deal {
 
 	// update order
 	order.Fill(fillPrice, fillQuantity)
@@ -241,7 +255,8 @@ func fillOrder(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper,
 		order.Unlock()
 	}
 
-	dealFee, feeReceiver := chargeFee(order, ctx, keeper, fillQuantity, feeParams)
+	dealFee, feeReceiver := chargeFee(order, ctx, keeper, fillPrice, fillQuantity, isMaker, feeParams)
 	keeper.UpdateOrder(order, ctx) // update order info on filled
-	return &types.Deal{OrderID: order.OrderID, Side: order.Side, Quantity: fillQuantity, Fee: dealFee.String(), FeeReceiver: feeReceiver}
+	return &types.Deal{OrderID: order.OrderID, Side: order.Side, Price: fillPrice, Quantity: fillQuantity,
+		Fee: dealFee.String(), FeeReceiver: feeReceiver}
 }
@@ -638,7 +638,7 @@ func TestFillOrder(t *testing.T) {
 	feeParams := types.DefaultTestParams()
 
 	for _, order := range orders {
-		retDeals := fillOrder(order, ctx, keeper, fillPrice, fillQuantity, &feeParams)
+		retDeals := fillOrder(order, ctx, keeper, fillPrice, fillQuantity, false, &feeParams)
 		require.NotEmpty(t, retDeals)
 	}
 }
@@ -705,7 +705,7 @@ func TestChargeFee(t *testing.T) {
 	feeParams := types.DefaultTestParams()
 
 	for _, order := range orders {
-		retFee, feeReceiver := chargeFee(order, ctx, keeper, fillQuantity, &feeParams)
+		retFee, feeReceiver := chargeFee(order, ctx, keeper, order.Price, fillQuantity, false, &feeParams)
 		require.NotEmpty(t, retFee)
 		require.NotEmpty(t, feeReceiver)
 	}
@@ -14,5 +14,12 @@ type PaEngine struct {
 func (e *PaEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {
 	cleanupExpiredOrders(ctx, keeper)
 	cleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx, keeper)
+	keeper.FireDeadManSwitches(ctx)
+	keeper.PruneAccountVolumes(ctx)
+	// the trigger orders fired after the previous auction place their orders into this one
+	keeper.ActivateTriggerOrders(ctx)
 	matchOrders(ctx, keeper)
+	// trigger orders are evaluated against the clearing prices this auction sets
+	keeper.ExpireTriggerOrders(ctx)
+	keeper.FireTriggerOrders(ctx)
 }
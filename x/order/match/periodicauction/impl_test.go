@@ -46,3 +46,47 @@ func TestPaEngine_Run(t *testing.T) {
 	require.EqualValues(t, types.OrderStatusOpen, order2.Status)
 	require.EqualValues(t, sdk.MustNewDecFromStr("2"), order2.RemainQuantity)
 }
+
+func TestPaEngine_RunFiresTriggerOrders(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	keeper.SetLastPrice(ctx, types.TestTokenPair, sdk.MustNewDecFromStr("10.0"))
+
+	params := keeper.GetParams(ctx)
+	trigger := types.NewTriggerOrder("", testInput.TestAddrs[0], types.TestTokenPair, types.BuyOrder,
+		types.TriggerTypeStopLoss, sdk.MustNewDecFromStr("11.0"), sdk.MustNewDecFromStr("12.0"),
+		sdk.MustNewDecFromStr("1.0"), types.OrderTypeLimit, 0, params.OrderExpireBlocks, params.FeePerBlock)
+	require.NoError(t, keeper.PlaceTriggerOrder(ctx, trigger))
+
+	// the auction of this block clears at 11
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "11.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "11.0", "1.0"),
+	}
+	orders[0].Sender = testInput.TestAddrs[0]
+	orders[1].Sender = testInput.TestAddrs[1]
+	for _, order := range orders {
+		require.NoError(t, keeper.PlaceOrder(ctx, order))
+	}
+
+	engine := &PaEngine{}
+	engine.Run(ctx, keeper)
+
+	// the trigger order fires on the clearing price the same auction sets
+	require.EqualValues(t, sdk.MustNewDecFromStr("11.0"), keeper.GetLastPrice(ctx, types.TestTokenPair))
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, []*types.TriggerOrder{trigger}, keeper.GetFiredTriggerOrders(ctx))
+
+	// its order is placed into the auction of the next block
+	ctx = ctx.WithBlockHeight(11)
+	engine.Run(ctx, keeper)
+	require.Nil(t, keeper.GetTriggerOrder(ctx, trigger.TriggerID))
+	order := keeper.GetOrder(ctx, types.FormatOrderID(11, 1))
+	require.NotNil(t, order)
+	require.EqualValues(t, trigger.TriggerID, order.TriggerID)
+	require.EqualValues(t, types.OrderStatusOpen, order.Status)
+}
@@ -265,16 +265,20 @@ func cleanupExpiredOrders(ctx sdk.Context, keeper keeper.Keeper) {
 func matchOrders(ctx sdk.Context, keeper keeper.Keeper) {
 	blockHeight := ctx.BlockHeight()
 	orderNum := keeper.GetBlockOrderNum(ctx, blockHeight)
-	// no new orders in this block & no product lock in previous blocks, skip match
-	if orderNum == 0 && !keeper.AnyProductLocked(ctx) {
+	// step0: get active products, which have new or amended orders in this block
+	products := keeper.GetDiskCache().GetNewDepthbookKeys()
+	// no active products in this block & no product lock in previous blocks, skip match
+	if orderNum == 0 && len(products) == 0 && !keeper.AnyProductLocked(ctx) {
 		return
 	}
 
-	// step0: get active products
-	products := keeper.GetDiskCache().GetNewDepthbookKeys()
 	products = keeper.FilterDelistedProducts(ctx, products)
+	products = filterPeriodicAuctionProducts(ctx, keeper, products)
 	keeper.GetDexKeeper().SortProducts(ctx, products) // sort products
 
+	// step0.1: cancel the FOK orders of this block which can't be fully filled
+	cancelUnfillableFOKOrders(ctx, keeper, products)
+
 	// step1: calc best price and max execution for every active product, save latest price
 	//updatedProductsBaseprice := make(map[string]types.MatchResult)
 	updatedProductsBasePrice := calcMatchPriceAndExecution(ctx, keeper, products)
@@ -289,6 +293,9 @@ func matchOrders(ctx sdk.Context, keeper keeper.Keeper) {
 	// step2: execute match results, fill orders in match results, transfer tokens and collect fees
 	executeMatch(ctx, keeper, products, updatedProductsBasePrice, lockMap)
 
+	// step2.1: cancel the remainder of IOC/FOK orders in the products which finish filling
+	cancelImmediateOrderRemainders(ctx, keeper, products, lockMap)
+
 	// step3: save match results for querying
 	if len(updatedProductsBasePrice) > 0 {
 		blockMatchResult := &types.BlockMatchResult{
@@ -300,6 +307,106 @@ func matchOrders(ctx sdk.Context, keeper keeper.Keeper) {
 	}
 }
 
+// filterPeriodicAuctionProducts drops the products which are filled by other match engines
+func filterPeriodicAuctionProducts(ctx sdk.Context, k keeper.Keeper, products []string) []string {
+	var periodicProducts []string
+	for _, product := range products {
+		if k.GetAuctionType(ctx, product) == types.AuctionTypePeriodic {
+			periodicProducts = append(periodicProducts, product)
+		}
+	}
+	return periodicProducts
+}
+
+// isFOKOrderFillable checks whether the FOK order would be fully filled by the periodic auction of the book.
+// Orders with better prices are filled first, then the earlier orders at the same price.
+func isFOKOrderFillable(ctx sdk.Context, k keeper.Keeper, book *types.DepthBook, order *types.Order,
+	pricePrecision int64) bool {
+	bestPrice, maxExecution := periodicAuctionMatchPrice(book, pricePrecision, k.GetLastPrice(ctx, order.Product))
+	if !maxExecution.IsPositive() {
+		return false
+	}
+	if (order.Side == types.BuyOrder && order.Price.LT(bestPrice)) ||
+		(order.Side == types.SellOrder && order.Price.GT(bestPrice)) {
+		return false
+	}
+
+	filledAhead := sdk.ZeroDec()
+	for _, item := range book.Items {
+		if order.Side == types.BuyOrder && item.Price.GT(order.Price) {
+			filledAhead = filledAhead.Add(item.BuyQuantity)
+		} else if order.Side == types.SellOrder && item.Price.LT(order.Price) {
+			filledAhead = filledAhead.Add(item.SellQuantity)
+		}
+	}
+	key := types.FormatOrderIDsKey(order.Product, order.Price, order.Side)
+	for _, orderID := range k.GetProductPriceOrderIDs(key) {
+		if orderID == order.OrderID {
+			break
+		}
+		if queuedOrder := k.GetOrder(ctx, orderID); queuedOrder != nil {
+			filledAhead = filledAhead.Add(queuedOrder.RemainQuantity)
+		}
+	}
+
+	return filledAhead.Add(order.RemainQuantity).LTE(maxExecution)
+}
+
+// cancelUnfillableFOKOrders cancels the FOK orders placed in this block which can't be fully filled
+func cancelUnfillableFOKOrders(ctx sdk.Context, k keeper.Keeper, products []string) {
+	logger := ctx.Logger().With("module", "order")
+	blockHeight := ctx.BlockHeight()
+	productSet := make(map[string]bool, len(products))
+	for _, product := range products {
+		productSet[product] = true
+	}
+
+	var fokOrders []*types.Order
+	orderNum := k.GetBlockOrderNum(ctx, blockHeight)
+	for num := int64(1); num <= orderNum; num++ {
+		order := k.GetOrder(ctx, types.FormatOrderID(blockHeight, num))
+		if order != nil && order.Status == types.OrderStatusOpen && productSet[order.Product] &&
+			order.GetOrderType() == types.OrderTypeFOK {
+			fokOrders = append(fokOrders, order)
+		}
+	}
+
+	// cancelling a FOK order changes the auction of its product, so check the rest again until none is cancelled
+	for len(fokOrders) > 0 {
+		var fillableOrders []*types.Order
+		for _, order := range fokOrders {
+			tokenPair := k.GetDexKeeper().GetTokenPair(ctx, order.Product)
+			if isFOKOrderFillable(ctx, k, k.GetDepthBookCopy(order.Product), order, tokenPair.MaxPriceDigit) {
+				fillableOrders = append(fillableOrders, order)
+				continue
+			}
+			k.CancelImmediateOrder(ctx, order, types.CloseReasonFOKUnfilled, logger)
+			logger.Info(fmt.Sprintf("order (%s) %s", order.OrderID, order.CloseReason))
+		}
+		if len(fillableOrders) == len(fokOrders) {
+			break
+		}
+		fokOrders = fillableOrders
+	}
+}
+
+// cancelImmediateOrderRemainders cancels the remainder of IOC/FOK orders once their product is not locked,
+// including the orders of locked products which are unlocked in this block
+func cancelImmediateOrderRemainders(ctx sdk.Context, k keeper.Keeper, products []string,
+	lockMap *types.ProductLockMap) {
+	unlockedProducts := make(map[string]bool)
+	for _, product := range products {
+		if k.IsProductLocked(ctx, product) {
+			continue
+		}
+		if lock, ok := lockMap.Data[product]; ok {
+			k.CancelImmediateOrders(ctx, lock.BlockHeight, map[string]bool{product: true})
+		}
+		unlockedProducts[product] = true
+	}
+	k.CancelImmediateOrders(ctx, ctx.BlockHeight(), unlockedProducts)
+}
+
 func calcMatchPriceAndExecution(ctx sdk.Context, k keeper.Keeper, products []string) map[string]types.MatchResult {
 	resultMap := make(map[string]types.MatchResult)
 
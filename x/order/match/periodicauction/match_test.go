@@ -518,6 +518,55 @@ func TestMatchOrders(t *testing.T) {
 	require.EqualValues(t, sdk.MustNewDecFromStr("2"), depthBook.Items[0].SellQuantity)
 }
 
+func TestMatchOrdersWithOrderTypes(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	orders := []*types.Order{
+		mockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "3.0"),
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "9.0", "1.0"),
+	}
+	orders[1].OrderType = types.OrderTypeIOC
+	orders[2].OrderType = types.OrderTypeFOK
+	orders[3].OrderType = types.OrderTypeIOC
+	orders[0].Sender = testInput.TestAddrs[1]
+	for i := 1; i < 4; i++ {
+		orders[i].Sender = testInput.TestAddrs[0]
+	}
+	for i := 0; i < 4; i++ {
+		err := keeper.PlaceOrder(ctx, orders[i])
+		require.NoError(t, err)
+	}
+
+	matchOrders(ctx, keeper)
+
+	// the FOK order can't be filled behind the IOC order, the IOC remainders are cancelled after filling
+	order0 := keeper.GetOrder(ctx, orders[0].OrderID)
+	order1 := keeper.GetOrder(ctx, orders[1].OrderID)
+	order2 := keeper.GetOrder(ctx, orders[2].OrderID)
+	order3 := keeper.GetOrder(ctx, orders[3].OrderID)
+	require.EqualValues(t, types.OrderStatusFilled, order0.Status)
+	require.EqualValues(t, types.OrderStatusPartialFilledCancelled, order1.Status)
+	require.EqualValues(t, types.CloseReasonIOCRemainder, order1.CloseReason)
+	require.EqualValues(t, sdk.MustNewDecFromStr("2"), order1.RemainQuantity)
+	require.EqualValues(t, types.OrderStatusCancelled, order2.Status)
+	require.EqualValues(t, types.CloseReasonFOKUnfilled, order2.CloseReason)
+	require.EqualValues(t, types.OrderStatusCancelled, order3.Status)
+	require.EqualValues(t, types.CloseReasonIOCRemainder, order3.CloseReason)
+	// the FOK order closed in the block it's placed is charged the fee of one block
+	receiveFee := orderkeeper.GetOrderNewFee(order2).Sub(sdk.DecCoins{order2.FeePerBlock})
+	require.EqualValues(t, receiveFee.String(), order2.GetExtraInfoWithKey(types.OrderExtraInfoKeyReceiveFee))
+
+	depthBook := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 0, len(depthBook.Items))
+}
+
 func TestMatchOrdersByEmptyBlock(t *testing.T) {
 	testInput := orderkeeper.CreateTestInput(t)
 	keeper := testInput.OrderKeeper
@@ -8,6 +8,14 @@ import (
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgNewOrders{}, "okexchain/order/MsgNew", nil)
 	cdc.RegisterConcrete(MsgCancelOrders{}, "okexchain/order/MsgCancel", nil)
+	cdc.RegisterConcrete(MsgAmendOrders{}, "okexchain/order/MsgAmend", nil)
+	cdc.RegisterConcrete(MsgNewTriggerOrder{}, "okexchain/order/MsgNewTrigger", nil)
+	cdc.RegisterConcrete(MsgCancelTriggerOrder{}, "okexchain/order/MsgCancelTrigger", nil)
+	cdc.RegisterConcrete(MsgSetFeeSchedule{}, "okexchain/order/MsgSetFeeSchedule", nil)
+	cdc.RegisterConcrete(MsgSetAuctionType{}, "okexchain/order/MsgSetAuctionType", nil)
+	cdc.RegisterConcrete(MsgCancelAllOrders{}, "okexchain/order/MsgCancelAll", nil)
+	cdc.RegisterConcrete(MsgHeartbeat{}, "okexchain/order/MsgHeartbeat", nil)
+	cdc.RegisterConcrete(MsgHybridSwap{}, "okexchain/order/MsgHybridSwap", nil)
 }
 
 // ModuleCdc generic sealed codec to be used throughout this module
@@ -12,7 +12,26 @@ const (
 	FeeTypeOrderExpire  = "expire"
 	FeeTypeOrderDeal    = "deal"
 	FeeTypeOrderReceive = "receive"
+	FeeTypeOrderRebate  = "rebate"
 	TestTokenPair       = common.TestToken + "_" + sdk.DefaultBondDenom
 	BuyOrder            = "BUY"
 	SellOrder           = "SELL"
 )
+
+// nolint : match engines a token pair can be traded with
+const (
+	AuctionTypePeriodic   = "periodicauction"
+	AuctionTypeContinuous = "continuousauction"
+	DefaultAuctionType    = AuctionTypePeriodic
+)
+
+// IsValidAuctionType checks whether the auction type is supported by the match engines
+func IsValidAuctionType(auctionType string) bool {
+	return auctionType == AuctionTypePeriodic || auctionType == AuctionTypeContinuous
+}
+
+// ProductAuctionType binds a product to the match engine which fills its orders
+type ProductAuctionType struct {
+	Product     string `json:"product"`
+	AuctionType string `json:"auction_type"`
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// DeadManSwitchMinTimeoutBlocks is the min timeout of a dead man's switch
+	DeadManSwitchMinTimeoutBlocks = 10
+	// DeadManSwitchMaxTimeoutBlocks is the max timeout of a dead man's switch
+	DeadManSwitchMaxTimeoutBlocks = 1000000
+	// DeadManSwitchMaxArmed is the max number of the dead man's switches armed at the same time
+	DeadManSwitchMaxArmed = 10000
+)
+
+// DeadManSwitch cancels all the open & trigger orders of an account
+// once the account sends no heartbeat until the deadline block
+type DeadManSwitch struct {
+	Address       sdk.AccAddress `json:"address"`
+	TimeoutBlocks int64          `json:"timeout_blocks"`
+	Deadline      int64          `json:"deadline"` // the switch fires at the end of this block without heartbeat
+}
+
+// NewDeadManSwitch creates a switch refreshed by a heartbeat at blockHeight
+func NewDeadManSwitch(addr sdk.AccAddress, timeoutBlocks, blockHeight int64) DeadManSwitch {
+	return DeadManSwitch{
+		Address:       addr,
+		TimeoutBlocks: timeoutBlocks,
+		Deadline:      blockHeight + timeoutBlocks,
+	}
+}
+
+// nolint
+func (s DeadManSwitch) String() string {
+	switchJSON, err := json.Marshal(s)
+	if err != nil {
+		panic(err)
+	}
+	return string(switchJSON)
+}
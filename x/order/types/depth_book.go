@@ -10,6 +10,7 @@ import (
 type Deal struct {
 	OrderID     string  `json:"order_id"`
 	Side        string  `json:"side"`
+	Price       sdk.Dec `json:"price"`
 	Quantity    sdk.Dec `json:"quantity"`
 	Fee         string  `json:"fee"`
 	FeeReceiver string  `json:"fee_receiver"`
@@ -120,6 +121,19 @@ func (depthBook *DepthBook) RemoveIfEmpty(index int) bool {
 	return res
 }
 
+// IsCrossed checks whether an order at the price on the side would be filled by the opposite side of depth book
+func (depthBook *DepthBook) IsCrossed(side string, price sdk.Dec) bool {
+	for _, item := range depthBook.Items {
+		if side == BuyOrder && item.SellQuantity.IsPositive() && item.Price.LTE(price) {
+			return true
+		}
+		if side == SellOrder && item.BuyQuantity.IsPositive() && item.Price.GTE(price) {
+			return true
+		}
+	}
+	return false
+}
+
 // Copy : depth copy of depth book
 func (depthBook *DepthBook) Copy() *DepthBook {
 	itemList := make([]DepthBookItem, 0, len(depthBook.Items))
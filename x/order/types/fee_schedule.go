@@ -0,0 +1,164 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// nolint
+const (
+	FeeScheduleTierLimit = 10
+
+	FeeRoleMaker = "maker"
+	FeeRoleTaker = "taker"
+
+	// FeeTierDefault is the name of the tier charged by Params.TradeFeeRate
+	FeeTierDefault = "default"
+)
+
+// FeeTier is the maker & taker fee rates applied to the accounts trading at least MinVolume in a period.
+// A negative maker rate is a rebate paid by the fee receiver of the product.
+type FeeTier struct {
+	MinVolume sdk.Dec `json:"min_volume"` // traded volume of the account in quote asset
+	MakerRate sdk.Dec `json:"maker_rate"`
+	TakerRate sdk.Dec `json:"taker_rate"`
+}
+
+// NewFeeTier creates a new instance of FeeTier
+func NewFeeTier(minVolume, makerRate, takerRate sdk.Dec) FeeTier {
+	return FeeTier{
+		MinVolume: minVolume,
+		MakerRate: makerRate,
+		TakerRate: takerRate,
+	}
+}
+
+// FeeSchedule is the deal fee pricing of a product set by its owner
+type FeeSchedule struct {
+	Product            string    `json:"product"`
+	Tiers              []FeeTier `json:"tiers"`                // sorted by MinVolume, starting from zero volume
+	VolumePeriodBlocks int64     `json:"volume_period_blocks"` // the period the volume is counted in, 0 means forever
+}
+
+// NewFeeSchedule creates a new instance of FeeSchedule
+func NewFeeSchedule(product string, tiers []FeeTier, volumePeriodBlocks int64) FeeSchedule {
+	return FeeSchedule{
+		Product:            product,
+		Tiers:              tiers,
+		VolumePeriodBlocks: volumePeriodBlocks,
+	}
+}
+
+// DefaultFeeSchedule returns the fee schedule of the products whose owner never set one
+func DefaultFeeSchedule(product string, tradeFeeRate sdk.Dec) FeeSchedule {
+	return NewFeeSchedule(product, []FeeTier{NewFeeTier(sdk.ZeroDec(), tradeFeeRate, tradeFeeRate)}, 0)
+}
+
+// nolint
+func (schedule FeeSchedule) String() string {
+	scheduleJSON, err := json.Marshal(schedule)
+	if err != nil {
+		panic(err)
+	}
+	return string(scheduleJSON)
+}
+
+// Validate checks whether the fee schedule is well formed
+func (schedule FeeSchedule) Validate() error {
+	if len(schedule.Tiers) == 0 || len(schedule.Tiers) > FeeScheduleTierLimit {
+		return fmt.Errorf("the number of fee tiers should be between 1 and %d", FeeScheduleTierLimit)
+	}
+	if schedule.VolumePeriodBlocks < 0 {
+		return fmt.Errorf("volume period blocks should not be negative")
+	}
+	for i, tier := range schedule.Tiers {
+		if tier.MinVolume.IsNil() || tier.MakerRate.IsNil() || tier.TakerRate.IsNil() {
+			return fmt.Errorf("fee tier %d is incomplete", i)
+		}
+		if i == 0 && !tier.MinVolume.IsZero() {
+			return fmt.Errorf("the min volume of the first fee tier should be zero")
+		}
+		if i > 0 && !tier.MinVolume.GT(schedule.Tiers[i-1].MinVolume) {
+			return fmt.Errorf("the min volumes of fee tiers should be increasing")
+		}
+		if tier.TakerRate.IsNegative() || tier.TakerRate.GTE(sdk.OneDec()) {
+			return fmt.Errorf("taker rate of fee tier %d should be in [0, 1)", i)
+		}
+		if tier.MakerRate.GTE(sdk.OneDec()) {
+			return fmt.Errorf("maker rate of fee tier %d should be less than 1", i)
+		}
+		// rebates are funded by taker fees
+		if tier.MakerRate.IsNegative() && tier.MakerRate.Neg().GT(tier.TakerRate) {
+			return fmt.Errorf("maker rebate of fee tier %d should not be more than its taker rate", i)
+		}
+	}
+	return nil
+}
+
+// GetTier returns the index of the highest tier reached by the volume, and the tier itself
+func (schedule FeeSchedule) GetTier(volume sdk.Dec) (int, FeeTier) {
+	index := 0
+	for i, tier := range schedule.Tiers {
+		if volume.GTE(tier.MinVolume) {
+			index = i
+		}
+	}
+	return index, schedule.Tiers[index]
+}
+
+// GetPeriod returns the volume period of the block height
+func (schedule FeeSchedule) GetPeriod(blockHeight int64) int64 {
+	if schedule.VolumePeriodBlocks <= 0 {
+		return 0
+	}
+	return blockHeight / schedule.VolumePeriodBlocks
+}
+
+// AccountVolume is the traded volume of an account on a product, in quote asset
+type AccountVolume struct {
+	Product          string         `json:"product"`
+	Address          sdk.AccAddress `json:"address"`
+	Period           int64          `json:"period"`
+	Volume           sdk.Dec        `json:"volume"`             // the volume traded in the period
+	LastPeriodVolume sdk.Dec        `json:"last_period_volume"` // the volume traded in the previous period
+}
+
+// TierVolume returns the volume deciding the fee tier in the period,
+// the better one of the current and the previous period
+func (volume AccountVolume) TierVolume(period int64) sdk.Dec {
+	switch period {
+	case volume.Period:
+		return sdk.MaxDec(volume.Volume, volume.LastPeriodVolume)
+	case volume.Period + 1:
+		return volume.Volume
+	default:
+		return sdk.ZeroDec()
+	}
+}
+
+// Add adds the traded volume to the period, rolling over the volumes of the elapsed periods
+func (volume *AccountVolume) Add(period int64, amount sdk.Dec) {
+	switch period {
+	case volume.Period:
+	case volume.Period + 1:
+		volume.LastPeriodVolume = volume.Volume
+		volume.Volume = sdk.ZeroDec()
+	default:
+		volume.LastPeriodVolume = sdk.ZeroDec()
+		volume.Volume = sdk.ZeroDec()
+	}
+	volume.Period = period
+	volume.Volume = volume.Volume.Add(amount)
+}
+
+// FormatFeeTier formats the tier applied to a deal fee, such as "maker:tier1:-0.0001" and "taker:default:0.001"
+func FormatFeeTier(role, tierName string, rate sdk.Dec) string {
+	return fmt.Sprintf("%s:%s:%s", role, tierName, rate.String())
+}
+
+// FeeTierName returns the name of the tier at index of a fee schedule
+func FeeTierName(index int) string {
+	return fmt.Sprintf("tier%d", index)
+}
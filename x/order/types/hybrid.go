@@ -0,0 +1,35 @@
+package types
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// HybridRoute is the quote of selling an amount on a side of a product across its depth book & its ammswap pool.
+// The ammswap leg is swapped immediately, the order-book leg is placed as a limit order at the worst price
+// it takes from the depth book
+type HybridRoute struct {
+	Product     string      `json:"product"`
+	Side        string      `json:"side"`
+	SoldToken   sdk.DecCoin `json:"sold_token"`   // the quote token buying, the base token selling
+	BoughtToken sdk.DecCoin `json:"bought_token"` // the token expected to be bought by both the legs
+
+	SwapSoldToken   sdk.DecCoin `json:"swap_sold_token"`   // the token sold to the ammswap pool
+	SwapBoughtToken sdk.DecCoin `json:"swap_bought_token"` // the token bought from the ammswap pool
+	SwapPath        string      `json:"swap_path"`         // the name of the swap token pair, empty if nothing is swapped
+
+	BookSoldToken   sdk.DecCoin `json:"book_sold_token"`   // the token sold to the depth book
+	BookBoughtToken sdk.DecCoin `json:"book_bought_token"` // the token bought from the resting orders at their prices
+	OrderPrice      sdk.Dec     `json:"order_price"`       // the limit price of the order-book leg
+	OrderQuantity   sdk.Dec     `json:"order_quantity"`    // the quantity of the order-book leg, zero if no order is placed
+}
+
+// String implements fmt.Stringer
+func (r HybridRoute) String() string {
+	routeJSON, err := json.Marshal(r)
+	if err != nil {
+		panic(err)
+	}
+	return string(routeJSON)
+}
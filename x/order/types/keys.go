@@ -20,11 +20,15 @@ const (
 	RouterKey = ModuleName
 
 	// QueryOrderDetail query endpoints supported by the governance Querier
-	QueryOrderDetail = "detail"
-	QueryDepthBook   = "depthbook"
-	QueryParameters  = "params"
-	QueryStore       = "store"
-	QueryDepthBookV2 = "depthbookV2"
+	QueryOrderDetail   = "detail"
+	QueryDepthBook     = "depthbook"
+	QueryParameters    = "params"
+	QueryStore         = "store"
+	QueryDepthBookV2   = "depthbookV2"
+	QueryTriggers      = "triggers"
+	QueryFeeSchedule   = "feeschedule"
+	QueryDeadManSwitch = "deadmanswitch"
+	QueryHybridRoute   = "hybridroute"
 
 	OrderStoreKey = ModuleName
 )
@@ -46,6 +50,42 @@ var (
 	LastExpiredBlockHeightKey = []byte{0x18}
 	OpenOrderNumKey           = []byte{0x19}
 	StoreOrderNumKey          = []byte{0x20}
+
+	// auction type of each product, keyed by product
+	AuctionTypeKey = []byte{0x21}
+
+	// trigger order keys
+	TriggerOrderKey    = []byte{0x22}
+	TriggerOrderSeqKey = []byte{0x23}
+
+	// fee schedule keys
+	FeeScheduleKey   = []byte{0x24}
+	AccountVolumeKey = []byte{0x25}
+
+	// dead man's switch keys
+	DeadManSwitchKey         = []byte{0x26}
+	DeadManSwitchDeadlineKey = []byte{0x27}
+
+	// trigger order index keys
+	TriggerPriceKey   = []byte{0x28}
+	TriggerExpireKey  = []byte{0x29}
+	TriggerSenderKey  = []byte{0x2A}
+	TriggerProductKey = []byte{0x2B}
+
+	// account volume index keys
+	AccountVolumePeriodKey = []byte{0x2C}
+
+	// open order index keys
+	OpenOrderSenderKey = []byte{0x2D}
+
+	// dead man's switch number key
+	DeadManSwitchNumKey = []byte{0x2E}
+
+	// fired trigger order index key
+	TriggerFiredKey = []byte{0x2F}
+
+	// set once the open orders placed before the open order index are indexed by sender
+	OpenOrderSenderIndexedKey = []byte{0x30}
 )
 
 // nolint
@@ -68,6 +108,118 @@ func GetPriceKey(key string) []byte {
 	return append(PriceKey, []byte(key)...)
 }
 
+// nolint
+func GetAuctionTypeKey(product string) []byte {
+	return append(AuctionTypeKey, []byte(product)...)
+}
+
+// nolint
+func GetTriggerOrderKey(triggerID string) []byte {
+	return append(TriggerOrderKey, []byte(triggerID)...)
+}
+
+// GetTriggerPricePrefix returns the prefix of the trigger orders of the product triggered in the direction,
+// whose keys are sorted by the trigger price
+func GetTriggerPricePrefix(product string, direction byte) []byte {
+	return append(append(TriggerPriceKey, []byte(product+":")...), direction)
+}
+
+// GetTriggerPriceKey returns the key indexing the trigger order by product, direction and trigger price
+func GetTriggerPriceKey(trigger *TriggerOrder) []byte {
+	prefix := GetTriggerPricePrefix(trigger.Product, trigger.Direction())
+	return append(append(prefix, SortableTriggerPriceBytes(trigger.TriggerPrice)...), []byte(trigger.TriggerID)...)
+}
+
+// SortableTriggerPriceBytes encodes a positive price into bytes sorted as the price, which are prefixed by their
+// length so that the encoding of one price is never the prefix of another
+func SortableTriggerPriceBytes(price sdk.Dec) []byte {
+	bz := price.Int.Bytes()
+	return append([]byte{byte(len(bz))}, bz...)
+}
+
+// nolint
+func GetTriggerExpirePrefix(expireHeight int64) []byte {
+	return append(TriggerExpireKey, sdk.Uint64ToBigEndian(uint64(expireHeight))...)
+}
+
+// nolint
+func GetTriggerExpireKey(expireHeight int64, triggerID string) []byte {
+	return append(GetTriggerExpirePrefix(expireHeight), []byte(triggerID)...)
+}
+
+// nolint
+func GetTriggerSenderPrefix(sender sdk.AccAddress) []byte {
+	return append(TriggerSenderKey, sender.Bytes()...)
+}
+
+// nolint
+func GetTriggerSenderKey(sender sdk.AccAddress, triggerID string) []byte {
+	return append(GetTriggerSenderPrefix(sender), []byte(triggerID)...)
+}
+
+// nolint
+func GetTriggerProductKey(product string) []byte {
+	return append(TriggerProductKey, []byte(product)...)
+}
+
+// nolint
+func GetTriggerFiredKey(triggerID string) []byte {
+	return append(TriggerFiredKey, []byte(triggerID)...)
+}
+
+// nolint
+func GetFeeScheduleKey(product string) []byte {
+	return append(FeeScheduleKey, []byte(product)...)
+}
+
+// nolint
+func GetAccountVolumeKey(product string, addr sdk.AccAddress) []byte {
+	return append(GetAccountVolumePrefix(product), addr.Bytes()...)
+}
+
+// nolint
+func GetAccountVolumePrefix(product string) []byte {
+	return append(AccountVolumeKey, []byte(product+":")...)
+}
+
+// nolint
+func GetAccountVolumePeriodPrefix(product string, period int64) []byte {
+	return append(append(AccountVolumePeriodKey, []byte(product+":")...), sdk.Uint64ToBigEndian(uint64(period))...)
+}
+
+// GetAccountVolumePeriodKey returns the key indexing the volumes of a product by period, so that the volumes outside
+// the fee tier window are pruned in the order of their periods
+func GetAccountVolumePeriodKey(product string, period int64, addr sdk.AccAddress) []byte {
+	return append(GetAccountVolumePeriodPrefix(product, period), addr.Bytes()...)
+}
+
+// nolint
+func GetOpenOrderSenderPrefix(sender sdk.AccAddress) []byte {
+	return append(OpenOrderSenderKey, sender.Bytes()...)
+}
+
+// GetOpenOrderSenderKey returns the key indexing the open orders by sender, so that the orders of an account are
+// got without scanning the depth books
+func GetOpenOrderSenderKey(sender sdk.AccAddress, orderID string) []byte {
+	return append(GetOpenOrderSenderPrefix(sender), []byte(orderID)...)
+}
+
+// nolint
+func GetDeadManSwitchKey(addr sdk.AccAddress) []byte {
+	return append(DeadManSwitchKey, addr.Bytes()...)
+}
+
+// GetDeadManSwitchDeadlineKey returns the key indexing the switches by deadline, so that the expired ones are
+// iterated in the order of their deadlines
+func GetDeadManSwitchDeadlineKey(deadline int64, addr sdk.AccAddress) []byte {
+	return append(GetDeadManSwitchDeadlinePrefix(deadline), addr.Bytes()...)
+}
+
+// nolint
+func GetDeadManSwitchDeadlinePrefix(deadline int64) []byte {
+	return append(DeadManSwitchDeadlineKey, sdk.Uint64ToBigEndian(uint64(deadline))...)
+}
+
 // nolint
 func GetOrderNumPerBlockKey(blockHeight int64) []byte {
 	return append(OrderNumPerBlockKey, sdk.Uint64ToBigEndian(uint64(blockHeight))...)
@@ -22,6 +22,8 @@ type MsgNewOrder struct {
 	Side     string         `json:"side"`     // BUY/SELL
 	Price    sdk.Dec        `json:"price"`    // price of the order
 	Quantity sdk.Dec        `json:"quantity"` // quantity of the order
+
+	OrderType string `json:"order_type"` // time in force of the order
 }
 
 // NewMsgNewOrder is a constructor function for MsgNewOrder
@@ -69,6 +71,8 @@ type OrderItem struct {
 	Side     string  `json:"side"`     // BUY/SELL
 	Price    sdk.Dec `json:"price"`    // price of the order
 	Quantity sdk.Dec `json:"quantity"` // quantity of the order
+
+	OrderType string `json:"order_type,omitempty"` // time in force of the order, LIMIT if empty
 }
 
 // nolint
@@ -82,6 +86,14 @@ func NewOrderItem(product string, side string, price string,
 	}
 }
 
+// NewOrderItemWithType creates an OrderItem with the specified time in force
+func NewOrderItemWithType(product string, side string, price string,
+	quantity string, orderType string) OrderItem {
+	item := NewOrderItem(product, side, price, quantity)
+	item.OrderType = orderType
+	return item
+}
+
 // NewMsgNewOrders is a constructor function for MsgNewOrder
 func NewMsgNewOrders(sender sdk.AccAddress, orderItems []OrderItem) MsgNewOrders {
 	return MsgNewOrders{
@@ -125,6 +137,9 @@ func (msg MsgNewOrders) ValidateBasic() sdk.Error {
 		if !(item.Price.IsPositive() && item.Quantity.IsPositive()) {
 			return sdk.ErrUnknownRequest("Price/Quantity must be positive")
 		}
+		if !IsValidOrderType(item.OrderType) {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("invalid order type \"%s\"", item.OrderType))
+		}
 	}
 
 	return nil
@@ -218,9 +233,487 @@ func (msg MsgCancelOrders) CalculateGas(gasUnit uint64) uint64 {
 	return uint64(len(msg.OrderIDs)) * gasUnit
 }
 
+// MsgAmendOrders changes the price and/or the quantity of open orders without cancelling them
+type MsgAmendOrders struct {
+	Sender     sdk.AccAddress   `json:"sender"` // order maker address
+	OrderItems []AmendOrderItem `json:"order_items"`
+}
+
+// AmendOrderItem is the new price and quantity of an open order, a zero price or quantity is left unchanged
+type AmendOrderItem struct {
+	OrderID  string  `json:"order_id"`
+	Price    sdk.Dec `json:"price"`    // new price of the order
+	Quantity sdk.Dec `json:"quantity"` // new quantity of the order, including the filled quantity
+}
+
+// NewAmendOrderItem creates an AmendOrderItem, an empty price or quantity is left unchanged
+func NewAmendOrderItem(orderID string, price string, quantity string) AmendOrderItem {
+	item := AmendOrderItem{
+		OrderID:  orderID,
+		Price:    sdk.ZeroDec(),
+		Quantity: sdk.ZeroDec(),
+	}
+	if price != "" {
+		item.Price = sdk.MustNewDecFromStr(price)
+	}
+	if quantity != "" {
+		item.Quantity = sdk.MustNewDecFromStr(quantity)
+	}
+	return item
+}
+
+// NewMsgAmendOrders is a constructor function for MsgAmendOrders
+func NewMsgAmendOrders(sender sdk.AccAddress, orderItems []AmendOrderItem) MsgAmendOrders {
+	return MsgAmendOrders{
+		Sender:     sender,
+		OrderItems: orderItems,
+	}
+}
+
+// nolint
+func (msg MsgAmendOrders) Route() string { return "order" }
+
+// nolint
+func (msg MsgAmendOrders) Type() string { return "amend" }
+
+// nolint
+func (msg MsgAmendOrders) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if len(msg.OrderItems) == 0 {
+		return sdk.ErrUnknownRequest("invalid OrderItems")
+	}
+	if len(msg.OrderItems) > MultiCancelOrderItemLimit {
+		return sdk.ErrUnknownRequest("Numbers of AmendOrderItem should not be more than " +
+			strconv.Itoa(MultiCancelOrderItemLimit))
+	}
+	orderIDs := make([]string, 0, len(msg.OrderItems))
+	for _, item := range msg.OrderItems {
+		if item.OrderID == "" {
+			return sdk.ErrUnknownRequest("orderID cannot be empty")
+		}
+		price, quantity := item.GetPrice(), item.GetQuantity()
+		if price.IsNegative() || quantity.IsNegative() {
+			return sdk.ErrUnknownRequest("Price/Quantity must not be negative")
+		}
+		if price.IsZero() && quantity.IsZero() {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("nothing to amend in order(%s)", item.OrderID))
+		}
+		orderIDs = append(orderIDs, item.OrderID)
+	}
+	if hasDuplicatedID(orderIDs) {
+		return sdk.ErrUnknownRequest("Duplicated order ids detected")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgAmendOrders) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgAmendOrders) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// Calculate customize gas
+func (msg MsgAmendOrders) CalculateGas(gasUnit uint64) uint64 {
+	return uint64(len(msg.OrderItems)) * gasUnit
+}
+
+// GetPrice returns the new price, zero if it's left unchanged
+func (item AmendOrderItem) GetPrice() sdk.Dec {
+	if item.Price.IsNil() {
+		return sdk.ZeroDec()
+	}
+	return item.Price
+}
+
+// GetQuantity returns the new quantity, zero if it's left unchanged
+func (item AmendOrderItem) GetQuantity() sdk.Dec {
+	if item.Quantity.IsNil() {
+		return sdk.ZeroDec()
+	}
+	return item.Quantity
+}
+
 // nolint
 type OrderResult struct {
 	Code    sdk.CodeType `json:"code"`    // order return code
 	Message string       `json:"msg"`     // order return error message
 	OrderID string       `json:"orderid"` // order return orderid
 }
+
+//********************MsgNewTriggerOrder*************
+// nolint
+type MsgNewTriggerOrder struct {
+	Sender       sdk.AccAddress `json:"sender"`        // trigger order maker address
+	Product      string         `json:"product"`       // product for trading pair in full name of the tokens
+	Side         string         `json:"side"`          // BUY/SELL
+	TriggerType  string         `json:"trigger_type"`  // STOP_LOSS/TAKE_PROFIT
+	TriggerPrice sdk.Dec        `json:"trigger_price"` // the last price which triggers the order
+	Price        sdk.Dec        `json:"price"`         // price of the order placed when triggered
+	Quantity     sdk.Dec        `json:"quantity"`      // quantity of the order placed when triggered
+	OrderType    string         `json:"order_type"`    // LIMIT/IOC, LIMIT if empty
+}
+
+// NewMsgNewTriggerOrder is a constructor function for MsgNewTriggerOrder
+func NewMsgNewTriggerOrder(sender sdk.AccAddress, product, side, triggerType, triggerPrice, price,
+	quantity, orderType string) MsgNewTriggerOrder {
+	return MsgNewTriggerOrder{
+		Sender:       sender,
+		Product:      product,
+		Side:         side,
+		TriggerType:  triggerType,
+		TriggerPrice: sdk.MustNewDecFromStr(triggerPrice),
+		Price:        sdk.MustNewDecFromStr(price),
+		Quantity:     sdk.MustNewDecFromStr(quantity),
+		OrderType:    orderType,
+	}
+}
+
+// nolint
+func (msg MsgNewTriggerOrder) Route() string { return "order" }
+
+// nolint
+func (msg MsgNewTriggerOrder) Type() string { return "new_trigger" }
+
+// ValidateBasic : Implements Msg.
+func (msg MsgNewTriggerOrder) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	symbols := strings.Split(msg.Product, "_")
+	if len(symbols) != 2 || symbols[0] == symbols[1] {
+		return sdk.ErrUnknownRequest("Product should be in the format of \"base_quote\"")
+	}
+	if msg.Side != BuyOrder && msg.Side != SellOrder {
+		return sdk.ErrUnknownRequest(
+			fmt.Sprintf("Side is expected to be \"BUY\" or \"SELL\", but got \"%s\"", msg.Side))
+	}
+	if !IsValidTriggerType(msg.TriggerType) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("invalid trigger type \"%s\"", msg.TriggerType))
+	}
+	if !(msg.TriggerPrice.IsPositive() && msg.Price.IsPositive() && msg.Quantity.IsPositive()) {
+		return sdk.ErrUnknownRequest("TriggerPrice/Price/Quantity must be positive")
+	}
+	if !IsValidTriggerOrderType(msg.OrderType) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("invalid order type \"%s\" of trigger order", msg.OrderType))
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgNewTriggerOrder) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgNewTriggerOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// nolint
+type MsgCancelTriggerOrder struct {
+	Sender    sdk.AccAddress `json:"sender"` // trigger order maker address
+	TriggerID string         `json:"trigger_id"`
+}
+
+// NewMsgCancelTriggerOrder is a constructor function for MsgCancelTriggerOrder
+func NewMsgCancelTriggerOrder(sender sdk.AccAddress, triggerID string) MsgCancelTriggerOrder {
+	return MsgCancelTriggerOrder{
+		Sender:    sender,
+		TriggerID: triggerID,
+	}
+}
+
+// nolint
+func (msg MsgCancelTriggerOrder) Route() string { return "order" }
+
+// nolint
+func (msg MsgCancelTriggerOrder) Type() string { return "cancel_trigger" }
+
+// nolint
+func (msg MsgCancelTriggerOrder) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if msg.TriggerID == "" {
+		return sdk.ErrUnknownRequest("triggerID cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCancelTriggerOrder) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCancelTriggerOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgSetFeeSchedule sets the deal fee schedule of a product, an empty Tiers restores Params.TradeFeeRate
+type MsgSetFeeSchedule struct {
+	Owner              sdk.AccAddress `json:"owner"` // the owner of the token pair
+	Product            string         `json:"product"`
+	Tiers              []FeeTier      `json:"tiers"`
+	VolumePeriodBlocks int64          `json:"volume_period_blocks"`
+}
+
+// NewMsgSetFeeSchedule is a constructor function for MsgSetFeeSchedule
+func NewMsgSetFeeSchedule(owner sdk.AccAddress, product string, tiers []FeeTier,
+	volumePeriodBlocks int64) MsgSetFeeSchedule {
+	return MsgSetFeeSchedule{
+		Owner:              owner,
+		Product:            product,
+		Tiers:              tiers,
+		VolumePeriodBlocks: volumePeriodBlocks,
+	}
+}
+
+// nolint
+func (msg MsgSetFeeSchedule) Route() string { return "order" }
+
+// nolint
+func (msg MsgSetFeeSchedule) Type() string { return "set_fee_schedule" }
+
+// nolint
+func (msg MsgSetFeeSchedule) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if len(msg.Product) == 0 {
+		return sdk.ErrUnknownRequest("Product cannot be empty")
+	}
+	if len(msg.Tiers) == 0 {
+		return nil
+	}
+	if err := msg.GetFeeSchedule().Validate(); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSetFeeSchedule) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSetFeeSchedule) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// GetFeeSchedule returns the fee schedule set by the msg
+func (msg MsgSetFeeSchedule) GetFeeSchedule() FeeSchedule {
+	return NewFeeSchedule(msg.Product, msg.Tiers, msg.VolumePeriodBlocks)
+}
+
+// MsgSetAuctionType sets the match engine filling the orders of a product
+type MsgSetAuctionType struct {
+	Owner       sdk.AccAddress `json:"owner"` // the owner of the token pair
+	Product     string         `json:"product"`
+	AuctionType string         `json:"auction_type"`
+}
+
+// NewMsgSetAuctionType is a constructor function for MsgSetAuctionType
+func NewMsgSetAuctionType(owner sdk.AccAddress, product, auctionType string) MsgSetAuctionType {
+	return MsgSetAuctionType{
+		Owner:       owner,
+		Product:     product,
+		AuctionType: auctionType,
+	}
+}
+
+// nolint
+func (msg MsgSetAuctionType) Route() string { return "order" }
+
+// nolint
+func (msg MsgSetAuctionType) Type() string { return "set_auction_type" }
+
+// nolint
+func (msg MsgSetAuctionType) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if len(msg.Product) == 0 {
+		return sdk.ErrUnknownRequest("Product cannot be empty")
+	}
+	if !IsValidAuctionType(msg.AuctionType) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("AuctionType is expected to be \"%s\" or \"%s\", but got \"%s\"",
+			AuctionTypePeriodic, AuctionTypeContinuous, msg.AuctionType))
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSetAuctionType) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSetAuctionType) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgCancelAllOrders cancels all the open orders of the sender, optionally filtered by product and side
+type MsgCancelAllOrders struct {
+	Sender  sdk.AccAddress `json:"sender"`
+	Product string         `json:"product"` // empty for all the products
+	Side    string         `json:"side"`    // BUY/SELL, empty for both sides
+}
+
+// NewMsgCancelAllOrders is a constructor function for MsgCancelAllOrders
+func NewMsgCancelAllOrders(sender sdk.AccAddress, product, side string) MsgCancelAllOrders {
+	return MsgCancelAllOrders{
+		Sender:  sender,
+		Product: product,
+		Side:    side,
+	}
+}
+
+// nolint
+func (msg MsgCancelAllOrders) Route() string { return "order" }
+
+// nolint
+func (msg MsgCancelAllOrders) Type() string { return "cancel_all" }
+
+// nolint
+func (msg MsgCancelAllOrders) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if msg.Side != "" && msg.Side != BuyOrder && msg.Side != SellOrder {
+		return sdk.ErrUnknownRequest(
+			fmt.Sprintf("Side is expected to be \"BUY\" or \"SELL\", but got \"%s\"", msg.Side))
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCancelAllOrders) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCancelAllOrders) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgHeartbeat arms or refreshes the dead man's switch of the sender: all its open & trigger orders are cancelled
+// if no other heartbeat is sent within TimeoutBlocks. A zero TimeoutBlocks disarms the switch.
+type MsgHeartbeat struct {
+	Sender        sdk.AccAddress `json:"sender"`
+	TimeoutBlocks int64          `json:"timeout_blocks"`
+}
+
+// NewMsgHeartbeat is a constructor function for MsgHeartbeat
+func NewMsgHeartbeat(sender sdk.AccAddress, timeoutBlocks int64) MsgHeartbeat {
+	return MsgHeartbeat{
+		Sender:        sender,
+		TimeoutBlocks: timeoutBlocks,
+	}
+}
+
+// nolint
+func (msg MsgHeartbeat) Route() string { return "order" }
+
+// nolint
+func (msg MsgHeartbeat) Type() string { return "heartbeat" }
+
+// nolint
+func (msg MsgHeartbeat) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	// 0 disarms the switch
+	if msg.TimeoutBlocks != 0 &&
+		(msg.TimeoutBlocks < DeadManSwitchMinTimeoutBlocks || msg.TimeoutBlocks > DeadManSwitchMaxTimeoutBlocks) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("timeout blocks should be 0 or between %d and %d",
+			DeadManSwitchMinTimeoutBlocks, DeadManSwitchMaxTimeoutBlocks))
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgHeartbeat) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgHeartbeat) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgHybridSwap sells the amount on the side of the product across its depth book & its ammswap pool,
+// split as quoted by the hybrid route. The ammswap leg is swapped immediately, the order-book leg is placed
+// as a limit order at the worst price it takes from the depth book. A buy order locks its quantity at that price,
+// which may be more than the amount of the leg, the surplus is unlocked when it's filled at a better price
+type MsgHybridSwap struct {
+	Sender          sdk.AccAddress `json:"sender"`
+	Product         string         `json:"product"`
+	Side            string         `json:"side"`              // BUY/SELL
+	Amount          sdk.Dec        `json:"amount"`            // the quote token buying, the base token selling
+	MinBoughtAmount sdk.Dec        `json:"min_bought_amount"` // the least expected to be bought by the ammswap leg
+}
+
+// NewMsgHybridSwap is a constructor function for MsgHybridSwap
+func NewMsgHybridSwap(sender sdk.AccAddress, product, side string, amount, minBoughtAmount sdk.Dec) MsgHybridSwap {
+	return MsgHybridSwap{
+		Sender:          sender,
+		Product:         product,
+		Side:            side,
+		Amount:          amount,
+		MinBoughtAmount: minBoughtAmount,
+	}
+}
+
+// nolint
+func (msg MsgHybridSwap) Route() string { return "order" }
+
+// nolint
+func (msg MsgHybridSwap) Type() string { return "hybrid_swap" }
+
+// nolint
+func (msg MsgHybridSwap) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	symbols := strings.Split(msg.Product, "_")
+	if len(symbols) != 2 || symbols[0] == symbols[1] {
+		return sdk.ErrUnknownRequest("Product should be in the format of \"base_quote\"")
+	}
+	if msg.Side != BuyOrder && msg.Side != SellOrder {
+		return sdk.ErrUnknownRequest(
+			fmt.Sprintf("Side is expected to be \"BUY\" or \"SELL\", but got \"%s\"", msg.Side))
+	}
+	if msg.Amount.IsNil() || !msg.Amount.IsPositive() {
+		return sdk.ErrUnknownRequest("Amount must be positive")
+	}
+	if msg.MinBoughtAmount.IsNil() || msg.MinBoughtAmount.IsNegative() {
+		return sdk.ErrUnknownRequest("MinBoughtAmount must not be negative")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgHybridSwap) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgHybridSwap) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/okexchain/x/common"
 
 	"github.com/stretchr/testify/require"
@@ -313,3 +314,124 @@ func TestHasDuplicatedID(t *testing.T) {
 	result2 := hasDuplicatedID(ids2)
 	require.EqualValues(t, true, result2)
 }
+
+func TestMsgNewOrdersOrderType(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+
+	for _, orderType := range []string{"", OrderTypeLimit, OrderTypeIOC, OrderTypeFOK,
+		OrderTypePostOnly} {
+		msg := NewMsgNewOrders(addr, []OrderItem{
+			NewOrderItemWithType(TestTokenPair, BuyOrder, "10.0", "1.0", orderType),
+		})
+		require.Nil(t, msg.ValidateBasic())
+	}
+
+	for _, orderType := range []string{"GTD", "MARKET"} {
+		msg := NewMsgNewOrders(addr, []OrderItem{
+			NewOrderItemWithType(TestTokenPair, BuyOrder, "10.0", "1.0", orderType),
+		})
+		require.NotNil(t, msg.ValidateBasic())
+	}
+}
+
+func TestMsgSetFeeSchedule(t *testing.T) {
+	owner := sdk.AccAddress("owner")
+	tiers := []FeeTier{
+		NewFeeTier(sdk.ZeroDec(), sdk.MustNewDecFromStr("0.001"), sdk.MustNewDecFromStr("0.002")),
+		NewFeeTier(sdk.NewDec(1000), sdk.MustNewDecFromStr("-0.0001"), sdk.MustNewDecFromStr("0.001")),
+	}
+	require.Nil(t, NewMsgSetFeeSchedule(owner, TestTokenPair, tiers, 100).ValidateBasic())
+	require.Nil(t, NewMsgSetFeeSchedule(owner, TestTokenPair, nil, 0).ValidateBasic())
+	require.NotNil(t, NewMsgSetFeeSchedule(nil, TestTokenPair, tiers, 100).ValidateBasic())
+	require.NotNil(t, NewMsgSetFeeSchedule(owner, "", tiers, 100).ValidateBasic())
+	require.NotNil(t, NewMsgSetFeeSchedule(owner, TestTokenPair, tiers, -1).ValidateBasic())
+
+	invalidTiers := []FeeTier{
+		// the first tier doesn't start from zero
+		NewFeeTier(sdk.NewDec(1), sdk.MustNewDecFromStr("0.001"), sdk.MustNewDecFromStr("0.002")),
+	}
+	require.NotNil(t, NewMsgSetFeeSchedule(owner, TestTokenPair, invalidTiers, 100).ValidateBasic())
+	invalidTiers = []FeeTier{tiers[1], tiers[0]}
+	require.NotNil(t, NewMsgSetFeeSchedule(owner, TestTokenPair, invalidTiers, 100).ValidateBasic())
+	invalidTiers = []FeeTier{
+		// the rebate is more than the taker fee
+		NewFeeTier(sdk.ZeroDec(), sdk.MustNewDecFromStr("-0.003"), sdk.MustNewDecFromStr("0.002")),
+	}
+	require.NotNil(t, NewMsgSetFeeSchedule(owner, TestTokenPair, invalidTiers, 100).ValidateBasic())
+}
+
+func TestMsgSetAuctionType(t *testing.T) {
+	owner := sdk.AccAddress("owner")
+	require.Nil(t, NewMsgSetAuctionType(owner, TestTokenPair, AuctionTypeContinuous).ValidateBasic())
+	require.Nil(t, NewMsgSetAuctionType(owner, TestTokenPair, AuctionTypePeriodic).ValidateBasic())
+	require.NotNil(t, NewMsgSetAuctionType(nil, TestTokenPair, AuctionTypeContinuous).ValidateBasic())
+	require.NotNil(t, NewMsgSetAuctionType(owner, "", AuctionTypeContinuous).ValidateBasic())
+	require.NotNil(t, NewMsgSetAuctionType(owner, TestTokenPair, "unknown").ValidateBasic())
+}
+
+func TestMsgAmendOrders(t *testing.T) {
+	addr := sdk.AccAddress("sender")
+	msg := NewMsgAmendOrders(addr, []AmendOrderItem{
+		NewAmendOrderItem("ID0000000010-1", "10.0", ""),
+		NewAmendOrderItem("ID0000000010-2", "", "2.0"),
+	})
+	require.Nil(t, msg.ValidateBasic())
+	require.EqualValues(t, "amend", msg.Type())
+	require.EqualValues(t, 2*10, msg.CalculateGas(10))
+
+	invalidMsgs := []MsgAmendOrders{
+		NewMsgAmendOrders(nil, msg.OrderItems),
+		NewMsgAmendOrders(addr, nil),
+		NewMsgAmendOrders(addr, []AmendOrderItem{NewAmendOrderItem("", "10.0", "")}),
+		NewMsgAmendOrders(addr, []AmendOrderItem{NewAmendOrderItem("ID0000000010-1", "", "")}),
+		NewMsgAmendOrders(addr, []AmendOrderItem{NewAmendOrderItem("ID0000000010-1", "-1.0", "")}),
+		NewMsgAmendOrders(addr, []AmendOrderItem{msg.OrderItems[0], msg.OrderItems[0]}),
+		// nil price & quantity are left unchanged, nothing to amend
+		NewMsgAmendOrders(addr, []AmendOrderItem{{OrderID: "ID0000000010-1"}}),
+	}
+	for _, invalid := range invalidMsgs {
+		require.NotNil(t, invalid.ValidateBasic())
+	}
+}
+
+func TestMsgCancelAllOrders(t *testing.T) {
+	addr := sdk.AccAddress("sender")
+	validMsgs := []MsgCancelAllOrders{
+		NewMsgCancelAllOrders(addr, "", ""),
+		NewMsgCancelAllOrders(addr, TestTokenPair, BuyOrder),
+	}
+	for _, msg := range validMsgs {
+		require.Nil(t, msg.ValidateBasic())
+		require.EqualValues(t, "cancel_all", msg.Type())
+	}
+
+	require.NotNil(t, NewMsgCancelAllOrders(nil, "", "").ValidateBasic())
+	require.NotNil(t, NewMsgCancelAllOrders(addr, "", "buy").ValidateBasic())
+}
+
+func TestMsgHeartbeat(t *testing.T) {
+	addr := sdk.AccAddress("sender")
+	require.Nil(t, NewMsgHeartbeat(addr, 100).ValidateBasic())
+	require.Nil(t, NewMsgHeartbeat(addr, 0).ValidateBasic())
+	require.EqualValues(t, "heartbeat", NewMsgHeartbeat(addr, 100).Type())
+
+	require.NotNil(t, NewMsgHeartbeat(nil, 100).ValidateBasic())
+	require.NotNil(t, NewMsgHeartbeat(addr, -1).ValidateBasic())
+	require.NotNil(t, NewMsgHeartbeat(addr, DeadManSwitchMinTimeoutBlocks-1).ValidateBasic())
+	require.NotNil(t, NewMsgHeartbeat(addr, DeadManSwitchMaxTimeoutBlocks+1).ValidateBasic())
+}
+
+func TestMsgHybridSwap(t *testing.T) {
+	addr := sdk.AccAddress("sender")
+	amount, minBought := sdk.NewDec(100), sdk.NewDec(9)
+	require.Nil(t, NewMsgHybridSwap(addr, TestTokenPair, BuyOrder, amount, minBought).ValidateBasic())
+	require.Nil(t, NewMsgHybridSwap(addr, TestTokenPair, SellOrder, amount, sdk.ZeroDec()).ValidateBasic())
+	require.EqualValues(t, "hybrid_swap", NewMsgHybridSwap(addr, TestTokenPair, BuyOrder, amount, minBought).Type())
+
+	require.NotNil(t, NewMsgHybridSwap(nil, TestTokenPair, BuyOrder, amount, minBought).ValidateBasic())
+	require.NotNil(t, NewMsgHybridSwap(addr, "xxb", BuyOrder, amount, minBought).ValidateBasic())
+	require.NotNil(t, NewMsgHybridSwap(addr, TestTokenPair, "NONE", amount, minBought).ValidateBasic())
+	require.NotNil(t, NewMsgHybridSwap(addr, TestTokenPair, BuyOrder, sdk.ZeroDec(), minBought).ValidateBasic())
+	require.NotNil(t, NewMsgHybridSwap(addr, TestTokenPair, BuyOrder, amount, sdk.NewDec(-1)).ValidateBasic())
+}
@@ -52,6 +52,31 @@ const (
 	//OrderStatusPartialFilled          = 6
 )
 
+// nolint : time in force of an order, an empty order type is treated as OrderTypeLimit
+const (
+	OrderTypeLimit    = "LIMIT"     // rests in the depth book until filled, cancelled or expired
+	OrderTypeIOC      = "IOC"       // immediate-or-cancel, the remainder is cancelled after matching
+	OrderTypeFOK      = "FOK"       // fill-or-kill, cancelled before matching if it can't be fully filled
+	OrderTypePostOnly = "POST_ONLY" // rejected if it would be filled as soon as it's placed
+)
+
+// nolint : reasons recorded when an order is closed by its order type or by the dead man's switch
+const (
+	CloseReasonIOCRemainder  = "cancelled: IOC remainder"
+	CloseReasonFOKUnfilled   = "cancelled: FOK not fully fillable"
+	CloseReasonDeadManSwitch = "cancelled: dead man's switch"
+)
+
+// IsValidOrderType checks whether the order type is supported
+func IsValidOrderType(orderType string) bool {
+	switch orderType {
+	case "", OrderTypeLimit, OrderTypeIOC, OrderTypeFOK, OrderTypePostOnly:
+		return true
+	default:
+		return false
+	}
+}
+
 // nolint
 const (
 	OrderExtraInfoKeyNewFee     = "newFee"
@@ -77,7 +102,10 @@ type Order struct {
 	Timestamp         int64          `json:"timestamp"`        // created timestamp
 	OrderExpireBlocks int64          `json:"order_expire_blocks"`
 	FeePerBlock       sdk.DecCoin    `json:"fee_per_block"`
-	ExtraInfo         string         `json:"extra_info"` // extra info of order in json format
+	ExtraInfo         string         `json:"extra_info"`             // extra info of order in json format
+	OrderType         string         `json:"order_type,omitempty"`   // time in force, see OrderTypeXXX
+	CloseReason       string         `json:"close_reason,omitempty"` // why the order was closed by its order type
+	TriggerID         string         `json:"trigger_id,omitempty"`   // the trigger order which placed this order
 }
 
 // nolint
@@ -104,6 +132,34 @@ func NewOrder(txHash string, sender sdk.AccAddress, product, side string, price,
 	return order
 }
 
+// GetOrderType returns the time in force of the order, OrderTypeLimit if not specified
+func (order *Order) GetOrderType() string {
+	if order.OrderType == "" {
+		return OrderTypeLimit
+	}
+	return order.OrderType
+}
+
+// IsImmediate checks whether the remainder of the order should be cancelled after matching
+func (order *Order) IsImmediate() bool {
+	switch order.GetOrderType() {
+	case OrderTypeIOC, OrderTypeFOK:
+		return true
+	default:
+		return false
+	}
+}
+
+// RemainderCloseReason returns the reason recorded when the remainder of an immediate order is cancelled
+func (order *Order) RemainderCloseReason() string {
+	switch order.GetOrderType() {
+	case OrderTypeFOK:
+		return CloseReasonFOKUnfilled
+	default:
+		return CloseReasonIOCRemainder
+	}
+}
+
 func (order *Order) String() string {
 	if orderJSON, err := json.Marshal(order); err != nil {
 		panic(err)
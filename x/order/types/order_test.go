@@ -166,3 +166,27 @@ func TestGetBlockHeightFromOrderID(t *testing.T) {
 	num = GetBlockHeightFromOrderID(orderID)
 	require.Equal(t, blockHeight, num)
 }
+
+func TestTriggerOrderIsTriggered(t *testing.T) {
+	trigger := &TriggerOrder{Side: SellOrder, TriggerType: TriggerTypeStopLoss, TriggerPrice: sdk.NewDec(10)}
+	require.True(t, trigger.IsTriggered(sdk.NewDec(9)))
+	require.True(t, trigger.IsTriggered(sdk.NewDec(10)))
+	require.False(t, trigger.IsTriggered(sdk.NewDec(11)))
+	require.False(t, trigger.IsTriggered(sdk.ZeroDec()))
+
+	trigger.TriggerType = TriggerTypeTakeProfit
+	require.False(t, trigger.IsTriggered(sdk.NewDec(9)))
+	require.True(t, trigger.IsTriggered(sdk.NewDec(11)))
+
+	trigger.Side = BuyOrder
+	require.True(t, trigger.IsTriggered(sdk.NewDec(9)))
+	require.False(t, trigger.IsTriggered(sdk.NewDec(11)))
+
+	trigger.TriggerType = TriggerTypeStopLoss
+	require.False(t, trigger.IsTriggered(sdk.NewDec(9)))
+	require.True(t, trigger.IsTriggered(sdk.NewDec(11)))
+
+	require.EqualValues(t, "TR0000000012", FormatTriggerID(12))
+	require.EqualValues(t, 12, GetSeqFromTriggerID(FormatTriggerID(12)))
+	require.EqualValues(t, 0, GetSeqFromTriggerID("ID0000000010-1"))
+}
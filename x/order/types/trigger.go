@@ -0,0 +1,152 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// nolint : the trigger types of a trigger order
+const (
+	TriggerTypeStopLoss   = "STOP_LOSS"   // triggered when the last price moves against the order side
+	TriggerTypeTakeProfit = "TAKE_PROFIT" // triggered when the last price moves in favor of the order side
+)
+
+// nolint : the directions the last price moves in to trigger an order, used to index the trigger orders
+const (
+	TriggerDirectionFall byte = 0
+	TriggerDirectionRise byte = 1
+)
+
+// TriggerOrderMaxPerAddress is the max number of the pending trigger orders of an account
+const TriggerOrderMaxPerAddress = 100
+
+// IsValidTriggerType checks whether the trigger type is supported
+func IsValidTriggerType(triggerType string) bool {
+	return triggerType == TriggerTypeStopLoss || triggerType == TriggerTypeTakeProfit
+}
+
+// IsValidTriggerOrderType checks whether a trigger order can be converted into the order type
+func IsValidTriggerOrderType(orderType string) bool {
+	switch orderType {
+	case "", OrderTypeLimit, OrderTypeIOC:
+		return true
+	default:
+		return false
+	}
+}
+
+// TriggerOrder is a conditional order which stays outside the depth book,
+// it's converted into a normal order once the last price of the product crosses the trigger price
+type TriggerOrder struct {
+	TriggerID         string         `json:"trigger_id"`          // trigger order id
+	TxHash            string         `json:"txhash"`              // txHash of the place trigger order tx
+	Sender            sdk.AccAddress `json:"sender"`              // trigger order maker address
+	Product           string         `json:"product"`             // product for trading pair
+	Side              string         `json:"side"`                // BUY/SELL
+	TriggerType       string         `json:"trigger_type"`        // STOP_LOSS/TAKE_PROFIT
+	TriggerPrice      sdk.Dec        `json:"trigger_price"`       // the last price which triggers the order
+	Price             sdk.Dec        `json:"price"`               // price of the order placed when triggered
+	Quantity          sdk.Dec        `json:"quantity"`            // quantity of the order placed when triggered
+	OrderType         string         `json:"order_type"`          // LIMIT/IOC
+	Timestamp         int64          `json:"timestamp"`           // created timestamp
+	OrderExpireBlocks int64          `json:"order_expire_blocks"` // used to calculate the locked fee
+	FeePerBlock       sdk.DecCoin    `json:"fee_per_block"`       // used to calculate the locked fee
+	ExpireHeight      int64          `json:"expire_height"`       // expired at the end of this block if pending
+}
+
+// nolint
+func NewTriggerOrder(txHash string, sender sdk.AccAddress, product, side, triggerType string,
+	triggerPrice, price, quantity sdk.Dec, orderType string, timestamp int64, orderExpireBlocks int64,
+	feePerBlock sdk.DecCoin) *TriggerOrder {
+	return &TriggerOrder{
+		TxHash:            txHash,
+		Sender:            sender,
+		Product:           product,
+		Side:              side,
+		TriggerType:       triggerType,
+		TriggerPrice:      triggerPrice,
+		Price:             price,
+		Quantity:          quantity,
+		OrderType:         orderType,
+		Timestamp:         timestamp,
+		OrderExpireBlocks: orderExpireBlocks,
+		FeePerBlock:       feePerBlock,
+	}
+}
+
+// Direction returns the direction the last price moves in to trigger the order.
+// A sell stop-loss and a buy take-profit are triggered when the price falls to the trigger price,
+// a sell take-profit and a buy stop-loss are triggered when the price rises to the trigger price.
+func (trigger *TriggerOrder) Direction() byte {
+	if (trigger.Side == SellOrder && trigger.TriggerType == TriggerTypeStopLoss) ||
+		(trigger.Side == BuyOrder && trigger.TriggerType == TriggerTypeTakeProfit) {
+		return TriggerDirectionFall
+	}
+	return TriggerDirectionRise
+}
+
+// IsTriggered checks whether the last price crosses the trigger price
+func (trigger *TriggerOrder) IsTriggered(lastPrice sdk.Dec) bool {
+	if !lastPrice.IsPositive() {
+		return false
+	}
+	if trigger.Direction() == TriggerDirectionFall {
+		return lastPrice.LTE(trigger.TriggerPrice)
+	}
+	return lastPrice.GTE(trigger.TriggerPrice)
+}
+
+// NeedLockCoins : the coins locked until the trigger order is triggered or cancelled
+func (trigger *TriggerOrder) NeedLockCoins() sdk.DecCoins {
+	if trigger.Side == BuyOrder {
+		token := strings.Split(trigger.Product, "_")[1]
+		return sdk.DecCoins{{Denom: token, Amount: trigger.Price.Mul(trigger.Quantity)}}
+	}
+	token := strings.Split(trigger.Product, "_")[0]
+	return sdk.DecCoins{{Denom: token, Amount: trigger.Quantity}}
+}
+
+// NeedLockFee : the fee locked until the trigger order is triggered or cancelled,
+// the same as the fee locked by the order placed when triggered
+func (trigger *TriggerOrder) NeedLockFee() sdk.DecCoins {
+	amount := trigger.FeePerBlock.Amount.Mul(sdk.NewDec(trigger.OrderExpireBlocks))
+	return sdk.DecCoins{sdk.NewDecCoinFromDec(trigger.FeePerBlock.Denom, amount)}
+}
+
+// nolint
+func (trigger *TriggerOrder) String() string {
+	triggerJSON, err := json.Marshal(trigger)
+	if err != nil {
+		panic(err)
+	}
+	return string(triggerJSON)
+}
+
+// GetOrderType returns the type of the order placed when triggered, OrderTypeLimit if not specified
+func (trigger *TriggerOrder) GetOrderType() string {
+	if trigger.OrderType == "" {
+		return OrderTypeLimit
+	}
+	return trigger.OrderType
+}
+
+// nolint
+func FormatTriggerID(seq uint64) string {
+	format := "TR%010d"
+	if seq > 9999999999 {
+		format = "TR%d"
+	}
+	return fmt.Sprintf(format, seq)
+}
+
+// nolint
+func GetSeqFromTriggerID(triggerID string) uint64 {
+	var seq uint64
+	if _, err := fmt.Sscanf(triggerID, "TR%d", &seq); err != nil {
+		return 0
+	}
+	return seq
+}
@@ -90,6 +90,25 @@ func (k Keeper) SetValidator(ctx sdk.Context, validator types.Validator) {
 	store := ctx.KVStore(k.storeKey)
 	bz := types.MustMarshalValidator(k.cdc, validator)
 	store.Set(types.GetValidatorKey(validator.OperatorAddress), bz)
+	k.setValidatorChanged(ctx, validator.OperatorAddress)
+}
+
+// setValidatorChanged marks the validator as changed in the current block
+func (k Keeper) setValidatorChanged(ctx sdk.Context, operator sdk.ValAddress) {
+	ctx.TransientStore(k.storeTKey).Set(types.GetChangedValidatorKey(operator), []byte{})
+}
+
+// GetChangedValidators gets the validators set or with power updated in the current block
+func (k Keeper) GetChangedValidators(ctx sdk.Context) (validators types.Validators) {
+	iterator := sdk.KVStorePrefixIterator(ctx.TransientStore(k.storeTKey), types.ChangedValidatorKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		validator, found := k.GetValidator(ctx, iterator.Key()[len(types.ChangedValidatorKey):])
+		if found {
+			validators = append(validators, validator)
+		}
+	}
+	return validators
 }
 
 // SetValidatorByConsAddr sets the operator address with the key of validator consensus pubkey
@@ -193,12 +212,14 @@ func (k Keeper) SetLastValidatorPower(ctx sdk.Context, operator sdk.ValAddress,
 	store := ctx.KVStore(k.storeKey)
 	bz := k.cdc.MustMarshalBinaryLengthPrefixed(power)
 	store.Set(types.GetLastValidatorPowerKey(operator), bz)
+	k.setValidatorChanged(ctx, operator)
 }
 
 // DeleteLastValidatorPower deletes the last validator power
 func (k Keeper) DeleteLastValidatorPower(ctx sdk.Context, operator sdk.ValAddress) {
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(types.GetLastValidatorPowerKey(operator))
+	k.setValidatorChanged(ctx, operator)
 }
 
 // LastValidatorsIterator returns an iterator for the consensus validators in the last block
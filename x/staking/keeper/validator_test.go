@@ -1 +1,27 @@
 package keeper
+
+import (
+	"testing"
+
+	"github.com/okex/okexchain/x/staking/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChangedValidators(t *testing.T) {
+	ctx, _, mockKeeper := CreateTestInput(t, false, SufficientInitBalance)
+	keeper := mockKeeper.Keeper
+	require.Empty(t, keeper.GetChangedValidators(ctx))
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{}, types.DefaultMinSelfDelegation)
+	keeper.SetValidator(ctx, validator)
+	keeper.SetLastValidatorPower(ctx, addrVals[1], 10)
+
+	changed := keeper.GetChangedValidators(ctx)
+	require.Equal(t, 1, len(changed))
+	require.Equal(t, validator.OperatorAddress, changed[0].OperatorAddress)
+
+	validator2 := types.NewValidator(addrVals[1], PKs[1], types.Description{}, types.DefaultMinSelfDelegation)
+	keeper.SetValidator(ctx, validator2)
+	keeper.DeleteLastValidatorPower(ctx, addrVals[1])
+	require.Equal(t, 2, len(keeper.GetChangedValidators(ctx)))
+}
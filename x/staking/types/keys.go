@@ -47,6 +47,9 @@ var (
 	// prefix key for vals info to enforce the update of validator-set
 	ValidatorAbandonedKey = []byte{0x60}
 
+	// prefix key for the validators changed in the current block, kept in the transient store
+	ChangedValidatorKey = []byte{0x70}
+
 	lenTime = len(sdk.FormatTimeBytes(time.Now()))
 )
 
@@ -80,6 +83,11 @@ func GetLastValidatorPowerKey(operator sdk.ValAddress) []byte {
 	return append(LastValidatorPowerKey, operator...)
 }
 
+// GetChangedValidatorKey gets the transient key marking the validator with address as changed in the current block
+func GetChangedValidatorKey(operatorAddr sdk.ValAddress) []byte {
+	return append(ChangedValidatorKey, operatorAddr.Bytes()...)
+}
+
 // GetValidatorQueueTimeKey gets the prefix for all unbonding delegations from a delegator
 func GetValidatorQueueTimeKey(timestamp time.Time) []byte {
 	bz := sdk.FormatTimeBytes(timestamp)
@@ -18,15 +18,17 @@ type Coordinator struct {
 	resultChan      chan Task
 	atomTaskTimeout int // In Million Second
 	logger          log.Logger
+	outbox          *Outbox
 }
 
-func NewCoordinator(logger log.Logger, taskCh chan *TaskWithData, resultCh chan Task, timeout int, engineMap map[EngineKind]types.IStreamEngine) *Coordinator {
+func NewCoordinator(logger log.Logger, taskCh chan *TaskWithData, resultCh chan Task, timeout int, engineMap map[EngineKind]types.IStreamEngine, outbox *Outbox) *Coordinator {
 	c := Coordinator{
 		logger:          logger,
 		taskChan:        taskCh,
 		resultChan:      resultCh,
 		atomTaskTimeout: timeout,
 		engineMap:       engineMap,
+		outbox:          outbox,
 	}
 	return &c
 }
@@ -92,6 +94,15 @@ func (c *Coordinator) run() {
 
 			}
 
+			// the data written by the engines is removed from the outbox
+			var doneKinds []Kind
+			for streamType, done := range task.DoneMap {
+				if done {
+					doneKinds = append(doneKinds, streamType)
+				}
+			}
+			c.outbox.Ack(task.Height, doneKinds...)
+
 			task.UpdatedAt = time.Now().Unix()
 			c.resultChan <- *task.Task
 
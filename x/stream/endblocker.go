@@ -114,7 +114,7 @@ func createStreamTaskWithData(ctx sdk.Context, s *Stream) *TaskWithData {
 			adata.SetData(ctx, s.orderKeeper, s.tokenKeeper, s.Cache)
 			data = adata
 		case EngineNotifyKind:
-			pBlock := pushservicetypes.NewRedisBlock()
+			pBlock := pushservicetypes.NewRedisBlock(s.depthSequencer)
 			pBlock.SetData(ctx, s.orderKeeper, s.tokenKeeper, s.dexKeeper, s.Cache)
 			data = pBlock
 		case EngineKlineKind:
@@ -124,15 +124,20 @@ func createStreamTaskWithData(ctx sdk.Context, s *Stream) *TaskWithData {
 			pulsarclient.InitTokenPairMap(ctx, s.dexKeeper)
 			data = pData
 		case EngineWebSocketKind:
-			websocket.InitialCache(ctx, s.orderKeeper, s.dexKeeper, s.logger)
+			websocket.InitialCache(ctx, s.orderKeeper, s.dexKeeper, s.swapKeeper, s.stakingKeeper, s.govKeeper, s.logger)
 			wsdata := websocket.NewPushData()
-			wsdata.SetData(ctx, s.orderKeeper, s.tokenKeeper, s.dexKeeper, s.Cache)
+			wsdata.SetData(ctx, s.orderKeeper, s.tokenKeeper, s.dexKeeper, s.swapKeeper, s.stakingKeeper, s.govKeeper,
+				s.Cache)
 			data = wsdata
 		}
 
 		sd.dataMap[streamKind] = data
 	}
 
+	if err := s.outbox.Put(ctx.BlockHeight(), sd.dataMap); err != nil {
+		s.logger.Error(fmt.Sprintf("createStreamTaskWithData: put data into outbox failed: %s", err.Error()))
+	}
+
 	return &sd
 }
 
@@ -173,6 +178,10 @@ func execute(sc Context) {
 			err := fmt.Errorf("stream unexpected exception, %+v", p1err)
 			panic(err)
 		case TaskPhase1NextActionJumpNextBlock:
+			// the block has been written by the engines
+			for kind := range sc.taskData.dataMap {
+				sc.stream.outbox.Ack(sc.blockHeight, kind)
+			}
 			return
 		default:
 			if p1Status != TaskPhase1NextActionNewTask {
@@ -23,11 +23,11 @@ type Keeper struct {
 }
 
 // nolint
-func NewKeeper(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dexKeeper types.DexKeeper, accountKeeper types.AccountKeeper, cdc *codec.Codec, logger log.Logger, cfg *config.Config, metrics *monitor.StreamMetrics) Keeper {
+func NewKeeper(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dexKeeper types.DexKeeper, accountKeeper types.AccountKeeper, swapKeeper types.SwapKeeper, stakingKeeper types.StakingKeeper, cdc *codec.Codec, logger log.Logger, cfg *config.Config, metrics *monitor.StreamMetrics) Keeper {
 	logger = logger.With("module", "stream")
 	k := Keeper{
 		metric: metrics,
-		stream: NewStream(orderKeeper, tokenKeeper, dexKeeper, cdc, logger, cfg),
+		stream: NewStream(orderKeeper, tokenKeeper, dexKeeper, swapKeeper, stakingKeeper, cdc, logger, cfg, metrics),
 	}
 	dexKeeper.SetObserverKeeper(k)
 	accountKeeper.SetObserverKeeper(k)
@@ -45,6 +45,11 @@ func (k Keeper) SyncTx(ctx sdk.Context, tx *auth.StdTx, txHash string, timestamp
 	}
 }
 
+// SetGovKeeper sets the gov keeper, whose proposals & votes are pushed by the websocket engine
+func (k Keeper) SetGovKeeper(govKeeper types.GovKeeper) {
+	k.stream.govKeeper = govKeeper
+}
+
 // GetMarketKeeper returns market keeper
 func (k Keeper) GetMarketKeeper() MarketKeeper {
 	return k.stream.marketKeeper
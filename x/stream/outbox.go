@@ -0,0 +1,173 @@
+package stream
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/stream/analyservice"
+	"github.com/okex/okexchain/x/stream/pulsarclient"
+	pushservicetypes "github.com/okex/okexchain/x/stream/pushservice/types"
+	"github.com/okex/okexchain/x/stream/types"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/cli"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	// FlagOutboxDir is the directory of the outbox db, which is the data directory of the node home by default
+	FlagOutboxDir = "stream.outbox_dir"
+
+	outboxDBName = "stream_outbox"
+)
+
+// outboxDataCreators creates the data of each kind persisted in the outbox to be unmarshaled into. The data of
+// websocket isn't persisted, since it's pushed through the events of the block
+var outboxDataCreators = map[Kind]func() types.IStreamData{
+	StreamMysqlKind:  func() types.IStreamData { return analyservice.NewDataAnalysis() },
+	StreamRedisKind:  func() types.IStreamData { return pushservicetypes.NewRedisBlock(nil) },
+	StreamPulsarKind: func() types.IStreamData { return pulsarclient.NewPulsarData() },
+}
+
+// GetOutboxDir returns the directory of the outbox db
+func GetOutboxDir() string {
+	if dir := viper.GetString(FlagOutboxDir); dir != "" {
+		return dir
+	}
+	return filepath.Join(viper.GetString(cli.HomeFlag), "data")
+}
+
+// Outbox persists the data of each block for every engine until the engine acknowledges it, so that the data which
+// isn't written before the node stops could be replayed into the engine
+type Outbox struct {
+	db dbm.DB
+}
+
+// NewOutbox opens the outbox db in the directory
+func NewOutbox(dir string) (*Outbox, error) {
+	db, err := sdk.NewLevelDB(outboxDBName, dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Outbox{db: db}, nil
+}
+
+// Close closes the outbox db
+func (o *Outbox) Close() {
+	o.db.Close()
+}
+
+func outboxKey(kind Kind, height int64) []byte {
+	return append([]byte{byte(kind)}, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// Put persists the data of the engines for the block, the nil outbox persists nothing
+func (o *Outbox) Put(height int64, dataMap map[Kind]types.IStreamData) error {
+	if o == nil {
+		return nil
+	}
+	batch := o.db.NewBatch()
+	defer batch.Close()
+	for kind, data := range dataMap {
+		if _, ok := outboxDataCreators[kind]; !ok || data == nil {
+			continue
+		}
+		bz, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		batch.Set(outboxKey(kind, height), bz)
+	}
+	batch.WriteSync()
+	return nil
+}
+
+// Ack removes the data of the block acknowledged by the engines of the kinds
+func (o *Outbox) Ack(height int64, kinds ...Kind) {
+	if o == nil {
+		return
+	}
+	batch := o.db.NewBatch()
+	defer batch.Close()
+	for _, kind := range kinds {
+		batch.Delete(outboxKey(kind, height))
+	}
+	batch.WriteSync()
+}
+
+// Get returns the data of the block for the engine of the kind, which is false if it has been acknowledged
+func (o *Outbox) Get(height int64, kind Kind) (data types.IStreamData, ok bool, err error) {
+	creator, ok := outboxDataCreators[kind]
+	if !ok {
+		return nil, false, fmt.Errorf("the data of stream kind %d isn't persisted in the outbox", kind)
+	}
+	bz := o.db.Get(outboxKey(kind, height))
+	if bz == nil {
+		return nil, false, nil
+	}
+	data = creator()
+	if err = json.Unmarshal(bz, data); err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// PendingHeights returns the heights of the data not acknowledged by the engine of the kind in order
+func (o *Outbox) PendingHeights(kind Kind) (heights []int64) {
+	iterator := dbm.IteratePrefix(o.db, []byte{byte(kind)})
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		heights = append(heights, int64(binary.BigEndian.Uint64(iterator.Key()[1:])))
+	}
+	return heights
+}
+
+// logPending logs the heights not acknowledged of each engine, which are replayed by the stream replay command
+func (o *Outbox) logPending(logger log.Logger) {
+	for kind := range outboxDataCreators {
+		if heights := o.PendingHeights(kind); len(heights) > 0 {
+			logger.Error("the stream data of the blocks isn't acknowledged by the engine, replay it to recover",
+				"stream", kind, "count", len(heights), "first", heights[0], "last", heights[len(heights)-1])
+		}
+	}
+}
+
+// ReplayOutbox writes the data of the blocks in [from, to] in the outbox into the engine of the kind in order, the
+// data is acknowledged after it's written. It stops at the first block failed to be written, and returns the
+// heights replayed
+func ReplayOutbox(outbox *Outbox, kind Kind, engine types.IStreamEngine, from, to int64,
+	logger log.Logger) (replayed []int64, err error) {
+	if _, ok := outboxDataCreators[kind]; !ok {
+		return nil, fmt.Errorf("the data of stream kind %d isn't persisted in the outbox", kind)
+	}
+	for _, height := range outbox.PendingHeights(kind) {
+		if height < from || height > to {
+			continue
+		}
+		data, _, err := outbox.Get(height, kind)
+		if err != nil {
+			return replayed, err
+		}
+
+		success := false
+		func() {
+			defer func() {
+				if e := recover(); e != nil {
+					logger.Error(fmt.Sprintf("replay panic: %+v", e))
+					success = false
+				}
+			}()
+			engine.Write(data, &success)
+		}()
+		if !success {
+			return replayed, fmt.Errorf("failed to write the data of block %d into the engine", height)
+		}
+		outbox.Ack(height, kind)
+		replayed = append(replayed, height)
+		logger.Info("stream data replayed", "height", height)
+	}
+	return replayed, nil
+}
@@ -0,0 +1,131 @@
+package stream
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/okex/okexchain/x/backend"
+	backendtypes "github.com/okex/okexchain/x/backend/types"
+	"github.com/okex/okexchain/x/stream/analyservice"
+	"github.com/okex/okexchain/x/stream/pulsarclient"
+	pushservicetypes "github.com/okex/okexchain/x/stream/pushservice/types"
+	"github.com/okex/okexchain/x/stream/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// mockOutboxEngine records the heights written, and fails to write the height of failHeight
+type mockOutboxEngine struct {
+	heights    []int64
+	failHeight int64
+}
+
+func (e *mockOutboxEngine) URL() string {
+	return ""
+}
+
+func (e *mockOutboxEngine) Write(data types.IStreamData, success *bool) {
+	if data.BlockHeight() == e.failHeight {
+		*success = false
+		return
+	}
+	e.heights = append(e.heights, data.BlockHeight())
+	*success = true
+}
+
+func newTestOutboxDataMap(height int64) map[Kind]types.IStreamData {
+	analysisData := analyservice.NewDataAnalysis()
+	analysisData.Height = height
+	analysisData.Deals = []*backend.Deal{{BlockHeight: height, OrderID: "ID1", Product: "xxb_okt",
+		Price: backendtypes.MustNewDecimalFromStr("1.5"), Quantity: backendtypes.MustNewDecimalFromStr("2")}}
+	redisBlock := pushservicetypes.NewRedisBlock(nil)
+	redisBlock.Height = height
+	redisBlock.DepthBooksMap["xxb_okt"] = pushservicetypes.BookRes{Product: "xxb_okt", Sequence: 1}
+	return map[Kind]types.IStreamData{
+		StreamMysqlKind:     analysisData,
+		StreamRedisKind:     redisBlock,
+		StreamPulsarKind:    &pulsarclient.PulsarData{Height: height},
+		StreamWebSocketKind: &pulsarclient.PulsarData{Height: height},
+	}
+}
+
+func TestOutbox(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stream_outbox")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	outbox, err := NewOutbox(dir)
+	require.Nil(t, err)
+	defer outbox.Close()
+
+	for height := int64(1); height <= 3; height++ {
+		require.Nil(t, outbox.Put(height, newTestOutboxDataMap(height)))
+	}
+	require.Equal(t, []int64{1, 2, 3}, outbox.PendingHeights(StreamMysqlKind))
+	require.Equal(t, []int64{1, 2, 3}, outbox.PendingHeights(StreamRedisKind))
+	// the data of websocket isn't persisted
+	require.Empty(t, outbox.PendingHeights(StreamWebSocketKind))
+	_, _, err = outbox.Get(1, StreamWebSocketKind)
+	require.NotNil(t, err)
+
+	// the data is unmarshaled into the type of the kind
+	data, ok, err := outbox.Get(2, StreamMysqlKind)
+	require.Nil(t, err)
+	require.True(t, ok)
+	analysisData := data.(*analyservice.DataAnalysis)
+	require.Equal(t, int64(2), analysisData.Height)
+	require.Equal(t, "1.5", analysisData.Deals[0].Price.String())
+	data, ok, err = outbox.Get(2, StreamRedisKind)
+	require.Nil(t, err)
+	require.True(t, ok)
+	require.Equal(t, int64(1), data.(*pushservicetypes.RedisBlock).DepthBooksMap["xxb_okt"].Sequence)
+	data, ok, err = outbox.Get(2, StreamPulsarKind)
+	require.Nil(t, err)
+	require.True(t, ok)
+	require.Equal(t, int64(2), data.BlockHeight())
+
+	// the data acknowledged is removed
+	outbox.Ack(1, StreamMysqlKind, StreamRedisKind)
+	require.Equal(t, []int64{2, 3}, outbox.PendingHeights(StreamMysqlKind))
+	require.Equal(t, []int64{1, 2, 3}, outbox.PendingHeights(StreamPulsarKind))
+	_, ok, err = outbox.Get(1, StreamMysqlKind)
+	require.Nil(t, err)
+	require.False(t, ok)
+
+	// the nil outbox does nothing
+	var nilOutbox *Outbox
+	require.Nil(t, nilOutbox.Put(1, newTestOutboxDataMap(1)))
+	nilOutbox.Ack(1, StreamMysqlKind)
+}
+
+func TestReplayOutbox(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stream_outbox")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	outbox, err := NewOutbox(dir)
+	require.Nil(t, err)
+	defer outbox.Close()
+	for height := int64(1); height <= 5; height++ {
+		require.Nil(t, outbox.Put(height, newTestOutboxDataMap(height)))
+	}
+	logger := log.NewNopLogger()
+
+	// the replay stops at the block failed
+	engine := &mockOutboxEngine{failHeight: 4}
+	replayed, err := ReplayOutbox(outbox, StreamRedisKind, engine, 2, 5, logger)
+	require.NotNil(t, err)
+	require.Equal(t, []int64{2, 3}, replayed)
+	require.Equal(t, []int64{2, 3}, engine.heights)
+	require.Equal(t, []int64{1, 4, 5}, outbox.PendingHeights(StreamRedisKind))
+	require.Equal(t, []int64{1, 2, 3, 4, 5}, outbox.PendingHeights(StreamMysqlKind))
+
+	// it's run again after the engine recovers
+	engine.failHeight = 0
+	replayed, err = ReplayOutbox(outbox, StreamRedisKind, engine, 1, 5, logger)
+	require.Nil(t, err)
+	require.Equal(t, []int64{1, 4, 5}, replayed)
+	require.Empty(t, outbox.PendingHeights(StreamRedisKind))
+
+	_, err = ReplayOutbox(outbox, StreamWebSocketKind, engine, 1, 5, logger)
+	require.NotNil(t, err)
+}
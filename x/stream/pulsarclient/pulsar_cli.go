@@ -76,12 +76,13 @@ func (p *PulsarProducer) SendAllMsg(data *PulsarData, logger log.Logger) (map[st
 			}
 
 			timestamp := matchResult.Timestamp * 1000
+			quantity, price := matchResult.Quantity.Float64(), matchResult.Price.Float64()
 			matchResultMsg := MatchResultMsg{
 				BizType:        &bizType,
 				MarketId:       &marketID,
 				MarketType:     &marketType,
-				Size:           &matchResult.Quantity,
-				Price:          &matchResult.Price,
+				Size:           &quantity,
+				Price:          &price,
 				CreatedTime:    &timestamp,
 				InstrumentId:   &marketID,
 				InstrumentName: &matchResult.Product,
@@ -101,7 +102,7 @@ func (p *PulsarProducer) SendAllMsg(data *PulsarData, logger log.Logger) (map[st
 				errChan <- err
 				return
 			}
-			logger.Debug(fmt.Sprintf("successfully send matchResult [marketId:%d, CreatedTime:%s, BlockHeight:%d, Quantity:%f, Price:%f, InstrumentName:%s]",
+			logger.Debug(fmt.Sprintf("successfully send matchResult [marketId:%d, CreatedTime:%s, BlockHeight:%d, Quantity:%s, Price:%s, InstrumentName:%s]",
 				marketID, time.Unix(matchResult.Timestamp, 0).Format("2006-01-02 15:04:05"), matchResult.BlockHeight, matchResult.Quantity, matchResult.Price, matchResult.Product))
 
 			// b, _ := json.Marshal(matchResultMsg) //removed in production,
@@ -50,8 +50,8 @@ func TestSendMsg(t *testing.T) {
 		results10 = append(results10, &backend.MatchResult{
 			BlockHeight: int64(i),
 			Product:     "gyl_" + common.NativeToken,
-			Price:       rand.Float64(),
-			Quantity:    rand.Float64(),
+			Price:       backend.NewDecimalFromFloat(rand.Float64()),
+			Quantity:    backend.NewDecimalFromFloat(rand.Float64()),
 			Timestamp:   timestamp,
 		})
 	}
@@ -70,8 +70,8 @@ func TestSendMsg(t *testing.T) {
 		results10 = append(results10, &backend.MatchResult{
 			BlockHeight: int64(i),
 			Product:     common.TestToken + common.NativeToken,
-			Price:       rand.Float64(),
-			Quantity:    rand.Float64(),
+			Price:       backend.NewDecimalFromFloat(rand.Float64()),
+			Quantity:    backend.NewDecimalFromFloat(rand.Float64()),
 			Timestamp:   timestamp,
 		})
 	}
@@ -1,6 +1,8 @@
 package pulsarclient
 
 import (
+	"encoding/json"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/okexchain/x/backend"
 	"github.com/okex/okexchain/x/dex"
@@ -36,3 +38,29 @@ func (p PulsarData) BlockHeight() int64 {
 func (p PulsarData) DataType() types.StreamDataKind {
 	return types.StreamDataKlineKind
 }
+
+// pulsarDataJSON is the json of PulsarData, in which the data is persisted in the outbox of the stream
+type pulsarDataJSON struct {
+	Height        int64                  `json:"height"`
+	MatchResults  []*backend.MatchResult `json:"match_results"`
+	NewTokenPairs []*dex.TokenPair       `json:"new_token_pairs"`
+}
+
+// MarshalJSON marshals the data with the unexported fields
+func (p PulsarData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pulsarDataJSON{
+		Height:        p.Height,
+		MatchResults:  p.matchResults,
+		NewTokenPairs: p.newTokenPairs,
+	})
+}
+
+// UnmarshalJSON unmarshals the data with the unexported fields
+func (p *PulsarData) UnmarshalJSON(bz []byte) error {
+	var data pulsarDataJSON
+	if err := json.Unmarshal(bz, &data); err != nil {
+		return err
+	}
+	p.Height, p.matchResults, p.newTokenPairs = data.Height, data.MatchResults, data.NewTokenPairs
+	return nil
+}
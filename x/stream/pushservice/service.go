@@ -71,7 +71,7 @@ func (p PushService) WriteSync(b *types.RedisBlock) (map[string]int, error) {
 		result["depth"] += len(val.Asks) + len(val.Bids)
 	}
 	for k, v := range b.DepthBooksMap {
-		if err := p.setDepthSnapshot(k, v); err != nil {
+		if err := p.setDepthSnapshot(k, v, b.DepthUpdatesMap[k]); err != nil {
 			return result, fmt.Errorf("setDepthSnapshot failed, %s", err.Error())
 		}
 	}
@@ -140,20 +140,24 @@ func (p PushService) setMatches(product string, matches backend.MatchResult) err
 	return p.client.PublicPub(key1, string(value))
 }
 
-// setDepthSnapshot push depth to public channel
-func (p PushService) setDepthSnapshot(product string, depth types.BookRes) error {
-	value, err := json.Marshal(depth)
+// setDepthSnapshot sets the depth snapshot to resync from, and push the incremental update to public channel
+func (p PushService) setDepthSnapshot(product string, snapshot, update types.BookRes) error {
+	snapshotValue, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	updateValue, err := json.Marshal(update)
 	if err != nil {
 		return err
 	}
 	key1 := channels.GetSpotDepthKey(product)
 	key2 := channels.GetCSpotDepthKey(product)
-	p.log.Debug("setDepthSnapshot_pub", "key", key1, "value", string(value))
-	p.log.Debug("setDepthSnapshot_set", "key", key2, "value", string(value))
-	if err := p.client.Set(key2, string(value)); err != nil {
+	p.log.Debug("setDepthSnapshot_pub", "key", key1, "value", string(updateValue))
+	p.log.Debug("setDepthSnapshot_set", "key", key2, "value", string(snapshotValue))
+	if err := p.client.Set(key2, string(snapshotValue)); err != nil {
 		return err
 	}
-	return p.client.DepthPub(key1, string(value))
+	return p.client.DepthPub(key1, string(updateValue))
 }
 
 // setInstruments push instruments to
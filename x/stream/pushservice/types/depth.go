@@ -0,0 +1,106 @@
+package types
+
+import (
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strings"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DepthChecksumLevels is the number of the top levels of asks & bids in the checksum of a depth book
+const DepthChecksumLevels = 25
+
+// DepthChecksum returns the crc32 of the top levels of the depth book, as a signed int32. The levels are interleaved
+// as bid1:ask1:bid2:ask2..., each of which is price:quantity, and the missing side of a level is skipped
+func DepthChecksum(asks, bids [][]string) int32 {
+	var items []string
+	for i := 0; i < DepthChecksumLevels; i++ {
+		if i < len(bids) {
+			items = append(items, fmt.Sprintf("%s:%s", bids[i][0], bids[i][1]))
+		}
+		if i < len(asks) {
+			items = append(items, fmt.Sprintf("%s:%s", asks[i][0], asks[i][1]))
+		}
+	}
+	return int32(crc32.ChecksumIEEE([]byte(strings.Join(items, ":"))))
+}
+
+// DepthSequencer keeps the last depth book of each product, and assigns a sequence to each change of it. The sequence
+// of a product starts from 1 and increases by 1 with each update, the prev_sequence of an update is the sequence it is
+// applied to. It restarts from 1 after the node restarts, which is detected by the prev_sequence as well
+type DepthSequencer struct {
+	lock  sync.RWMutex
+	books map[string]BookRes
+}
+
+// NewDepthSequencer creates a depth sequencer without any depth book
+func NewDepthSequencer() *DepthSequencer {
+	return &DepthSequencer{books: make(map[string]BookRes)}
+}
+
+// Next sequences the depth book of the product at the height. It returns the book as the new snapshot with the
+// incremental update from the last one, whose levels removed are with the quantity of 0. It returns false without any
+// change if the book is the same as the last one
+func (s *DepthSequencer) Next(book BookRes, height int64) (snapshot, update BookRes, changed bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	last, ok := s.books[book.Product]
+	asks := diffDepthLevels(last.Asks, book.Asks, false)
+	bids := diffDepthLevels(last.Bids, book.Bids, true)
+	if ok && len(asks) == 0 && len(bids) == 0 {
+		return snapshot, update, false
+	}
+
+	snapshot = book
+	snapshot.PrevSequence = last.Sequence
+	snapshot.Sequence = last.Sequence + 1
+	snapshot.BlockHeight = height
+	snapshot.Checksum = DepthChecksum(book.Asks, book.Bids)
+	s.books[book.Product] = snapshot
+
+	update = snapshot
+	update.Asks, update.Bids = asks, bids
+	return snapshot, update, true
+}
+
+// Snapshot returns the last depth book of the product
+func (s *DepthSequencer) Snapshot(product string) (snapshot BookRes, ok bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	snapshot, ok = s.books[product]
+	return snapshot, ok
+}
+
+// diffDepthLevels returns the levels of the new side changed from the old one, sorted as the side of the book
+func diffDepthLevels(oldLevels, newLevels [][]string, desc bool) [][]string {
+	oldMap := make(map[string][]string, len(oldLevels))
+	for _, level := range oldLevels {
+		oldMap[level[0]] = level
+	}
+
+	changes := [][]string{}
+	for _, level := range newLevels {
+		oldLevel, ok := oldMap[level[0]]
+		delete(oldMap, level[0])
+		if ok && oldLevel[1] == level[1] && oldLevel[2] == level[2] {
+			continue
+		}
+		changes = append(changes, level)
+	}
+	for price := range oldMap {
+		changes = append(changes, []string{price, "0", "0"})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		pi, pj := sdk.MustNewDecFromStr(changes[i][0]), sdk.MustNewDecFromStr(changes[j][0])
+		if desc {
+			return pi.GT(pj)
+		}
+		return pi.LT(pj)
+	})
+	return changes
+}
@@ -0,0 +1,106 @@
+package types
+
+import (
+	"hash/crc32"
+	"sort"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+// applyDepthLevels applies the levels of an update to a side of the book as a client does
+func applyDepthLevels(levels, changes [][]string, desc bool) [][]string {
+	levelMap := make(map[string][]string)
+	for _, level := range levels {
+		levelMap[level[0]] = level
+	}
+	for _, change := range changes {
+		if sdk.MustNewDecFromStr(change[1]).IsZero() {
+			delete(levelMap, change[0])
+		} else {
+			levelMap[change[0]] = change
+		}
+	}
+	result := [][]string{}
+	for _, level := range levelMap {
+		result = append(result, level)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		pi, pj := sdk.MustNewDecFromStr(result[i][0]), sdk.MustNewDecFromStr(result[j][0])
+		if desc {
+			return pi.GT(pj)
+		}
+		return pi.LT(pj)
+	})
+	return result
+}
+
+func TestDepthChecksum(t *testing.T) {
+	asks := [][]string{{"10.1", "1", "1"}, {"10.2", "2", "1"}}
+	bids := [][]string{{"9.9", "3", "2"}}
+	expected := int32(crc32.ChecksumIEEE([]byte("9.9:3:10.1:1:10.2:2")))
+	require.Equal(t, expected, DepthChecksum(asks, bids))
+
+	// only the top levels are in the checksum
+	var manyAsks [][]string
+	for i := 0; i < DepthChecksumLevels+5; i++ {
+		manyAsks = append(manyAsks, []string{sdk.NewDec(int64(i + 1)).String(), "1", "1"})
+	}
+	require.Equal(t, DepthChecksum(manyAsks[:DepthChecksumLevels], nil), DepthChecksum(manyAsks, nil))
+	require.NotEqual(t, DepthChecksum(manyAsks[:DepthChecksumLevels-1], nil), DepthChecksum(manyAsks, nil))
+}
+
+func TestDepthSequencer(t *testing.T) {
+	sequencer := NewDepthSequencer()
+	_, ok := sequencer.Snapshot("xxb_okt")
+	require.False(t, ok)
+
+	// the first update is the full book
+	book := BookRes{
+		Asks:    [][]string{{"10.1", "1", "1"}, {"10.2", "2", "1"}},
+		Bids:    [][]string{{"9.9", "3", "2"}, {"9.8", "4", "1"}},
+		Product: "xxb_okt",
+	}
+	snapshot, update, changed := sequencer.Next(book, 10)
+	require.True(t, changed)
+	require.Equal(t, int64(1), snapshot.Sequence)
+	require.Equal(t, int64(0), snapshot.PrevSequence)
+	require.Equal(t, int64(10), snapshot.BlockHeight)
+	require.Equal(t, DepthChecksum(book.Asks, book.Bids), snapshot.Checksum)
+	require.Equal(t, book.Asks, update.Asks)
+	require.Equal(t, book.Bids, update.Bids)
+	cached, ok := sequencer.Snapshot("xxb_okt")
+	require.True(t, ok)
+	require.Equal(t, snapshot, cached)
+
+	// the same book isn't sequenced again
+	_, _, changed = sequencer.Next(book, 11)
+	require.False(t, changed)
+
+	// the update has the levels changed, added & removed
+	newBook := BookRes{
+		Asks:    [][]string{{"10.05", "5", "1"}, {"10.2", "2", "1"}},
+		Bids:    [][]string{{"9.9", "1", "1"}, {"9.8", "4", "1"}, {"9.7", "6", "3"}},
+		Product: "xxb_okt",
+	}
+	newSnapshot, update, changed := sequencer.Next(newBook, 12)
+	require.True(t, changed)
+	require.Equal(t, int64(2), newSnapshot.Sequence)
+	require.Equal(t, snapshot.Sequence, update.PrevSequence)
+	require.Equal(t, int64(12), update.BlockHeight)
+	require.Equal(t, [][]string{{"10.05", "5", "1"}, {"10.1", "0", "0"}}, update.Asks)
+	require.Equal(t, [][]string{{"9.9", "1", "1"}, {"9.7", "6", "3"}}, update.Bids)
+
+	// the client gets the same book by applying the update to the snapshot, which matches the checksum
+	asks := applyDepthLevels(snapshot.Asks, update.Asks, false)
+	bids := applyDepthLevels(snapshot.Bids, update.Bids, true)
+	require.Equal(t, newBook.Asks, asks)
+	require.Equal(t, newBook.Bids, bids)
+	require.Equal(t, update.Checksum, DepthChecksum(asks, bids))
+
+	// the products are sequenced separately
+	snapshot, _, changed = sequencer.Next(BookRes{Product: "aab_okt"}, 12)
+	require.True(t, changed)
+	require.Equal(t, int64(1), snapshot.Sequence)
+}
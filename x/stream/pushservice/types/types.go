@@ -22,24 +22,31 @@ type Writer interface {
 }
 
 type RedisBlock struct {
-	Height        int64                      `json:"height"`     // blockHeight
-	OrdersMap     map[string][]backend.Order `json:"orders"`     // key: address
-	DepthBooksMap map[string]BookRes         `json:"depthBooks"` // key: product
+	Height          int64                      `json:"height"`       // blockHeight
+	OrdersMap       map[string][]backend.Order `json:"orders"`       // key: address
+	DepthBooksMap   map[string]BookRes         `json:"depthBooks"`   // key: product, the sequenced snapshots
+	DepthUpdatesMap map[string]BookRes         `json:"depthUpdates"` // key: product, the incremental updates
 
 	AccountsMap map[string]token.CoinInfo      `json:"accounts"`    // key: instrument_id:<address>
 	Instruments map[string]struct{}            `json:"instruments"` // P3K:spot:instruments
 	MatchesMap  map[string]backend.MatchResult `json:"matches"`     // key: product
+
+	depthSequencer *DepthSequencer
 }
 
-func NewRedisBlock() *RedisBlock {
+// NewRedisBlock creates an empty block, whose depth books are sequenced by the depth sequencer
+func NewRedisBlock(depthSequencer *DepthSequencer) *RedisBlock {
 	return &RedisBlock{
-		Height:        -1,
-		OrdersMap:     make(map[string][]backend.Order),
-		DepthBooksMap: make(map[string]BookRes),
+		Height:          -1,
+		OrdersMap:       make(map[string][]backend.Order),
+		DepthBooksMap:   make(map[string]BookRes),
+		DepthUpdatesMap: make(map[string]BookRes),
 
 		AccountsMap: make(map[string]token.CoinInfo),
 		Instruments: make(map[string]struct{}),
 		MatchesMap:  make(map[string]backend.MatchResult),
+
+		depthSequencer: depthSequencer,
 	}
 }
 func (rb RedisBlock) String() string {
@@ -68,7 +75,7 @@ func (rb *RedisBlock) storeInstrumentsWhenChanged(ctx sdk.Context, isTokenPairCh
 }
 
 func (rb *RedisBlock) Empty() bool {
-	if rb.Height == -1 && len(rb.DepthBooksMap) == 0 &&
+	if rb.Height == -1 && len(rb.DepthBooksMap) == 0 && len(rb.DepthUpdatesMap) == 0 &&
 		len(rb.OrdersMap) == 0 && len(rb.AccountsMap) == 0 &&
 		len(rb.Instruments) == 0 && len(rb.MatchesMap) == 0 {
 		return true
@@ -80,6 +87,7 @@ func (rb *RedisBlock) Clear() {
 	rb.Height = -1
 	rb.OrdersMap = make(map[string][]backend.Order)
 	rb.DepthBooksMap = make(map[string]BookRes)
+	rb.DepthUpdatesMap = make(map[string]BookRes)
 	rb.Instruments = make(map[string]struct{})
 	rb.AccountsMap = make(map[string]token.CoinInfo)
 	rb.MatchesMap = make(map[string]backend.MatchResult)
@@ -157,11 +165,17 @@ type BookResItem struct {
 	OrderCount string `json:"order_count"`
 }
 
+// BookRes is the depth book of a product, or the incremental update of it. The checksum is of the top levels of the
+// full book after the update, see DepthChecksum
 type BookRes struct {
-	Asks      [][]string `json:"asks"`
-	Bids      [][]string `json:"bids"`
-	Product   string     `json:"instrument_id"`
-	Timestamp string     `json:"timestamp"`
+	Asks         [][]string `json:"asks"`
+	Bids         [][]string `json:"bids"`
+	Product      string     `json:"instrument_id"`
+	Timestamp    string     `json:"timestamp"`
+	Sequence     int64      `json:"sequence"`
+	PrevSequence int64      `json:"prev_sequence"`
+	BlockHeight  int64      `json:"block_height"`
+	Checksum     int32      `json:"checksum"`
 }
 
 func (bri *BookResItem) toJSONList() []string {
@@ -181,8 +195,13 @@ func (rb *RedisBlock) storeDepthBooks(ctx sdk.Context, orderKeeper types.OrderKe
 	for _, product := range products {
 		depthBook := orderKeeper.GetDepthBookCopy(product)
 		bookRes := ConvertBookRes(product, orderKeeper, depthBook, size)
-		rb.DepthBooksMap[product] = bookRes
-		logger.Debug("storeDepthBooks", "product", product, "depthBook", bookRes)
+		snapshot, update, changed := rb.depthSequencer.Next(bookRes, rb.Height)
+		if !changed {
+			continue
+		}
+		rb.DepthBooksMap[product] = snapshot
+		rb.DepthUpdatesMap[product] = update
+		logger.Debug("storeDepthBooks", "product", product, "depthBook", snapshot, "update", update)
 	}
 }
 
@@ -213,10 +232,10 @@ func ConvertBookRes(product string, orderKeeper types.OrderKeeper, depthBook *or
 	}
 
 	bookRes := BookRes{
-		asks,
-		bids,
-		product,
-		time.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
+		Asks:      asks,
+		Bids:      bids,
+		Product:   product,
+		Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
 	}
 	return bookRes
 }
@@ -14,8 +14,10 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 
 	"github.com/okex/okexchain/x/backend"
+	"github.com/okex/okexchain/x/common/monitor"
 	"github.com/okex/okexchain/x/stream/common"
 	"github.com/okex/okexchain/x/stream/pushservice"
+	pushservicetypes "github.com/okex/okexchain/x/stream/pushservice/types"
 	"github.com/okex/okexchain/x/stream/types"
 	"github.com/tendermint/tendermint/libs/log"
 )
@@ -32,6 +34,9 @@ type Stream struct {
 	tokenKeeper    types.TokenKeeper    // The reference to the TokenKeeper to get fee details
 	marketKeeper   backend.MarketKeeper // The reference to MarketKeeper to get ticker/klines
 	dexKeeper      types.DexKeeper
+	swapKeeper     types.SwapKeeper
+	stakingKeeper  types.StakingKeeper
+	govKeeper      types.GovKeeper
 	cdc            *codec.Codec // The wire codec for binary encoding/decoding.
 	logger         log.Logger
 	engines        map[EngineKind]types.IStreamEngine
@@ -46,19 +51,27 @@ type Stream struct {
 	coordinator     *Coordinator
 	cacheQueue      *CacheQueue
 	cfg             *appCfg.StreamConfig
+	// the sequencer of the depth books pushed by the notify engine
+	depthSequencer *pushservicetypes.DepthSequencer
+	// the data of the blocks not acknowledged by the engines
+	outbox *Outbox
 }
 
-func NewStream(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dexKeeper types.DexKeeper, cdc *codec.Codec, logger log.Logger, cfg *appCfg.Config) *Stream {
+func NewStream(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dexKeeper types.DexKeeper, swapKeeper types.SwapKeeper, stakingKeeper types.StakingKeeper, cdc *codec.Codec, logger log.Logger, cfg *appCfg.Config, metrics *monitor.StreamMetrics) *Stream {
 
 	logger.Info("entering NewStreamEngine")
 
 	se := &Stream{
-		orderKeeper: orderKeeper,
-		tokenKeeper: tokenKeeper,
-		dexKeeper:   dexKeeper,
-		cdc:         cdc,
-		logger:      logger,
-		Cache:       common.NewCache(),
+		orderKeeper:   orderKeeper,
+		tokenKeeper:   tokenKeeper,
+		dexKeeper:     dexKeeper,
+		swapKeeper:    swapKeeper,
+		stakingKeeper: stakingKeeper,
+		cdc:           cdc,
+		logger:        logger,
+		Cache:         common.NewCache(),
+
+		depthSequencer: pushservicetypes.NewDepthSequencer(),
 	}
 	// read config
 	se.cfg = cfg.StreamConfig
@@ -122,7 +135,15 @@ func NewStream(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dex
 	se.taskChan = make(chan *TaskWithData, 1)
 	se.resultChan = make(chan Task, 1)
 	se.distrLatestTask = nil
-	se.coordinator = NewCoordinator(logger, se.taskChan, se.resultChan, distributeLockTimeout, se.engines)
+	outbox, err := NewOutbox(GetOutboxDir())
+	if err != nil {
+		errStr := fmt.Sprintf("open stream outbox failed: %+v", err)
+		logger.Error(errStr)
+		panic(errStr)
+	}
+	outbox.logPending(logger)
+	se.outbox = outbox
+	se.coordinator = NewCoordinator(logger, se.taskChan, se.resultChan, distributeLockTimeout, se.engines, se.outbox)
 	go se.coordinator.run()
 
 	// start stream cache queue
@@ -135,7 +156,7 @@ func NewStream(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dex
 
 	// Enable websocket
 	if se.engines[EngineWebSocketKind] != nil {
-		go websocket.StartWSServer(logger, se.engines[EngineWebSocketKind].URL())
+		go websocket.StartWSServer(logger, se.engines[EngineWebSocketKind].URL(), metrics)
 	}
 
 	return se
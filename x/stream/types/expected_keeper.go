@@ -1,10 +1,15 @@
 package types
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/okex/okexchain/x/ammswap"
 	"github.com/okex/okexchain/x/dex"
+	"github.com/okex/okexchain/x/gov"
 	"github.com/okex/okexchain/x/order"
+	"github.com/okex/okexchain/x/staking"
 	"github.com/okex/okexchain/x/stream/exported"
 	"github.com/okex/okexchain/x/token"
 	"github.com/willf/bitset"
@@ -36,3 +41,25 @@ type DexKeeper interface {
 	GetTokenPairs(ctx sdk.Context) []*dex.TokenPair
 	SetObserverKeeper(keeper exported.StreamKeeper)
 }
+
+// SwapKeeper expected swap keeper
+type SwapKeeper interface {
+	GetSwapExecutions(ctx sdk.Context) []ammswap.SwapExecution
+	GetSwapTokenPairs(ctx sdk.Context) []ammswap.SwapTokenPair
+	GetChangedSwapTokenPairs(ctx sdk.Context) []ammswap.SwapTokenPair
+}
+
+// StakingKeeper expected staking keeper
+type StakingKeeper interface {
+	GetAllValidators(ctx sdk.Context) staking.Validators
+	GetChangedValidators(ctx sdk.Context) staking.Validators
+	GetLastValidatorPower(ctx sdk.Context, operator sdk.ValAddress) int64
+}
+
+// GovKeeper expected gov keeper
+type GovKeeper interface {
+	GetProposal(ctx sdk.Context, proposalID uint64) (gov.Proposal, bool)
+	IterateActiveProposalsQueue(ctx sdk.Context, endTime time.Time, cb func(proposal gov.Proposal) (stop bool))
+	IterateInactiveProposalsQueue(ctx sdk.Context, endTime time.Time, cb func(proposal gov.Proposal) (stop bool))
+	GetVotes(ctx sdk.Context, proposalID uint64) gov.Votes
+}
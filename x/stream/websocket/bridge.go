@@ -1,19 +1,16 @@
 package websocket
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"runtime/debug"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/spf13/viper"
-
 	"github.com/gorilla/websocket"
 	"github.com/tendermint/tendermint/libs/log"
-	rpccli "github.com/tendermint/tendermint/rpc/client"
-	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
 type Context struct {
@@ -36,37 +33,39 @@ func newContext() *Context {
 }
 
 type Conn struct {
-	cliConn      *websocket.Conn
-	rpcConn      *rpccli.HTTP
-	ctx          *Context
-	logger       log.Logger
-	loginAddress string
-
-	cliInChan    chan []byte
-	cliOutChan   chan interface{}
-	rpcEventChan chan ctypes.ResultEvent
-	rpcStopChan  chan interface{}
+	cliConn *websocket.Conn
+	hub     *Hub
+	ctx     *Context
+	logger  log.Logger
+	session *loginSession
+
+	cliInChan  chan []byte
+	cliOutChan chan interface{}
+	// the events of the channels subscribed, which are buffered up to connEventBufferSize
+	eventChan chan hubEvent
+	slowOnce  sync.Once
 }
 
-func newOKWSConn(ctx *Context, cliConn *websocket.Conn, logger log.Logger) *Conn {
-	if ctx == nil || cliConn == nil {
+func newOKWSConn(ctx *Context, cliConn *websocket.Conn, hub *Hub, logger log.Logger) *Conn {
+	if ctx == nil || cliConn == nil || hub == nil {
 		return nil
 	}
 
-	conn := Conn{
-		cliConn:      cliConn,
-		rpcConn:      nil,
-		ctx:          ctx,
-		logger:       logger,
-		cliInChan:    make(chan []byte),
-		cliOutChan:   make(chan interface{}),
-		rpcEventChan: make(chan ctypes.ResultEvent, 64),
-		rpcStopChan:  make(chan interface{}),
+	conn := &Conn{
+		cliConn:    cliConn,
+		hub:        hub,
+		ctx:        ctx,
+		logger:     logger,
+		session:    &loginSession{},
+		cliInChan:  make(chan []byte),
+		cliOutChan: make(chan interface{}),
+		eventChan:  make(chan hubEvent, connEventBufferSize),
 	}
 
+	hub.register(conn)
 	conn.start()
 
-	return &conn
+	return conn
 }
 
 func (conn *Conn) start() {
@@ -75,34 +74,26 @@ func (conn *Conn) start() {
 	go conn.handleFinalise()
 	go conn.handleCliRead()
 	go conn.handleCliWrite()
-	go conn.handleRPCEventReceived()
+	go conn.handleHubEventReceived()
 	go conn.handleConvert()
 }
 
 func (conn *Conn) stopAll() {
 	conn.logger.Debug("okWSConn.stopAll start")
 
-	// 1. close all the connection
+	// 1. close all the connection, no more event is sent by the hub after it's unregistered
 	conn.logger.Debug("okWSConn.stopAll close bi-connection")
+	conn.hub.unregister(conn)
 	if conn.cliConn != nil {
 		conn.cliConn.Close()
 		conn.logger.Debug("okWSConn'connection to client is closed.")
 	}
 
-	if conn.rpcConn != nil {
-		err := conn.rpcConn.Stop()
-		if err != nil {
-			conn.logger.Error("rpcConn stop error", "msg", err.Error())
-		}
-		conn.logger.Debug("okWSConn'connection to rpc websocket is closed.")
-	}
-
 	// 2. close all the channel
 	conn.logger.Debug("okWSConn.stopAll close connection channel")
-	close(conn.rpcStopChan)
 	close(conn.cliInChan)
 	close(conn.cliOutChan)
-	close(conn.rpcEventChan)
+	close(conn.eventChan)
 
 	conn.logger.Debug("okWSConn.stopAll close connection context channel")
 	conn.ctx.closeAll()
@@ -182,27 +173,13 @@ func (conn *Conn) handleCliWrite() {
 	}
 }
 
-func (conn *Conn) convert2WSTableResponseFromMap(resultEvt ctypes.ResultEvent, topic *SubscriptionTopic) (r interface{}, e error) {
-	innerChannel, e := topic.ToString()
-	if e != nil {
-		return nil, e
-	}
-
+func (conn *Conn) convert2WSTableResponseFromMap(eventItemStr string, topic *SubscriptionTopic) (r interface{}, e error) {
 	resp := TableResponse{
 		Table:  topic.Channel,
 		Action: "update",
 		Data:   nil,
 	}
 
-	matchFilterIdx := 0
-	for idx, channel := range resultEvt.Events[rpcChannelKey] {
-		if channel == innerChannel {
-			matchFilterIdx = idx
-			break
-		}
-	}
-
-	eventItemStr := resultEvt.Events[rpcChannelDataKey][matchFilterIdx]
 	var obj map[string]interface{}
 	jerr := json.Unmarshal([]byte(eventItemStr), &obj)
 	if jerr == nil {
@@ -214,27 +191,13 @@ func (conn *Conn) convert2WSTableResponseFromMap(resultEvt ctypes.ResultEvent, t
 	return resp, e
 }
 
-func (conn *Conn) convertWSTableResponseFromList(resultEvt ctypes.ResultEvent, topic *SubscriptionTopic) (r interface{}, e error) {
-	innerChannel, e := topic.ToString()
-	if e != nil {
-		return nil, e
-	}
-
+func (conn *Conn) convertWSTableResponseFromList(eventItemStr string, topic *SubscriptionTopic) (r interface{}, e error) {
 	resp := TableResponse{
 		Table:  topic.Channel,
 		Action: "update",
 		Data:   nil,
 	}
 
-	matchFilterIdx := 0
-	for idx, channel := range resultEvt.Events[rpcChannelKey] {
-		if channel == innerChannel {
-			matchFilterIdx = idx
-			break
-		}
-	}
-
-	eventItemStr := resultEvt.Events[rpcChannelDataKey][matchFilterIdx]
 	var obj []interface{}
 	e = json.Unmarshal([]byte(eventItemStr), &obj)
 	if e == nil {
@@ -243,45 +206,59 @@ func (conn *Conn) convertWSTableResponseFromList(resultEvt ctypes.ResultEvent, t
 	return resp, e
 }
 
-func (conn *Conn) handleRPCEventReceived() {
+func (conn *Conn) handleHubEventReceived() {
 	defer func() {
 		if err := recover(); err != nil {
-			conn.logger.Error(fmt.Sprintf("handleRPCEventReceived recover panic:%v", err))
+			conn.logger.Error(fmt.Sprintf("handleHubEventReceived recover panic:%v", err))
 		}
 	}()
-	conn.logger.Debug("handleRPCEventReceived start")
+	conn.logger.Debug("handleHubEventReceived start")
 
-	convertors := map[string]func(event ctypes.ResultEvent, topic *SubscriptionTopic) (interface{}, error){
+	convertors := map[string]func(eventItemStr string, topic *SubscriptionTopic) (interface{}, error){
 		DexSpotAccount:     conn.convert2WSTableResponseFromMap,
 		DexSpotTicker:      conn.convert2WSTableResponseFromMap,
 		DexSpotOrder:       conn.convertWSTableResponseFromList,
 		DexSpotAllTicker3s: conn.convertWSTableResponseFromList,
+		DexSwapTrade:       conn.convertWSTableResponseFromList,
+		DexGovVote:         conn.convertWSTableResponseFromList,
 	}
 
-	for evt := range conn.rpcEventChan {
-		topic := query2SubscriptionTopic(evt.Query)
-		if topic != nil {
-			convertFunc := convertors[topic.Channel]
-			if convertFunc == nil {
-				convertFunc = conn.convert2WSTableResponseFromMap
-			}
+	for evt := range conn.eventChan {
+		topic := FormSubscriptionTopic(evt.channel)
+		// the events of the private channels are dropped after the login expires or changes
+		if topic.NeedLogin() && !conn.isLoggedInTopic(topic) {
+			conn.logger.Debug("handleHubEventReceived drop event of private channel", "channel", topic.Channel)
+			continue
+		}
 
-			if convertFunc != nil {
-				r, e := convertFunc(evt, topic)
-				if e == nil {
-					conn.cliOutChan <- r
-				} else {
-					conn.ctx.interruptedCh <- e
-					break
-				}
-			}
+		convertFunc := convertors[topic.Channel]
+		if convertFunc == nil {
+			convertFunc = conn.convert2WSTableResponseFromMap
+		}
+
+		r, e := convertFunc(evt.data, topic)
+		if e == nil {
+			conn.cliOutChan <- r
 		} else {
-			conn.logger.Debug("handleRPCEventReceived get event", "event", evt.Events)
-			conn.ctx.interruptedCh <- evt
+			conn.ctx.interruptedCh <- e
+			break
 		}
 	}
 }
 
+// closeSlowConsumer interrupts the connection whose buffer of the events is full, it returns true only at the
+// first time
+func (conn *Conn) closeSlowConsumer() (closed bool) {
+	conn.slowOnce.Do(func() {
+		closed = true
+		select {
+		case conn.ctx.interruptedCh <- fmt.Errorf("slow consumer, %d events buffered", connEventBufferSize):
+		default:
+		}
+	})
+	return closed
+}
+
 func (conn *Conn) cliPing() (err error) {
 	msg := "pong"
 	conn.cliOutChan <- msg
@@ -301,7 +278,8 @@ func (conn *Conn) cliSubscribe(op *BaseOp) (err error) {
 			}
 			// private channel
 			if topic.NeedLogin() {
-				if conn.loginAddress == "" {
+				loginAddress := conn.session.getAddress(time.Now())
+				if loginAddress == "" {
 					errResp := ErrorResponse{
 						Event:     "error",
 						Message:   fmt.Sprintf("User not logged in / User must be logined in, before subscribe:%s", topic.Channel),
@@ -310,7 +288,7 @@ func (conn *Conn) cliSubscribe(op *BaseOp) (err error) {
 					conn.cliOutChan <- errResp
 					continue
 				}
-				topic.Filter = fmt.Sprintf("%s:%s", topic.Filter, conn.loginAddress)
+				topic.Filter = fmt.Sprintf("%s:%s", topic.Filter, loginAddress)
 			}
 			topics = append(topics, topic)
 
@@ -320,48 +298,31 @@ func (conn *Conn) cliSubscribe(op *BaseOp) (err error) {
 		err = fmt.Errorf("BaseOp {%+v} is not a valid one, expected type: %s", op, eventSubscribe)
 	}
 
-	// 2. if rpc client does not exist, create one
-	if err == nil && conn.rpcConn == nil {
-		rpcAddr := viper.GetString("rpc.laddr")
-		conn.logger.Debug("cliSubscribe", "rpc.laddr", rpcAddr)
-		// HTTP client can be replaced with LocalClient
-		c := rpccli.NewHTTP(rpcAddr, "/websocket")
-		conn.rpcConn = c
-		err = c.Start()
-	}
-
-	// 3. do rpc subscription
-	if err == nil && conn.rpcConn != nil {
-		subscriber := conn.getSubsciber()
+	// 2. subscribe the channels from the hub
+	if err == nil {
 		for _, topic := range topics {
-			ctx, cancel := context.WithTimeout(context.Background(), maxRPCContextTimeout)
-
-			channel, query := subscriptionTopic2Query(topic)
-			eventCh, rpcErr := conn.rpcConn.Subscribe(ctx, subscriber, query)
-
-			if rpcErr == nil {
-				conn.logger.Debug(fmt.Sprintf("%s subscribe to %s", subscriber, query))
-				eventResp := EventResponse{
-					Event:   op.Op,
-					Channel: channel,
-				}
-				conn.cliOutChan <- eventResp
-
-				// a goroutine receive result event from rpc websocket client
-				go conn.receiveRPCResultEvents(eventCh, subscriber, channel)
-
-			} else {
+			channel, topicErr := topic.ToString()
+			if topicErr != nil {
 				errResp := ErrorResponse{
 					Event:     "error",
-					Message:   fmt.Sprintf("fail to subscribe %s, error: %s", channel, rpcErr.Error()),
+					Message:   fmt.Sprintf("fail to subscribe %s, error: %s", channel, topicErr.Error()),
 					ErrorCode: 30043,
 				}
 				conn.cliOutChan <- errResp
+				continue
+			}
+
+			conn.hub.Subscribe(conn, channel)
+			conn.logger.Debug(fmt.Sprintf("%s subscribe to %s", conn.getSubsciber(), channel))
+			eventResp := EventResponse{
+				Event:   op.Op,
+				Channel: channel,
 			}
-			cancel()
+			conn.cliOutChan <- eventResp
 		}
 	}
 
+	// 3. push initial data
 	// 4. push initial data
 	initialDataMap := map[string]func(topic *SubscriptionTopic){
 		DexSpotDepthBook: conn.initialDepthBook,
@@ -377,6 +338,8 @@ func (conn *Conn) cliSubscribe(op *BaseOp) (err error) {
 	return err
 }
 
+// initialDepthBook sends the latest snapshot of the depth book as a partial, the updates are applied to it in the
+// order of the sequences. The updates whose sequences aren't greater than the snapshot's are skipped by the client
 func (conn *Conn) initialDepthBook(topic *SubscriptionTopic) {
 	depthBookRes, ok := GetDepthBookFromCache(topic.Filter)
 	conn.logger.Debug("initialDepthBook", "depthBookRes", depthBookRes, "ok", ok)
@@ -391,26 +354,6 @@ func (conn *Conn) initialDepthBook(topic *SubscriptionTopic) {
 	conn.cliOutChan <- resp
 }
 
-func (conn *Conn) receiveRPCResultEvents(eventCh <-chan ctypes.ResultEvent, subscriber, channel string) {
-	conn.logger.Debug("receiveRPCResultEvents start", subscriber, channel)
-
-	time.Sleep(time.Millisecond)
-
-	for {
-		select {
-		case event, ok := <-eventCh:
-			if !ok {
-				conn.logger.Debug("receiveRPCResultEvents's eventCh is closed or something else", conn.getSubsciber(), event.Query)
-				break
-			}
-			conn.rpcEventChan <- event
-
-		case <-conn.rpcStopChan:
-			break
-		}
-	}
-}
-
 func (conn *Conn) getSubsciber() string {
 	return conn.cliConn.RemoteAddr().String()
 }
@@ -427,7 +370,8 @@ func (conn *Conn) cliUnSubscribe(op *BaseOp) (err error) {
 			}
 			// private channel
 			if topic.NeedLogin() {
-				if conn.loginAddress == "" {
+				loginAddress := conn.session.getAddress(time.Now())
+				if loginAddress == "" {
 					errResp := ErrorResponse{
 						Event:     "error",
 						Message:   fmt.Sprintf("User not logged in / User must be logined in, before subscribe:%s", topic.Channel),
@@ -436,7 +380,7 @@ func (conn *Conn) cliUnSubscribe(op *BaseOp) (err error) {
 					conn.cliOutChan <- errResp
 					continue
 				}
-				topic.Filter = fmt.Sprintf("%s:%s", topic.Filter, conn.loginAddress)
+				topic.Filter = fmt.Sprintf("%s:%s", topic.Filter, loginAddress)
 			}
 			topics = append(topics, topic)
 		}
@@ -445,56 +389,113 @@ func (conn *Conn) cliUnSubscribe(op *BaseOp) (err error) {
 		err = fmt.Errorf("BaseOp {%+v} is not a valid one, expected type: %s", op, eventUnsubscribe)
 	}
 
-	if conn.rpcConn == nil {
-		// 2. if rpcConn is not initialized, raise error
-		err = fmt.Errorf("RPC WS Client hasn't been initialized properly")
-	} else {
-		// 3. do unsubscribe work
-		subscriber := conn.getSubsciber()
-		for _, topic := range topics {
-			ctx, cancel := context.WithTimeout(context.Background(), maxRPCContextTimeout)
-			channel, query := subscriptionTopic2Query(topic)
-			rpcErr := conn.rpcConn.Unsubscribe(ctx, subscriber, query)
-			if rpcErr == nil {
-				conn.logger.Debug(fmt.Sprintf("%s unsubscribe to %s", subscriber, query))
-				eventResp := EventResponse{
-					Event:   op.Op,
-					Channel: channel,
-				}
-				conn.cliOutChan <- eventResp
-
-			} else {
-				errResp := ErrorResponse{
-					Event:     "error",
-					Message:   fmt.Sprintf("fail to unsubscribe %s, error: %s", channel, rpcErr.Error()),
-					ErrorCode: 30043,
-				}
-				conn.cliOutChan <- errResp
-			}
-			cancel()
+	// 2. unsubscribe the channels from the hub
+	for _, topic := range topics {
+		channel, topicErr := topic.ToString()
+		if topicErr != nil {
+			continue
+		}
+		conn.hub.Unsubscribe(conn, channel)
+		conn.logger.Debug(fmt.Sprintf("%s unsubscribe to %s", conn.getSubsciber(), channel))
+		eventResp := EventResponse{
+			Event:   op.Op,
+			Channel: channel,
 		}
+		conn.cliOutChan <- eventResp
 	}
 
 	return err
 }
 
+// cliLoginChallenge issues a login challenge for the address of the args, whose message is signed to log in
+func (conn *Conn) cliLoginChallenge(op *BaseOp) error {
+	if op == nil || op.Op != eventLoginChallenge || len(op.Args) != 1 {
+		return conn.invalidRequest(eventLoginChallenge)
+	}
+
+	message, expireAt, err := conn.session.newChallenge(op.Args[0], time.Now())
+	if err != nil {
+		conn.cliOutChan <- ErrorResponse{
+			Event:     "error",
+			Message:   fmt.Sprintf("fail to issue login challenge, error: %s", err.Error()),
+			ErrorCode: 30041,
+		}
+		return nil
+	}
+	conn.cliOutChan <- LoginResponse{
+		Event:    eventLoginChallenge,
+		Message:  message,
+		ExpireAt: expireAt.Unix(),
+	}
+	return nil
+}
+
+// cliLogin logs in by the args of the address, the pubkey & the signature of the challenge message. It's called
+// again with a new challenge to renew the login before it expires
 func (conn *Conn) cliLogin(op *BaseOp) error {
-	if op == nil || op.Op != eventLogin || len(op.Args) != 1 {
-		err := fmt.Errorf("invalid request, when doing: %s", eventLogin)
-		errResp := ErrorResponse{
+	if op == nil || op.Op != eventLogin || len(op.Args) != 3 {
+		return conn.invalidRequest(eventLogin)
+	}
+
+	expireAt, err := conn.session.login(op.Args[0], op.Args[1], op.Args[2], time.Now())
+	if err != nil {
+		conn.logger.Debug("cliLogin failed", "address", op.Args[0], "error", err.Error())
+		conn.cliOutChan <- ErrorResponse{
 			Event:     "error",
-			Message:   err.Error(),
-			ErrorCode: 30043,
+			Message:   fmt.Sprintf("fail to login, error: %s", err.Error()),
+			ErrorCode: 30041,
 		}
-		conn.cliOutChan <- errResp
+		return nil
+	}
+	conn.cliOutChan <- LoginResponse{
+		Event:    eventLogin,
+		Success:  "true",
+		ExpireAt: expireAt.Unix(),
+	}
+	return nil
+}
 
-		conn.logger.Error(err.Error())
-		return err
+// cliResync sends the latest snapshots of the depth channels of the args again. It's requested by the client when an
+// update of the depth book is missed or mismatches the checksum
+func (conn *Conn) cliResync(op *BaseOp) error {
+	if op == nil || op.Op != eventResync || len(op.Args) == 0 {
+		return conn.invalidRequest(eventResync)
+	}
+
+	for _, arg := range op.Args {
+		topic := FormSubscriptionTopic(arg)
+		if _, ok := GetDepthBookFromCache(topic.Filter); topic.Channel != DexSpotDepthBook || !ok {
+			conn.cliOutChan <- ErrorResponse{
+				Event:     "error",
+				Message:   fmt.Sprintf("fail to resync %s, only the depth book of a product could be resynced", arg),
+				ErrorCode: 30043,
+			}
+			continue
+		}
+		conn.initialDepthBook(topic)
 	}
-	conn.loginAddress = op.Args[0]
 	return nil
 }
 
+func (conn *Conn) invalidRequest(event string) error {
+	err := fmt.Errorf("invalid request, when doing: %s", event)
+	errResp := ErrorResponse{
+		Event:     "error",
+		Message:   err.Error(),
+		ErrorCode: 30043,
+	}
+	conn.cliOutChan <- errResp
+
+	conn.logger.Error(err.Error())
+	return err
+}
+
+// isLoggedInTopic checks whether the private topic is subscribed by the address logged in now
+func (conn *Conn) isLoggedInTopic(topic *SubscriptionTopic) bool {
+	loginAddress := conn.session.getAddress(time.Now())
+	return loginAddress != "" && strings.HasSuffix(topic.Filter, ":"+loginAddress)
+}
+
 func (conn *Conn) handleConvert() {
 	defer func() {
 		if err := recover(); err != nil {
@@ -505,9 +506,11 @@ func (conn *Conn) handleConvert() {
 	conn.logger.Debug("handleConvert start")
 
 	cliEventMap := map[string]func(op *BaseOp) error{
-		eventSubscribe:   conn.cliSubscribe,
-		eventUnsubscribe: conn.cliUnSubscribe,
-		eventLogin:       conn.cliLogin,
+		eventSubscribe:      conn.cliSubscribe,
+		eventUnsubscribe:    conn.cliUnSubscribe,
+		eventLogin:          conn.cliLogin,
+		eventLoginChallenge: conn.cliLoginChallenge,
+		eventResync:         conn.cliResync,
 	}
 
 	for cliInMsg := range conn.cliInChan {
@@ -1,6 +1,7 @@
 package websocket
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/tendermint/tendermint/libs/log"
@@ -11,8 +12,16 @@ import (
 )
 
 type cache struct {
-	depthBooksMap map[string]pushservice.BookRes
-	lock          sync.RWMutex
+	// the sequenced depth books, whose snapshots are sent when subscribing or resyncing the depth channel
+	depthSequencer *pushservice.DepthSequencer
+
+	// the states of the last block, the ones changed in a block are pushed. Only the active proposals & the votes of
+	// the ones in the voting period are kept
+	lock       sync.Mutex
+	swapPools  map[string]SwapPoolRes  // key: pool
+	validators map[string]ValidatorRes // key: operator address
+	proposals  map[uint64]ProposalRes  // key: proposal id
+	votes      map[string]VoteRes      // key: proposal id:voter
 }
 
 var (
@@ -20,33 +29,122 @@ var (
 	once           sync.Once
 )
 
-func InitialCache(ctx sdk.Context, orderKeeper types.OrderKeeper, dexKeeper types.DexKeeper, logger log.Logger) {
+func InitialCache(ctx sdk.Context, orderKeeper types.OrderKeeper, dexKeeper types.DexKeeper,
+	swapKeeper types.SwapKeeper, stakingKeeper types.StakingKeeper, govKeeper types.GovKeeper, logger log.Logger) {
 	once.Do(func() {
 		size := 200
 		tokenPairs := dexKeeper.GetTokenPairs(ctx)
 		logger.Debug("initial websocket cache", "tokenPairs", tokenPairs)
-		depthBooksMap := make(map[string]pushservice.BookRes, len(tokenPairs))
+		depthSequencer := pushservice.NewDepthSequencer()
 		for _, tokenPair := range tokenPairs {
 			depthBook := orderKeeper.GetDepthBookCopy(tokenPair.Name())
 			bookRes := pushservice.ConvertBookRes(tokenPair.Name(), orderKeeper, depthBook, size)
-			depthBooksMap[tokenPair.Name()] = bookRes
+			depthSequencer.Next(bookRes, ctx.BlockHeight())
 		}
-		logger.Debug("initial websocket cache", "depthbook", depthBooksMap)
-		singletonCache = &cache{
-			depthBooksMap: depthBooksMap,
+		logger.Debug("initial websocket cache", "tokenPairs", len(tokenPairs))
+		c := &cache{
+			depthSequencer: depthSequencer,
 		}
+
+		// only the changes after the states initialized are pushed
+		c.changedSwapPools(getSwapPools(ctx, swapKeeper))
+		c.changedValidators(getValidators(ctx, stakingKeeper))
+		proposals, votes := getActiveProposals(ctx, govKeeper)
+		c.changedProposals(proposals)
+		c.changedVotes(votes)
+		singletonCache = c
 	})
 }
 
+// GetDepthBookFromCache returns the latest sequenced snapshot of the depth book of the product
 func GetDepthBookFromCache(product string) (depthBook pushservice.BookRes, ok bool) {
-	singletonCache.lock.RLock()
-	defer singletonCache.lock.RUnlock()
-	depthBook, ok = singletonCache.depthBooksMap[product]
-	return
+	return singletonCache.depthSequencer.Snapshot(product)
 }
 
-func UpdateDepthBookCache(product string, bookRes pushservice.BookRes) {
-	singletonCache.lock.Lock()
-	defer singletonCache.lock.Unlock()
-	singletonCache.depthBooksMap[product] = bookRes
+// changedSwapPools returns the pools whose reserves changed from the states in the cache, and keeps the pools as the
+// states of the last block
+func (c *cache) changedSwapPools(pools []SwapPoolRes) (changed []SwapPoolRes) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.swapPools == nil {
+		c.swapPools = make(map[string]SwapPoolRes, len(pools))
+	}
+	for _, pool := range pools {
+		lastPool, ok := c.swapPools[pool.Pool]
+		c.swapPools[pool.Pool] = pool
+		if ok {
+			lastPool.BlockHeight = pool.BlockHeight
+			if lastPool == pool {
+				continue
+			}
+		}
+		changed = append(changed, pool)
+	}
+	return changed
+}
+
+// changedValidators returns the validators whose status or shares changed from the states in the cache, and keeps
+// the validators as the states of the last block
+func (c *cache) changedValidators(validators []ValidatorRes) (changed []ValidatorRes) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.validators == nil {
+		c.validators = make(map[string]ValidatorRes, len(validators))
+	}
+	for _, validator := range validators {
+		lastValidator, ok := c.validators[validator.OperatorAddress]
+		c.validators[validator.OperatorAddress] = validator
+		if ok {
+			lastValidator.BlockHeight = validator.BlockHeight
+			if lastValidator == validator {
+				continue
+			}
+		}
+		changed = append(changed, validator)
+	}
+	return changed
+}
+
+// changedProposals returns the active proposals submitted or whose status changed since the last block, with the ids
+// of the ones no longer active in ascending order, and keeps the active proposals as the states of the last block
+func (c *cache) changedProposals(proposals []ProposalRes) (changed []ProposalRes, ended []uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	last := c.proposals
+	c.proposals = make(map[uint64]ProposalRes, len(proposals))
+	for _, proposal := range proposals {
+		c.proposals[proposal.ProposalID] = proposal
+		if lastProposal, ok := last[proposal.ProposalID]; ok {
+			lastProposal.BlockHeight = proposal.BlockHeight
+			if lastProposal == proposal {
+				continue
+			}
+		}
+		changed = append(changed, proposal)
+	}
+	for proposalID := range last {
+		if _, ok := c.proposals[proposalID]; !ok {
+			ended = append(ended, proposalID)
+		}
+	}
+	sort.Slice(ended, func(i, j int) bool { return ended[i] < ended[j] })
+	return changed, ended
+}
+
+// changedVotes returns the votes cast or changed since the last block, and keeps the votes as the states of the last
+// block
+func (c *cache) changedVotes(votes []VoteRes) (changed []VoteRes) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	last := c.votes
+	c.votes = make(map[string]VoteRes, len(votes))
+	for _, vote := range votes {
+		key := voteKey(vote)
+		c.votes[key] = vote
+		if lastVote, ok := last[key]; ok && lastVote.Option == vote.Option {
+			continue
+		}
+		changed = append(changed, vote)
+	}
+	return changed
 }
@@ -0,0 +1,70 @@
+package websocket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_ChangedStates(t *testing.T) {
+	c := &cache{}
+
+	// the states initialized are all changed
+	pools := []SwapPoolRes{
+		{Pool: "xxb_okt", BasePooledCoin: "10xxb", QuotePooledCoin: "20okt", Price: "2", BlockHeight: 1},
+		{Pool: "yyb_okt", BasePooledCoin: "10yyb", QuotePooledCoin: "10okt", Price: "1", BlockHeight: 1},
+	}
+	require.Equal(t, pools, c.changedSwapPools(pools))
+
+	// only the pools with the reserves changed are pushed at the next height
+	pools = []SwapPoolRes{
+		{Pool: "xxb_okt", BasePooledCoin: "10xxb", QuotePooledCoin: "20okt", Price: "2", BlockHeight: 2},
+		{Pool: "yyb_okt", BasePooledCoin: "5yyb", QuotePooledCoin: "20okt", Price: "4", BlockHeight: 2},
+		{Pool: "zzb_okt", BasePooledCoin: "1zzb", QuotePooledCoin: "1okt", Price: "1", BlockHeight: 2},
+	}
+	require.Equal(t, pools[1:], c.changedSwapPools(pools))
+	require.Empty(t, c.changedSwapPools(pools))
+	// the pools changed in a block are merged into the states
+	pools = []SwapPoolRes{{Pool: "xxb_okt", BasePooledCoin: "20xxb", QuotePooledCoin: "10okt", Price: "0.5", BlockHeight: 3}}
+	require.Equal(t, pools, c.changedSwapPools(pools))
+	require.Equal(t, 3, len(c.swapPools))
+
+	validators := []ValidatorRes{
+		{OperatorAddress: "val1", Status: "Bonded", DelegatorShares: "100", Power: 100, BlockHeight: 1},
+		{OperatorAddress: "val2", Status: "Bonded", DelegatorShares: "50", Power: 50, BlockHeight: 1},
+	}
+	c.changedValidators(validators)
+	validators = []ValidatorRes{
+		{OperatorAddress: "val1", Status: "Bonded", DelegatorShares: "100", Power: 100, BlockHeight: 2},
+		{OperatorAddress: "val2", Status: "Unbonding", Jailed: true, DelegatorShares: "50", BlockHeight: 2},
+	}
+	require.Equal(t, validators[1:], c.changedValidators(validators))
+	require.Empty(t, c.changedValidators(validators[:1]))
+	require.Equal(t, 2, len(c.validators))
+
+	proposals := []ProposalRes{{ProposalID: 1, Title: "p1", Status: "DepositPeriod", BlockHeight: 1}}
+	c.changedProposals(proposals)
+	proposals = []ProposalRes{
+		{ProposalID: 1, Title: "p1", Status: "VotingPeriod", BlockHeight: 2},
+		{ProposalID: 2, Title: "p2", Status: "DepositPeriod", BlockHeight: 2},
+	}
+	changed, ended := c.changedProposals(proposals)
+	require.Equal(t, proposals, changed)
+	require.Empty(t, ended)
+	// the proposals no longer active are dropped from the states
+	changed, ended = c.changedProposals(proposals[1:])
+	require.Empty(t, changed)
+	require.Equal(t, []uint64{1}, ended)
+	require.Equal(t, 1, len(c.proposals))
+
+	votes := []VoteRes{{ProposalID: 1, Voter: "voter1", Option: "Yes", BlockHeight: 2}}
+	require.Equal(t, votes, c.changedVotes(votes))
+	votes = []VoteRes{
+		{ProposalID: 1, Voter: "voter1", Option: "Yes", BlockHeight: 3},
+		{ProposalID: 1, Voter: "voter2", Option: "No", BlockHeight: 3},
+	}
+	require.Equal(t, votes[1:], c.changedVotes(votes))
+	// a vote changed is pushed again
+	votes[0].Option = "NoWithVeto"
+	require.Equal(t, votes[:1], c.changedVotes(votes))
+}
@@ -22,9 +22,17 @@ const (
 	DexSpotTicker      = "dex_spot/ticker"
 	DexSpotDepthBook   = "dex_spot/optimized_depth"
 
-	eventSubscribe   = "subscribe"
-	eventUnsubscribe = "unsubscribe"
-	eventLogin       = "dex_jwt"
+	DexSwapPool         = "dex_swap/pool"
+	DexSwapTrade        = "dex_swap/trade"
+	DexStakingValidator = "dex_staking/validator"
+	DexGovProposal      = "dex_gov/proposal"
+	DexGovVote          = "dex_gov/vote"
+
+	eventSubscribe      = "subscribe"
+	eventUnsubscribe    = "unsubscribe"
+	eventLogin          = "login"
+	eventLoginChallenge = "login_challenge"
+	eventResync         = "resync"
 )
 
 var (
@@ -0,0 +1,198 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/okex/okexchain/x/common/monitor"
+	"github.com/tendermint/tendermint/libs/log"
+	rpccli "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+const (
+	// the capacity of the events buffered for a connection, the connection is closed as a slow consumer when the
+	// buffer is full
+	connEventBufferSize = 256
+	// the subscriber of the only rpc subscription of the hub
+	hubSubscriber = "okexchain-websocket-hub"
+	// the interval to subscribe again after the rpc subscription fails
+	hubResubscribeInterval = 3 * time.Second
+)
+
+// hubEvent is the data of a backend channel in a block
+type hubEvent struct {
+	channel string
+	data    string
+}
+
+// Hub subscribes all of the backend channels from the node once, and fans the events out to the connections
+// subscribing them
+type Hub struct {
+	lock sync.RWMutex
+	// the connections subscribing each channel
+	subscribers map[string]map[*Conn]struct{}
+	// the channels subscribed by each connection
+	conns map[*Conn]map[string]struct{}
+
+	logger  log.Logger
+	metrics *monitor.StreamMetrics
+}
+
+// NewHub creates a hub without any connection
+func NewHub(logger log.Logger, metrics *monitor.StreamMetrics) *Hub {
+	return &Hub{
+		subscribers: make(map[string]map[*Conn]struct{}),
+		conns:       make(map[*Conn]map[string]struct{}),
+		logger:      logger,
+		metrics:     metrics,
+	}
+}
+
+// register adds the connection to the hub
+func (h *Hub) register(conn *Conn) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if _, ok := h.conns[conn]; !ok {
+		h.conns[conn] = make(map[string]struct{})
+		h.metrics.WSConnections.Add(1)
+	}
+}
+
+// unregister removes the connection with all of its subscriptions, no more event is sent to it after it returns
+func (h *Hub) unregister(conn *Conn) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	channels, ok := h.conns[conn]
+	if !ok {
+		return
+	}
+	for channel := range channels {
+		h.removeSubscriber(channel, conn)
+	}
+	delete(h.conns, conn)
+	h.metrics.WSSubscriptions.Add(-float64(len(channels)))
+	h.metrics.WSConnections.Add(-1)
+}
+
+// Subscribe subscribes the channel for the connection registered
+func (h *Hub) Subscribe(conn *Conn, channel string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	channels, ok := h.conns[conn]
+	if !ok {
+		return
+	}
+	if _, ok := channels[channel]; ok {
+		return
+	}
+	channels[channel] = struct{}{}
+	if h.subscribers[channel] == nil {
+		h.subscribers[channel] = make(map[*Conn]struct{})
+	}
+	h.subscribers[channel][conn] = struct{}{}
+	h.metrics.WSSubscriptions.Add(1)
+}
+
+// Unsubscribe unsubscribes the channel for the connection
+func (h *Hub) Unsubscribe(conn *Conn, channel string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	channels, ok := h.conns[conn]
+	if !ok {
+		return
+	}
+	if _, ok := channels[channel]; !ok {
+		return
+	}
+	delete(channels, channel)
+	h.removeSubscriber(channel, conn)
+	h.metrics.WSSubscriptions.Add(-1)
+}
+
+func (h *Hub) removeSubscriber(channel string, conn *Conn) {
+	delete(h.subscribers[channel], conn)
+	if len(h.subscribers[channel]) == 0 {
+		delete(h.subscribers, channel)
+	}
+}
+
+// Publish sends the event of the channel to the buffers of its subscribers without blocking. The event is dropped for
+// a connection with its buffer full, which is closed as a slow consumer
+func (h *Hub) Publish(channel, data string) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	for conn := range h.subscribers[channel] {
+		select {
+		case conn.eventChan <- hubEvent{channel: channel, data: data}:
+		default:
+			h.metrics.WSDroppedEvents.Add(1)
+			if conn.closeSlowConsumer() {
+				h.metrics.WSSlowConsumerClosings.Add(1)
+				h.logger.Info("websocket connection is closed as a slow consumer", "channel", channel)
+			}
+		}
+	}
+}
+
+// publishResultEvent publishes all of the backend channels in the result event of a block
+func (h *Hub) publishResultEvent(evt ctypes.ResultEvent) {
+	channels, data := evt.Events[rpcChannelKey], evt.Events[rpcChannelDataKey]
+	if len(channels) != len(data) {
+		h.logger.Error(fmt.Sprintf("mismatched backend channels and data: %d vs %d", len(channels), len(data)))
+		return
+	}
+	for i, channel := range channels {
+		h.Publish(channel, data[i])
+	}
+}
+
+// Run subscribes all of the backend channels from the rpc of the node, and publishes the events until the context
+// is done. The subscription is made again when it fails
+func (h *Hub) Run(ctx context.Context, rpcAddr string) {
+	query := fmt.Sprintf("tm.event='NewBlock' AND %s EXISTS", rpcChannelKey)
+	for {
+		if err := h.runSubscription(ctx, rpcAddr, query); err != nil {
+			h.logger.Error("websocket hub subscription failed", "rpc", rpcAddr, "error", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(hubResubscribeInterval):
+		}
+	}
+}
+
+func (h *Hub) runSubscription(ctx context.Context, rpcAddr, query string) error {
+	client := rpccli.NewHTTP(rpcAddr, "/websocket")
+	if err := client.Start(); err != nil {
+		return err
+	}
+	defer func() {
+		if err := client.Stop(); err != nil {
+			h.logger.Error("websocket hub rpc client stop error", "error", err.Error())
+		}
+	}()
+
+	subCtx, cancel := context.WithTimeout(ctx, maxRPCContextTimeout)
+	eventCh, err := client.Subscribe(subCtx, hubSubscriber, query)
+	cancel()
+	if err != nil {
+		return err
+	}
+	h.logger.Info("websocket hub subscribed", "rpc", rpcAddr, "query", query)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case evt, ok := <-eventCh:
+			if !ok {
+				return fmt.Errorf("subscription of %s is closed", query)
+			}
+			h.publishResultEvent(evt)
+		}
+	}
+}
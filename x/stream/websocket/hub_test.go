@@ -0,0 +1,73 @@
+package websocket
+
+import (
+	"testing"
+
+	"github.com/okex/okexchain/x/common/monitor"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+func newHubTestConn(bufferSize int) *Conn {
+	return &Conn{ctx: newContext(), eventChan: make(chan hubEvent, bufferSize)}
+}
+
+func TestHub(t *testing.T) {
+	hub := NewHub(log.NewNopLogger(), monitor.NopStreamMetrics())
+	conn1, conn2 := newHubTestConn(connEventBufferSize), newHubTestConn(connEventBufferSize)
+	hub.register(conn1)
+	hub.register(conn2)
+	tickerChannel := DexSpotTicker + ":xxb_okt"
+	hub.Subscribe(conn1, tickerChannel)
+	hub.Subscribe(conn1, tickerChannel)
+	hub.Subscribe(conn2, tickerChannel)
+	hub.Subscribe(conn2, DexSpotMatch+":xxb_okt")
+
+	// the events of a block are fanned out by the channels
+	hub.publishResultEvent(ctypes.ResultEvent{Events: map[string][]string{
+		rpcChannelKey:     {tickerChannel, DexSpotMatch + ":xxb_okt", DexSpotTicker + ":aab_okt"},
+		rpcChannelDataKey: {`{"price":"1"}`, `[]`, `{"price":"2"}`},
+	}})
+	require.Equal(t, 1, len(conn1.eventChan))
+	require.Equal(t, 2, len(conn2.eventChan))
+	evt := <-conn1.eventChan
+	require.Equal(t, tickerChannel, evt.channel)
+	require.Equal(t, `{"price":"1"}`, evt.data)
+
+	// no more event after the unsubscription
+	hub.Unsubscribe(conn2, tickerChannel)
+	hub.Unsubscribe(conn2, tickerChannel)
+	hub.Publish(tickerChannel, `{"price":"3"}`)
+	require.Equal(t, 1, len(conn1.eventChan))
+	require.Equal(t, 2, len(conn2.eventChan))
+
+	hub.unregister(conn1)
+	hub.Publish(tickerChannel, `{"price":"4"}`)
+	require.Equal(t, 1, len(conn1.eventChan))
+	require.Equal(t, 0, len(hub.subscribers[tickerChannel]))
+	require.Equal(t, 1, len(hub.conns))
+
+	// the connections not registered can't subscribe
+	hub.Subscribe(conn1, tickerChannel)
+	require.Equal(t, 0, len(hub.subscribers[tickerChannel]))
+}
+
+func TestHub_SlowConsumer(t *testing.T) {
+	hub := NewHub(log.NewNopLogger(), monitor.NopStreamMetrics())
+	slow, fast := newHubTestConn(1), newHubTestConn(3)
+	for _, conn := range []*Conn{slow, fast} {
+		hub.register(conn)
+		hub.Subscribe(conn, DexSpotMatch)
+	}
+
+	hub.Publish(DexSpotMatch, "1")
+	hub.Publish(DexSpotMatch, "2")
+	hub.Publish(DexSpotMatch, "3")
+	// the slow one is interrupted only once, and the others aren't affected
+	require.Equal(t, 1, len(slow.eventChan))
+	require.Equal(t, 1, len(slow.ctx.interruptedCh))
+	require.Equal(t, 3, len(fast.eventChan))
+	require.Equal(t, 0, len(fast.ctx.interruptedCh))
+	require.False(t, slow.closeSlowConsumer())
+}
@@ -0,0 +1,214 @@
+package websocket
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/ammswap"
+	"github.com/okex/okexchain/x/gov"
+	"github.com/okex/okexchain/x/staking"
+	"github.com/okex/okexchain/x/stream/types"
+)
+
+// SwapPoolRes is the reserves & the price of a swap pool, which is pushed when the reserves change
+type SwapPoolRes struct {
+	Pool            string `json:"pool"`
+	BasePooledCoin  string `json:"base_pooled_coin"`
+	QuotePooledCoin string `json:"quote_pooled_coin"`
+	PoolTokenName   string `json:"pool_token_name"`
+	Price           string `json:"price"` // the quote token per base token
+	BlockHeight     int64  `json:"block_height"`
+}
+
+// SwapTradeRes is a hop of a swap executed in the block
+type SwapTradeRes struct {
+	Pool        string `json:"pool"`
+	TxHash      string `json:"tx_hash"`
+	Index       uint64 `json:"index"`
+	Sender      string `json:"sender"`
+	SoldToken   string `json:"sold_token"`
+	BoughtToken string `json:"bought_token"`
+	Fee         string `json:"fee"`
+	BlockHeight int64  `json:"block_height"`
+	Timestamp   int64  `json:"timestamp"`
+}
+
+// ValidatorRes is the status & the shares of a validator, which is pushed when any of them changes. The power is the
+// one in the validator set of the last block, which is 0 out of the set
+type ValidatorRes struct {
+	OperatorAddress string `json:"operator_address"`
+	Moniker         string `json:"moniker"`
+	Status          string `json:"status"`
+	Jailed          bool   `json:"jailed"`
+	DelegatorShares string `json:"delegator_shares"`
+	Power           int64  `json:"power"`
+	BlockHeight     int64  `json:"block_height"`
+}
+
+// ProposalRes is a proposal, which is pushed when its status changes
+type ProposalRes struct {
+	ProposalID    uint64 `json:"proposal_id"`
+	Title         string `json:"title"`
+	ProposalType  string `json:"proposal_type"`
+	Status        string `json:"proposal_status"`
+	VotingEndTime int64  `json:"voting_end_time"`
+	BlockHeight   int64  `json:"block_height"`
+}
+
+// VoteRes is a vote of a proposal in the voting period, which is pushed when it's cast or changed
+type VoteRes struct {
+	ProposalID  uint64 `json:"proposal_id"`
+	Voter       string `json:"voter"`
+	Option      string `json:"option"`
+	BlockHeight int64  `json:"block_height"`
+}
+
+// maxQueueEndTime is the end time to iterate over all the proposals in the gov queues
+var maxQueueEndTime = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// getSwapPools returns the swap pools at the height of the context
+func getSwapPools(ctx sdk.Context, swapKeeper types.SwapKeeper) []SwapPoolRes {
+	if swapKeeper == nil {
+		return nil
+	}
+	return newSwapPoolResList(ctx, swapKeeper.GetSwapTokenPairs(ctx))
+}
+
+// getChangedSwapPools returns the swap pools set in the block
+func getChangedSwapPools(ctx sdk.Context, swapKeeper types.SwapKeeper) []SwapPoolRes {
+	if swapKeeper == nil {
+		return nil
+	}
+	return newSwapPoolResList(ctx, swapKeeper.GetChangedSwapTokenPairs(ctx))
+}
+
+func newSwapPoolResList(ctx sdk.Context, swapTokenPairs []ammswap.SwapTokenPair) []SwapPoolRes {
+	pools := make([]SwapPoolRes, 0, len(swapTokenPairs))
+	for _, pair := range swapTokenPairs {
+		price := sdk.ZeroDec()
+		if pair.BasePooledCoin.Amount.IsPositive() {
+			price = pair.QuotePooledCoin.Amount.Quo(pair.BasePooledCoin.Amount)
+		}
+		pools = append(pools, SwapPoolRes{
+			Pool:            pair.TokenPairName(),
+			BasePooledCoin:  pair.BasePooledCoin.String(),
+			QuotePooledCoin: pair.QuotePooledCoin.String(),
+			PoolTokenName:   pair.PoolTokenName,
+			Price:           price.String(),
+			BlockHeight:     ctx.BlockHeight(),
+		})
+	}
+	return pools
+}
+
+// getSwapTrades returns the hops of the swaps executed in the block
+func getSwapTrades(ctx sdk.Context, swapKeeper types.SwapKeeper) []SwapTradeRes {
+	if swapKeeper == nil {
+		return nil
+	}
+	executions := swapKeeper.GetSwapExecutions(ctx)
+	trades := make([]SwapTradeRes, 0, len(executions))
+	for _, execution := range executions {
+		trades = append(trades, SwapTradeRes{
+			Pool:        execution.TokenPairName,
+			TxHash:      execution.TxHash,
+			Index:       execution.Index,
+			Sender:      execution.Sender.String(),
+			SoldToken:   execution.SoldToken.String(),
+			BoughtToken: execution.BoughtToken.String(),
+			Fee:         execution.Fee.String(),
+			BlockHeight: ctx.BlockHeight(),
+			Timestamp:   ctx.BlockHeader().Time.Unix(),
+		})
+	}
+	return trades
+}
+
+// getValidators returns the validators at the height of the context
+func getValidators(ctx sdk.Context, stakingKeeper types.StakingKeeper) []ValidatorRes {
+	if stakingKeeper == nil {
+		return nil
+	}
+	return newValidatorResList(ctx, stakingKeeper, stakingKeeper.GetAllValidators(ctx))
+}
+
+// getChangedValidators returns the validators set or with the power updated in the block
+func getChangedValidators(ctx sdk.Context, stakingKeeper types.StakingKeeper) []ValidatorRes {
+	if stakingKeeper == nil {
+		return nil
+	}
+	return newValidatorResList(ctx, stakingKeeper, stakingKeeper.GetChangedValidators(ctx))
+}
+
+func newValidatorResList(ctx sdk.Context, stakingKeeper types.StakingKeeper,
+	stakingValidators staking.Validators) []ValidatorRes {
+	validators := make([]ValidatorRes, 0, len(stakingValidators))
+	for _, validator := range stakingValidators {
+		validators = append(validators, ValidatorRes{
+			OperatorAddress: validator.OperatorAddress.String(),
+			Moniker:         validator.Description.Moniker,
+			Status:          validator.Status.String(),
+			Jailed:          validator.Jailed,
+			DelegatorShares: validator.DelegatorShares.String(),
+			Power:           stakingKeeper.GetLastValidatorPower(ctx, validator.OperatorAddress),
+			BlockHeight:     ctx.BlockHeight(),
+		})
+	}
+	return validators
+}
+
+// getActiveProposals returns the proposals in the deposit or the voting period at the height of the context, with the
+// votes of the ones in the voting period
+func getActiveProposals(ctx sdk.Context, govKeeper types.GovKeeper) (proposals []ProposalRes, votes []VoteRes) {
+	if govKeeper == nil {
+		return nil, nil
+	}
+	govKeeper.IterateInactiveProposalsQueue(ctx, maxQueueEndTime, func(proposal gov.Proposal) bool {
+		proposals = append(proposals, newProposalRes(ctx, proposal))
+		return false
+	})
+	govKeeper.IterateActiveProposalsQueue(ctx, maxQueueEndTime, func(proposal gov.Proposal) bool {
+		proposals = append(proposals, newProposalRes(ctx, proposal))
+		for _, vote := range govKeeper.GetVotes(ctx, proposal.ProposalID) {
+			votes = append(votes, VoteRes{
+				ProposalID:  vote.ProposalID,
+				Voter:       vote.Voter.String(),
+				Option:      vote.Option.String(),
+				BlockHeight: ctx.BlockHeight(),
+			})
+		}
+		return false
+	})
+	return proposals, votes
+}
+
+// getEndedProposals returns the proposals of the ids which left the deposit or the voting period, the deleted ones
+// are skipped
+func getEndedProposals(ctx sdk.Context, govKeeper types.GovKeeper, proposalIDs []uint64) (proposals []ProposalRes) {
+	if govKeeper == nil {
+		return nil
+	}
+	for _, proposalID := range proposalIDs {
+		if proposal, ok := govKeeper.GetProposal(ctx, proposalID); ok {
+			proposals = append(proposals, newProposalRes(ctx, proposal))
+		}
+	}
+	return proposals
+}
+
+func newProposalRes(ctx sdk.Context, proposal gov.Proposal) ProposalRes {
+	return ProposalRes{
+		ProposalID:    proposal.ProposalID,
+		Title:         proposal.GetTitle(),
+		ProposalType:  proposal.ProposalType(),
+		Status:        proposal.Status.String(),
+		VotingEndTime: proposal.VotingEndTime.Unix(),
+		BlockHeight:   ctx.BlockHeight(),
+	}
+}
+
+// voteKey is the key of a vote in the cache
+func voteKey(vote VoteRes) string {
+	return fmt.Sprintf("%d:%s", vote.ProposalID, vote.Voter)
+}
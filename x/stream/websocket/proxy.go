@@ -1,6 +1,7 @@
 package websocket
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os/signal"
@@ -8,6 +9,8 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/okex/okexchain/x/common/monitor"
+	"github.com/spf13/viper"
 	"github.com/tendermint/tendermint/libs/log"
 )
 
@@ -20,7 +23,7 @@ var (
 	}
 )
 
-func bridgeMsgHandler(w http.ResponseWriter, r *http.Request, logger log.Logger) {
+func bridgeMsgHandler(w http.ResponseWriter, r *http.Request, hub *Hub, logger log.Logger) {
 	logger.Debug(fmt.Sprintf("bridgeMsgHandler remoteAddr: %s", r.RemoteAddr))
 	c, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -34,17 +37,21 @@ func bridgeMsgHandler(w http.ResponseWriter, r *http.Request, logger log.Logger)
 
 	connCtx := newContext()
 	signal.Notify(connCtx.signalCh, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
-	newOKWSConn(connCtx, c, logger)
+	newOKWSConn(connCtx, c, hub, logger)
 }
 
-func bridgeMsgHandlerWithLogger(logger log.Logger) func(w http.ResponseWriter, r *http.Request) {
+func bridgeMsgHandlerWithLogger(hub *Hub, logger log.Logger) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		bridgeMsgHandler(w, r, logger)
+		bridgeMsgHandler(w, r, hub, logger)
 	}
 }
 
-func StartWSServer(logger log.Logger, endpoint string) {
-	http.HandleFunc("/ws/v3", bridgeMsgHandlerWithLogger(logger))
+// StartWSServer starts the websocket server, whose connections share the events subscribed by a hub from the rpc
+func StartWSServer(logger log.Logger, endpoint string, metrics *monitor.StreamMetrics) {
+	hub := NewHub(logger, metrics)
+	go hub.Run(context.Background(), viper.GetString("rpc.laddr"))
+
+	http.HandleFunc("/ws/v3", bridgeMsgHandlerWithLogger(hub, logger))
 	logger.Info("Starting WebSocket server on ", endpoint)
 	err := http.ListenAndServe(endpoint, nil)
 	if err != nil {
@@ -82,8 +82,8 @@ func (engine *Engine) Write(data types.IStreamData, success *bool) {
 		events = append(events, event)
 	}
 
-	// 4. collect dex_spot/optimized_depth events
-	for key, value := range wsData.DepthBooksMap {
+	// 4. collect dex_spot/optimized_depth events of the incremental updates
+	for key, value := range wsData.DepthUpdatesMap {
 		// dex_spot/optimized_depth:xxb_okt
 		channel := fmt.Sprintf("%s:%s", DexSpotDepthBook, key)
 		event, err := engine.NewEvent(channel, value)
@@ -93,6 +93,61 @@ func (engine *Engine) Write(data types.IStreamData, success *bool) {
 		events = append(events, event)
 	}
 
+	// 5. collect dex_swap/pool events
+	for key, value := range wsData.SwapPoolsMap {
+		// dex_swap/pool:xxb_okt
+		channel := fmt.Sprintf("%s:%s", DexSwapPool, key)
+		event, err := engine.NewEvent(channel, value)
+		if err != nil {
+			panic(err)
+		}
+		events = append(events, event)
+	}
+
+	// 6. collect dex_swap/trade events
+	for key, value := range wsData.SwapTradesMap {
+		// dex_swap/trade:xxb_okt
+		channel := fmt.Sprintf("%s:%s", DexSwapTrade, key)
+		event, err := engine.NewEvent(channel, value)
+		if err != nil {
+			panic(err)
+		}
+		events = append(events, event)
+	}
+
+	// 7. collect dex_staking/validator events
+	for key, value := range wsData.ValidatorsMap {
+		// dex_staking/validator:okexchainvaloper1...
+		channel := fmt.Sprintf("%s:%s", DexStakingValidator, key)
+		event, err := engine.NewEvent(channel, value)
+		if err != nil {
+			panic(err)
+		}
+		events = append(events, event)
+	}
+
+	// 8. collect dex_gov/proposal events
+	for key, value := range wsData.ProposalsMap {
+		// dex_gov/proposal:1
+		channel := fmt.Sprintf("%s:%s", DexGovProposal, key)
+		event, err := engine.NewEvent(channel, value)
+		if err != nil {
+			panic(err)
+		}
+		events = append(events, event)
+	}
+
+	// 9. collect dex_gov/vote events
+	for key, value := range wsData.VotesMap {
+		// dex_gov/vote:1
+		channel := fmt.Sprintf("%s:%s", DexGovVote, key)
+		event, err := engine.NewEvent(channel, value)
+		if err != nil {
+			panic(err)
+		}
+		events = append(events, event)
+	}
+
 	wsData.eventMgr.EmitEvents(events)
 	*success = true
 }
@@ -0,0 +1,127 @@
+package websocket
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+const (
+	// the time allowed to sign the challenge
+	loginChallengeTTL = time.Minute
+	// the time a login lasts, the client logs in again before it to keep the private channels
+	loginSessionTTL = time.Hour
+
+	loginNonceSize     = 32
+	loginMessagePrefix = "okexchain websocket login:"
+)
+
+var (
+	errLoginNoChallenge      = errors.New("no login challenge of the address, or it has expired")
+	errLoginInvalidPubKey    = errors.New("invalid secp256k1 pubkey")
+	errLoginAddressMismatch  = errors.New("the pubkey doesn't belong to the address")
+	errLoginInvalidSignature = errors.New("invalid signature of the login challenge")
+)
+
+// loginSession is the login state of a connection. A client logs in by signing the message of a challenge issued
+// for its address, which could be used only once before it expires
+type loginSession struct {
+	lock sync.RWMutex
+
+	challengeAddress  string
+	challengeMessage  string
+	challengeExpireAt time.Time
+
+	address  string
+	expireAt time.Time
+}
+
+// loginChallengeMessage returns the message signed by the client to log in
+func loginChallengeMessage(address, nonce string) string {
+	return fmt.Sprintf("%s%s:%s", loginMessagePrefix, address, nonce)
+}
+
+// newChallenge issues a challenge for the address, which replaces the one issued before. It returns the message to
+// be signed & when it expires
+func (s *loginSession) newChallenge(address string, now time.Time) (message string, expireAt time.Time, err error) {
+	if _, err = sdk.AccAddressFromBech32(address); err != nil {
+		return "", expireAt, err
+	}
+	nonce := make([]byte, loginNonceSize)
+	if _, err = rand.Read(nonce); err != nil {
+		return "", expireAt, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.challengeAddress = address
+	s.challengeMessage = loginChallengeMessage(address, hex.EncodeToString(nonce))
+	s.challengeExpireAt = now.Add(loginChallengeTTL)
+	return s.challengeMessage, s.challengeExpireAt, nil
+}
+
+// login verifies the signature of the challenge by the pubkey of the address, and starts a new session of the
+// address. The pubkey is in bech32 or hex, the signature is the 64 bytes of r || s in base64. The challenge is
+// consumed whether it succeeds or not
+func (s *loginSession) login(address, pubKeyStr, signatureStr string, now time.Time) (expireAt time.Time, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	message := s.challengeMessage
+	if s.challengeAddress != address || message == "" || now.After(s.challengeExpireAt) {
+		return expireAt, errLoginNoChallenge
+	}
+	s.challengeAddress, s.challengeMessage = "", ""
+
+	pubKey, err := parseLoginPubKey(pubKeyStr)
+	if err != nil {
+		return expireAt, err
+	}
+	if sdk.AccAddress(pubKey.Address()).String() != address {
+		return expireAt, errLoginAddressMismatch
+	}
+	signature, err := base64.StdEncoding.DecodeString(signatureStr)
+	if err != nil || !pubKey.VerifyBytes([]byte(message), signature) {
+		return expireAt, errLoginInvalidSignature
+	}
+
+	s.address = address
+	s.expireAt = now.Add(loginSessionTTL)
+	return s.expireAt, nil
+}
+
+// getAddress returns the address logged in, which is empty if it has expired
+func (s *loginSession) getAddress(now time.Time) string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.address == "" || now.After(s.expireAt) {
+		return ""
+	}
+	return s.address
+}
+
+// parseLoginPubKey parses the secp256k1 pubkey in bech32 or the hex of its 33 compressed bytes
+func parseLoginPubKey(pubKeyStr string) (crypto.PubKey, error) {
+	if pubKey, err := sdk.GetAccPubKeyBech32(pubKeyStr); err == nil {
+		if _, ok := pubKey.(secp256k1.PubKeySecp256k1); ok {
+			return pubKey, nil
+		}
+		return nil, errLoginInvalidPubKey
+	}
+
+	bz, err := hex.DecodeString(pubKeyStr)
+	if err != nil || len(bz) != secp256k1.PubKeySecp256k1Size {
+		return nil, errLoginInvalidPubKey
+	}
+	var pubKey secp256k1.PubKeySecp256k1
+	copy(pubKey[:], bz)
+	return pubKey, nil
+}
@@ -0,0 +1,85 @@
+package websocket
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func TestLoginSession(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+	pubKey := privKey.PubKey().(secp256k1.PubKeySecp256k1)
+	address := sdk.AccAddress(pubKey.Address()).String()
+	pubKeyHex := hex.EncodeToString(pubKey[:])
+	sign := func(message string) string {
+		signature, err := privKey.Sign([]byte(message))
+		require.Nil(t, err)
+		return base64.StdEncoding.EncodeToString(signature)
+	}
+	now := time.Now()
+	session := &loginSession{}
+
+	// no login without a challenge
+	_, err := session.login(address, pubKeyHex, sign("anything"), now)
+	require.Equal(t, errLoginNoChallenge, err)
+	_, _, err = session.newChallenge("invalid address", now)
+	require.NotNil(t, err)
+
+	// the challenge is consumed by a failed login
+	message, _, err := session.newChallenge(address, now)
+	require.Nil(t, err)
+	_, err = session.login(address, pubKeyHex, sign("anything"), now)
+	require.Equal(t, errLoginInvalidSignature, err)
+	_, err = session.login(address, pubKeyHex, sign(message), now)
+	require.Equal(t, errLoginNoChallenge, err)
+
+	// the pubkey of another account
+	otherPubKey := secp256k1.GenPrivKey().PubKey().(secp256k1.PubKeySecp256k1)
+	message, _, err = session.newChallenge(address, now)
+	require.Nil(t, err)
+	_, err = session.login(address, hex.EncodeToString(otherPubKey[:]), sign(message), now)
+	require.Equal(t, errLoginAddressMismatch, err)
+
+	// the challenge expires
+	message, _, err = session.newChallenge(address, now)
+	require.Nil(t, err)
+	_, err = session.login(address, pubKeyHex, sign(message), now.Add(loginChallengeTTL+time.Second))
+	require.Equal(t, errLoginNoChallenge, err)
+	require.Equal(t, "", session.getAddress(now))
+
+	// login by the pubkey in bech32
+	message, _, err = session.newChallenge(address, now)
+	require.Nil(t, err)
+	expireAt, err := session.login(address, sdk.MustBech32ifyAccPub(pubKey), sign(message), now)
+	require.Nil(t, err)
+	require.Equal(t, now.Add(loginSessionTTL), expireAt)
+	require.Equal(t, address, session.getAddress(now))
+	_, err = session.login(address, pubKeyHex, sign(message), now)
+	require.Equal(t, errLoginNoChallenge, err)
+
+	// the login expires, and it's renewed by a new challenge
+	require.Equal(t, "", session.getAddress(expireAt.Add(time.Second)))
+	later := expireAt.Add(time.Second)
+	message, _, err = session.newChallenge(address, later)
+	require.Nil(t, err)
+	_, err = session.login(address, pubKeyHex, sign(message), later)
+	require.Nil(t, err)
+	require.Equal(t, address, session.getAddress(later))
+
+	_, err = parseLoginPubKey("not a pubkey")
+	require.Equal(t, errLoginInvalidPubKey, err)
+}
+
+func TestConn_isLoggedInTopic(t *testing.T) {
+	conn := &Conn{session: &loginSession{address: "addr1", expireAt: time.Now().Add(time.Minute)}}
+	require.True(t, conn.isLoggedInTopic(FormSubscriptionTopic(DexSpotAccount+":okt:addr1")))
+	require.False(t, conn.isLoggedInTopic(FormSubscriptionTopic(DexSpotOrder+":xxb_okt:addr2")))
+
+	conn.session.expireAt = time.Now().Add(-time.Second)
+	require.False(t, conn.isLoggedInTopic(FormSubscriptionTopic(DexSpotAccount+":okt:addr1")))
+}
@@ -1,6 +1,8 @@
 package websocket
 
 import (
+	"strconv"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/okexchain/x/stream/common"
 	pushservice "github.com/okex/okexchain/x/stream/pushservice/types"
@@ -10,27 +12,58 @@ import (
 type PushData struct {
 	*pushservice.RedisBlock
 	eventMgr *sdk.EventManager
+
+	SwapPoolsMap  map[string]SwapPoolRes    // key: pool
+	SwapTradesMap map[string][]SwapTradeRes // key: pool
+	ValidatorsMap map[string]ValidatorRes   // key: operator address
+	ProposalsMap  map[string]ProposalRes    // key: proposal id
+	VotesMap      map[string][]VoteRes      // key: proposal id
 }
 
+// NewPushData creates the data of a block, whose depth books are sequenced by the cache. InitialCache is called
+// before it
 func NewPushData() *PushData {
-	baseData := pushservice.NewRedisBlock()
-	pd := PushData{RedisBlock: baseData, eventMgr: nil}
+	baseData := pushservice.NewRedisBlock(singletonCache.depthSequencer)
+	pd := PushData{
+		RedisBlock: baseData,
+		eventMgr:   nil,
+
+		SwapPoolsMap:  make(map[string]SwapPoolRes),
+		SwapTradesMap: make(map[string][]SwapTradeRes),
+		ValidatorsMap: make(map[string]ValidatorRes),
+		ProposalsMap:  make(map[string]ProposalRes),
+		VotesMap:      make(map[string][]VoteRes),
+	}
 	return &pd
 }
 
-func (data *PushData) SetData(ctx sdk.Context, orderKeeper types.OrderKeeper,
-	tokenKeeper types.TokenKeeper, dexKeeper types.DexKeeper, cache *common.Cache) {
+// SetData collects the data of the block, the depth books in the cache are updated along with the sequences. The
+// swap pools & validators changed in the block, the active proposals & votes are collected when they change from the
+// states of the last block in the cache, and the proposals which left the active queues with their final status
+func (data *PushData) SetData(ctx sdk.Context, orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper,
+	dexKeeper types.DexKeeper, swapKeeper types.SwapKeeper, stakingKeeper types.StakingKeeper,
+	govKeeper types.GovKeeper, cache *common.Cache) {
 	data.eventMgr = ctx.EventManager()
 	data.RedisBlock.SetData(ctx, orderKeeper, tokenKeeper, dexKeeper, cache)
 
-	// update depthBook cache
-	products := orderKeeper.GetUpdatedDepthbookKeys()
-	for _, product := range products {
-		depthBook := orderKeeper.GetDepthBookCopy(product)
-		bookRes := pushservice.ConvertBookRes(product, orderKeeper, depthBook, 200)
-		UpdateDepthBookCache(product, bookRes)
+	for _, pool := range singletonCache.changedSwapPools(getChangedSwapPools(ctx, swapKeeper)) {
+		data.SwapPoolsMap[pool.Pool] = pool
+	}
+	for _, trade := range getSwapTrades(ctx, swapKeeper) {
+		data.SwapTradesMap[trade.Pool] = append(data.SwapTradesMap[trade.Pool], trade)
+	}
+	for _, validator := range singletonCache.changedValidators(getChangedValidators(ctx, stakingKeeper)) {
+		data.ValidatorsMap[validator.OperatorAddress] = validator
+	}
+	proposals, votes := getActiveProposals(ctx, govKeeper)
+	changedProposals, endedProposalIDs := singletonCache.changedProposals(proposals)
+	for _, proposal := range append(changedProposals, getEndedProposals(ctx, govKeeper, endedProposalIDs)...) {
+		data.ProposalsMap[strconv.FormatUint(proposal.ProposalID, 10)] = proposal
+	}
+	for _, vote := range singletonCache.changedVotes(votes) {
+		key := strconv.FormatUint(vote.ProposalID, 10)
+		data.VotesMap[key] = append(data.VotesMap[key], vote)
 	}
-
 }
 
 func (data PushData) DataType() types.StreamDataKind {
@@ -47,6 +80,14 @@ func (r *EventResponse) Valid() bool {
 	return (len(r.Event) > 0 && len(r.Channel) > 0) || r.Event == "login"
 }
 
+// LoginResponse is the response of a login challenge with the message to be signed, or the response of a login
+type LoginResponse struct {
+	Event    string `json:"event"`
+	Success  string `json:"success,omitempty"`
+	Message  string `json:"message,omitempty"`
+	ExpireAt int64  `json:"expire_at"`
+}
+
 type TableResponse struct {
 	Table  string        `json:"table"`
 	Action string        `json:"action"`
@@ -341,6 +341,12 @@ func (k Keeper) GetFeeDetailList() []*FeeDetail {
 
 // nolint
 func (k Keeper) AddFeeDetail(ctx sdk.Context, from string, fee sdk.DecCoins, feeType string, receiver string) {
+	k.AddFeeDetailWithTier(ctx, from, fee, feeType, receiver, "")
+}
+
+// AddFeeDetailWithTier adds the fee detail with the fee tier it was charged by
+func (k Keeper) AddFeeDetailWithTier(ctx sdk.Context, from string, fee sdk.DecCoins, feeType string, receiver string,
+	feeTier string) {
 	if k.enableBackend {
 		feeDetail := &FeeDetail{
 			Address:   from,
@@ -348,6 +354,7 @@ func (k Keeper) AddFeeDetail(ctx sdk.Context, from string, fee sdk.DecCoins, fee
 			FeeType:   feeType,
 			Timestamp: ctx.BlockHeader().Time.Unix(),
 			Receiver:  receiver,
+			FeeTier:   feeTier,
 		}
 		k.cache.addFeeDetail(feeDetail)
 	}
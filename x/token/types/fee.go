@@ -7,4 +7,7 @@ type FeeDetail struct {
 	Fee       string `gorm:"type:varchar(40)" json:"fee" v2:"fee"`
 	FeeType   string `gorm:"index;type:varchar(20)" json:"fee_type" v2:"fee_type"` 		 // defined in order/types/const.go
 	Timestamp int64  `gorm:"type:bigint" json:"timestamp" v2:"timestamp"`
+	FeeTier   string `gorm:"type:varchar(40)" json:"fee_tier" v2:"fee_tier"` // role, tier & rate of a deal fee
+	// the height of the block, by which the fee details are replaced when the block is stored again
+	BlockHeight int64 `gorm:"index;type:bigint" json:"block_height" v2:"block_height"`
 }